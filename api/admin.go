@@ -6,6 +6,7 @@ import (
 	
 	"github.com/gin-gonic/gin"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -17,7 +18,7 @@ import (
 //	@Success		200	{object}	db.AdminDashboard	"Dashboard statistics with order type details"
 //	@Router			/admin/dashboard [get]
 func (server *Server) getAdminDashboard(c *gin.Context) {
-	_ = c.MustGet(adminPayloadKey).(*db.User)
+	_ = c.MustGet(adminPayloadKey).(*token.Payload)
 	
 	var resp db.AdminDashboard
 	
@@ -18,51 +18,75 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-//	@Summary		Get platform auctions
-//	@Description	Retrieves upcoming and ongoing auctions from the platform.
-//	@Tags			auctions
-//	@Produce		json
-//	@Param			status	query	string				false	"Filter by status"	Enums(scheduled, active)
-//	@Success		200		{array}	db.AuctionDetails	"List of auctions"
-//	@Router			/auctions [get]
-func (server *Server) listAuctions(c *gin.Context) {
-	status := db.AuctionStatus(c.Query("status"))
-	if status != "" {
-		if status != db.AuctionStatusScheduled && status != db.AuctionStatusActive {
-			err := fmt.Errorf("invalid status: %s, allowed statuses: [scheduled, active]", status)
-			c.JSON(http.StatusBadRequest, errorResponse(err))
-			return
+// Các kiểu sắp xếp phiên đấu giá
+const (
+	auctionSortEndingSoon   = "ending_soon"
+	auctionSortStartingSoon = "starting_soon"
+)
+
+var auctionSorts = []string{sortNewest, sortOldest, auctionSortEndingSoon, auctionSortStartingSoon}
+
+// defaultAuctionSort ưu tiên phiên sắp kết thúc khi lọc phiên đang diễn ra và phiên sắp bắt đầu khi lọc phiên sắp diễn ra.
+func defaultAuctionSort(status db.AuctionStatus) string {
+	switch status {
+	case db.AuctionStatusActive:
+		return auctionSortEndingSoon
+	case db.AuctionStatusScheduled:
+		return auctionSortStartingSoon
+	default:
+		return sortNewest
+	}
+}
+
+func auctionCursorOf(sort string) func(auction db.Auction) (string, string) {
+	return func(auction db.Auction) (string, string) {
+		switch sort {
+		case auctionSortEndingSoon:
+			return timeCursorValue(auction.EndTime), auction.ID.String()
+		case auctionSortStartingSoon:
+			return timeCursorValue(auction.StartTime), auction.ID.String()
+		default:
+			return timeCursorValue(auction.CreatedAt), auction.ID.String()
 		}
 	}
-	
-	// Lấy danh sách các phiên đấu giá, có lọc theo trạng thái, có sắp xếp hợp lý theo trạng thái
-	auctions, err := server.dbStore.ListAuctions(c, db.NullAuctionStatus{
-		AuctionStatus: status,
-		Valid:         status != "",
+}
+
+// listAuctionDetails lấy một trang phiên đấu giá kèm người tham gia và lịch sử đặt giá.
+func (server *Server) listAuctionDetails(ctx context.Context, status db.AuctionStatus, page pagination) (pageResponse[db.AuctionDetails], error) {
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		return pageResponse[db.AuctionDetails]{}, err
+	}
+	
+	rows, err := server.dbStore.ListAuctions(ctx, db.ListAuctionsParams{
+		Status: db.NullAuctionStatus{
+			AuctionStatus: status,
+			Valid:         status != "",
+		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
-		err = fmt.Errorf("failed to list auctions: %w", err)
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return pageResponse[db.AuctionDetails]{}, fmt.Errorf("failed to list auctions: %w", err)
 	}
 	
+	auctions, info := paginate(rows, page, auctionCursorOf(page.Sort))
+	
 	// Danh sách thông tin đấu giá chi tiết
-	var resp []db.AuctionDetails
+	resp := make([]db.AuctionDetails, 0, len(auctions))
 	for _, auction := range auctions {
 		// Lấy danh sách người tham gia đấu giá (sắp xếp theo thời gian tham gia gần nhất)
-		participants, err := server.dbStore.ListAuctionParticipants(c, auction.ID)
+		participants, err := server.dbStore.ListAuctionParticipants(ctx, auction.ID)
 		if err != nil {
-			err = fmt.Errorf("failed to list auction participants: %w", err)
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
+			return pageResponse[db.AuctionDetails]{}, fmt.Errorf("failed to list auction participants: %w", err)
 		}
 		
 		// Lấy danh sách giá đấu đã đặt (sắp xếp theo thời gian đặt giá gần nhất)
-		bids, err := server.dbStore.ListAuctionBids(c, &auction.ID)
+		bids, err := server.dbStore.ListAuctionBids(ctx, &auction.ID)
 		if err != nil {
-			err = fmt.Errorf("failed to list auction bids: %w", err)
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
+			return pageResponse[db.AuctionDetails]{}, fmt.Errorf("failed to list auction bids: %w", err)
 		}
 		
 		resp = append(resp, db.AuctionDetails{
@@ -72,6 +96,47 @@ func (server *Server) listAuctions(c *gin.Context) {
 		})
 	}
 	
+	return newPageResponse(resp, info), nil
+}
+
+//	@Summary		Get platform auctions
+//	@Description	Retrieves upcoming and ongoing auctions from the platform with cursor pagination.
+//	@Tags			auctions
+//	@Produce		json
+//	@Param			status	query		string							false	"Filter by status"																				Enums(scheduled, active)
+//	@Param			sort	query		string							false	"Sort order (default: ending_soon for active, starting_soon for scheduled, newest otherwise)"	Enums(newest, oldest, ending_soon, starting_soon)
+//	@Param			cursor	query		string							false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer							false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.AuctionDetails]	"List of auctions"
+//	@Failure		400		"Bad Request - Invalid query parameters"
+//	@Router			/auctions [get]
+func (server *Server) listAuctions(c *gin.Context) {
+	status := db.AuctionStatus(c.Query("status"))
+	if status != "" {
+		if status != db.AuctionStatusScheduled && status != db.AuctionStatusActive {
+			err := fmt.Errorf("invalid status: %s, allowed statuses: [scheduled, active]", status)
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	
+	page, err := parsePageQuery(c, auctionSorts, defaultAuctionSort(status))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	resp, err := server.listAuctionDetails(c.Request.Context(), status, page)
+	if err != nil {
+		if errors.Is(err, errInvalidCursor) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
 	c.JSON(http.StatusOK, resp)
 }
 
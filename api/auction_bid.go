@@ -13,6 +13,7 @@ import (
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
 	"github.com/katatrina/gundam-BE/internal/event"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
@@ -31,6 +32,7 @@ type placeBidRequest struct {
 //	@Param			request		body						placeBidRequest	true	"Request body containing bid amount"
 //	@Success		200			"Successful bid placement"	db.PlaceBidTxResult
 //	@Security		accessToken
+//	@Failure		429	"Too many requests"
 //	@Router			/users/me/auctions/{auctionID}/bids [post]
 func (server *Server) placeBid(c *gin.Context) {
 	// Lấy thông tin người dùng từ token
@@ -256,6 +258,9 @@ func (server *Server) placeBid(c *gin.Context) {
 		return
 	}
 	
+	// Ngôn ngữ email phải được lấy trước khi handler trả về, vì gin.Context không được dùng lại trong goroutine
+	emailLocale := mailer.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language"))
+	
 	// Broadcast events và send notifications async
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -315,6 +320,20 @@ func (server *Server) placeBid(c *gin.Context) {
 					Msg("failed to send win notification")
 			}
 			
+			if result.Auction.WinnerPaymentDeadline != nil {
+				err = worker.DistributeEmail(ctx, server.taskDistributor, userID, mailer.TemplateAuctionWon, emailLocale, mailer.AuctionWonData{
+					GundamName:      result.Auction.GundamSnapshot.Name,
+					FinalPrice:      req.Amount,
+					PaymentDeadline: *result.Auction.WinnerPaymentDeadline,
+				})
+				if err != nil {
+					log.Warn().Err(err).
+						Str("recipient_id", userID).
+						Str("auction_id", result.Auction.ID.String()).
+						Msg("failed to send auction won email")
+				}
+			}
+			
 			// Thông báo cho người bán
 			err = server.taskDistributor.DistributeTaskSendNotification(
 				ctx,
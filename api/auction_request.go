@@ -8,6 +8,7 @@ import (
 	
 	"github.com/gin-gonic/gin"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/validator"
 )
 
@@ -51,7 +52,7 @@ func (req *createAuctionRequestBody) validate() error {
 //	@Success		201			{object}	db.AuctionRequest			"Successfully created auction request"
 //	@Router			/sellers/{sellerID}/auction-requests [post]
 func (server *Server) createAuctionRequest(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	var req createAuctionRequestBody
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -78,8 +79,8 @@ func (server *Server) createAuctionRequest(c *gin.Context) {
 	}
 	
 	// Check if gundam exists and belongs to the user
-	if gundam.OwnerID != user.ID {
-		err = fmt.Errorf("gundam ID %d does not belong to user ID %s", req.GundamID, user.ID)
+	if gundam.OwnerID != user.Subject {
+		err = fmt.Errorf("gundam ID %d does not belong to user ID %s", req.GundamID, user.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -91,6 +92,13 @@ func (server *Server) createAuctionRequest(c *gin.Context) {
 		return
 	}
 	
+	// Gundam đã ngừng đăng bán nhưng vẫn còn đơn vị được giữ cho đơn hàng chưa hoàn tất
+	if gundam.ReservedQuantity > 0 {
+		err = fmt.Errorf("gundam ID %d has %d units reserved for pending orders", req.GundamID, gundam.ReservedQuantity)
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+	
 	// Check if gundam is already in the auction request
 	pendingCount, err := server.dbStore.CountExistingPendingAuctionRequest(c, &gundam.ID)
 	if err != nil {
@@ -105,10 +113,10 @@ func (server *Server) createAuctionRequest(c *gin.Context) {
 	}
 	
 	// Check subscription and open auctions limit
-	subscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c.Request.Context(), user.ID)
+	subscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c.Request.Context(), user.Subject)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
-			err = fmt.Errorf("user ID %s does not have an active subscription", user.ID)
+			err = fmt.Errorf("user ID %s does not have an active subscription", user.Subject)
 			c.JSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
@@ -159,14 +167,15 @@ func (server *Server) createAuctionRequest(c *gin.Context) {
 	
 	// Tạo snapshot
 	snapshot := db.GundamSnapshot{
-		ID:       gundam.ID,
-		Name:     gundam.Name,
-		Slug:     gundam.Slug,
-		Grade:    grade.DisplayName,
-		Scale:    string(gundam.Scale),
-		Quantity: gundam.Quantity,
-		Weight:   gundam.Weight,
-		ImageURL: primaryImageURL,
+		ID:        gundam.ID,
+		Name:      gundam.Name,
+		Slug:      gundam.Slug,
+		Grade:     grade.DisplayName,
+		Scale:     string(gundam.Scale),
+		Quantity:  gundam.Quantity,
+		Weight:    gundam.Weight,
+		ImageURL:  primaryImageURL,
+		Condition: string(gundam.Condition),
 	}
 	
 	// Thực thi transaction
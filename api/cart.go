@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	
 	"github.com/gin-gonic/gin"
@@ -12,16 +13,28 @@ import (
 
 type addCartItemRequest struct {
 	GundamID int64 `json:"gundam_id" binding:"required"`
+	// Number of units to add, added to the quantity already in the cart (default: 1)
+	Quantity *int64 `json:"quantity" binding:"omitempty,min=1"`
+}
+
+func (req *addCartItemRequest) getQuantity() int64 {
+	if req.Quantity != nil {
+		return *req.Quantity
+	}
+	
+	return 1
 }
 
 //	@Summary		Add Item to Cart
-//	@Description	Adds a Gundam model to the user's shopping cart
+//	@Description	Adds a Gundam model to the user's shopping cart. If the Gundam is already in the cart, its quantity is increased.
+//	@Description	The total quantity in the cart cannot exceed the units of the Gundam still available for purchase.
 //	@Tags			cart
 //	@Accept			json
 //	@Produce		json
 //	@Security		accessToken
 //	@Param			request	body		addCartItemRequest	true	"Gundam to add to cart"
 //	@Success		200		{object}	db.AddCartItemRow	"Successfully added item to cart"
+//	@Failure		422		"Unprocessable Entity - Not enough units available"
 //	@Router			/cart/items [post]
 func (server *Server) addCartItem(ctx *gin.Context) {
 	req := new(addCartItemRequest)
@@ -59,26 +72,31 @@ func (server *Server) addCartItem(ctx *gin.Context) {
 		return
 	}
 	
-	arg := db.AddCartItemParams{
-		CartID:   cartID,
-		GundamID: req.GundamID,
-	}
-	
-	// Check if Gundam is already in the cart
-	exists, err := server.dbStore.CheckCartItemExists(ctx, db.CheckCartItemExistsParams{
+	// Số lượng trong giỏ (kể cả số lượng đã có) không được vượt quá số đơn vị còn có thể mua.
+	// Giỏ hàng không giữ hàng, số lượng được kiểm tra lại khi tạo đơn hàng.
+	cartQuantity, err := server.dbStore.GetCartItemQuantity(ctx, db.GetCartItemQuantityParams{
 		CartID:   cartID,
 		GundamID: req.GundamID,
 	})
-	if err != nil {
-		log.Err(err).Msg("failed to check if Gundam is already in the cart")
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		log.Err(err).Msg("failed to get cart item quantity")
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	if exists {
-		ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrCartItemExists))
+	
+	available := gundam.Quantity - gundam.ReservedQuantity
+	if cartQuantity+req.getQuantity() > available {
+		err = fmt.Errorf("%w: gundam ID %d has %d units available, %d already in cart", db.ErrInsufficientStock, req.GundamID, available, cartQuantity)
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
 		return
 	}
 	
+	arg := db.AddCartItemParams{
+		CartID:   cartID,
+		GundamID: req.GundamID,
+		Quantity: req.getQuantity(),
+	}
+	
 	cartItem, err := server.dbStore.AddCartItem(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -19,6 +19,7 @@ type checkEmailRequest struct {
 //	@Produce		json
 //	@Param			request	body		checkEmailRequest	true	"Check email request"
 //	@Success		200		{object}	map[string]bool
+//	@Failure		429		"Too many requests"
 //	@Router			/check-email [post]
 func (server *Server) checkEmailExists(ctx *gin.Context) {
 	var req checkEmailRequest
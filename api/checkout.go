@@ -0,0 +1,289 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+type checkoutRequest struct {
+	// Client-generated ID of the checkout. Sending the same ID again returns the orders created by the first request instead of creating new ones.
+	// example: 0195f0c8-8a3e-7d2b-9c41-3f6b2a1d5e77
+	CheckoutID string `json:"checkout_id" binding:"required,uuid"`
+	
+	// ID of the buyer's chosen address
+	// example: 42
+	BuyerAddressID int64 `json:"buyer_address_id" binding:"required"`
+	
+	// Payment method (wallet: pay via platform wallet, cod: cash on delivery)
+	// enums: wallet,cod
+	// example: wallet
+	PaymentMethod string `json:"payment_method" binding:"required,oneof=wallet cod"`
+	
+	// Deprecated: ignored, the expected delivery time is quoted by the delivery provider
+	// example: 2025-04-05T10:00:00Z
+	ExpectedDeliveryTime *time.Time `json:"expected_delivery_time"`
+	
+	// IDs of the cart items to check out, all items in the cart are checked out when omitted
+	CartItemIDs []string `json:"cart_item_ids" binding:"omitempty,dive,required"`
+	
+	// Optional notes for the orders, keyed by seller ID
+	Notes map[string]string `json:"notes" binding:"omitempty,dive,max=255"`
+}
+
+//	@Summary		Check out the cart
+//	@Description	Create one order per seller for the items in the cart in a single transaction: either all orders are created or none.
+//	@Description	Prices are taken from the current Gundam prices and delivery fees and expected delivery times are quoted by the delivery provider, the client does not send any amount.
+//	@Description	The request is idempotent per checkout_id: retrying with the same checkout_id returns the orders that were already created with status 200.
+//	@Description	Checked out items are removed from the cart.
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		checkoutRequest		true	"Checkout details"
+//	@Success		201		{object}	db.CheckoutTxResult	"Orders created successfully"
+//	@Success		200		{object}	db.CheckoutTxResult	"Checkout was already processed, the created orders are returned"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		409		"Conflict - Checkout ID is used by another buyer"
+//	@Failure		422		"Unprocessable Entity - Empty cart, unavailable Gundam, not enough units or insufficient balance"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/checkout [post]
+func (server *Server) checkout(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req checkoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	checkoutID := uuid.MustParse(req.CheckoutID) // Đã kiểm tra định dạng uuid khi bind
+	
+	// Checkout ID đã được xử lý thì trả về các đơn hàng đã tạo, giỏ hàng lúc này có thể đã trống
+	existingCheckout, err := server.dbStore.GetCheckoutByID(c.Request.Context(), checkoutID)
+	if err == nil {
+		if existingCheckout.BuyerID != userID {
+			c.JSON(http.StatusConflict, errorResponse(db.ErrCheckoutConflict))
+			return
+		}
+		
+		result, err := server.dbStore.GetCheckoutResult(c.Request.Context(), existingCheckout)
+		if err != nil {
+			log.Err(err).Msg("failed to get checkout result")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		log.Err(err).Msg("failed to get checkout by ID")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	cartID, err := server.dbStore.GetCartByUserID(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = errors.New("cart is empty")
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get cart by user ID")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	cartItems, err := server.dbStore.ListCartItems(c.Request.Context(), cartID)
+	if err != nil {
+		log.Err(err).Msg("failed to list cart items")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Chỉ thanh toán các sản phẩm được chọn nếu client gửi cart_item_ids
+	if len(req.CartItemIDs) > 0 {
+		cartItemsByID := make(map[string]db.CartItem, len(cartItems))
+		for _, item := range cartItems {
+			cartItemsByID[item.ID] = item
+		}
+		
+		selected := make([]db.CartItem, 0, len(req.CartItemIDs))
+		for _, id := range req.CartItemIDs {
+			item, ok := cartItemsByID[id]
+			if !ok {
+				err = fmt.Errorf("cart item ID %s not found in cart", id)
+				c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			
+			selected = append(selected, item)
+			delete(cartItemsByID, id) // Bỏ qua ID bị gửi trùng
+		}
+		cartItems = selected
+	}
+	
+	if len(cartItems) == 0 {
+		err = errors.New("cart is empty")
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+	
+	buyerAddress, err := server.dbStore.GetUserAddressByID(c.Request.Context(), db.GetUserAddressByIDParams{
+		ID:     req.BuyerAddressID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("cannot find user address with ID %d for buyer with ID %s", req.BuyerAddressID, userID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user address by ID")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Gom các sản phẩm theo người bán, giữ thứ tự xuất hiện trong giỏ hàng
+	var sellerIDs []string
+	ordersBySeller := make(map[string]*db.CreateOrderTxParams)
+	weightBySeller := make(map[string]int64)
+	
+	for _, cartItem := range cartItems {
+		gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), cartItem.GundamID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				err = fmt.Errorf("gundam ID %d not found", cartItem.GundamID)
+				c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			
+			log.Err(err).Msg("failed to get gundam by ID")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		if gundam.Status != db.GundamStatusPublished {
+			err = fmt.Errorf("gundam ID %d is not in published status", gundam.ID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		if gundam.OwnerID == userID {
+			err = fmt.Errorf("seller ID %s cannot buy their own gundam ID %d", userID, gundam.ID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		if gundam.Price == nil {
+			err = fmt.Errorf("gundam ID %d has no price set", gundam.ID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		// Số lượng được giữ lại trong transaction, kiểm tra ở đây để trả lỗi sớm
+		if available := gundam.Quantity - gundam.ReservedQuantity; cartItem.Quantity > available {
+			err = fmt.Errorf("%w: gundam ID %d has %d units available, %d in cart", db.ErrInsufficientStock, gundam.ID, available, cartItem.Quantity)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		order, ok := ordersBySeller[gundam.OwnerID]
+		if !ok {
+			sellerIDs = append(sellerIDs, gundam.OwnerID)
+			order = &db.CreateOrderTxParams{
+				BuyerID:       userID,
+				BuyerAddress:  buyerAddress,
+				SellerID:      gundam.OwnerID,
+				PaymentMethod: db.PaymentMethod(req.PaymentMethod),
+			}
+			if note, ok := req.Notes[gundam.OwnerID]; ok {
+				order.Note = &note
+			}
+			ordersBySeller[gundam.OwnerID] = order
+		}
+		
+		order.ItemsSubtotal += *gundam.Price * cartItem.Quantity
+		order.Items = append(order.Items, db.CreateOrderTxItem{
+			Gundam:   gundam,
+			Quantity: cartItem.Quantity,
+		})
+		weightBySeller[gundam.OwnerID] += gundam.Weight * cartItem.Quantity
+	}
+	
+	// Phí vận chuyển và thời gian giao hàng dự kiến của từng đơn hàng do đơn vị vận chuyển báo giá
+	orders := make([]db.CreateOrderTxParams, 0, len(sellerIDs))
+	for _, sellerID := range sellerIDs {
+		order := ordersBySeller[sellerID]
+		
+		sellerAddress, err := server.dbStore.GetUserPickupAddress(c.Request.Context(), sellerID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				err = fmt.Errorf("seller pickup address not found for seller ID %s", sellerID)
+				c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			
+			log.Err(err).Msg("failed to get user pickup address")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		order.SellerAddress = sellerAddress
+		
+		quote, err := server.quoteDelivery(c.Request.Context(), userAddressLocation(sellerAddress), userAddressLocation(buyerAddress), weightBySeller[sellerID])
+		if err != nil {
+			log.Err(err).Str("seller_id", sellerID).Msg("failed to quote delivery")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		order.DeliveryFee = quote.DeliveryFee()
+		order.ExpectedDeliveryTime = quote.ExpectedDeliveryTime()
+		order.DeliveryQuote = quote
+		order.TotalAmount = order.ItemsSubtotal + order.DeliveryFee
+		orders = append(orders, *order)
+	}
+	
+	result, err := server.dbStore.CheckoutTx(c.Request.Context(), db.CheckoutTxParams{
+		CheckoutID: checkoutID,
+		BuyerID:    userID,
+		CartID:     cartID,
+		Orders:     orders,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrCheckoutConflict):
+			c.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientStock),
+			errors.Is(err, db.ErrInsufficientBalance),
+			errors.Is(err, db.ErrGundamPriceChanged):
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		default:
+			log.Err(err).Msg("failed to check out cart")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	
+	// Request đồng thời với cùng checkout ID đã tạo các đơn hàng
+	if result.Replayed {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	
+	for _, order := range result.Orders {
+		server.notifyOrderCreated(c, order.Order, order.OrderItems, order.OrderDelivery)
+	}
+	
+	c.JSON(http.StatusCreated, result)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/delivery"
+)
+
+var errExchangeDeliveryAddressesMissing = errors.New("both parties must provide delivery addresses before the delivery fee can be quoted")
+
+// deliveryQuote là báo giá của đơn vị vận chuyển (delivery.Quote) trả về cho client.
+type deliveryQuote struct {
+	// ID of the delivery service used for the quote
+	ServiceID int64 `json:"service_id"`
+	
+	// Delivery fee (VND)
+	DeliveryFee int64 `json:"delivery_fee"`
+	
+	// Expected delivery time
+	ExpectedDeliveryTime time.Time `json:"expected_delivery_time"`
+}
+
+func newDeliveryQuote(quote delivery.Quote) deliveryQuote {
+	return deliveryQuote{
+		ServiceID:            quote.ServiceID(),
+		DeliveryFee:          quote.DeliveryFee(),
+		ExpectedDeliveryTime: quote.ExpectedDeliveryTime(),
+	}
+}
+
+func userAddressLocation(address db.UserAddress) delivery.Location {
+	return delivery.Location{
+		DistrictID: address.GhnDistrictID,
+		WardCode:   address.GhnWardCode,
+	}
+}
+
+func deliveryInformationLocation(info db.DeliveryInformation) delivery.Location {
+	return delivery.Location{
+		DistrictID: info.GhnDistrictID,
+		WardCode:   info.GhnWardCode,
+	}
+}
+
+// quoteDelivery lấy báo giá của đơn vị vận chuyển cho một kiện hàng nặng weight gram được gửi từ địa chỉ from tới địa chỉ to.
+func (server *Server) quoteDelivery(ctx context.Context, from delivery.Location, to delivery.Location, weight int64) (delivery.Quote, error) {
+	return delivery.QuoteDelivery(ctx, server.deliveryService, from, to, weight)
+}
+
+// quoteExchangeDelivery báo giá chiều giao hàng mà một bên của cuộc trao đổi phải trả phí:
+// Gundam của đối tác được gửi từ địa chỉ gửi của đối tác tới địa chỉ nhận của người dùng.
+// Cả hai địa chỉ phải đã được cung cấp.
+func (server *Server) quoteExchangeDelivery(ctx context.Context, exchange db.Exchange, isPoster bool) (delivery.Quote, error) {
+	toDeliveryID := exchange.PosterToDeliveryID
+	if !isPoster {
+		toDeliveryID = exchange.OffererToDeliveryID
+	}
+	if toDeliveryID == nil {
+		return delivery.Quote{}, errExchangeDeliveryAddressesMissing
+	}
+	
+	toInfo, err := server.dbStore.GetDeliveryInformation(ctx, *toDeliveryID)
+	if err != nil {
+		return delivery.Quote{}, fmt.Errorf("failed to get receiver delivery information: %w", err)
+	}
+	
+	return server.quoteExchangeDeliveryTo(ctx, exchange, isPoster, deliveryInformationLocation(toInfo))
+}
+
+// quoteExchangeDeliveryTo giống quoteExchangeDelivery nhưng địa chỉ nhận của người dùng là to,
+// dùng khi người dùng chưa lưu địa chỉ nhận vào cuộc trao đổi. Địa chỉ gửi của đối tác phải đã được cung cấp.
+func (server *Server) quoteExchangeDeliveryTo(ctx context.Context, exchange db.Exchange, isPoster bool, to delivery.Location) (delivery.Quote, error) {
+	fromDeliveryID := exchange.OffererFromDeliveryID
+	if !isPoster {
+		fromDeliveryID = exchange.PosterFromDeliveryID
+	}
+	if fromDeliveryID == nil {
+		return delivery.Quote{}, errExchangeDeliveryAddressesMissing
+	}
+	
+	fromInfo, err := server.dbStore.GetDeliveryInformation(ctx, *fromDeliveryID)
+	if err != nil {
+		return delivery.Quote{}, fmt.Errorf("failed to get sender delivery information: %w", err)
+	}
+	
+	// Người dùng nhận các Gundam của đối tác
+	isFromPoster := !isPoster
+	items, err := server.dbStore.ListExchangeItems(ctx, db.ListExchangeItemsParams{
+		ExchangeID:   exchange.ID,
+		IsFromPoster: &isFromPoster,
+	})
+	if err != nil {
+		return delivery.Quote{}, fmt.Errorf("failed to list exchange items: %w", err)
+	}
+	
+	totalWeight := int64(0)
+	for _, item := range items {
+		totalWeight += item.Weight * item.Quantity
+	}
+	
+	return server.quoteDelivery(ctx, deliveryInformationLocation(fromInfo), to, totalWeight)
+}
@@ -199,7 +199,7 @@ func (server *Server) getExchangeDetails(c *gin.Context) {
 	}
 	
 	// Xây dựng thông tin người đối tác
-	partner, err := server.dbStore.GetUserByID(c.Request.Context(), partnerID)
+	partner, err := server.dbStore.GetUserByIDIncludingDeleted(c.Request.Context(), partnerID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			err = fmt.Errorf("user ID %s not found", partnerID)
@@ -482,7 +482,7 @@ func (server *Server) listUserExchanges(c *gin.Context) {
 		}
 		
 		// Xây dựng thông tin người đối tác
-		partner, err := server.dbStore.GetUserByID(c.Request.Context(), partnerID)
+		partner, err := server.dbStore.GetUserByIDIncludingDeleted(c.Request.Context(), partnerID)
 		if err != nil {
 			if errors.Is(err, db.ErrRecordNotFound) {
 				c.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("partner ID %s not found", partnerID)))
@@ -589,10 +589,18 @@ type provideExchangeDeliveryAddressesRequest struct {
 	
 	// ID địa chỉ nhận đã được lưu trong bảng user_addresses
 	ToAddressID int64 `json:"to_address_id" binding:"required"`
+	
+	// Delivery fee (VND) shown to the user, optional. When provided, it must match the fee quoted for the new receiver address
+	DeliveryFee *int64 `json:"delivery_fee" binding:"omitempty,min=0"`
+	
+	// Expected delivery time shown to the user, optional. When provided, it must match the quoted expected delivery time
+	ExpectedDeliveryTime *time.Time `json:"expected_delivery_time"`
 }
 
 //	@Summary		Provide delivery addresses for exchange
 //	@Description	Provides shipping addresses (from and to) for an exchange transaction. Both participants must provide their addresses before proceeding.
+//	@Description	When delivery_fee or expected_delivery_time is provided, the partner's sender address must already be known and
+//	@Description	the values must match the delivery provider's quote for the user's receiver address, otherwise the request is rejected.
 //	@Tags			exchanges
 //	@Accept			json
 //	@Produce		json
@@ -710,6 +718,31 @@ func (server *Server) provideExchangeDeliveryAddresses(c *gin.Context) {
 		return
 	}
 	
+	// 3. Phí vận chuyển và thời gian giao hàng mà client hiển thị cho người dùng phải khớp với báo giá của đơn vị vận chuyển
+	if req.DeliveryFee != nil || req.ExpectedDeliveryTime != nil {
+		quote, err := server.quoteExchangeDeliveryTo(c.Request.Context(), exchange, isPoster, userAddressLocation(toAddress))
+		if err != nil {
+			if errors.Is(err, errExchangeDeliveryAddressesMissing) {
+				c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			
+			log.Error().Err(err).Msg("failed to quote exchange delivery")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		deliveryFee := quote.DeliveryFee()
+		if req.DeliveryFee != nil {
+			deliveryFee = *req.DeliveryFee
+		}
+		
+		if err = db.VerifyDeliveryQuote(quote, deliveryFee, req.ExpectedDeliveryTime); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+	}
+	
 	// Phần 2: Xử lý transaction
 	
 	// Chuẩn bị tham số cho transaction
@@ -777,14 +810,76 @@ func (server *Server) provideExchangeDeliveryAddresses(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+//	@Summary		Quote the delivery fee of an exchange
+//	@Description	Get the delivery fee and the expected delivery time quoted by the delivery provider for the delivery the user pays for:
+//	@Description	the partner's Gundams sent from the partner's sender address to the user's receiver address.
+//	@Description	Both parties must have provided their delivery addresses.
+//	@Tags			exchanges
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			exchangeID	path		string			true	"Exchange ID"
+//	@Success		200			{object}	deliveryQuote	"Delivery quote"
+//	@Failure		400			"Bad Request - Invalid exchange ID"
+//	@Failure		403			"Forbidden - User is not a participant of the exchange"
+//	@Failure		404			"Not Found - Exchange does not exist"
+//	@Failure		422			"Unprocessable Entity - Delivery addresses have not been provided"
+//	@Failure		500			"Internal Server Error"
+//	@Router			/exchanges/{exchangeID}/delivery-quote [get]
+func (server *Server) getExchangeDeliveryQuote(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	exchangeIDStr := c.Param("exchangeID")
+	exchangeID, err := uuid.Parse(exchangeIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid exchange ID: %s", exchangeIDStr)))
+		return
+	}
+	
+	exchange, err := server.dbStore.GetExchangeByID(c.Request.Context(), exchangeID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("exchange ID %s not found", exchangeIDStr)))
+			return
+		}
+		
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	isPoster := exchange.PosterID == userID
+	isOfferer := exchange.OffererID == userID
+	if !isPoster && !isOfferer {
+		err = fmt.Errorf("exchange ID %s does not belong to user ID %s", exchangeIDStr, userID)
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+	
+	quote, err := server.quoteExchangeDelivery(c.Request.Context(), exchange, isPoster)
+	if err != nil {
+		if errors.Is(err, errExchangeDeliveryAddressesMissing) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to quote exchange delivery")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, newDeliveryQuote(quote))
+}
+
 type payExchangeDeliveryFeeRequest struct {
-	DeliveryFee          int64     `json:"delivery_fee" binding:"required,min=1"`
-	ExpectedDeliveryTime time.Time `json:"expected_delivery_time" binding:"required"`
-	Note                 *string   `json:"note"`
+	// Delivery fee (VND), must match the fee quoted by GET /exchanges/{exchangeID}/delivery-quote
+	DeliveryFee int64 `json:"delivery_fee" binding:"required,min=1"`
+	// Deprecated: ignored, the expected delivery time is quoted by the delivery provider
+	ExpectedDeliveryTime *time.Time `json:"expected_delivery_time"`
+	Note                 *string    `json:"note"`
 }
 
 //	@Summary		Pay delivery fee for exchange
 //	@Description	Pays the delivery fee for an exchange transaction. When both parties have paid, the system creates two orders.
+//	@Description	The payment is rejected when delivery_fee does not match the fee quoted by the delivery provider.
 //	@Tags			exchanges
 //	@Accept			json
 //	@Produce		json
@@ -857,6 +952,24 @@ func (server *Server) payExchangeDeliveryFee(c *gin.Context) {
 	// Get delivery fee amount from request
 	deliveryFee := req.DeliveryFee
 	
+	// Phí vận chuyển phải khớp với báo giá của đơn vị vận chuyển
+	quote, err := server.quoteExchangeDelivery(c.Request.Context(), exchange, isPoster)
+	if err != nil {
+		if errors.Is(err, errExchangeDeliveryAddressesMissing) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to quote exchange delivery")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if err = db.VerifyDeliveryQuote(quote, deliveryFee, nil); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+	
 	// Check user's wallet balance
 	wallet, err := server.dbStore.GetWalletByUserID(c.Request.Context(), userID)
 	if err != nil {
@@ -883,7 +996,7 @@ func (server *Server) payExchangeDeliveryFee(c *gin.Context) {
 		IsPoster:             isPoster,
 		DeliveryFee:          deliveryFee,
 		Note:                 req.Note,
-		ExpectedDeliveryTime: req.ExpectedDeliveryTime,
+		ExpectedDeliveryTime: quote.ExpectedDeliveryTime(),
 	}
 	
 	result, err := server.dbStore.PayExchangeDeliveryFeeTx(c.Request.Context(), arg)
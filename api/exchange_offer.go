@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
@@ -51,6 +52,7 @@ type createExchangeOfferRequest struct {
 //	@Security		accessToken
 //	@Param			request	body		createExchangeOfferRequest		true	"Create exchange offer request"
 //	@Success		201		{object}	db.CreateExchangeOfferTxResult	"Exchange offer created successfully"
+//	@Failure		429		"Too many requests"
 //	@Router			/users/me/exchange-offers [post]
 func (server *Server) createExchangeOffer(c *gin.Context) {
 	// Lấy thông tin người dùng đã đăng nhập
@@ -223,6 +225,11 @@ func (server *Server) createExchangeOffer(c *gin.Context) {
 			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
 			return
 		}
+		if offererGundam.ReservedQuantity > 0 {
+			err = fmt.Errorf("offerer gundam ID %d has %d units reserved for pending orders", gundamID, offererGundam.ReservedQuantity)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
 	}
 	
 	// TODO: Có thể kiểm tra Gundam đã tham gia các đề xuất khác chưa? Nếu có thì không cho phép tạo đề xuất mới.
@@ -840,6 +847,20 @@ func (server *Server) acceptExchangeOffer(c *gin.Context) {
 		log.Err(err).Msgf("failed to send notification to user ID %s", offer.OffererID)
 	}
 	
+	// Gửi email cho người có đề xuất được chấp nhận.
+	// Request đến từ chủ bài đăng nên không dùng Accept-Language để chọn ngôn ngữ email.
+	emailData := mailer.ExchangeAcceptedData{
+		PostExcerpt: util.TruncateString(post.Content, 50),
+	}
+	if result.Exchange.PayerID != nil && result.Exchange.CompensationAmount != nil {
+		emailData.CompensationAmount = *result.Exchange.CompensationAmount
+		emailData.PaysCompensation = *result.Exchange.PayerID == offer.OffererID
+	}
+	err = worker.DistributeEmail(c.Request.Context(), server.taskDistributor, offer.OffererID, mailer.TemplateExchangeAccepted, mailer.DefaultLocale, emailData)
+	if err != nil {
+		log.Warn().Err(err).Msgf("failed to send exchange accepted email to user ID %s", offer.OffererID)
+	}
+	
 	// Gửi thông báo cho những người khác có đề xuất không được chấp nhận.
 	for _, rejectedOffer := range result.RejectedOffers {
 		err = server.taskDistributor.DistributeTaskSendNotification(c.Request.Context(), &worker.PayloadSendNotification{
@@ -875,160 +896,16 @@ func (server *Server) listUserExchangeOffers(c *gin.Context) {
 		return
 	}
 	
-	var result []db.UserExchangeOfferDetails
-	
-	for _, offer := range offers {
-		var offerDetails db.UserExchangeOfferDetails
-		
-		offerInfo := db.ExchangeOfferInfo{
-			ID:     offer.ID,
-			PostID: offer.PostID,
-			// Offerer:              db.User{},
-			PayerID:            offer.PayerID,
-			CompensationAmount: offer.CompensationAmount,
-			Note:               offer.Note,
-			// OffererExchangeItems: nil,
-			// PosterExchangeItems:  nil,
-			NegotiationsCount:    offer.NegotiationsCount,
-			MaxNegotiations:      offer.MaxNegotiations,
-			NegotiationRequested: offer.NegotiationRequested,
-			LastNegotiationAt:    offer.LastNegotiationAt,
-			// NegotiationNotes:                nil,
-			CreatedAt: offer.CreatedAt,
-			UpdatedAt: offer.UpdatedAt,
-		}
-		
-		// Lấy thông tin bài đăng của offer
-		post, err := server.dbStore.GetExchangePost(c.Request.Context(), offer.PostID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("exchange post ID %s not found", offer.PostID)
-				c.JSON(http.StatusNotFound, errorResponse(err))
-				return
-			}
-			
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		offerDetails.ExchangePost = post
-		
-		// Lấy thông tin chủ bài đăng
-		poster, err := server.dbStore.GetUserByID(c.Request.Context(), post.UserID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("user ID %s not found", post.UserID)
-				c.JSON(http.StatusNotFound, errorResponse(err))
-				return
-			}
-			
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		offerDetails.Poster = poster
-		
-		// Lấy thông tin các item của bài đăng
-		postItems, err := server.dbStore.ListExchangePostItems(c.Request.Context(), post.ID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("no postItems found for exchange post ID %s", post.ID)
-				c.JSON(http.StatusNotFound, errorResponse(err))
-				return
-			}
-			
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		// Lấy thông tin chi tiết của từng item
-		postGundams := make([]db.GundamDetails, 0, len(postItems))
-		for _, item := range postItems {
-			gundam, err := server.dbStore.GetGundamDetailsByID(c.Request.Context(), nil, item.GundamID)
-			if err != nil {
-				if errors.Is(err, db.ErrRecordNotFound) {
-					err = fmt.Errorf("gundam ID %d not found", item.GundamID)
-					c.JSON(http.StatusNotFound, errorResponse(err))
-					return
-				}
-				
-				c.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-			
-			postGundams = append(postGundams, gundam)
-		}
-		offerDetails.ExchangePostItems = postGundams
-		
-		// Lấy các item từ bài đăng mà người đề xuất muốn trao đổi
-		posterItems, err := server.dbStore.ListExchangeOfferItems(c.Request.Context(), db.ListExchangeOfferItemsParams{
-			OfferID:      offer.ID,
-			IsFromPoster: util.BoolPointer(true),
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		// Lấy thông tin chi tiết của từng posterItems
-		posterGundams := make([]db.GundamDetails, 0, len(posterItems))
-		for _, item := range posterItems {
-			gundam, err := server.dbStore.GetGundamDetailsByID(c.Request.Context(), nil, item.GundamID)
-			if err != nil {
-				if errors.Is(err, db.ErrRecordNotFound) {
-					err = fmt.Errorf("gundam ID %d not found", item.GundamID)
-					c.JSON(http.StatusNotFound, errorResponse(err))
-					return
-				}
-				
-				c.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-			
-			posterGundams = append(posterGundams, gundam)
-		}
-		offerInfo.PosterExchangeItems = posterGundams
-		
-		// Lấy các offer item từ người đề xuất
-		offererItems, err := server.dbStore.ListExchangeOfferItems(c.Request.Context(), db.ListExchangeOfferItemsParams{
-			OfferID:      offer.ID,
-			IsFromPoster: util.BoolPointer(false),
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		// Lấy thông tin chi tiết của từng offererItems
-		offererGundams := make([]db.GundamDetails, 0, len(offererItems))
-		for _, item := range offererItems {
-			gundam, err := server.dbStore.GetGundamDetailsByID(c.Request.Context(), nil, item.GundamID)
-			if err != nil {
-				if errors.Is(err, db.ErrRecordNotFound) {
-					err = fmt.Errorf("gundam ID %d not found", item.GundamID)
-					c.JSON(http.StatusNotFound, errorResponse(err))
-					return
-				}
-				
-				c.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-			
-			offererGundams = append(offererGundams, gundam)
-		}
-		offerInfo.OffererExchangeItems = offererGundams
-		
-		// Lấy thông các ghi chú thương lượng (nếu có)
-		negotiationNotes, err := server.dbStore.ListExchangeOfferNotes(c.Request.Context(), offer.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
+	// Lấy bài đăng, chủ bài đăng, các item và ghi chú thương lượng của tất cả offer theo lô
+	result, err := server.dbStore.LoadUserExchangeOfferDetails(c.Request.Context(), offers)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
-		offerInfo.NegotiationNotes = negotiationNotes
 		
-		// Lấy thông tin người đề xuất (bỏ qua)
-		
-		offerDetails.Offer = offerInfo
-		
-		result = append(result, offerDetails)
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 	
 	c.JSON(http.StatusOK, result)
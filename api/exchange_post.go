@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
@@ -75,7 +76,7 @@ func (server *Server) createExchangePost(c *gin.Context) {
 		}
 		
 		// Kiểm tra trạng thái hiện tại của Gundam có được phép trao đổi không
-		if gundam.Status != db.GundamStatusInstore {
+		if gundam.Status != db.GundamStatusInstore || gundam.ReservedQuantity > 0 {
 			err = fmt.Errorf("gundam ID %d is not available for exchange", itemID)
 			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
 			return
@@ -87,9 +88,14 @@ func (server *Server) createExchangePost(c *gin.Context) {
 		Content:          req.Content,
 		PostImages:       req.PostImages,
 		PostItemIDs:      req.PostItemIDs,
-		UploadImagesFunc: server.uploadFileToCloudinary,
+		UploadImagesFunc: server.uploadImages,
 	})
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -265,11 +271,17 @@ func (server *Server) listUserExchangePosts(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+var exchangePostSorts = []string{sortNewest, sortOldest}
+
 //	@Summary		List all open exchange posts
-//	@Description	List all open exchange posts.
+//	@Description	List open exchange posts with cursor pagination.
 //	@Tags			exchanges
 //	@Produce		json
-//	@Success		200	{array}	db.OpenExchangePostInfo	"List of open exchange posts"
+//	@Param			sort	query		string									false	"Sort order (default: newest)"	Enums(newest, oldest)
+//	@Param			cursor	query		string									false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer									false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.OpenExchangePostInfo]	"List of open exchange posts"
+//	@Failure		400		"Bad Request - Invalid pagination parameters"
 //	@Router			/exchange-posts [get]
 func (server *Server) listOpenExchangePosts(c *gin.Context) {
 	// var userID string
@@ -283,300 +295,46 @@ func (server *Server) listOpenExchangePosts(c *gin.Context) {
 	// 	}
 	// }
 	
+	page, err := parsePageQuery(c, exchangePostSorts, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
 	// Lấy danh sách các bài đăng trao đổi đang mở
-	posts, err := server.dbStore.ListExchangePosts(c.Request.Context(), db.NullExchangePostStatus{
-		ExchangePostStatus: db.ExchangePostStatusOpen,
-		Valid:              true,
+	rows, err := server.dbStore.ListExchangePosts(c.Request.Context(), db.ListExchangePostsParams{
+		Status: db.NullExchangePostStatus{
+			ExchangePostStatus: db.ExchangePostStatusOpen,
+			Valid:              true,
+		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	
-	result := make([]db.OpenExchangePostInfo, 0, len(posts))
+	posts, info := paginate(rows, page, func(post db.ExchangePost) (string, string) {
+		return timeCursorValue(post.CreatedAt), post.ID.String()
+	})
 	
-	for _, post := range posts {
-		postInfo := db.OpenExchangePostInfo{
-			ExchangePost: post,
-		}
-		
-		// Lấy thông tin người đăng
-		poster, err := server.dbStore.GetUserByID(c.Request.Context(), post.UserID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		postInfo.Poster = poster
-		
-		// Lấy danh sách các item trong bài đăng
-		items, err := server.dbStore.ListExchangePostItems(c.Request.Context(), post.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		postItemDetails := make([]db.GundamDetails, 0, len(items))
-		
-		// Lặp qua từng item trong bài đăng để lấy thông tin chi tiết của Gundam
-		for _, item := range items {
-			// Lấy toàn bộ thông tin chi tiết của Gundam
-			gundamDetails, err := server.dbStore.GetGundamDetailsByID(c.Request.Context(), nil, item.GundamID)
-			if err != nil {
-				c.JSON(http.StatusInternalServerError, errorResponse(err))
-				return
-			}
-			
-			postItemDetails = append(postItemDetails, gundamDetails)
-		}
-		postInfo.ExchangePostItems = postItemDetails
-		
-		// Đếm số lượng offer của bài đăng
-		offerCount, err := server.dbStore.CountExchangeOffers(c.Request.Context(), post.ID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		postInfo.OfferCount = offerCount
-		
-		// Lấy offer của người dùng đã đăng nhập (nếu có)
-		// if userID != "" && userID != post.User { // Kiểm tra người dùng không phải là người đăng bài
-		// 	offer, err := server.dbStore.GetUserExchangeOfferForPost(c.Request.Context(), db.GetUserExchangeOfferForPostParams{
-		// 		PostID:    post.OfferID,
-		// 		OffererID: userID,
-		// 	})
-		// 	if err != nil {
-		// 		if !errors.Is(err, db.ErrRecordNotFound) {
-		// 			c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 			return
-		// 		}
-		// 		// Nếu không tìm thấy offer, thì authenticatedUserOffer sẽ là nil
-		// 	} else {
-		// 		postInfo.AuthenticatedUserOffer = &offer
-		//
-		// 		// Lấy thông tin các Gundam trong offer (chỉ từ người đề xuất)
-		// 		offerItemsFromOfferer, err := server.dbStore.ListExchangeOfferItems(c.Request.Context(), db.ListExchangeOfferItemsParams{
-		// 			OfferID:      offer.OfferID,
-		// 			IsFromPoster: util.BoolPointer(false),
-		// 		})
-		// 		if err != nil {
-		// 			c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 			return
-		// 		}
-		// 		offerGundamDetails := make([]db.GundamDetails, 0, len(offerItemsFromOfferer))
-		// 		// Lặp qua từng item trong offer để lấy thông tin chi tiết của Gundam
-		// 		for _, item := range offerItemsFromOfferer {
-		// 			// Lấy thông tin Gundam của mỗi item
-		// 			gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), item.GundamID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("gundam ID %d not found", item.GundamID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy thông tin grade của Gundam
-		// 			grade, err := server.dbStore.GetGradeByID(c.Request.Context(), gundam.GradeID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("grade IDf %d not found", gundam.GradeID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy ảnh chính của Gundam
-		// 			primaryImageURL, err := server.dbStore.GetGundamPrimaryImageURL(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("primary image of gundam ID %d not found", gundam.OfferID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy ảnh phụ của Gundam
-		// 			secondaryImageURLs, err := server.dbStore.GetGundamSecondaryImageURLs(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("secondary images of gundam ID %d not found", gundam.OfferID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy thông tin phụ kiện của Gundam
-		// 			accessories, err := server.dbStore.GetGundamAccessories(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("accessories of gundam ID %d not found", gundam.OfferID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Chuyển đổi phụ kiện sang định dạng DTO
-		// 			accessoryDTOs := make([]db.GundamAccessoryDTO, len(accessories))
-		// 			for i, accessory := range accessories {
-		// 				accessoryDTOs[i] = db.ConvertGundamAccessoryToDTO(accessory)
-		// 			}
-		//
-		// 			// Tạo đối tượng GundamDetails
-		// 			detail := db.GundamDetails{
-		// 				OfferID:                   gundam.OfferID,
-		// 				OwnerID:              gundam.OwnerID,
-		// 				Name:                 gundam.Name,
-		// 				Slug:                 gundam.Slug,
-		// 				Grade:                grade.DisplayName,
-		// 				Series:               gundam.Series,
-		// 				PartsTotal:           gundam.PartsTotal,
-		// 				Material:             gundam.Material,
-		// 				Version:              gundam.Version,
-		// 				Quantity:             gundam.Quantity,
-		// 				Condition:            string(gundam.Condition),
-		// 				ConditionDescription: gundam.ConditionDescription,
-		// 				Manufacturer:         gundam.Manufacturer,
-		// 				Weight:               gundam.Weight,
-		// 				Scale:                string(gundam.Scale),
-		// 				Description:          gundam.Description,
-		// 				Price:                gundam.Price,
-		// 				ReleaseYear:          gundam.ReleaseYear,
-		// 				Status:               string(gundam.Status),
-		// 				Accessories:          accessoryDTOs,
-		// 				PrimaryImageURL:      primaryImageURL,
-		// 				SecondaryImageURLs:   secondaryImageURLs,
-		// 				CreatedAt:            gundam.CreatedAt,
-		// 				UpdatedAt:            gundam.UpdatedAt,
-		// 			}
-		//
-		// 			offerGundamDetails = append(offerGundamDetails, detail)
-		// 		}
-		// 		postInfo.AuthenticatedUserOfferItems = offerGundamDetails
-		//
-		// 		// Lấy thông tin các Gundam từ người đăng mà người đề xuất muốn nhận
-		// 		offerItemsFromPoster, err := server.dbStore.ListExchangeOfferItems(c.Request.Context(), db.ListExchangeOfferItemsParams{
-		// 			OfferID:      offer.OfferID,
-		// 			IsFromPoster: util.BoolPointer(true),
-		// 		})
-		// 		if err != nil {
-		// 			c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 			return
-		// 		}
-		// 		wantedGundamDetails := make([]db.GundamDetails, 0, len(offerItemsFromOfferer))
-		// 		// Lặp qua từng item trong offer để lấy thông tin chi tiết của Gundam
-		// 		for _, item := range offerItemsFromPoster {
-		// 			// Lấy thông tin Gundam của mỗi item
-		// 			gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), item.GundamID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("gundam ID %d not found", item.GundamID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy thông tin grade của Gundam
-		// 			grade, err := server.dbStore.GetGradeByID(c.Request.Context(), gundam.GradeID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("grade ID %d not found", gundam.GradeID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy ảnh chính của Gundam
-		// 			primaryImageURL, err := server.dbStore.GetGundamPrimaryImageURL(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				if errors.Is(err, db.ErrRecordNotFound) {
-		// 					err = fmt.Errorf("primary image of gundam ID %d not found", gundam.OfferID)
-		// 					c.JSON(http.StatusNotFound, errorResponse(err))
-		// 					return
-		// 				}
-		//
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy ảnh phụ của Gundam
-		// 			secondaryImageURLs, err := server.dbStore.GetGundamSecondaryImageURLs(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Lấy thông tin phụ kiện của Gundam
-		// 			accessories, err := server.dbStore.GetGundamAccessories(c.Request.Context(), gundam.OfferID)
-		// 			if err != nil {
-		// 				c.JSON(http.StatusInternalServerError, errorResponse(err))
-		// 				return
-		// 			}
-		//
-		// 			// Chuyển đổi phụ kiện sang định dạng DTO
-		// 			accessoryDTOs := make([]db.GundamAccessoryDTO, len(accessories))
-		// 			for i, accessory := range accessories {
-		// 				accessoryDTOs[i] = db.ConvertGundamAccessoryToDTO(accessory)
-		// 			}
-		//
-		// 			// Tạo đối tượng GundamDetails
-		// 			detail := db.GundamDetails{
-		// 				OfferID:                   gundam.OfferID,
-		// 				OwnerID:              gundam.OwnerID,
-		// 				Name:                 gundam.Name,
-		// 				Slug:                 gundam.Slug,
-		// 				Grade:                grade.DisplayName,
-		// 				Series:               gundam.Series,
-		// 				PartsTotal:           gundam.PartsTotal,
-		// 				Material:             gundam.Material,
-		// 				Version:              gundam.Version,
-		// 				Quantity:             gundam.Quantity,
-		// 				Condition:            string(gundam.Condition),
-		// 				ConditionDescription: gundam.ConditionDescription,
-		// 				Manufacturer:         gundam.Manufacturer,
-		// 				Weight:               gundam.Weight,
-		// 				Scale:                string(gundam.Scale),
-		// 				Description:          gundam.Description,
-		// 				Price:                gundam.Price,
-		// 				ReleaseYear:          gundam.ReleaseYear,
-		// 				Status:               string(gundam.Status),
-		// 				Accessories:          accessoryDTOs,
-		// 				PrimaryImageURL:      primaryImageURL,
-		// 				SecondaryImageURLs:   secondaryImageURLs,
-		// 				CreatedAt:            gundam.CreatedAt,
-		// 				UpdatedAt:            gundam.UpdatedAt,
-		// 			}
-		//
-		// 			wantedGundamDetails = append(wantedGundamDetails, detail)
-		// 		}
-		// 		postInfo.AuthenticatedUserWantedItems = wantedGundamDetails
-		// 	}
-		// }
-		
-		result = append(result, postInfo)
+	// Lắp ráp thông tin người đăng, Gundam và số lượng offer theo lô cho cả trang
+	result, err := server.dbStore.LoadOpenExchangePostInfos(c.Request.Context(), posts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 	
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, newPageResponse(result, info))
 }
 
 //	@Summary		Delete an exchange post
@@ -650,17 +408,17 @@ func (server *Server) deleteExchangePost(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
-// @Summary		Get user's exchange post details
-// @Description	Get detailed information about a specific exchange post owned by the authenticated user, including items and offers.
-// @Tags			exchanges
-// @Produce		json
-// @Security		accessToken
-// @Param			postID	path		string						true	"Exchange Post ID"
-// @Param			status	query		string						false	"Filter by status (open, closed)"
-// @Success		200		{object}	db.UserExchangePostDetails	"User exchange post details"
-// @Failure		400		{object}	error						"Invalid post ID or status"
-// @Failure		404		{object}	error						"Post not found"
-// @Router			/users/me/exchange-posts/{postID} [get]
+//	@Summary		Get user's exchange post details
+//	@Description	Get detailed information about a specific exchange post owned by the authenticated user, including items and offers.
+//	@Tags			exchanges
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			postID	path		string						true	"Exchange Post ID"
+//	@Param			status	query		string						false	"Filter by status (open, closed)"
+//	@Success		200		{object}	db.UserExchangePostDetails	"User exchange post details"
+//	@Failure		400		{object}	error						"Invalid post ID or status"
+//	@Failure		404		{object}	error						"Post not found"
+//	@Router			/users/me/exchange-posts/{postID} [get]
 func (server *Server) getUserExchangePost(c *gin.Context) {
 	// Lấy thông tin người dùng đã đăng nhập
 	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
@@ -6,12 +6,16 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
+	"unicode"
 	
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/rs/zerolog/log"
 )
 
@@ -33,30 +37,142 @@ func (server *Server) listGundamGrades(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, grades)
 }
 
+// Các kiểu sắp xếp hỗ trợ khi tìm kiếm Gundam.
+const (
+	gundamSortRelevance = "relevance"
+	gundamSortPriceAsc  = "price_asc"
+	gundamSortPriceDesc = "price_desc"
+)
+
+var gundamSorts = []string{sortNewest, sortOldest, gundamSortPriceAsc, gundamSortPriceDesc, gundamSortRelevance}
+
 type listGundamsRequest struct {
-	Name      *string `form:"name"`
-	GradeSlug *string `form:"grade"`
-	Status    *string `form:"status"`
+	Query        *string `form:"q"`
+	Name         *string `form:"name"` // Deprecated: dùng q thay thế
+	GradeSlug    *string `form:"grade"`
+	Scale        *string `form:"scale"`
+	Condition    *string `form:"condition"`
+	Manufacturer *string `form:"manufacturer"`
+	Status       *string `form:"status"`
+	MinPrice     *int64  `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice     *int64  `form:"max_price" binding:"omitempty,min=0"`
+	ReleaseYear  *int64  `form:"release_year" binding:"omitempty,min=1970"`
+	ModelKitID   *int64  `form:"model_kit_id" binding:"omitempty,min=1"`
+	pageQuery
 }
 
 func (r *listGundamsRequest) validate() error {
 	if r.Status != nil {
-		return db.IsValidGundamStatus(*r.Status)
+		if err := db.IsValidGundamStatus(*r.Status); err != nil {
+			return err
+		}
+	}
+	
+	if r.Scale != nil {
+		if err := db.IsValidGundamScale(*r.Scale); err != nil {
+			return err
+		}
+	}
+	
+	if r.Condition != nil {
+		if err := db.IsValidGundamCondition(*r.Condition); err != nil {
+			return err
+		}
+	}
+	
+	if r.MinPrice != nil && r.MaxPrice != nil && *r.MinPrice > *r.MaxPrice {
+		return fmt.Errorf("min_price %d must not be greater than max_price %d", *r.MinPrice, *r.MaxPrice)
 	}
 	
 	return nil
 }
 
+// normalize giữ tương thích với client cũ còn dùng tham số name.
+func (r *listGundamsRequest) normalize() {
+	if r.Query == nil && r.Name != nil {
+		r.Query = r.Name
+	}
+}
+
+// defaultSort sắp xếp theo độ liên quan khi có từ khóa, ngược lại theo thời gian tạo.
+func (r *listGundamsRequest) defaultSort() string {
+	if r.Query != nil {
+		return gundamSortRelevance
+	}
+	
+	return sortNewest
+}
+
+// buildSearchQuery chuyển từ khóa người dùng nhập thành tsquery dạng prefix, ví dụ "RX-78 Gundam" => "rx:* & 78:* & gundam:*".
+// Chỉ giữ lại chữ cái và chữ số nên người dùng không thể chèn toán tử tsquery. Trả về nil nếu không còn từ khóa hợp lệ.
+func buildSearchQuery(raw *string) *string {
+	if raw == nil {
+		return nil
+	}
+	
+	tokens := strings.FieldsFunc(strings.ToLower(*raw), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(tokens) == 0 {
+		return nil
+	}
+	
+	for i, token := range tokens {
+		tokens[i] = token + ":*"
+	}
+	
+	query := strings.Join(tokens, " & ")
+	return &query
+}
+
+type listGundamsResponse struct {
+	pageResponse[db.GundamDetails]
+	Total  int64                            `json:"total"`
+	Facets map[string][]db.GundamFacetValue `json:"facets"`
+}
+
+// gundamCursorOf trả về giá trị cursor của một dòng kết quả tìm kiếm theo kiểu sắp xếp.
+func gundamCursorOf(sort string) func(row db.SearchGundamsRow) (string, string) {
+	return func(row db.SearchGundamsRow) (string, string) {
+		id := int64CursorValue(row.GundamID)
+		
+		switch sort {
+		case gundamSortPriceAsc, gundamSortPriceDesc:
+			var price int64
+			if row.Price != nil {
+				price = *row.Price
+			}
+			return int64CursorValue(price), id
+		case gundamSortRelevance:
+			return float32CursorValue(row.Rank), id
+		default:
+			return timeCursorValue(row.CreatedAt), id
+		}
+	}
+}
+
 //	@Summary		List Gundams
-//	@Description	Retrieves a list of selling Gundams, optionally filtered by grade
+//	@Description	Full-text search over the Gundam catalog (name, series, version, description) with filters, sorting, pagination and facet counts.
+//	@Description	Search is accent-insensitive and matches word prefixes, e.g. "rx 78" matches "RX-78-2 Gundam".
 //	@Tags			gundams
 //	@Produce		json
-//	@Param			name	query	string				false	"Filter by Gundam name"			example(YR-04 Fire Lord)
-//	@Param			grade	query	string				false	"Filter by Gundam grade slug"	example(master-grade)
-//	@Param			status	query	string				false	"Filter by Gundam status"		Enums(in store, published, processing, pending auction approval, auctioning)
-//	@Success		200		array	db.GundamDetails	"Successfully retrieved list of Gundams"
-//	@Failure		400		"Bad Request - Invalid query parameters"
-//	@Failure		500		"Internal Server Error - Failed to retrieve Gundams"
+//	@Param			q				query		string				false	"Search keywords"	example(rx 78)
+//	@Param			name			query		string				false	"Deprecated: use q"
+//	@Param			grade			query		string				false	"Filter by Gundam grade slug"				example(master-grade)
+//	@Param			scale			query		string				false	"Filter by scale"							Enums(1/144, 1/100, 1/60, 1/48)
+//	@Param			condition		query		string				false	"Filter by condition"						Enums(new, open box, used)
+//	@Param			manufacturer	query		string				false	"Filter by manufacturer (case-insensitive)"	example(Bandai)
+//	@Param			status			query		string				false	"Filter by Gundam status"					Enums(in store, published, processing, pending auction approval, auctioning)
+//	@Param			min_price		query		integer				false	"Minimum price (inclusive)"
+//	@Param			max_price		query		integer				false	"Maximum price (exclusive)"
+//	@Param			release_year	query		integer				false	"Filter by release year"
+//	@Param			model_kit_id	query		integer				false	"Only listings linked to this model kit"
+//	@Param			sort			query		string				false	"Sort order (default: relevance when q is set, newest otherwise)"	Enums(relevance, newest, oldest, price_asc, price_desc)
+//	@Param			cursor			query		string				false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit			query		integer				false	"Page size (default: 20, max: 100)"
+//	@Success		200				{object}	listGundamsResponse	"Successfully retrieved list of Gundams"
+//	@Failure		400				"Bad Request - Invalid query parameters"
+//	@Failure		500				"Internal Server Error - Failed to retrieve Gundams"
 //	@Router			/gundams [get]
 func (server *Server) listGundams(ctx *gin.Context) {
 	req := new(listGundamsRequest)
@@ -67,86 +183,97 @@ func (server *Server) listGundams(ctx *gin.Context) {
 		return
 	}
 	
-	// Validate the status parameter
+	// Validate the filter parameters
 	if err := req.validate(); err != nil {
-		log.Error().Err(err).Msg("invalid status")
+		log.Error().Err(err).Msg("invalid query parameters")
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 	
-	arg := db.ListGundamsWithFiltersParams{
-		GradeSlug: req.GradeSlug,
-		Status:    req.Status,
-		Name:      req.Name,
+	req.normalize()
+	searchQuery := buildSearchQuery(req.Query)
+	
+	page, err := req.parse(gundamSorts, req.defaultSort())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	arg := db.SearchGundamsParams{
+		Query:        searchQuery,
+		GradeSlug:    req.GradeSlug,
+		Scale:        req.Scale,
+		Condition:    req.Condition,
+		Manufacturer: req.Manufacturer,
+		Status:       req.Status,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		ReleaseYear:  req.ReleaseYear,
+		ModelKitID:   req.ModelKitID,
+		CursorID:     cursorID,
+		Sort:         page.Sort,
+		CursorValue:  page.CursorValue(),
+		Limit:        page.FetchLimit(),
+	}
+	
+	rows, err := server.dbStore.SearchGundams(ctx, arg)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to search gundams")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 	
-	result, err := server.dbStore.ListGundamsWithFilters(ctx, arg)
+	facetRows, err := server.dbStore.SearchGundamFacets(ctx, db.SearchGundamFacetsParams{
+		Query:        searchQuery,
+		GradeSlug:    req.GradeSlug,
+		Scale:        req.Scale,
+		Condition:    req.Condition,
+		Manufacturer: req.Manufacturer,
+		Status:       req.Status,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		ReleaseYear:  req.ReleaseYear,
+		ModelKitID:   req.ModelKitID,
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("failed to list result")
+		log.Error().Err(err).Msg("failed to count gundam facets")
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	
-	resp := make([]db.GundamDetails, len(result))
+	result, info := paginate(rows, page, gundamCursorOf(page.Sort))
+	facets := db.GroupGundamFacets(facetRows)
 	
-	// Map the result to the response struct
+	// Tổng số Gundam khớp bộ lọc (không phụ thuộc cursor) lấy từ facet condition vì mỗi Gundam có đúng một condition
+	var total int64
+	for _, facet := range facets["condition"] {
+		total += facet.Count
+	}
+	
+	// Tải ảnh và phụ kiện của cả trang theo lô
+	gundamIDs := make([]int64, len(result))
 	for i, row := range result {
-		primaryImageURL, err := server.dbStore.GetGundamPrimaryImageURL(ctx, row.GundamID)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to get primary image")
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		secondaryImageURLs, err := server.dbStore.GetGundamSecondaryImageURLs(ctx, row.GundamID)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to get secondary images")
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		accessories, err := server.dbStore.GetGundamAccessories(ctx, row.GundamID)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to get gundam accessories")
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		
-		// Map the accessories to the response struct
-		accessoryDTOs := make([]db.GundamAccessoryDTO, len(accessories))
-		for i, accessory := range accessories {
-			accessoryDTOs[i] = db.ConvertGundamAccessoryToDTO(accessory)
-		}
-		
-		resp[i] = db.GundamDetails{
-			ID:                   row.GundamID,
-			OwnerID:              row.OwnerID,
-			Name:                 row.Name,
-			Slug:                 row.Slug,
-			Grade:                row.Grade,
-			Series:               row.Series,
-			PartsTotal:           row.PartsTotal,
-			Material:             row.Material,
-			Version:              row.Version,
-			Quantity:             row.Quantity,
-			Condition:            string(row.Condition),
-			ConditionDescription: row.ConditionDescription,
-			Manufacturer:         row.Manufacturer,
-			Weight:               row.Weight,
-			Scale:                string(row.Scale),
-			Description:          row.Description,
-			Price:                row.Price,
-			ReleaseYear:          row.ReleaseYear,
-			Status:               string(row.Status),
-			Accessories:          accessoryDTOs,
-			PrimaryImageURL:      primaryImageURL,
-			SecondaryImageURLs:   secondaryImageURLs,
-			CreatedAt:            row.CreatedAt,
-			UpdatedAt:            row.UpdatedAt,
-		}
+		gundamIDs[i] = row.GundamID
 	}
 	
-	ctx.JSON(http.StatusOK, resp)
+	gundams, err := server.dbStore.ListGundamDetailsByIDs(ctx, gundamIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load gundam details")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, listGundamsResponse{
+		pageResponse: newPageResponse(gundams, info),
+		Total:        total,
+		Facets:       facets,
+	})
 }
 
 type getGundamBySlugQuery struct {
@@ -215,6 +342,7 @@ func (server *Server) getGundamBySlug(ctx *gin.Context) {
 	resp.Material = row.Material
 	resp.Version = row.Version
 	resp.Quantity = row.Quantity
+	resp.AvailableQuantity = row.Quantity - row.ReservedQuantity
 	resp.Condition = string(row.Condition)
 	resp.ConditionDescription = row.ConditionDescription
 	resp.Manufacturer = row.Manufacturer
@@ -224,6 +352,7 @@ func (server *Server) getGundamBySlug(ctx *gin.Context) {
 	resp.Price = row.Price
 	resp.ReleaseYear = row.ReleaseYear
 	resp.Status = string(row.Status)
+	resp.ModelKitID = row.ModelKitID
 	resp.CreatedAt = row.CreatedAt
 	resp.UpdatedAt = row.UpdatedAt
 	
@@ -260,17 +389,21 @@ func (server *Server) getGundamBySlug(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// createGundamRequest: khi có model_kit_id, các trường name, grade_id, scale, series, manufacturer, version, release_year
+// có thể bỏ trống và sẽ được điền từ model kit (xem prefillFromModelKit).
 type createGundamRequest struct {
-	Name                 string                  `form:"name" binding:"required"`
-	GradeID              int64                   `form:"grade_id" binding:"required"`
-	Series               string                  `form:"series" binding:"required"`
+	ModelKitID           *int64                  `form:"model_kit_id" binding:"omitempty,min=1"`
+	Name                 string                  `form:"name"`
+	GradeID              int64                   `form:"grade_id"`
+	Series               string                  `form:"series"`
 	PartsTotal           int64                   `form:"parts_total" binding:"required"`
 	Material             string                  `form:"material" binding:"required"`
-	Version              string                  `form:"version" binding:"required"`
+	Version              string                  `form:"version"`
 	Condition            string                  `form:"condition" binding:"required"`
-	Manufacturer         string                  `form:"manufacturer" binding:"required"`
-	Scale                string                  `form:"scale" binding:"required"`
+	Manufacturer         string                  `form:"manufacturer"`
+	Scale                string                  `form:"scale"`
 	Weight               int64                   `form:"weight" binding:"required"`
+	Quantity             *int64                  `form:"quantity" binding:"omitempty,min=1"`
 	Description          string                  `form:"description" binding:"required"`
 	Price                *int64                  `form:"price"`
 	ReleaseYear          *int64                  `form:"release_year"`
@@ -280,22 +413,85 @@ type createGundamRequest struct {
 	Accessories          []db.GundamAccessoryDTO `form:"accessory"`
 }
 
+// prefillFromModelKit điền các trường còn trống từ model kit mà Gundam liên kết tới.
+// Grade và scale nếu được gửi lên phải khớp với model kit, để mọi tin đăng của cùng một kit là cùng một sản phẩm.
+func (req *createGundamRequest) prefillFromModelKit(kit db.ModelKit) error {
+	if req.GradeID != 0 && req.GradeID != kit.GradeID {
+		return fmt.Errorf("grade_id %d does not match grade of model kit ID %d", req.GradeID, kit.ID)
+	}
+	
+	if req.Scale != "" && req.Scale != string(kit.Scale) {
+		return fmt.Errorf("scale %s does not match scale of model kit ID %d", req.Scale, kit.ID)
+	}
+	
+	req.GradeID = kit.GradeID
+	req.Scale = string(kit.Scale)
+	
+	if req.Name == "" {
+		req.Name = kit.Name
+	}
+	if req.Series == "" {
+		req.Series = kit.Series
+	}
+	if req.Manufacturer == "" {
+		req.Manufacturer = kit.Manufacturer
+	}
+	if req.Version == "" && kit.Version != nil {
+		req.Version = *kit.Version
+	}
+	if req.ReleaseYear == nil {
+		req.ReleaseYear = kit.ReleaseYear
+	}
+	
+	return nil
+}
+
+func (req *createGundamRequest) getQuantity() int64 {
+	if req.Quantity != nil {
+		return *req.Quantity
+	}
+	
+	return 1 // Default quantity is 1
+}
+
+// validate kiểm tra các trường bắt buộc sau khi đã điền từ model kit (nếu có).
+func (req *createGundamRequest) validate() error {
+	switch {
+	case req.Name == "":
+		return errors.New("name is required")
+	case req.GradeID == 0:
+		return errors.New("grade_id is required")
+	case req.Series == "":
+		return errors.New("series is required")
+	case req.Version == "":
+		return errors.New("version is required")
+	case req.Manufacturer == "":
+		return errors.New("manufacturer is required")
+	case req.Scale == "":
+		return errors.New("scale is required")
+	}
+	
+	return db.IsValidGundamScale(req.Scale)
+}
+
 //	@Summary		Create a new Gundam model
 //	@Description	Create a new Gundam model with images and accessories
 //	@Tags			users
 //	@Accept			multipart/form-data
 //	@Produce		json
 //	@Param			id						path		string	true	"User ID"
-//	@Param			name					formData	string	true	"Gundam name"
-//	@Param			grade_id				formData	integer	true	"Gundam grade ID"
-//	@Param			series					formData	string	true	"Gundam series name"
+//	@Param			model_kit_id			formData	integer	false	"Model kit the Gundam is a listing of. Empty name, grade_id, scale, series, manufacturer, version and release_year are prefilled from the kit"
+//	@Param			name					formData	string	false	"Gundam name (required without model_kit_id)"
+//	@Param			grade_id				formData	integer	false	"Gundam grade ID (required without model_kit_id)"
+//	@Param			series					formData	string	false	"Gundam series name (required without model_kit_id)"
 //	@Param			parts_total				formData	integer	true	"Total number of parts"
 //	@Param			material				formData	string	true	"Gundam material"
-//	@Param			version					formData	string	true	"Gundam version"
+//	@Param			version					formData	string	false	"Gundam version (required unless the model kit has one)"
 //	@Param			condition				formData	string	true	"Condition of the Gundam"	Enums(new, open box, used)
-//	@Param			manufacturer			formData	string	true	"Manufacturer name"
-//	@Param			scale					formData	string	true	"Gundam scale"	Enums(1/144, 1/100, 1/60)
+//	@Param			manufacturer			formData	string	false	"Manufacturer name (required without model_kit_id)"
+//	@Param			scale					formData	string	false	"Gundam scale (required without model_kit_id)"	Enums(1/144, 1/100, 1/60, 1/48)
 //	@Param			weight					formData	integer	true	"Weight in grams"
+//	@Param			quantity				formData	integer	false	"Number of units in stock, e.g. several sealed kits sold under one listing (default: 1)"
 //	@Param			description				formData	string	true	"Detailed description"
 //	@Param			price					formData	integer	false	"Price in VND"
 //	@Param			release_year			formData	integer	false	"Release year"
@@ -306,7 +502,7 @@ type createGundamRequest struct {
 //	@Security		accessToken
 //	@Success		201	{object}	db.GundamDetails	"Successfully created Gundam"
 //	@Failure		400	"Bad Request - Invalid input data"
-//	@Failure		404	"Not Found - User with specified ID does not exist"
+//	@Failure		404	"Not Found - User or model kit with specified ID does not exist"
 //	@Failure		403	"Forbidden - User is not authorized to create Gundam for this user"
 //	@Failure		500	"Internal Server Error - Failed to create Gundam"
 //	@Router			/users/:id/gundams [post]
@@ -341,6 +537,31 @@ func (server *Server) createGundam(ctx *gin.Context) {
 		return
 	}
 	
+	if req.ModelKitID != nil {
+		kit, err := server.dbStore.GetModelKitByID(ctx, *req.ModelKitID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				err = fmt.Errorf("model kit ID %d not found", *req.ModelKitID)
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			
+			log.Error().Err(err).Msg("failed to get model kit")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		if err = req.prefillFromModelKit(kit); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	
+	if err := req.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
 	arg := db.CreateGundamTxParams{
 		OwnerID:              userID,
 		Name:                 req.Name,
@@ -350,7 +571,7 @@ func (server *Server) createGundam(ctx *gin.Context) {
 		PartsTotal:           req.PartsTotal,
 		Material:             req.Material,
 		Version:              req.Version,
-		Quantity:             1, // Default quantity is 1
+		Quantity:             req.getQuantity(),
 		Condition:            db.GundamCondition(req.Condition),
 		ConditionDescription: req.ConditionDescription,
 		Manufacturer:         req.Manufacturer,
@@ -359,14 +580,20 @@ func (server *Server) createGundam(ctx *gin.Context) {
 		Description:          req.Description,
 		Price:                req.Price,
 		ReleaseYear:          req.ReleaseYear,
+		ModelKitID:           req.ModelKitID,
 		Accessories:          req.Accessories,
 		PrimaryImage:         req.PrimaryImage,
 		SecondaryImages:      req.SecondaryImages,
-		UploadImagesFunc:     server.uploadFileToCloudinary,
+		UploadImagesFunc:     server.uploadImages,
 	}
 	
 	result, err := server.dbStore.CreateGundamTx(ctx, arg)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
 		log.Error().Err(err).Msg("failed to create gundam")
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -478,6 +705,7 @@ func (server *Server) listGundamsByUser(ctx *gin.Context) {
 			Material:             gundam.Material,
 			Version:              gundam.Version,
 			Quantity:             gundam.Quantity,
+			AvailableQuantity:    gundam.Quantity - gundam.ReservedQuantity,
 			Condition:            string(gundam.Condition),
 			ConditionDescription: gundam.ConditionDescription,
 			Manufacturer:         gundam.Manufacturer,
@@ -487,6 +715,7 @@ func (server *Server) listGundamsByUser(ctx *gin.Context) {
 			Price:                gundam.Price,
 			ReleaseYear:          gundam.ReleaseYear,
 			Status:               string(gundam.Status),
+			ModelKitID:           gundam.ModelKitID,
 			Accessories:          accessoryDTOs,
 			PrimaryImageURL:      primaryImageURL,
 			SecondaryImageURLs:   secondaryImageURLs,
@@ -509,6 +738,7 @@ type updateGundamBasisInfoRequest struct {
 	ConditionDescription *string `json:"condition_description" binding:"omitempty,max=1000"`
 	Manufacturer         *string `json:"manufacturer" binding:"omitempty,min=1,max=255"`
 	Weight               *int64  `json:"weight" binding:"omitempty,gt=0"`
+	Quantity             *int64  `json:"quantity" binding:"omitempty,gt=0"`
 	Scale                *string `json:"scale" binding:"omitempty,oneof=1/144 1/100 1/60 1/48"`
 	Description          *string `json:"description" binding:"omitempty,min=1"`
 	Price                *int64  `json:"price" binding:"omitempty,gte=0"`
@@ -531,8 +761,29 @@ func (req *updateGundamBasisInfoRequest) getScale() string {
 	return *req.Scale
 }
 
+// onlyPriceOrQuantity cho biết yêu cầu chỉ cập nhật giá và/hoặc số lượng,
+// là các thay đổi duy nhất được phép khi Gundam đang được đăng bán (ví dụ: người bán nhập thêm hàng).
+func (req *updateGundamBasisInfoRequest) onlyPriceOrQuantity() bool {
+	return (req.Price != nil || req.Quantity != nil) &&
+		req.Name == nil &&
+		req.GradeID == nil &&
+		req.Series == nil &&
+		req.PartsTotal == nil &&
+		req.Material == nil &&
+		req.Version == nil &&
+		req.Condition == nil &&
+		req.ConditionDescription == nil &&
+		req.Manufacturer == nil &&
+		req.Weight == nil &&
+		req.Scale == nil &&
+		req.Description == nil &&
+		req.ReleaseYear == nil
+}
+
 //	@Summary		Update Gundam basis info
-//	@Description	Update the basic information of a Gundam model
+//	@Description	Update the basic information of a Gundam model. A published Gundam can only change its price and quantity.
+//	@Description	The quantity cannot be lower than the units reserved for pending orders.
+//	@Description	Price changes are recorded in the price history, and users watching a published Gundam are notified when its price drops.
 //	@Tags			gundams
 //	@Accept			json
 //	@Produce		json
@@ -548,6 +799,7 @@ func (req *updateGundamBasisInfoRequest) getScale() string {
 //	@Param			condition_description	body	string	false	"Additional details about condition"
 //	@Param			manufacturer			body	string	false	"Manufacturer name"
 //	@Param			weight					body	integer	false	"Weight in grams"
+//	@Param			quantity				body	integer	false	"Number of units in stock"
 //	@Param			scale					body	string	false	"Gundam scale"	Enums(1/144, 1/100, 1/60, 1/48)
 //	@Param			description				body	string	false	"Detailed description"
 //	@Param			price					body	integer	false	"Price in VND"
@@ -589,7 +841,7 @@ func (server *Server) updateGundamBasisInfo(c *gin.Context) {
 	gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), gundamID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
-			err = fmt.Errorf("gundam ID %s not found", gundamID)
+			err = fmt.Errorf("gundam ID %d not found", gundamID)
 			c.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -605,15 +857,29 @@ func (server *Server) updateGundamBasisInfo(c *gin.Context) {
 		return
 	}
 	
-	if gundam.Status != db.GundamStatusInstore {
+	var req updateGundamBasisInfoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	switch {
+	case gundam.Status == db.GundamStatusInstore:
+	case gundam.Status == db.GundamStatusPublished && req.onlyPriceOrQuantity():
+	case gundam.Status == db.GundamStatusPublished:
+		err = fmt.Errorf("gundam ID %d is published, only its price and quantity can be updated", gundamID)
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	default:
 		err = fmt.Errorf("gundam ID %d is not in store", gundamID)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
 	
-	var req updateGundamBasisInfoRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse(err))
+	// Không thể giảm số lượng xuống dưới số đơn vị đang được giữ cho các đơn hàng chưa hoàn tất
+	if req.Quantity != nil && *req.Quantity < gundam.ReservedQuantity {
+		err = fmt.Errorf("quantity %d is less than the %d units reserved for pending orders of gundam ID %d", *req.Quantity, gundam.ReservedQuantity, gundamID)
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
 		return
 	}
 	
@@ -632,6 +898,7 @@ func (server *Server) updateGundamBasisInfo(c *gin.Context) {
 		ConditionDescription: req.ConditionDescription,
 		Manufacturer:         req.Manufacturer,
 		Weight:               req.Weight,
+		Quantity:             req.Quantity,
 		Scale: db.NullGundamScale{
 			GundamScale: db.GundamScale(req.getScale()),
 			Valid:       req.Scale != nil,
@@ -641,17 +908,41 @@ func (server *Server) updateGundamBasisInfo(c *gin.Context) {
 		ReleaseYear: req.ReleaseYear,
 	}
 	
-	err = server.dbStore.UpdateGundam(c.Request.Context(), arg)
+	result, err := server.dbStore.UpdateGundamTx(c.Request.Context(), db.UpdateGundamTxParams{
+		UpdateGundamParams: arg,
+		PreviousPrice:      gundam.Price,
+	})
 	if err != nil {
+		// Một đơn hàng giữ hàng sau khi kiểm tra số lượng ở trên
+		if pgErr := db.ErrorDescription(err); pgErr != nil && pgErr.Code == db.CheckViolationCode && pgErr.ConstraintName == db.GundamReservedQuantityConstraint {
+			err = fmt.Errorf("quantity is less than the units reserved for pending orders of gundam ID %d", gundamID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	
+	// Báo cho những người đang theo dõi khi Gundam đang bán được giảm giá, lỗi chỉ được ghi log vì giá đã được cập nhật
+	priceChange := result.PriceChange
+	if gundam.Status == db.GundamStatusPublished && priceChange != nil &&
+		priceChange.PreviousPrice != nil && priceChange.Price < *priceChange.PreviousPrice {
+		err = worker.DistributeMatchWatchers(c.Request.Context(), server.taskDistributor, &worker.PayloadMatchWatchers{
+			GundamID:      gundamID,
+			Price:         priceChange.Price,
+			PreviousPrice: priceChange.PreviousPrice,
+		})
+		if err != nil {
+			log.Error().Err(err).Int64("gundam_id", gundamID).Msg("failed to distribute match watchers task")
+		}
+	}
+	
 	// Return the updated Gundam details
 	updatedGundam, err := server.dbStore.GetGundamDetailsByID(c.Request.Context(), nil, gundamID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
-			err = fmt.Errorf("gundam ID %s not found", gundamID)
+			err = fmt.Errorf("gundam ID %d not found", gundamID)
 			c.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -790,7 +1081,14 @@ func (server *Server) hardDeleteGundam(c *gin.Context) {
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
-	
+
+	// Gundam đã ngừng đăng bán vẫn có thể còn đơn hàng chưa hoàn tất đang giữ hàng
+	if gundam.ReservedQuantity > 0 {
+		err = fmt.Errorf("gundam ID %d has %d units reserved for pending orders", gundamID, gundam.ReservedQuantity)
+		c.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
 	err = server.dbStore.DeleteGundam(c.Request.Context(), db.DeleteGundamParams{
 		ID:      gundamID,
 		OwnerID: userID,
@@ -818,7 +1116,7 @@ func (server *Server) hardDeleteGundam(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id			path	string					true	"User ID"
-//	@Param			gundamID	path	string					true	"Gundam ID"	
+//	@Param			gundamID	path	string					true	"Gundam ID"
 //	@Param			request		body	[]db.GundamAccessoryDTO	true	"Array of Gundam accessories"
 //	@Security		accessToken
 //	@Success		200	{object}	db.GundamDetails	"Successfully updated Gundam details"
@@ -994,16 +1292,21 @@ func (server *Server) updateGundamPrimaryImage(c *gin.Context) {
 		return
 	}
 	
-	// Upload the new primary image to Cloudinary
-	uploadedFileURLs, err := server.uploadFileToCloudinary("gundam", gundam.Slug, util.FolderGundams, req.PrimaryImage)
+	// Upload the new primary image to the file store
+	uploadedFileURLs, err := server.uploadImages(c.Request.Context(), "gundam", gundam.Slug, util.FolderGundams, req.PrimaryImage)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
 		log.Error().Err(err).Msg("failed to upload primary image")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	
 	if len(uploadedFileURLs) == 0 {
-		err = fmt.Errorf("no primary image URL returned from Cloudinary")
+		err = fmt.Errorf("no primary image URL returned from file store")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -1020,9 +1323,10 @@ func (server *Server) updateGundamPrimaryImage(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	currentPrimaryImagePublicID, err := util.ExtractPublicIDFromURL(currentPrimaryImageURL)
+	// Ảnh được tải lên trước khi đổi file store không thuộc file store hiện tại nên không xóa được
+	currentPrimaryImageKey, err := server.fileStore.KeyFromURL(currentPrimaryImageURL)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to extract public ID from URL")
+		log.Warn().Err(err).Str("url", currentPrimaryImageURL).Msg("failed to get file key from primary image URL")
 	}
 	
 	primaryImageURL := uploadedFileURLs[0]
@@ -1042,10 +1346,15 @@ func (server *Server) updateGundamPrimaryImage(c *gin.Context) {
 		return
 	}
 	
-	// Delete the old primary image from Cloudinary
-	err = server.fileStore.DeleteFile(currentPrimaryImagePublicID, "")
+	// Delete the old primary image from the file store if no Gundam still uses it (images are content-addressed)
+	count, err := server.dbStore.CountGundamImagesByURL(c.Request.Context(), currentPrimaryImageURL)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to delete old primary image from Cloudinary")
+		log.Error().Err(err).Msg("failed to count gundam images by URL")
+	} else if count == 0 && currentPrimaryImageKey != "" {
+		err = server.fileStore.DeleteFile(c.Request.Context(), currentPrimaryImageKey)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to delete old primary image from file store")
+		}
 	}
 	
 	// Return the updated Gundam details
@@ -1145,9 +1454,14 @@ func (server *Server) addGundamSecondaryImages(c *gin.Context) {
 	result, err := server.dbStore.AddGundamSecondaryImagesTx(c.Request.Context(), db.AddGundamSecondaryImagesTxParams{
 		Gundam:           gundam,
 		Images:           req.Images,
-		UploadImagesFunc: server.uploadFileToCloudinary,
+		UploadImagesFunc: server.uploadImages,
 	})
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
 		log.Error().Err(err).Msg("failed to add gundam secondary images")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -1258,15 +1572,15 @@ func (server *Server) deleteGundamSecondaryImage(c *gin.Context) {
 		return
 	}
 	
-	publicID, err := util.ExtractPublicIDFromURL(req.ImageURL)
+	// Ảnh được tải lên trước khi đổi file store vẫn được xóa khỏi database, chỉ bỏ qua việc xóa file
+	fileKey, err := server.fileStore.KeyFromURL(req.ImageURL)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid image URL: %w", err)))
-		return
+		log.Warn().Err(err).Str("url", req.ImageURL).Msg("failed to get file key from image URL")
 	}
 	
 	err = server.dbStore.DeleteGundamSecondaryImageTx(c.Request.Context(), db.DeleteGundamSecondaryImageTxParams{
 		GundamImage:     gundamImage,
-		PublicID:        publicID,
+		FileKey:         fileKey,
 		DeleteImageFunc: server.fileStore.DeleteFile,
 	})
 	if err != nil {
@@ -1315,3 +1629,44 @@ func (server *Server) getGundamDetails(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, gundam)
 }
+
+//	@Summary		Get Gundam price history
+//	@Description	List every price change of a Gundam, newest first. The first entry of a Gundam has no previous price.
+//	@Tags			gundams
+//	@Produce		json
+//	@Param			gundamID	path	integer					true	"Gundam ID"
+//	@Success		200			{array}	db.GundamPriceHistory	"Price history of the Gundam"
+//	@Failure		400			"Bad Request - Invalid Gundam ID"
+//	@Failure		404			"Not Found - Gundam does not exist"
+//	@Failure		500			"Internal Server Error - Failed to list price history"
+//	@Router			/gundams/{gundamID}/price-history [get]
+func (server *Server) getGundamPriceHistory(c *gin.Context) {
+	gundamID, err := strconv.ParseInt(c.Param("gundamID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid gundam ID %s", c.Param("gundamID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	_, err = server.dbStore.GetGundamByID(c.Request.Context(), gundamID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("gundam ID %d not found", gundamID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get gundam")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	history, err := server.dbStore.ListGundamPriceHistory(c.Request.Context(), gundamID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list gundam price history")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, history)
+}
@@ -15,17 +15,27 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/rs/zerolog/log"
 )
 
-func (server *Server) uploadFileToCloudinary(key string, value string, folder string, files ...*multipart.FileHeader) (uploadedFileURLs []string, err error) {
+// uploadImages xử lý từng ảnh (kiểm tra định dạng và kích thước, xóa EXIF/GPS, xoay đúng chiều, tạo thumbnail)
+// rồi tải ảnh và thumbnail lên file store dưới tên file theo nội dung của ảnh.
+// key và value là loại và slug/mã của đối tượng sở hữu ảnh, dùng để ghi nhận dấu vân tay và phát hiện ảnh trùng.
+// Lỗi do ảnh không hợp lệ bọc storage.ErrInvalidImage.
+func (server *Server) uploadImages(ctx context.Context, key string, value string, folder string, files ...*multipart.FileHeader) (uploadedFileURLs []string, err error) {
 	if len(files) == 0 {
 		return nil, fmt.Errorf("no files provided")
 	}
 	
 	for _, file := range files {
+		if file.Size > int64(storage.DefaultImageOptions.MaxBytes) {
+			return nil, fmt.Errorf("file %s: %w", file.Filename, storage.ErrImageTooLarge)
+		}
+		
 		// Open and read file
 		currentFile, err := file.Open()
 		if err != nil {
@@ -38,15 +48,24 @@ func (server *Server) uploadFileToCloudinary(key string, value string, folder st
 			return nil, fmt.Errorf("failed to read file: %w", err)
 		}
 		
-		fileName := fmt.Sprintf("%s_%s_%d", key, value, time.Now().Unix())
+		processed, err := storage.ProcessImage(fileBytes, storage.DefaultImageOptions)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %w", file.Filename, err)
+		}
 		
-		// Upload new avatar to cloudinary
-		uploadedFileURL, err := server.fileStore.UploadFile(fileBytes, fileName, folder)
+		uploadedFile, err := server.fileStore.UploadFile(ctx, processed.Data, processed.Filename(), folder)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upload file: %w", err)
 		}
 		
-		uploadedFileURLs = append(uploadedFileURLs, uploadedFileURL)
+		thumbnail, err := server.fileStore.UploadFile(ctx, processed.Thumbnail, processed.ThumbnailFilename(), folder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload thumbnail: %w", err)
+		}
+		
+		server.recordImageFingerprint(ctx, key, value, folder, uploadedFile.URL, thumbnail.URL, processed)
+		
+		uploadedFileURLs = append(uploadedFileURLs, uploadedFile.URL)
 	}
 	
 	return uploadedFileURLs, nil
@@ -103,8 +122,9 @@ func (server *Server) handleRegularOrderConfirmation(ctx *gin.Context, order db.
 				return db.CompleteRegularOrderTxResult{}, err
 			}
 			
-			if gundam.Status != db.GundamStatusProcessing {
-				return db.CompleteRegularOrderTxResult{}, fmt.Errorf("gundam ID %d is not in processing status", *item.GundamID)
+			// Gundam phải đang được giữ cho đơn hàng: đã hết hàng ("processing") hoặc còn số lượng đang được giữ
+			if gundam.Status != db.GundamStatusProcessing && gundam.ReservedQuantity < item.Quantity {
+				return db.CompleteRegularOrderTxResult{}, fmt.Errorf("gundam ID %d is not reserved for order item %d", *item.GundamID, item.ID)
 			}
 		} else {
 			log.Warn().Msg("gundam ID is nil in order item")
@@ -553,6 +573,20 @@ func (server *Server) sendOrderCancelNotifications(ctx context.Context, result d
 	}
 }
 
+// sendEmail đưa email mẫu name vào hàng đợi để worker gửi cho người dùng recipientID.
+// Ngôn ngữ của email được chọn theo header Accept-Language của request hiện tại.
+func (server *Server) sendEmail(ctx *gin.Context, recipientID string, name mailer.TemplateName, data any) {
+	locale := mailer.LocaleFromAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	
+	err := worker.DistributeEmail(ctx.Request.Context(), server.taskDistributor, recipientID, name, locale, data)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("recipient_id", recipientID).
+			Str("template", string(name)).
+			Msg("failed to send email")
+	}
+}
+
 // generateRandomAvatar tự động tạo avatar ngẫu nhiên cho người dùng.
 func (server *Server) generateRandomAvatar(ctx context.Context, fullName string) (string, error) {
 	// Danh sách các style có sẵn trong DiceBear 9.x
@@ -634,13 +668,13 @@ func (server *Server) generateRandomAvatar(ctx context.Context, fullName string)
 		return "", fmt.Errorf("empty SVG data received from DiceBear")
 	}
 	
-	// Tải lên Cloudinary trực tiếp từ dữ liệu trong bộ nhớ
-	uploadedAvatarURL, err := server.fileStore.UploadFile(svgData, fileName, "avatars")
+	// Tải lên file store trực tiếp từ dữ liệu trong bộ nhớ
+	uploadedAvatar, err := server.fileStore.UploadFile(ctx, svgData, fileName, util.FolderAvatars)
 	if err != nil {
-		return "", fmt.Errorf("failed to upload avatar to Cloudinary: %w", err)
+		return "", fmt.Errorf("failed to upload avatar to file store: %w", err)
 	}
 	
-	return uploadedAvatarURL, nil
+	return uploadedAvatar.URL, nil
 }
 
 func transformSubscriptionDetails(sub db.GetCurrentActiveSubscriptionDetailsForSellerRow) SubscriptionDetailsResponse {
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type createImageCleanupRunRequest struct {
+	// true: chỉ tạo báo cáo các ảnh mồ côi, false: xóa các ảnh mồ côi đã quá grace period
+	DryRun *bool `json:"dry_run" binding:"required"`
+}
+
+//	@Summary		Start an orphaned image cleanup
+//	@Description	Reconcile stored images with the image URLs referenced in the database and delete images that are no longer used.
+//	@Description	Only images uploaded more than the grace period (72 hours) ago are considered orphaned.
+//	@Description	A dry run only records the orphaned images in the report without deleting them.
+//	@Description	The cleanup runs in the background, poll GET /admin/image-cleanup-runs/{runID} for the report.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			body	body		createImageCleanupRunRequest	true	"Cleanup options"
+//	@Success		202		{object}	db.ImageCleanupRun				"Queued cleanup run"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/admin/image-cleanup-runs [post]
+func (server *Server) createImageCleanupRun(c *gin.Context) {
+	admin := c.MustGet(adminPayloadKey).(*token.Payload)
+	
+	var req createImageCleanupRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	run, err := server.dbStore.CreateImageCleanupRun(c.Request.Context(), db.CreateImageCleanupRunParams{
+		DryRun:           *req.DryRun,
+		GracePeriodHours: int32(worker.ImageCleanupGracePeriod.Hours()),
+		RequestedBy:      &admin.Subject,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create image cleanup run")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.taskDistributor.DistributeTaskCleanupImages(c.Request.Context(), &worker.PayloadCleanupImages{
+		RunID: run.ID,
+	},
+		asynq.MaxRetry(1),
+		asynq.Queue(worker.QueueDefault),
+	)
+	if err != nil {
+		log.Error().Err(err).Int64("run_id", run.ID).Msg("failed to distribute image cleanup task")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusAccepted, run)
+}
+
+//	@Summary		List orphaned image cleanup runs
+//	@Description	List scheduled and admin-requested image cleanup runs, newest first, without the orphaned image lists.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			cursor	query		string										false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer										false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.ListImageCleanupRunsRow]	"List of cleanup runs"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/admin/image-cleanup-runs [get]
+func (server *Server) listImageCleanupRuns(c *gin.Context) {
+	_ = c.MustGet(adminPayloadKey).(*token.Payload)
+	
+	page, err := parsePageQuery(c, []string{sortNewest}, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListImageCleanupRuns(c.Request.Context(), db.ListImageCleanupRunsParams{
+		CursorID: cursorID,
+		Limit:    page.FetchLimit(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list image cleanup runs")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	runs, info := paginate(rows, page, func(row db.ListImageCleanupRunsRow) (string, string) {
+		return int64CursorValue(row.ID), int64CursorValue(row.ID)
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(runs, info))
+}
+
+//	@Summary		Get an orphaned image cleanup report
+//	@Description	Get a cleanup run with its counts and the list of orphaned images (at most 1000 entries).
+//	@Tags			admin
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			runID	path		integer				true	"Cleanup run ID"
+//	@Success		200		{object}	db.ImageCleanupRun	"Cleanup report"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		404		"Not Found - Cleanup run does not exist"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/admin/image-cleanup-runs/{runID} [get]
+func (server *Server) getImageCleanupRun(c *gin.Context) {
+	_ = c.MustGet(adminPayloadKey).(*token.Payload)
+	
+	runID, err := strconv.ParseInt(c.Param("runID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid image cleanup run ID %s", c.Param("runID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	run, err := server.dbStore.GetImageCleanupRun(c.Request.Context(), runID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("image cleanup run ID %d not found", runID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get image cleanup run")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, run)
+}
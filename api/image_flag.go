@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+// maxDuplicateImageDistance là khoảng cách Hamming tối đa (trên 64 bit) để hai ảnh được xem là gần giống nhau.
+const maxDuplicateImageDistance = 6
+
+// duplicateCheckedFolders là các thư mục chứa ảnh đăng bán/trao đổi, ảnh tải lên các thư mục này được so với
+// ảnh của các đối tượng khác để phát hiện ảnh bị trùng hoặc bị lấy cắp.
+var duplicateCheckedFolders = []string{util.FolderGundams, util.FolderExchanges}
+
+// recordImageFingerprint lưu dấu vân tay của ảnh vừa tải lên và đánh dấu các ảnh gần giống của đối tượng khác cho moderator.
+// Lỗi chỉ được ghi log vì không ảnh hưởng đến việc tải ảnh lên.
+func (server *Server) recordImageFingerprint(ctx context.Context, refType string, refID string, folder string, url string, thumbnailURL string, image *storage.ProcessedImage) {
+	fingerprint, err := server.dbStore.CreateImageFingerprint(ctx, db.CreateImageFingerprintParams{
+		URL:            url,
+		ThumbnailURL:   thumbnailURL,
+		Folder:         folder,
+		RefType:        refType,
+		RefID:          refID,
+		ContentHash:    image.ContentHash,
+		PerceptualHash: int64(image.PerceptualHash),
+		Width:          int32(image.Width),
+		Height:         int32(image.Height),
+	})
+	if err != nil {
+		log.Error().Err(err).Str("url", url).Msg("failed to create image fingerprint")
+		return
+	}
+	
+	if !slices.Contains(duplicateCheckedFolders, folder) {
+		return
+	}
+	
+	flagged, err := server.dbStore.FlagDuplicateImages(ctx, db.FlagDuplicateImagesParams{
+		FingerprintID: fingerprint.ID,
+		MaxDistance:   maxDuplicateImageDistance,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("fingerprint_id", fingerprint.ID).Msg("failed to flag duplicate images")
+		return
+	}
+	
+	if flagged > 0 {
+		log.Warn().
+			Str("ref_type", refType).
+			Str("ref_id", refID).
+			Str("url", url).
+			Int64("flagged", flagged).
+			Msg("uploaded image is similar to existing images, flagged for moderator review")
+	}
+}
+
+//	@Summary		List flagged duplicate images
+//	@Description	List pairs of near-duplicate listing images uploaded for different Gundams or exchange posts.
+//	@Description	A flag means a newly uploaded image looks like an image that already belongs to another listing,
+//	@Description	which may be a stolen or reused photo.
+//	@Tags			moderator
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			status	query		string								false	"Filter by flag status"	Enums(pending, dismissed, confirmed)
+//	@Param			cursor	query		string								false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer								false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.ListImageFlagsRow]	"List of flagged images"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/mod/image-flags [get]
+func (server *Server) listImageFlags(c *gin.Context) {
+	_ = c.MustGet(moderatorPayloadKey).(*token.Payload)
+	
+	status := c.Query("status")
+	if status != "" {
+		if err := db.IsValidImageFlagStatus(status); err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	
+	page, err := parsePageQuery(c, []string{sortNewest}, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListImageFlags(c.Request.Context(), db.ListImageFlagsParams{
+		Status: db.NullImageFlagStatus{
+			ImageFlagStatus: db.ImageFlagStatus(status),
+			Valid:           status != "",
+		},
+		CursorID: cursorID,
+		Limit:    page.FetchLimit(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list image flags")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	flags, info := paginate(rows, page, func(row db.ListImageFlagsRow) (string, string) {
+		return int64CursorValue(row.ID), int64CursorValue(row.ID)
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(flags, info))
+}
+
+type reviewImageFlagRequest struct {
+	// dismissed: hai ảnh không liên quan hoặc cùng người bán, confirmed: ảnh bị lấy cắp hoặc dùng lại
+	Status string `json:"status" binding:"required,oneof=dismissed confirmed"`
+}
+
+//	@Summary		Review a flagged duplicate image
+//	@Description	Mark a flagged pair of images as dismissed (not a problem) or confirmed (stolen or reused photo).
+//	@Tags			moderator
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			flagID	path		integer					true	"Image flag ID"
+//	@Param			body	body		reviewImageFlagRequest	true	"Review result"
+//	@Success		200		{object}	db.ImageFlag			"Reviewed image flag"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		404		"Not Found - Image flag does not exist"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/mod/image-flags/{flagID} [patch]
+func (server *Server) reviewImageFlag(c *gin.Context) {
+	user := c.MustGet(moderatorPayloadKey).(*token.Payload)
+	
+	flagID, err := strconv.ParseInt(c.Param("flagID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid image flag ID %s", c.Param("flagID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	var req reviewImageFlagRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	flag, err := server.dbStore.ReviewImageFlag(c.Request.Context(), db.ReviewImageFlagParams{
+		Status:     db.ImageFlagStatus(req.Status),
+		ReviewedBy: &user.Subject,
+		ID:         flagID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("image flag ID %d not found", flagID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to review image flag")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, flag)
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMarketPriceMonths = 12
+	// minMarketPriceSamples là số lần bán tối thiểu để đưa ra khoảng giá gợi ý
+	minMarketPriceSamples = 3
+)
+
+// Cơ sở để gom các lần bán khi ước tính giá thị trường của một Gundam.
+const (
+	marketPriceBasisModelKit   = "model_kit"
+	marketPriceBasisSlugFamily = "slug_family"
+)
+
+// Nguồn của một lần bán trong view gundam_sales.
+const (
+	saleSourceOrder    = "order"
+	saleSourceAuction  = "auction"
+	saleSourceExchange = "exchange"
+)
+
+// marketPriceSources trả về các nguồn bán được dùng để thống kê giá. Giao dịch trao đổi chỉ được tính khi được yêu cầu
+// vì tiền bù (compensation_amount) chỉ là phần chênh lệch giữa hai bên, không phải giá của một Gundam.
+func marketPriceSources(includeExchanges bool) []string {
+	sources := []string{saleSourceOrder, saleSourceAuction}
+	if includeExchanges {
+		sources = append(sources, saleSourceExchange)
+	}
+	
+	return sources
+}
+
+// marketPriceStats là thống kê giá bán của một tình trạng, Condition = nil đối với thống kê tổng hợp.
+type marketPriceStats struct {
+	Condition  *string `json:"condition"`
+	SampleSize int64   `json:"sample_size"`
+	MinPrice   *int64  `json:"min_price"`
+	P25        *int64  `json:"p25"`
+	Median     *int64  `json:"median"`
+	P75        *int64  `json:"p75"`
+	MaxPrice   *int64  `json:"max_price"`
+}
+
+// suggestedPriceRange là khoảng giá gợi ý (p25 - p75) cho một Gundam.
+// Condition là tình trạng được dùng để tính, nil nếu không đủ dữ liệu cho tình trạng của Gundam và phải dùng thống kê tổng hợp.
+type suggestedPriceRange struct {
+	Low        int64   `json:"low"`
+	Median     int64   `json:"median"`
+	High       int64   `json:"high"`
+	Condition  *string `json:"condition"`
+	SampleSize int64   `json:"sample_size"`
+}
+
+// marketPrice là thống kê giá bán của các Gundam cùng model kit, hoặc cùng họ slug nếu Gundam chưa liên kết model kit.
+type marketPrice struct {
+	Basis       string             `json:"basis"`
+	ModelKitID  *int64             `json:"model_kit_id"`
+	Since       time.Time          `json:"since"`
+	Sources     []string           `json:"sources"`
+	Overall     marketPriceStats   `json:"overall"`
+	ByCondition []marketPriceStats `json:"by_condition"`
+}
+
+func newMarketPriceStats(row db.GetMarketPriceStatsRow) marketPriceStats {
+	stats := marketPriceStats{
+		SampleSize: row.SampleSize,
+		MinPrice:   row.MinPrice,
+		P25:        row.P25,
+		Median:     row.Median,
+		P75:        row.P75,
+		MaxPrice:   row.MaxPrice,
+	}
+	if !row.IsOverall {
+		condition := string(row.Condition.GundamCondition)
+		stats.Condition = &condition
+	}
+	
+	return stats
+}
+
+// getMarketPrice tính thống kê giá bán cho Gundam từ các lần bán thuộc sources kể từ since.
+func (server *Server) getMarketPrice(ctx context.Context, gundam db.Gundam, since time.Time, sources []string) (marketPrice, error) {
+	result := marketPrice{
+		Basis:       marketPriceBasisSlugFamily,
+		ModelKitID:  gundam.ModelKitID,
+		Since:       since,
+		Sources:     sources,
+		ByCondition: []marketPriceStats{},
+	}
+	if gundam.ModelKitID != nil {
+		result.Basis = marketPriceBasisModelKit
+	}
+	
+	rows, err := server.dbStore.GetMarketPriceStats(ctx, db.GetMarketPriceStatsParams{
+		ModelKitID: gundam.ModelKitID,
+		Slug:       slugFilter(gundam),
+		Since:      since,
+		Sources:    sources,
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to get market price stats: %w", err)
+	}
+	
+	for _, row := range rows {
+		switch {
+		case row.IsOverall:
+			result.Overall = newMarketPriceStats(row)
+		case row.Condition.Valid:
+			// Bỏ qua các lần bán không biết tình trạng (bán trước khi có snapshot và Gundam đã bị xóa), chúng vẫn được tính trong thống kê tổng hợp
+			result.ByCondition = append(result.ByCondition, newMarketPriceStats(row))
+		}
+	}
+	
+	return result, nil
+}
+
+// suggestPriceRange ưu tiên thống kê theo tình trạng của Gundam, nếu không đủ dữ liệu thì dùng thống kê tổng hợp.
+// Trả về nil nếu không đủ minMarketPriceSamples lần bán.
+func (m marketPrice) suggestPriceRange(condition db.GundamCondition) *suggestedPriceRange {
+	candidates := make([]marketPriceStats, 0, 2)
+	for _, stats := range m.ByCondition {
+		if *stats.Condition == string(condition) {
+			candidates = append(candidates, stats)
+		}
+	}
+	candidates = append(candidates, m.Overall)
+	
+	for _, stats := range candidates {
+		if stats.SampleSize < minMarketPriceSamples || stats.P25 == nil || stats.Median == nil || stats.P75 == nil {
+			continue
+		}
+		
+		return &suggestedPriceRange{
+			Low:        *stats.P25,
+			Median:     *stats.Median,
+			High:       *stats.P75,
+			Condition:  stats.Condition,
+			SampleSize: stats.SampleSize,
+		}
+	}
+	
+	return nil
+}
+
+type getGundamMarketPriceRequest struct {
+	Months           int     `form:"months" binding:"omitempty,min=1,max=36"`
+	Condition        *string `form:"condition"`
+	IncludeExchanges bool    `form:"include_exchanges"`
+}
+
+type gundamMarketPriceResponse struct {
+	GundamID int64 `json:"gundam_id"`
+	marketPrice
+	Trend               []db.ListMarketPriceTrendRow `json:"trend"`
+	SuggestedPriceRange *suggestedPriceRange         `json:"suggested_price_range"`
+}
+
+//	@Summary		Get market price of a Gundam
+//	@Description	Estimate the market price of a Gundam from completed orders and auctions of the same model kit,
+//	@Description	or of Gundams with the same slug family (slug without its random suffix) when it is not linked to a kit.
+//	@Description	Returns median, p25 and p75 overall and per condition, a monthly median trend and a suggested price range.
+//	@Description	The condition of each sale is the condition of the Gundam at the time it was sold.
+//	@Description	Completed exchanges with compensation are only counted when include_exchanges is true: each Gundam of the side
+//	@Description	receiving the compensation counts as a sale at compensation_amount, which is only the difference between the two sides.
+//	@Description	The sources field lists the kinds of sales that were counted (order, auction, exchange).
+//	@Tags			gundams
+//	@Produce		json
+//	@Param			gundamID			path		integer						true	"Gundam ID"
+//	@Param			months				query		integer						false	"Look-back window in months (default: 12, max: 36)"
+//	@Param			condition			query		string						false	"Only include sales of this condition in the trend"	Enums(new, open box, used)
+//	@Param			include_exchanges	query		boolean						false	"Also count completed exchanges with compensation (default: false)"
+//	@Success		200					{object}	gundamMarketPriceResponse	"Market price of the Gundam"
+//	@Failure		400					"Bad Request - Invalid parameters"
+//	@Failure		404					"Not Found - Gundam does not exist"
+//	@Failure		500					"Internal Server Error - Failed to estimate market price"
+//	@Router			/gundams/{gundamID}/market-price [get]
+func (server *Server) getGundamMarketPrice(c *gin.Context) {
+	gundamID, err := strconv.ParseInt(c.Param("gundamID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid gundam ID %s", c.Param("gundamID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	var req getGundamMarketPriceRequest
+	if err = c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.Condition != nil {
+		if err = db.IsValidGundamCondition(*req.Condition); err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	
+	if req.Months == 0 {
+		req.Months = defaultMarketPriceMonths
+	}
+	
+	gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), gundamID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("gundam ID %d not found", gundamID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get gundam")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	since := time.Now().AddDate(0, -req.Months, 0)
+	sources := marketPriceSources(req.IncludeExchanges)
+	market, err := server.getMarketPrice(c.Request.Context(), gundam, since, sources)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to get market price")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	trend, err := server.dbStore.ListMarketPriceTrend(c.Request.Context(), db.ListMarketPriceTrendParams{
+		ModelKitID: gundam.ModelKitID,
+		Slug:       slugFilter(gundam),
+		Condition:  req.Condition,
+		Since:      since,
+		Sources:    sources,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list market price trend")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, gundamMarketPriceResponse{
+		GundamID:            gundam.ID,
+		marketPrice:         market,
+		Trend:               trend,
+		SuggestedPriceRange: market.suggestPriceRange(gundam.Condition),
+	})
+}
+
+// slugFilter trả về slug để lọc theo họ slug khi Gundam chưa liên kết model kit.
+func slugFilter(gundam db.Gundam) *string {
+	if gundam.ModelKitID != nil {
+		return nil
+	}
+	
+	return &gundam.Slug
+}
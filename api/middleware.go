@@ -3,12 +3,17 @@ package api
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 	
 	"github.com/gin-gonic/gin"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/ratelimit"
 	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
 )
 
 const (
@@ -22,7 +27,7 @@ const (
 )
 
 // authMiddleware authenticates the user.
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, tokenVersionStore *token.TokenVersionStore, sessionStore *token.SessionStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if authorizationHeader == "" {
@@ -46,18 +51,45 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		}
 		
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
 		
+		// Từ chối token được cấp trước lần thay đổi quyền gần nhất (ví dụ: trở thành seller)
+		err = tokenVersionStore.Verify(ctx, payload)
+		if err != nil {
+			if errors.Is(err, token.ErrTokenVersionRevoked) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			
+			log.Err(err).Msg("failed to verify token version")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		// Từ chối token thuộc phiên đăng nhập đã bị thu hồi (đăng xuất từ thiết bị khác)
+		sessionID, err := sessionStore.Touch(ctx, payload, ctx.ClientIP())
+		if err != nil {
+			if errors.Is(err, token.ErrSessionRevoked) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			
+			log.Err(err).Msg("failed to verify session")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
 		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Set(sessionIDKey, sessionID)
 		ctx.Next()
 	}
 }
 
-func optionalAuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func optionalAuthMiddleware(tokenMaker token.Maker, tokenVersionStore *token.TokenVersionStore, sessionStore *token.SessionStore) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		
@@ -85,7 +117,7 @@ func optionalAuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		}
 		
 		accessToken := fields[1]
-		payload, err := tokenMaker.VerifyToken(accessToken)
+		payload, err := tokenMaker.VerifyToken(accessToken, token.TokenTypeAccessToken)
 		if err != nil {
 			// Token không hợp lệ nhưng vẫn cho phép tiếp tục
 			ctx.Set(authorizationPayloadKey, nil)
@@ -93,98 +125,174 @@ func optionalAuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 		
+		if err = tokenVersionStore.Verify(ctx, payload); err != nil {
+			// Token đã bị thu hồi nhưng vẫn cho phép tiếp tục
+			ctx.Set(authorizationPayloadKey, nil)
+			ctx.Next()
+			return
+		}
+		
+		sessionID, err := sessionStore.Touch(ctx, payload, ctx.ClientIP())
+		if err != nil {
+			// Phiên đăng nhập đã bị thu hồi nhưng vẫn cho phép tiếp tục
+			ctx.Set(authorizationPayloadKey, nil)
+			ctx.Next()
+			return
+		}
+		
 		// Nếu token hợp lệ, lưu payload vào context
 		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Set(sessionIDKey, sessionID)
 		ctx.Next()
 	}
 }
 
-func requiredSellerRole(dbStore db.Store) gin.HandlerFunc {
+// requiredSellerRole chỉ cho phép seller truy cập vào tài nguyên của chính mình.
+// Role được lấy từ access token nên không cần truy vấn database.
+func requiredSellerRole() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 		authenticatedUserID := authPayload.Subject
 		sellerID := ctx.Param("sellerID")
 		
-		seller, err := dbStore.GetUserByID(ctx, sellerID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("user ID %s not found", sellerID)
-				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
-				return
-			}
-			
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		if !authPayload.HasRole(string(db.UserRoleSeller)) {
+			err := fmt.Errorf("user ID %s is not a seller", authenticatedUserID)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
 		
-		if seller.Role != db.UserRoleSeller {
-			err = fmt.Errorf("user ID %s is not a seller", sellerID)
+		if authenticatedUserID != sellerID {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrSellerIDMismatch))
+			return
+		}
+		
+		ctx.Set(sellerPayloadKey, authPayload)
+		ctx.Next()
+	}
+}
+
+func requiredModeratorRole() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		
+		if !authPayload.HasRole(string(db.UserRoleModerator)) {
+			err := fmt.Errorf("user ID %s is not a moderator", authPayload.Subject)
 			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
 		
-		if authenticatedUserID != seller.ID {
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrSellerIDMismatch))
+		// Tài khoản quản trị bắt buộc phải đăng nhập bằng xác thực hai lớp
+		if !authPayload.MFA {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrTwoFactorRequired))
 			return
 		}
 		
-		ctx.Set(sellerPayloadKey, &seller)
+		ctx.Set(moderatorPayloadKey, authPayload)
 		ctx.Next()
 	}
 }
 
-func requiredModeratorRole(dbStore db.Store) gin.HandlerFunc {
+func requiredAdminRole() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-		authenticatedUserID := authPayload.Subject
 		
-		user, err := dbStore.GetUserByID(ctx, authenticatedUserID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("user ID %s not found", authenticatedUserID)
-				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
-				return
-			}
-			
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		if !authPayload.HasRole(string(db.UserRoleAdmin)) {
+			err := fmt.Errorf("user ID %s is not an admin", authPayload.Subject)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
 		
-		if user.Role != db.UserRoleModerator {
-			err = fmt.Errorf("user ID %s is not a moderator", authenticatedUserID)
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		if !authPayload.MFA {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ErrTwoFactorRequired))
 			return
 		}
 		
-		ctx.Set(moderatorPayloadKey, &user)
+		ctx.Set(adminPayloadKey, authPayload)
 		ctx.Next()
 	}
 }
 
-func requiredAdminRole(dbStore db.Store) gin.HandlerFunc {
+// requiredPermissions từ chối request nếu access token không có đủ các quyền được yêu cầu.
+// Quyền được nhúng vào access token theo role (xem token.PermissionsForRole) nên không cần truy vấn database.
+// Phải được đặt sau authMiddleware.
+func requiredPermissions(permissions ...token.Permission) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-		authenticatedUserID := authPayload.Subject
 		
-		user, err := dbStore.GetUserByID(ctx, authenticatedUserID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("user ID %s not found", authenticatedUserID)
-				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
+		for _, permission := range permissions {
+			if !authPayload.HasPermission(permission) {
+				err := fmt.Errorf("user ID %s does not have permission %s", authPayload.Subject, permission)
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
-			
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		
+		ctx.Next()
+	}
+}
+
+// requiredTwoFactorStepUp yêu cầu người dùng nhập lại mã TOTP (header X-TOTP-Code) trước các thao tác nhạy cảm
+// như chuyển tiền, kể cả khi access token đã qua xác thực hai lớp lúc đăng nhập.
+func (server *Server) requiredTwoFactorStepUp() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		
+		code := ctx.GetHeader(stepUpCodeHeaderKey)
+		if code == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ErrStepUpRequired))
 			return
 		}
 		
-		if user.Role != db.UserRoleAdmin {
-			err = fmt.Errorf("user ID %s is not an admin", authenticatedUserID)
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		err := server.verifySecondFactor(ctx, authPayload.Subject, code, "")
+		if err != nil {
+			handleTwoFactorError(ctx, err)
 			return
 		}
 		
-		ctx.Set(adminPayloadKey, &user)
 		ctx.Next()
 	}
 }
+
+// rateLimitMiddleware giới hạn số request theo policy. Request đã xác thực được tính theo user ID,
+// request chưa xác thực được tính theo IP, nên middleware này cần được đặt sau authMiddleware (nếu có).
+// Nếu Redis gặp lỗi, request vẫn được cho qua để không làm gián đoạn hệ thống.
+func rateLimitMiddleware(limiter *ratelimit.Limiter, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		subject := "ip:" + ctx.ClientIP()
+		if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+			if authPayload, ok := payload.(*token.Payload); ok && authPayload != nil {
+				subject = "user:" + authPayload.Subject
+			}
+		}
+		
+		result, err := limiter.Allow(ctx, policy, subject)
+		if err != nil {
+			log.Err(err).Str("policy", policy.Name).Msg("failed to check rate limit")
+			ctx.Next()
+			return
+		}
+		
+		// Header theo draft IETF "RateLimit header fields for HTTP"
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, ceilSeconds(policy.Window)))
+		ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.FormatInt(ceilSeconds(result.ResetAfter), 10))
+		
+		if !result.Allowed {
+			retryAfter := max(ceilSeconds(result.RetryAfter), 1)
+			ctx.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "too many requests, please try again later",
+				"retry_after": retryAfter,
+			})
+			return
+		}
+		
+		ctx.Next()
+	}
+}
+
+// ceilSeconds làm tròn lên thời gian theo giây
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,415 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const modelKitSortName = "name"
+
+// modelKitSorts là các kiểu sắp xếp của danh sách model kit, mặc định theo tên.
+var modelKitSorts = []string{modelKitSortName, sortNewest}
+
+type listModelKitsRequest struct {
+	Query     *string `form:"q"`
+	GradeSlug *string `form:"grade"`
+	Scale     *string `form:"scale"`
+	Series    *string `form:"series"`
+	pageQuery
+}
+
+//	@Summary		List model kits
+//	@Description	List the curated model kit catalog. Each kit includes the number of published listings linked to it and their lowest price.
+//	@Description	Use GET /gundams?model_kit_id={id} to list every listing of a kit.
+//	@Tags			model-kits
+//	@Produce		json
+//	@Param			q		query		string								false	"Search by kit name (accent-insensitive)"	example(rx-78)
+//	@Param			grade	query		string								false	"Filter by Gundam grade slug"				example(master-grade)
+//	@Param			scale	query		string								false	"Filter by scale"							Enums(1/144, 1/100, 1/60, 1/48)
+//	@Param			series	query		string								false	"Filter by series (case-insensitive)"
+//	@Param			sort	query		string								false	"Sort order (default: name)"	Enums(name, newest)
+//	@Param			cursor	query		string								false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer								false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.ListModelKitsRow]	"List of model kits"
+//	@Failure		400		"Bad Request - Invalid query parameters"
+//	@Failure		500		"Internal Server Error - Failed to list model kits"
+//	@Router			/model-kits [get]
+func (server *Server) listModelKits(c *gin.Context) {
+	var req listModelKitsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.Scale != nil {
+		if err := db.IsValidGundamScale(*req.Scale); err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	
+	page, err := req.parse(modelKitSorts, modelKitSortName)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListModelKits(c.Request.Context(), db.ListModelKitsParams{
+		Query:       req.Query,
+		GradeSlug:   req.GradeSlug,
+		Scale:       req.Scale,
+		Series:      req.Series,
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: page.CursorValue(),
+		Limit:       page.FetchLimit(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list model kits")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	kits, info := paginate(rows, page, func(row db.ListModelKitsRow) (string, string) {
+		if page.Sort == modelKitSortName {
+			return row.Name, int64CursorValue(row.ID)
+		}
+		
+		return timeCursorValue(row.CreatedAt), int64CursorValue(row.ID)
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(kits, info))
+}
+
+//	@Summary		Get model kit details
+//	@Description	Get a model kit with the number of published listings linked to it and their lowest price.
+//	@Tags			model-kits
+//	@Produce		json
+//	@Param			kitID	path		integer						true	"Model kit ID"
+//	@Success		200		{object}	db.GetModelKitDetailsRow	"Model kit details"
+//	@Failure		400		"Bad Request - Invalid model kit ID"
+//	@Failure		404		"Not Found - Model kit does not exist"
+//	@Failure		500		"Internal Server Error - Failed to get model kit"
+//	@Router			/model-kits/{kitID} [get]
+func (server *Server) getModelKit(c *gin.Context) {
+	kitID, err := strconv.ParseInt(c.Param("kitID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid model kit ID %s", c.Param("kitID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	kit, err := server.dbStore.GetModelKitDetails(c.Request.Context(), kitID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("model kit ID %d not found", kitID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get model kit details")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, kit)
+}
+
+type createModelKitRequest struct {
+	Name         string                `form:"name" binding:"required,max=255"`
+	GradeID      int64                 `form:"grade_id" binding:"required,gt=0"`
+	Scale        string                `form:"scale" binding:"required,oneof=1/144 1/100 1/60 1/48"`
+	Series       string                `form:"series" binding:"required,max=255"`
+	Manufacturer string                `form:"manufacturer" binding:"required,max=255"`
+	Version      *string               `form:"version" binding:"omitempty,min=1,max=255"`
+	ReleaseYear  *int64                `form:"release_year" binding:"omitempty,gt=1900,lt=2100"`
+	Msrp         *int64                `form:"msrp" binding:"omitempty,gte=0"`
+	Image        *multipart.FileHeader `form:"image"`
+}
+
+//	@Summary		Create a model kit
+//	@Description	Add a model kit to the curated catalog. Sellers can link their listings to it when creating a Gundam.
+//	@Tags			moderator
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			name			formData	string		true	"Kit name"	example(RX-78-2 Gundam)
+//	@Param			grade_id		formData	integer		true	"Gundam grade ID"
+//	@Param			scale			formData	string		true	"Scale"			Enums(1/144, 1/100, 1/60, 1/48)
+//	@Param			series			formData	string		true	"Series"		example(Mobile Suit Gundam)
+//	@Param			manufacturer	formData	string		true	"Manufacturer"	example(Bandai)
+//	@Param			version			formData	string		false	"Version"		example(Ver.Ka)
+//	@Param			release_year	formData	integer		false	"Official release year"
+//	@Param			msrp			formData	integer		false	"Manufacturer's suggested retail price in VND"
+//	@Param			image			formData	file		false	"Canonical image of the kit"
+//	@Success		201				{object}	db.ModelKit	"Created model kit"
+//	@Failure		400				"Bad Request - Invalid input data"
+//	@Failure		404				"Not Found - Grade does not exist"
+//	@Failure		500				"Internal Server Error - Failed to create model kit"
+//	@Router			/mod/model-kits [post]
+func (server *Server) createModelKit(c *gin.Context) {
+	var req createModelKitRequest
+	if err := c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if !server.checkGradeExists(c, req.GradeID) {
+		return
+	}
+	
+	arg := db.CreateModelKitParams{
+		Name:         req.Name,
+		Slug:         util.GenerateRandomSlug(req.Name),
+		GradeID:      req.GradeID,
+		Scale:        db.GundamScale(req.Scale),
+		Series:       req.Series,
+		Manufacturer: req.Manufacturer,
+		Version:      req.Version,
+		ReleaseYear:  req.ReleaseYear,
+		Msrp:         req.Msrp,
+	}
+	
+	if req.Image != nil {
+		uploadedFileURLs, err := server.uploadImages(c.Request.Context(), "model_kit", arg.Slug, util.FolderModelKits, req.Image)
+		if err != nil {
+			if errors.Is(err, storage.ErrInvalidImage) {
+				c.JSON(http.StatusBadRequest, errorResponse(err))
+				return
+			}
+			
+			log.Error().Err(err).Msg("failed to upload model kit image")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		arg.ImageURL = &uploadedFileURLs[0]
+	}
+	
+	kit, err := server.dbStore.CreateModelKit(c.Request.Context(), arg)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create model kit")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusCreated, kit)
+}
+
+type updateModelKitRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=255"`
+	GradeID      *int64  `json:"grade_id" binding:"omitempty,gt=0"`
+	Scale        *string `json:"scale" binding:"omitempty,oneof=1/144 1/100 1/60 1/48"`
+	Series       *string `json:"series" binding:"omitempty,min=1,max=255"`
+	Manufacturer *string `json:"manufacturer" binding:"omitempty,min=1,max=255"`
+	Version      *string `json:"version" binding:"omitempty,min=1,max=255"`
+	ReleaseYear  *int64  `json:"release_year" binding:"omitempty,gt=1900,lt=2100"`
+	Msrp         *int64  `json:"msrp" binding:"omitempty,gte=0"`
+}
+
+//	@Summary		Update a model kit
+//	@Description	Update the reference data of a model kit. Only the provided fields are changed.
+//	@Tags			moderator
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			kitID	path		integer					true	"Model kit ID"
+//	@Param			request	body		updateModelKitRequest	true	"Fields to update"
+//	@Success		200		{object}	db.ModelKit				"Updated model kit"
+//	@Failure		400		"Bad Request - Invalid input data"
+//	@Failure		404		"Not Found - Model kit or grade does not exist"
+//	@Failure		500		"Internal Server Error - Failed to update model kit"
+//	@Router			/mod/model-kits/{kitID} [patch]
+func (server *Server) updateModelKit(c *gin.Context) {
+	kitID, err := strconv.ParseInt(c.Param("kitID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid model kit ID %s", c.Param("kitID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	var req updateModelKitRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.GradeID != nil && !server.checkGradeExists(c, *req.GradeID) {
+		return
+	}
+	
+	arg := db.UpdateModelKitParams{
+		ID:           kitID,
+		Name:         req.Name,
+		GradeID:      req.GradeID,
+		Series:       req.Series,
+		Manufacturer: req.Manufacturer,
+		Version:      req.Version,
+		ReleaseYear:  req.ReleaseYear,
+		Msrp:         req.Msrp,
+	}
+	if req.Scale != nil {
+		arg.Scale = db.NullGundamScale{
+			GundamScale: db.GundamScale(*req.Scale),
+			Valid:       true,
+		}
+	}
+	
+	kit, err := server.dbStore.UpdateModelKit(c.Request.Context(), arg)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("model kit ID %d not found", kitID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to update model kit")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, kit)
+}
+
+type updateModelKitImageRequest struct {
+	Image *multipart.FileHeader `form:"image" binding:"required"`
+}
+
+//	@Summary		Update model kit image
+//	@Description	Replace the canonical image of a model kit.
+//	@Tags			moderator
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			kitID	path		integer		true	"Model kit ID"
+//	@Param			image	formData	file		true	"New canonical image of the kit"
+//	@Success		200		{object}	db.ModelKit	"Updated model kit"
+//	@Failure		400		"Bad Request - Invalid input data"
+//	@Failure		404		"Not Found - Model kit does not exist"
+//	@Failure		500		"Internal Server Error - Failed to update model kit image"
+//	@Router			/mod/model-kits/{kitID}/image [patch]
+func (server *Server) updateModelKitImage(c *gin.Context) {
+	kitID, err := strconv.ParseInt(c.Param("kitID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid model kit ID %s", c.Param("kitID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	var req updateModelKitImageRequest
+	if err = c.ShouldBindWith(&req, binding.FormMultipart); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	kit, err := server.dbStore.GetModelKitByID(c.Request.Context(), kitID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("model kit ID %d not found", kitID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get model kit")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	uploadedFileURLs, err := server.uploadImages(c.Request.Context(), "model_kit", kit.Slug, util.FolderModelKits, req.Image)
+	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to upload model kit image")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	kit, err = server.dbStore.UpdateModelKit(c.Request.Context(), db.UpdateModelKitParams{
+		ID:       kitID,
+		ImageURL: &uploadedFileURLs[0],
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to update model kit image")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, kit)
+}
+
+//	@Summary		Delete a model kit
+//	@Description	Delete a model kit from the catalog. Listings linked to it are kept and simply unlinked.
+//	@Tags			moderator
+//	@Security		accessToken
+//	@Param			kitID	path	integer	true	"Model kit ID"
+//	@Success		204		"Model kit deleted"
+//	@Failure		400		"Bad Request - Invalid model kit ID"
+//	@Failure		404		"Not Found - Model kit does not exist"
+//	@Failure		500		"Internal Server Error - Failed to delete model kit"
+//	@Router			/mod/model-kits/{kitID} [delete]
+func (server *Server) deleteModelKit(c *gin.Context) {
+	kitID, err := strconv.ParseInt(c.Param("kitID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid model kit ID %s", c.Param("kitID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	_, err = server.dbStore.GetModelKitByID(c.Request.Context(), kitID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("model kit ID %d not found", kitID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get model kit")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if err = server.dbStore.DeleteModelKit(c.Request.Context(), kitID); err != nil {
+		log.Error().Err(err).Msg("failed to delete model kit")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.Status(http.StatusNoContent)
+}
+
+// checkGradeExists trả về false và ghi response lỗi nếu grade không tồn tại.
+func (server *Server) checkGradeExists(c *gin.Context, gradeID int64) bool {
+	_, err := server.dbStore.GetGradeByID(c.Request.Context(), gradeID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("grade ID %d not found", gradeID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		
+		log.Error().Err(err).Msg("failed to get grade")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	
+	return true
+}
@@ -10,19 +10,27 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/validator"
 	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 )
 
+// moderatorListSorts là các kiểu sắp xếp cho danh sách yêu cầu mà moderator cần xử lý.
+var moderatorListSorts = []string{sortNewest, sortOldest}
+
 //	@Summary		List all auction requests for moderator
 //	@Description	Get a list of all auction requests with optional status filter.
 //	@Tags			moderator
 //	@Produce		json
 //	@Security		accessToken
-//	@Param			status	query	string				false	"Filter by auction request status"	Enums(pending,approved,rejected)
-//	@Success		200		{array}	db.AuctionRequest	"List of auction requests"
+//	@Param			status	query		string							false	"Filter by auction request status"	Enums(pending,approved,rejected)
+//	@Param			sort	query		string							false	"Sort order (default: newest)"		Enums(newest, oldest)
+//	@Param			cursor	query		string							false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer							false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.AuctionRequest]	"List of auction requests"
 //	@Router			/mod/auction-requests [get]
 func (server *Server) listAuctionRequestsForModerator(c *gin.Context) {
 	status := c.Query("status")
@@ -33,9 +41,27 @@ func (server *Server) listAuctionRequestsForModerator(c *gin.Context) {
 		}
 	}
 	
-	auctionRequests, err := server.dbStore.ListAuctionRequests(c.Request.Context(), db.NullAuctionRequestStatus{
-		AuctionRequestStatus: db.AuctionRequestStatus(status),
-		Valid:                status != "",
+	page, err := parsePageQuery(c, moderatorListSorts, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListAuctionRequests(c.Request.Context(), db.ListAuctionRequestsParams{
+		Status: db.NullAuctionRequestStatus{
+			AuctionRequestStatus: db.AuctionRequestStatus(status),
+			Valid:                status != "",
+		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
 		log.Error().Err(err).Msg("failed to list auction requests")
@@ -43,7 +69,11 @@ func (server *Server) listAuctionRequestsForModerator(c *gin.Context) {
 		return
 	}
 	
-	c.JSON(http.StatusOK, auctionRequests)
+	auctionRequests, info := paginate(rows, page, func(request db.AuctionRequest) (string, string) {
+		return timeCursorValue(request.CreatedAt), request.ID.String()
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(auctionRequests, info))
 }
 
 type rejectAuctionRequestBody struct {
@@ -61,7 +91,7 @@ type rejectAuctionRequestBody struct {
 //	@Success		200			{object}	db.AuctionRequest			"Rejected auction request"
 //	@Router			/mod/auction-requests/{requestID}/reject [patch]
 func (server *Server) rejectAuctionRequest(c *gin.Context) {
-	user := c.MustGet(moderatorPayloadKey).(*db.User)
+	user := c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	requestID, err := uuid.Parse(c.Param("requestID"))
 	if err != nil {
@@ -99,7 +129,7 @@ func (server *Server) rejectAuctionRequest(c *gin.Context) {
 	// Reject the auction request in transaction
 	rejectedRequest, err := server.dbStore.RejectAuctionRequestTx(c.Request.Context(), db.RejectAuctionRequestTxParams{
 		RequestID:      requestID,
-		RejectedBy:     user.ID,
+		RejectedBy:     user.Subject,
 		RejectedReason: req.Reason,
 	})
 	if err != nil {
@@ -137,7 +167,7 @@ func (server *Server) rejectAuctionRequest(c *gin.Context) {
 //	@Success		200			{object}	db.ApproveAuctionRequestTxResult	"Result of the approval transaction"
 //	@Router			/mod/auction-requests/{requestID}/approve [patch]
 func (server *Server) approveAuctionRequest(c *gin.Context) {
-	user := c.MustGet(moderatorPayloadKey).(*db.User)
+	user := c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	requestID, err := uuid.Parse(c.Param("requestID"))
 	if err != nil {
@@ -200,7 +230,7 @@ func (server *Server) approveAuctionRequest(c *gin.Context) {
 	// Now transaction only does data manipulation
 	result, err := server.dbStore.ApproveAuctionRequestTx(c.Request.Context(), db.ApproveAuctionRequestTxParams{
 		RequestID:  requestID,
-		ApprovedBy: user.ID,
+		ApprovedBy: user.Subject,
 		AfterAuctionCreated: func(auction db.Auction) error {
 			// 1. Schedule start auction task với custom task ID
 			startTaskPayload := &worker.PayloadStartAuction{
@@ -243,7 +273,7 @@ func (server *Server) approveAuctionRequest(c *gin.Context) {
 		log.Error().
 			Err(err).
 			Str("request_id", requestID.String()).
-			Str("moderator_id", user.ID).
+			Str("moderator_id", user.Subject).
 			Msg("failed to approve auction request")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -280,6 +310,18 @@ func (server *Server) approveAuctionRequest(c *gin.Context) {
 		log.Err(err).Msgf("failed to send notification to user ID %s", result.CreatedAuction.SellerID)
 	}
 	
+	// Báo cho những người đang theo dõi Gundam về phiên đấu giá sắp diễn ra
+	if result.CreatedAuction.GundamID != nil {
+		err = worker.DistributeMatchWatchers(c.Request.Context(), server.taskDistributor, &worker.PayloadMatchWatchers{
+			GundamID:  *result.CreatedAuction.GundamID,
+			AuctionID: &result.CreatedAuction.ID,
+			Price:     result.CreatedAuction.StartingPrice,
+		})
+		if err != nil {
+			log.Err(err).Msgf("failed to distribute match watchers task for auction ID %s", result.CreatedAuction.ID)
+		}
+	}
+	
 	c.JSON(http.StatusOK, result)
 }
 
@@ -299,7 +341,7 @@ type updateAuctionDetailsByModeratorBody struct {
 //	@Success		200			{object}	db.UpdateAuctionByModeratorTxResult	"Updated auction details"
 //	@Router			/mod/auctions/{auctionID} [patch]
 func (server *Server) updateAuctionDetailsByModerator(c *gin.Context) {
-	_ = c.MustGet(moderatorPayloadKey).(*db.User)
+	_ = c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	var req updateAuctionDetailsByModeratorBody
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -347,16 +389,21 @@ type completeWithdrawalRequestRequest struct {
 //	@Summary		Complete withdrawal request
 //	@Description	Complete a withdrawal request with transaction reference from bank.
 //	@Description	The request must be in pending or approved status.
+//	@Description	A fresh TOTP code is required in the X-TOTP-Code header.
 //	@Tags			moderator
 //	@Accept			json
 //	@Produce		json
 //	@Security		accessToken
+//	@Param			X-TOTP-Code	header		string								true	"Current TOTP code"
 //	@Param			requestID	path		string								true	"Withdrawal Request ID"
 //	@Param			body		body		completeWithdrawalRequestRequest	true	"Request body"
 //	@Success		200			{object}	db.WithdrawalRequestDetails			"Updated withdrawal request details"
+//	@Failure		401			"Missing or invalid TOTP code"
+//	@Failure		403			"Two-factor authentication required"
+//	@Failure		429			"Too many attempts"
 //	@Router			/mod/withdrawal-requests/{requestID}/complete [patch]
 func (server *Server) completeWithdrawalRequest(c *gin.Context) {
-	user := c.MustGet(moderatorPayloadKey).(*db.User)
+	user := c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	var req completeWithdrawalRequestRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -394,7 +441,7 @@ func (server *Server) completeWithdrawalRequest(c *gin.Context) {
 	// Execute transaction to complete withdrawal request
 	arg := db.CompleteWithdrawalRequestTxParams{
 		WithdrawalRequest:    request.WithdrawalRequest,
-		ModeratorID:          user.ID,
+		ModeratorID:          user.Subject,
 		TransactionReference: req.TransactionReference,
 	}
 	updatedRequest, err := server.dbStore.CompleteWithdrawalRequestTx(c, arg)
@@ -417,10 +464,25 @@ func (server *Server) completeWithdrawalRequest(c *gin.Context) {
 		if err != nil {
 			log.Err(err).Msgf("failed to distribute task notification for withdrawal request ID %s", request.WithdrawalRequest.ID.String())
 		}
-		
-		// TODO: Gửi qua email
 	}()
 	
+	// Gửi email xác nhận cho người dùng qua worker.
+	// Request đến từ moderator nên không dùng Accept-Language để chọn ngôn ngữ email.
+	completedAt := time.Now()
+	if updatedRequest.CompletedAt != nil {
+		completedAt = *updatedRequest.CompletedAt
+	}
+	emailErr := worker.DistributeEmail(c.Request.Context(), server.taskDistributor, updatedRequest.UserID, mailer.TemplateWithdrawalCompleted, mailer.DefaultLocale, mailer.WithdrawalCompletedData{
+		Amount:               updatedRequest.Amount,
+		BankName:             request.UserBankAccount.BankName,
+		AccountNumber:        mailer.MaskAccountNumber(request.UserBankAccount.AccountNumber),
+		TransactionReference: req.TransactionReference,
+		CompletedAt:          completedAt,
+	})
+	if emailErr != nil {
+		log.Warn().Err(emailErr).Str("withdrawal_request_id", updatedRequest.ID.String()).Msg("failed to send withdrawal completed email")
+	}
+	
 	resp := db.NewWithdrawalRequestDetails(updatedRequest, request.UserBankAccount)
 	
 	c.JSON(http.StatusOK, resp)
@@ -431,11 +493,14 @@ func (server *Server) completeWithdrawalRequest(c *gin.Context) {
 //	@Tags			moderator
 //	@Produce		json
 //	@Security		accessToken
-//	@Param			status	query	string						false	"Filter by withdrawal request status"	Enums(pending, approved, rejected, completed, rejected, canceled)
-//	@Success		200		{array}	db.WithdrawalRequestDetails	"List of withdrawal requests"
+//	@Param			status	query		string										false	"Filter by withdrawal request status"	Enums(pending, approved, rejected, completed, rejected, canceled)
+//	@Param			sort	query		string										false	"Sort order (default: newest)"			Enums(newest, oldest)
+//	@Param			cursor	query		string										false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer										false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.WithdrawalRequestDetails]	"List of withdrawal requests"
 //	@Router			/mod/withdrawal-requests [get]
 func (server *Server) listWithdrawalRequests(c *gin.Context) {
-	_ = c.MustGet(moderatorPayloadKey).(*db.User)
+	_ = c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	status := c.Query("status")
 	if status != "" {
@@ -445,9 +510,27 @@ func (server *Server) listWithdrawalRequests(c *gin.Context) {
 		}
 	}
 	
-	requests, err := server.dbStore.ListWithdrawalRequests(c, db.NullWithdrawalRequestStatus{
-		WithdrawalRequestStatus: db.WithdrawalRequestStatus(status),
-		Valid:                   status != "",
+	page, err := parsePageQuery(c, moderatorListSorts, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListWithdrawalRequests(c, db.ListWithdrawalRequestsParams{
+		Status: db.NullWithdrawalRequestStatus{
+			WithdrawalRequestStatus: db.WithdrawalRequestStatus(status),
+			Valid:                   status != "",
+		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
 		err = fmt.Errorf("failed to list withdrawal requests: %w", err)
@@ -455,12 +538,16 @@ func (server *Server) listWithdrawalRequests(c *gin.Context) {
 		return
 	}
 	
+	requests, info := paginate(rows, page, func(row db.ListWithdrawalRequestsRow) (string, string) {
+		return timeCursorValue(row.WithdrawalRequest.CreatedAt), row.WithdrawalRequest.ID.String()
+	})
+	
 	resp := make([]db.WithdrawalRequestDetails, 0, len(requests))
 	for _, req := range requests {
 		resp = append(resp, db.NewWithdrawalRequestDetails(req.WithdrawalRequest, req.UserBankAccount))
 	}
 	
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, newPageResponse(resp, info))
 }
 
 type rejectWithdrawalRequestRequest struct {
@@ -470,7 +557,7 @@ type rejectWithdrawalRequestRequest struct {
 
 //	@Summary		Reject withdrawal request
 //	@Description	Reject a withdrawal request with reason from moderator. The request must be in pending status.
-//	@Tags			moderator 
+//	@Tags			moderator
 //	@Accept			json
 //	@Produce		json
 //	@Security		accessToken
@@ -479,7 +566,7 @@ type rejectWithdrawalRequestRequest struct {
 //	@Success		200			{object}	db.WithdrawalRequestDetails		"Updated withdrawal request details"
 //	@Router			/mod/withdrawal-requests/{requestID}/reject [patch]
 func (server *Server) rejectWithdrawalRequest(c *gin.Context) {
-	user := c.MustGet(moderatorPayloadKey).(*db.User)
+	user := c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	var req rejectWithdrawalRequestRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -517,7 +604,7 @@ func (server *Server) rejectWithdrawalRequest(c *gin.Context) {
 	// Execute transaction to reject withdrawal request
 	arg := db.RejectWithdrawalRequestTxParams{
 		WithdrawalRequest: request.WithdrawalRequest,
-		ModeratorID:       user.ID,
+		ModeratorID:       user.Subject,
 		Reason:            req.Reason,
 	}
 	updatedRequest, err := server.dbStore.RejectWithdrawalRequestTx(c, arg)
@@ -557,7 +644,7 @@ func (server *Server) rejectWithdrawalRequest(c *gin.Context) {
 //	@Success		200	{object}	db.ModeratorDashboard	"Dashboard statistics"
 //	@Router			/mod/dashboard [get]
 func (server *Server) getModeratorDashboard(c *gin.Context) {
-	_ = c.MustGet(moderatorPayloadKey).(*db.User)
+	_ = c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	var resp db.ModeratorDashboard
 	
@@ -613,15 +700,18 @@ func (server *Server) getModeratorDashboard(c *gin.Context) {
 }
 
 //	@Summary		List auctions for moderator
-//	@Description	List all auctions with optional status filter.
+//	@Description	List all auctions with optional status filter and cursor pagination.
 //	@Tags			moderator
 //	@Produce		json
-//	@Param			status	query	string				false	"Filter by status"	Enums(scheduled, active, ended, completed, failed, canceled)
-//	@Success		200		{array}	db.AuctionDetails	"List of auctions"
+//	@Param			status	query		string							false	"Filter by status"				Enums(scheduled, active, ended, completed, failed, canceled)
+//	@Param			sort	query		string							false	"Sort order (default: newest)"	Enums(newest, oldest, ending_soon, starting_soon)
+//	@Param			cursor	query		string							false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer							false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.AuctionDetails]	"List of auctions"
 //	@Security		accessToken
 //	@Router			/mod/auctions [get]
 func (server *Server) listAuctionsForModerator(c *gin.Context) {
-	_ = c.MustGet(moderatorPayloadKey).(*db.User)
+	_ = c.MustGet(moderatorPayloadKey).(*token.Payload)
 	
 	status := c.Query("status")
 	if status != "" {
@@ -631,41 +721,22 @@ func (server *Server) listAuctionsForModerator(c *gin.Context) {
 		}
 	}
 	
-	auctions, err := server.dbStore.ListAuctions(c.Request.Context(), db.NullAuctionStatus{
-		AuctionStatus: db.AuctionStatus(status),
-		Valid:         status != "",
-	})
+	page, err := parsePageQuery(c, auctionSorts, sortNewest)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to list auctions")
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 	
-	var resp []db.AuctionDetails
-	
-	for _, auction := range auctions {
-		var auctionDetails db.AuctionDetails
-		auctionDetails.Auction = auction
-		
-		// Lấy danh sách người tham gia đấu giá
-		participants, err := server.dbStore.ListAuctionParticipants(c.Request.Context(), auction.ID)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to get auction participants")
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
-		}
-		auctionDetails.AuctionParticipants = participants
-		
-		// Lấy danh sách giá đấu
-		bids, err := server.dbStore.ListAuctionBids(c.Request.Context(), &auction.ID)
-		if err != nil {
-			log.Error().Err(err).Msg("failed to get auction bids")
-			c.JSON(http.StatusInternalServerError, errorResponse(err))
+	resp, err := server.listAuctionDetails(c.Request.Context(), db.AuctionStatus(status), page)
+	if err != nil {
+		if errors.Is(err, errInvalidCursor) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
 			return
 		}
-		auctionDetails.AuctionBids = bids
 		
-		resp = append(resp, auctionDetails)
+		log.Error().Err(err).Msg("failed to list auctions")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 	
 	c.JSON(http.StatusOK, resp)
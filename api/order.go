@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
@@ -21,22 +22,25 @@ type createOrderRequest struct {
 	// example: user123
 	SellerID string `json:"seller_id" binding:"required"`
 	
-	// List of Gundam IDs in the order
+	// Gundams in the order with the number of units to buy
+	Items []orderItemRequest `json:"items" binding:"omitempty,dive"`
+	
+	// Deprecated: use items instead. List of Gundam IDs in the order, one unit of each
 	// example: [1, 2, 3]
-	GundamIDs []int64 `json:"gundam_ids" binding:"required,dive,min=1"`
+	GundamIDs []int64 `json:"gundam_ids" binding:"omitempty,dive,min=1"`
 	
 	// ID of the buyer's chosen address
 	// example: 42
 	BuyerAddressID int64 `json:"buyer_address_id" binding:"required"`
 	
-	// Delivery fee (VND)
+	// Delivery fee (VND), must match the fee quoted by POST /orders/delivery-quote
 	// minimum: 0
 	// example: 30000
 	DeliveryFee int64 `json:"delivery_fee" binding:"required,min=0"`
 	
-	// Expected delivery time
+	// Optional, must match the expected delivery time quoted by POST /orders/delivery-quote when provided
 	// example: 2025-04-05T10:00:00Z
-	ExpectedDeliveryTime time.Time `json:"expected_delivery_time" binding:"required"`
+	ExpectedDeliveryTime *time.Time `json:"expected_delivery_time"`
 	
 	// Payment method (wallet: pay via platform wallet, cod: cash on delivery)
 	// enums: wallet,cod
@@ -59,8 +63,44 @@ type createOrderRequest struct {
 	Note *string `json:"note" binding:"max=255"`
 }
 
+type orderItemRequest struct {
+	// ID of the Gundam
+	// example: 1
+	GundamID int64 `json:"gundam_id" binding:"required,min=1"`
+	
+	// Number of units to buy
+	// minimum: 1
+	// example: 2
+	Quantity int64 `json:"quantity" binding:"required,min=1"`
+}
+
+// getItems trả về các mặt hàng của đơn hàng, gundam_ids (cách gửi cũ) được xem là mua một đơn vị mỗi Gundam.
+func (req *createOrderRequest) getItems() ([]orderItemRequest, error) {
+	items := make([]orderItemRequest, 0, len(req.Items)+len(req.GundamIDs))
+	items = append(items, req.Items...)
+	for _, gundamID := range req.GundamIDs {
+		items = append(items, orderItemRequest{GundamID: gundamID, Quantity: 1})
+	}
+	
+	if len(items) == 0 {
+		return nil, errors.New("items is required")
+	}
+	
+	seen := make(map[int64]bool, len(items))
+	for _, item := range items {
+		if seen[item.GundamID] {
+			return nil, fmt.Errorf("gundam ID %d appears more than once in the order", item.GundamID)
+		}
+		seen[item.GundamID] = true
+	}
+	
+	return items, nil
+}
+
 //	@Summary		Create a new order
-//	@Description	Create a new order for purchasing Gundam models
+//	@Description	Create a new order for purchasing Gundam models. The ordered units are reserved until the order is completed, canceled or failed.
+//	@Description	The order is rejected when a Gundam does not have enough units available.
+//	@Description	The delivery fee is quoted by the delivery provider from the seller's pickup address, the buyer's address and the item weights, the order is rejected when delivery_fee or expected_delivery_time does not match the quote.
 //	@Tags			orders
 //	@Accept			json
 //	@Produce		json
@@ -103,13 +143,21 @@ func (server *Server) createOrder(c *gin.Context) {
 		return
 	}
 	
+	items, err := req.getItems()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
 	// Tính toán tổng giá trị thực tế của các sản phẩm
 	actualItemsSubtotal := int64(0)
 	actualTotalAmount := int64(0)
-	gundams := make([]db.Gundam, len(req.GundamIDs))
+	totalWeight := int64(0)
+	orderItems := make([]db.CreateOrderTxItem, len(items))
 	
 	// Duyệt qua từng gundam trong danh sách để kiểm tra tính hợp lệ
-	for i, gundamID := range req.GundamIDs {
+	for i, item := range items {
+		gundamID := item.GundamID
 		gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), gundamID)
 		if err != nil {
 			if errors.Is(err, db.ErrRecordNotFound) {
@@ -151,8 +199,19 @@ func (server *Server) createOrder(c *gin.Context) {
 			return
 		}
 		
-		actualItemsSubtotal += *gundam.Price
-		gundams[i] = gundam
+		// Kiểm tra số lượng còn có thể mua, số lượng được giữ lại trong transaction để tránh bán quá số lượng
+		if available := gundam.Quantity - gundam.ReservedQuantity; item.Quantity > available {
+			err = fmt.Errorf("%w: gundam ID %d has %d units available, %d requested", db.ErrInsufficientStock, gundamID, available, item.Quantity)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		actualItemsSubtotal += *gundam.Price * item.Quantity
+		totalWeight += gundam.Weight * item.Quantity
+		orderItems[i] = db.CreateOrderTxItem{
+			Gundam:   gundam,
+			Quantity: item.Quantity,
+		}
 	}
 	
 	// Kiểm tra xem tổng giá trị sản phẩm có khớp với tổng giá trị thực tế không
@@ -201,6 +260,19 @@ func (server *Server) createOrder(c *gin.Context) {
 		return
 	}
 	
+	// Phí vận chuyển và thời gian giao hàng dự kiến do đơn vị vận chuyển báo giá, không tin giá trị do client gửi lên
+	quote, err := server.quoteDelivery(c.Request.Context(), userAddressLocation(sellerAddress), userAddressLocation(buyerAddress), totalWeight)
+	if err != nil {
+		log.Err(err).Msg("failed to quote delivery")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if err = db.VerifyDeliveryQuote(quote, req.DeliveryFee, req.ExpectedDeliveryTime); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+	
 	// Chuẩn bị tham số cho transaction createOrder
 	arg := db.CreateOrderTxParams{
 		BuyerID:              userID,
@@ -210,10 +282,11 @@ func (server *Server) createOrder(c *gin.Context) {
 		ItemsSubtotal:        req.ItemsSubtotal, // Tổng giá trị các sản phẩm
 		DeliveryFee:          req.DeliveryFee,   // Phí vận chuyển
 		TotalAmount:          req.TotalAmount,   // Tổng giá trị đơn hàng (bao gồm phí vận chuyển)
-		ExpectedDeliveryTime: req.ExpectedDeliveryTime,
+		ExpectedDeliveryTime: quote.ExpectedDeliveryTime(),
+		DeliveryQuote:        quote,
 		PaymentMethod:        db.PaymentMethod(req.PaymentMethod),
 		Note:                 req.Note,
-		Gundams:              gundams,
+		Items:                orderItems,
 	}
 	
 	// Thực hiện transaction tạo đơn hàng
@@ -226,42 +299,179 @@ func (server *Server) createOrder(c *gin.Context) {
 	
 	log.Info().Msgf("FailedOrder created successfully: %v", result)
 	
+	server.notifyOrderCreated(c, result.Order, result.OrderItems, result.OrderDelivery)
+	
+	c.JSON(http.StatusCreated, result)
+}
+
+// notifyOrderCreated gửi thông báo và email xác nhận cho người mua, thông báo đơn hàng mới cho người bán.
+func (server *Server) notifyOrderCreated(c *gin.Context, order db.Order, orderItems []db.OrderItem, orderDelivery db.OrderDelivery) {
 	opts := []asynq.Option{
 		asynq.MaxRetry(3),
 		asynq.Queue(worker.QueueCritical),
 	}
 	
 	// Gửi thông báo cho người mua
-	err = server.taskDistributor.DistributeTaskSendNotification(c.Request.Context(), &worker.PayloadSendNotification{
-		RecipientID: result.Order.BuyerID,
-		Title:       fmt.Sprintf("Đơn hàng %s đã được tạo thành công", result.Order.Code),
-		Message:     fmt.Sprintf("Đơn hàng %s đã được tạo thành công với tổng giá trị %s. Người bán sẽ xác nhận đơn hàng của bạn trong thời gian sớm nhất. Bạn có thể theo dõi trạng thái đơn hàng trong trang Đơn Hàng.", result.Order.Code, util.FormatVND(result.Order.TotalAmount)),
+	err := server.taskDistributor.DistributeTaskSendNotification(c.Request.Context(), &worker.PayloadSendNotification{
+		RecipientID: order.BuyerID,
+		Title:       fmt.Sprintf("Đơn hàng %s đã được tạo thành công", order.Code),
+		Message:     fmt.Sprintf("Đơn hàng %s đã được tạo thành công với tổng giá trị %s. Người bán sẽ xác nhận đơn hàng của bạn trong thời gian sớm nhất. Bạn có thể theo dõi trạng thái đơn hàng trong trang Đơn Hàng.", order.Code, util.FormatVND(order.TotalAmount)),
 		Type:        "order",
-		ReferenceID: result.Order.Code,
+		ReferenceID: order.Code,
 	}, opts...)
 	if err != nil {
 		log.Err(err).Msg("failed to send notification to buyer")
 	}
-	log.Info().Msgf("Notification sent to buyer: %s", result.Order.BuyerID)
+	log.Info().Msgf("Notification sent to buyer: %s", order.BuyerID)
+	
+	// Gửi email xác nhận đơn hàng cho người mua
+	emailItems := make([]mailer.OrderItemData, 0, len(orderItems))
+	for _, item := range orderItems {
+		emailItems = append(emailItems, mailer.OrderItemData{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Price:    item.Price,
+		})
+	}
+	server.sendEmail(c, order.BuyerID, mailer.TemplateOrderConfirmation, mailer.OrderConfirmationData{
+		OrderCode:            order.Code,
+		Items:                emailItems,
+		ItemsSubtotal:        order.ItemsSubtotal,
+		DeliveryFee:          order.DeliveryFee,
+		TotalAmount:          order.TotalAmount,
+		ExpectedDeliveryTime: orderDelivery.ExpectedDeliveryTime,
+	})
 	
 	// Gửi thông báo cho người bán
 	err = server.taskDistributor.DistributeTaskSendNotification(c.Request.Context(), &worker.PayloadSendNotification{
-		RecipientID: result.Order.SellerID,
-		Title:       fmt.Sprintf("Đơn hàng mới %s cần xác nhận", result.Order.Code),
-		Message:     fmt.Sprintf("Bạn có đơn hàng mới %s với giá trị %s. Vui lòng xác nhận đơn hàng trong thời gian sớm nhất để chuẩn bị giao cho đơn vị vận chuyển.", result.Order.Code, util.FormatVND(result.Order.ItemsSubtotal)),
+		RecipientID: order.SellerID,
+		Title:       fmt.Sprintf("Đơn hàng mới %s cần xác nhận", order.Code),
+		Message:     fmt.Sprintf("Bạn có đơn hàng mới %s với giá trị %s. Vui lòng xác nhận đơn hàng trong thời gian sớm nhất để chuẩn bị giao cho đơn vị vận chuyển.", order.Code, util.FormatVND(order.ItemsSubtotal)),
 		Type:        "order",
-		ReferenceID: result.Order.Code,
+		ReferenceID: order.Code,
 	}, opts...)
 	if err != nil {
 		log.Err(err).Msg("failed to send notification to seller")
 	}
-	log.Info().Msgf("Notification sent to seller: %s", result.Order.SellerID)
+	log.Info().Msgf("Notification sent to seller: %s", order.SellerID)
+}
+
+type quoteOrderDeliveryRequest struct {
+	// ID of the seller
+	// example: user123
+	SellerID string `json:"seller_id" binding:"required"`
 	
-	c.JSON(http.StatusCreated, result)
+	// Gundams in the order with the number of units to buy
+	Items []orderItemRequest `json:"items" binding:"required,min=1,dive"`
+	
+	// ID of the buyer's chosen address
+	// example: 42
+	BuyerAddressID int64 `json:"buyer_address_id" binding:"required"`
+}
+
+//	@Summary		Quote the delivery of an order
+//	@Description	Get the delivery fee and the expected delivery time quoted by the delivery provider for an order,
+//	@Description	from the seller's pickup address to the buyer's address, based on the total weight of the items.
+//	@Description	The delivery_fee sent to POST /orders must match the quoted fee.
+//	@Tags			orders
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		quoteOrderDeliveryRequest	true	"Order to quote"
+//	@Success		200		{object}	deliveryQuote				"Delivery quote"
+//	@Failure		400		"Bad Request - Invalid parameters"
+//	@Failure		404		"Not Found - Gundam does not exist"
+//	@Failure		422		"Unprocessable Entity - Gundam does not belong to the seller or address not found"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/orders/delivery-quote [post]
+func (server *Server) quoteOrderDelivery(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req quoteOrderDeliveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	totalWeight := int64(0)
+	for _, item := range req.Items {
+		gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), item.GundamID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				err = fmt.Errorf("gundam ID %d not found", item.GundamID)
+				c.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			
+			log.Err(err).Msg("failed to get gundam by ID")
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		if gundam.OwnerID != req.SellerID {
+			err = fmt.Errorf("gundam ID %d does not belong to seller ID %s", item.GundamID, req.SellerID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		totalWeight += gundam.Weight * item.Quantity
+	}
+	
+	buyerAddress, err := server.dbStore.GetUserAddressByID(c.Request.Context(), db.GetUserAddressByIDParams{
+		ID:     req.BuyerAddressID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("cannot find user address with ID %d for buyer with ID %s", req.BuyerAddressID, userID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user address by ID")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	sellerAddress, err := server.dbStore.GetUserPickupAddress(c.Request.Context(), req.SellerID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("seller pickup address not found for seller ID %s", req.SellerID)
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user pickup address")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	quote, err := server.quoteDelivery(c.Request.Context(), userAddressLocation(sellerAddress), userAddressLocation(buyerAddress), totalWeight)
+	if err != nil {
+		log.Err(err).Msg("failed to quote delivery")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, newDeliveryQuote(quote))
+}
+
+// orderSorts là các kiểu sắp xếp cho danh sách đơn hàng của người mua và người bán.
+var orderSorts = []string{sortRecentlyUpdated, sortNewest, sortOldest}
+
+func orderCursorOf(sort string) func(order db.Order) (string, string) {
+	return func(order db.Order) (string, string) {
+		if sort == sortRecentlyUpdated {
+			return timeCursorValue(order.UpdatedAt), order.ID.String()
+		}
+		
+		return timeCursorValue(order.CreatedAt), order.ID.String()
+	}
 }
 
 type listMemberOrdersRequest struct {
 	Status *string `form:"status"`
+	pageQuery
 }
 
 func (req *listMemberOrdersRequest) getStatus() string {
@@ -284,11 +494,15 @@ func (req *listMemberOrdersRequest) validate() error {
 
 //	@Summary		List all orders of a member
 //	@Description	List all orders of a member with optional filtering by order status
+//	@Description	Note: the recently_updated cursor is keyed on updated_at, so an order updated while paging may be skipped or returned twice. Use newest or oldest for a stable traversal.
 //	@Tags			orders
 //	@Produce		json
 //	@Security		accessToken
-//	@Param			status	query	string				false	"Filter by order status"	Enums(pending, packaging, delivering, delivered, completed, canceled, failed)
-//	@Success		200		array	db.MemberOrderInfo	"List of orders"
+//	@Param			status	query		string								false	"Filter by order status"					Enums(pending, packaging, delivering, delivered, completed, canceled, failed)
+//	@Param			sort	query		string								false	"Sort order (default: recently_updated)"	Enums(recently_updated, newest, oldest)
+//	@Param			cursor	query		string								false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer								false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.MemberOrderInfo]	"List of orders"
 //	@Router			/orders [get]
 func (server *Server) listMemberOrders(ctx *gin.Context) {
 	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
@@ -318,13 +532,29 @@ func (server *Server) listMemberOrders(ctx *gin.Context) {
 		return
 	}
 	
+	page, err := req.parse(orderSorts, sortRecentlyUpdated)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
 	// Thực hiện truy vấn để lấy danh sách đơn hàng
-	orders, err := server.dbStore.ListMemberOrders(ctx, db.ListMemberOrdersParams{
+	rows, err := server.dbStore.ListMemberOrders(ctx, db.ListMemberOrdersParams{
 		BuyerID: userID,
 		Status: db.NullOrderStatus{
 			OrderStatus: db.OrderStatus(req.getStatus()),
 			Valid:       req.Status != nil,
 		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
 		log.Err(err).Msg("failed to list orders for member")
@@ -332,6 +562,8 @@ func (server *Server) listMemberOrders(ctx *gin.Context) {
 		return
 	}
 	
+	orders, info := paginate(rows, page, orderCursorOf(page.Sort))
+	
 	resp := make([]db.MemberOrderInfo, 0, len(orders))
 	
 	for _, order := range orders {
@@ -348,7 +580,7 @@ func (server *Server) listMemberOrders(ctx *gin.Context) {
 		resp = append(resp, orderInfo)
 	}
 	
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusOK, newPageResponse(resp, info))
 }
 
 //	@Summary		Confirm order received
@@ -550,7 +782,7 @@ func (server *Server) getMemberOrderDetails(c *gin.Context) {
 	resp.IsReceiver = isReceiver
 	
 	// Lấy thông tin người nhận đơn hàng
-	receiver, err := server.dbStore.GetUserByID(c.Request.Context(), order.BuyerID)
+	receiver, err := server.dbStore.GetUserByIDIncludingDeleted(c.Request.Context(), order.BuyerID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			err = fmt.Errorf("receiver ID %s not found", order.BuyerID)
@@ -565,7 +797,7 @@ func (server *Server) getMemberOrderDetails(c *gin.Context) {
 	resp.Receiver = receiver
 	
 	// Lấy thông tin người gửi đơn hàng
-	sender, err := server.dbStore.GetUserByID(c.Request.Context(), order.SellerID)
+	sender, err := server.dbStore.GetUserByIDIncludingDeleted(c.Request.Context(), order.SellerID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			err = fmt.Errorf("sender ID %s not found", order.SellerID)
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 	
 	"github.com/gin-gonic/gin"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
 	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/otp"
 	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,14 +24,13 @@ type GeneratePhoneOTPRequest struct {
 
 // GeneratePhoneOTPResponse represents the response structure after OTP generation
 type GeneratePhoneOTPResponse struct {
-	OTPCode     string    `json:"otp_code"`     // Mã OTP được tạo
 	PhoneNumber string    `json:"phone_number"` // Số điện thoại đã gửi OTP
 	ExpiresAt   time.Time `json:"expires_at"`   // Thời điểm OTP hết hạn
 	CreatedAt   time.Time `json:"created_at"`   // Thời điểm OTP được tạo
 }
 
 // @Summary		Generate a One-Time Password (OTP) for phone number
-// @Description	Generates and sends an OTP to the specified phone number. The OTP will be valid for 10 minutes.
+// @Description	Generates and sends an OTP to the specified phone number. The OTP will be valid for 10 minutes and is only delivered by SMS, it is not returned in the response.
 // Phone number must be a valid Vietnamese phone number (10-11 digits, starting with 03, 05, 07, 08, 09, or 84).
 // @Tags			authentication
 // @Accept			json
@@ -36,6 +38,7 @@ type GeneratePhoneOTPResponse struct {
 // @Param			request	body		GeneratePhoneOTPRequest		true	"OTP Generation Request"
 // @Success		200		{object}	GeneratePhoneOTPResponse	"OTP generated successfully"
 // @Failure		400		"Bad Request - Invalid phone number format"
+// @Failure		429		{object}	otpErrorResponse	"Too Many Requests - Resend cooldown (OTP_RESEND_COOLDOWN) or temporary lockout (OTP_LOCKED, OTP_IP_LOCKED, OTP_TOO_MANY_RESENDS)"
 // @Failure		500		"Internal Server Error - Failed to generate or send OTP"
 // @Router			/otp/phone-number/generate [post]
 func (server *Server) generatePhoneNumberOTP(c *gin.Context) {
@@ -54,15 +57,14 @@ func (server *Server) generatePhoneNumberOTP(c *gin.Context) {
 	}
 	
 	// Tạo và gửi OTP
-	code, expiresAt, createdAt, err := server.phoneNumberService.SendOTP(c, req.PhoneNumber)
+	// Mã OTP chỉ được gửi qua SMS, không trả về trong response
+	_, expiresAt, createdAt, err := server.phoneNumberService.SendOTP(c, req.PhoneNumber, c.ClientIP())
 	if err != nil {
-		log.Error().Err(err).Msg("failed to send OTP")
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		handleOTPError(c, err, "failed to send OTP")
 		return
 	}
 	
 	c.JSON(http.StatusOK, GeneratePhoneOTPResponse{
-		OTPCode:     code,
 		PhoneNumber: req.PhoneNumber,
 		ExpiresAt:   expiresAt,
 		CreatedAt:   createdAt,
@@ -82,9 +84,10 @@ type VerifyPhoneOTPRequest struct {
 // @Produce		json
 // @Param			request	body	VerifyPhoneOTPRequest	true	"OTP Verification Request"
 // @Success		200		"OTP verified successfully"
-// @Failure		400		"Bad Request - Invalid input or OTP verification failed"
+// @Failure		400		{object}	otpErrorResponse	"Bad Request - Invalid input or OTP verification failed (OTP_INVALID, OTP_EXPIRED, OTP_INVALID_FORMAT)"
 // @Failure		401		"Unauthorized - Invalid OTP code"
 // @Failure		404		"Not Found - User not found"
+// @Failure		429		{object}	otpErrorResponse	"Too Many Requests - Too many failed attempts (OTP_TOO_MANY_ATTEMPTS, OTP_LOCKED, OTP_IP_LOCKED)"
 // @Failure		500		"Internal Server Error - Failed to update user information"
 // @Router			/otp/phone-number/verify [post]
 func (server *Server) verifyPhoneNumberOTP(c *gin.Context) {
@@ -124,10 +127,9 @@ func (server *Server) verifyPhoneNumberOTP(c *gin.Context) {
 	}
 	
 	// Xác thực OTP
-	valid, err := server.phoneNumberService.VerifyOTP(c, req.PhoneNumber, req.OTPCode)
+	valid, err := server.phoneNumberService.VerifyOTP(c, req.PhoneNumber, req.OTPCode, c.ClientIP())
 	if err != nil {
-		log.Error().Err(err).Msg("failed to verify OTP")
-		c.JSON(http.StatusBadRequest, errorResponse(err))
+		handleOTPError(c, err, "failed to verify OTP")
 		return
 	}
 	
@@ -168,21 +170,20 @@ type GenerateEmailOTPRequest struct {
 }
 
 type GenerateEmailOTPResponse struct {
-	OTPCode   string    `json:"otp_code"`
 	Email     string    `json:"email"`
 	ExpiresAt time.Time `json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 // @Summary		Generate a One-Time Password (OTP) for email
-// @Description	Generates and sends an OTP to the specified email address
+// @Description	Generates and sends an OTP to the specified email address. The OTP is only delivered by email, it is not returned in the response.
 // @Tags			authentication
 // @Accept			json
 // @Produce		json
 // @Param			request	body		GenerateEmailOTPRequest		true	"OTP Generation Request"
 // @Success		200		{object}	GenerateEmailOTPResponse	"OTP generated successfully"
 // @Failure		400		"Bad Request - Invalid input"
-// @Failure		429		"Too Many Requests - OTP request rate limit exceeded"
+// @Failure		429		{object}	otpErrorResponse	"Too Many Requests - Resend cooldown (OTP_RESEND_COOLDOWN) or temporary lockout (OTP_LOCKED, OTP_IP_LOCKED, OTP_TOO_MANY_RESENDS)"
 // @Failure		500		"Internal Server Error"
 // @Router			/otp/email/generate [post]
 func (server *Server) generateEmailOTP(c *gin.Context) {
@@ -192,25 +193,39 @@ func (server *Server) generateEmailOTP(c *gin.Context) {
 		return
 	}
 	
-	mailHeader := mailer.EmailHeader{
-		Subject: "Your OTP Code",
-		To:      []string{req.Email},
-	}
-	code, createdAt, expiresAt, err := server.mailService.SendOTP(mailHeader)
+	createdAt, expiresAt, err := server.sendOTPEmail(c, server.emailOTPService, mailer.TemplateEmailVerification, req.Email)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to send OTP email")
-		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		handleOTPError(c, err, "failed to send OTP email")
 		return
 	}
 	
 	c.JSON(http.StatusOK, GenerateEmailOTPResponse{
-		OTPCode:   code,
 		Email:     req.Email,
 		ExpiresAt: expiresAt,
 		CreatedAt: createdAt,
 	})
 }
 
+// sendOTPEmail tạo mã OTP bằng otpService và đưa email chứa mã vào hàng đợi để worker gửi đến địa chỉ email,
+// handler không phải chờ SMTP
+func (server *Server) sendOTPEmail(c *gin.Context, otpService *otp.OTPService, name mailer.TemplateName, email string) (createdAt time.Time, expiresAt time.Time, err error) {
+	code, createdAt, expiresAt, err := otpService.GenerateOTP(c, email, c.ClientIP())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	
+	locale := mailer.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language"))
+	err = worker.DistributeEmailToAddress(c.Request.Context(), server.taskDistributor, email, name, locale, mailer.OTPData{
+		Code:      code,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to enqueue OTP email: %w", err)
+	}
+	
+	return createdAt, expiresAt, nil
+}
+
 type VerifyEmailOTPRequest struct {
 	Email   string `json:"email" binding:"required,email"`
 	OTPCode string `json:"otp_code" binding:"required,len=6"`
@@ -223,8 +238,9 @@ type VerifyEmailOTPRequest struct {
 // @Produce		json
 // @Param			request	body	VerifyEmailOTPRequest	true	"OTP Verification Request"
 // @Success		200		"OTP verified successfully"
-// @Failure		400		"Bad Request - Invalid input or OTP verification failed"
+// @Failure		400		{object}	otpErrorResponse	"Bad Request - Invalid input or OTP verification failed (OTP_INVALID, OTP_EXPIRED, OTP_INVALID_FORMAT)"
 // @Failure		401		"Unauthorized - Invalid OTP code"
+// @Failure		429		{object}	otpErrorResponse	"Too Many Requests - Too many failed attempts (OTP_TOO_MANY_ATTEMPTS, OTP_LOCKED, OTP_IP_LOCKED)"
 // @Failure		500		"Internal Server Error - Failed to update user information"
 // @Router			/otp/email/verify [post]
 func (server *Server) verifyEmailOTP(c *gin.Context) {
@@ -235,10 +251,9 @@ func (server *Server) verifyEmailOTP(c *gin.Context) {
 		return
 	}
 	
-	valid, err := server.mailService.VerifyOTP(c.Request.Context(), req.Email, req.OTPCode)
+	valid, err := server.emailOTPService.VerifyOTP(c.Request.Context(), req.Email, req.OTPCode, c.ClientIP())
 	if err != nil {
-		log.Error().Err(err).Msg("failed to verify OTP")
-		c.JSON(http.StatusBadRequest, errorResponse(err))
+		handleOTPError(c, err, "failed to verify OTP")
 		return
 	}
 	
@@ -250,3 +265,41 @@ func (server *Server) verifyEmailOTP(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, gin.H{"message": "OTP verified successfully"})
 }
+
+// otpErrorResponse là response khi OTP bị từ chối, kèm mã lỗi để client xử lý
+type otpErrorResponse struct {
+	Error             string `json:"error"`
+	Code              string `json:"code"`                         // Ví dụ: OTP_INVALID, OTP_RESEND_COOLDOWN, OTP_LOCKED
+	RetryAfter        int64  `json:"retry_after,omitempty"`        // Số giây cần chờ trước khi thử lại
+	RemainingAttempts *int   `json:"remaining_attempts,omitempty"` // Số lần nhập sai còn lại
+}
+
+// handleOTPError trả về response tương ứng với lỗi khi gửi hoặc xác thực OTP.
+// Lỗi do vượt giới hạn trả về 429 kèm header Retry-After, lỗi nhập sai trả về 400.
+func handleOTPError(c *gin.Context, err error, msg string) {
+	var otpErr *otp.Error
+	if !errors.As(err, &otpErr) {
+		log.Error().Err(err).Msg(msg)
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	resp := otpErrorResponse{
+		Error: otpErr.Error(),
+		Code:  otpErr.Code,
+	}
+	
+	status := http.StatusBadRequest
+	if otpErr.RetryAfter > 0 {
+		status = http.StatusTooManyRequests
+		resp.RetryAfter = ceilSeconds(otpErr.RetryAfter)
+		c.Header("Retry-After", strconv.FormatInt(resp.RetryAfter, 10))
+	}
+	
+	if otpErr.Code == otp.ErrCodeInvalid {
+		resp.RemainingAttempts = &otpErr.RemainingAttempts
+	}
+	
+	log.Warn().Str("code", otpErr.Code).Str("ip", c.ClientIP()).Msg(msg)
+	c.JSON(status, resp)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Các kiểu sắp xếp dùng chung giữa các endpoint danh sách.
+// recently_updated phân trang theo updated_at (có thể thay đổi), nên cursor của nó không ổn định khi dữ liệu được cập nhật giữa các trang.
+const (
+	sortNewest          = "newest"
+	sortOldest          = "oldest"
+	sortRecentlyUpdated = "recently_updated"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// pageQuery là các tham số phân trang keyset chung cho mọi endpoint danh sách.
+// Cursor là chuỗi mờ (opaque) lấy từ next_cursor của trang trước, client không được tự tạo.
+type pageQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort   string `form:"sort"`
+}
+
+// pageCursor là nội dung đã giải mã của cursor: giá trị của cột sắp xếp và ID của dòng cuối cùng trang trước.
+// Sort được lưu kèm để cursor không bị dùng lại với một kiểu sắp xếp khác.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"i"`
+}
+
+func (c pageCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageCursor(s string) (*pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	
+	var cursor pageCursor
+	if err = json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" {
+		return nil, errInvalidCursor
+	}
+	
+	return &cursor, nil
+}
+
+// pagination là tham số phân trang đã được kiểm tra, dùng để truyền xuống các câu truy vấn sqlc.
+type pagination struct {
+	Sort   string
+	Limit  int32
+	cursor *pageCursor
+}
+
+// parse kiểm tra sort theo danh sách cho phép (phần tử đầu tiên là mặc định nếu defaultSort rỗng)
+// và giải mã cursor của trang trước.
+func (q pageQuery) parse(allowedSorts []string, defaultSort string) (pagination, error) {
+	p := pagination{
+		Sort:  q.Sort,
+		Limit: q.Limit,
+	}
+	
+	if p.Sort == "" {
+		p.Sort = defaultSort
+		if p.Sort == "" {
+			p.Sort = allowedSorts[0]
+		}
+	}
+	if !slices.Contains(allowedSorts, p.Sort) {
+		return p, fmt.Errorf("invalid sort: %s, must be one of %v", p.Sort, allowedSorts)
+	}
+	
+	if p.Limit == 0 {
+		p.Limit = defaultPageLimit
+	}
+	if p.Limit > maxPageLimit {
+		p.Limit = maxPageLimit
+	}
+	
+	if q.Cursor != "" {
+		cursor, err := decodePageCursor(q.Cursor)
+		if err != nil {
+			return p, err
+		}
+		if cursor.Sort != p.Sort {
+			return p, fmt.Errorf("cursor was issued for sort %s, not %s", cursor.Sort, p.Sort)
+		}
+		p.cursor = cursor
+	}
+	
+	return p, nil
+}
+
+// parsePageQuery đọc các tham số cursor, limit, sort từ query string và kiểm tra chúng.
+func parsePageQuery(c *gin.Context, allowedSorts []string, defaultSort string) (pagination, error) {
+	var query pageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		return pagination{}, err
+	}
+	
+	return query.parse(allowedSorts, defaultSort)
+}
+
+// FetchLimit trả về số dòng cần lấy: thêm một dòng để biết còn trang sau hay không.
+func (p pagination) FetchLimit() *int32 {
+	limit := p.Limit + 1
+	return &limit
+}
+
+// CursorValue trả về giá trị cột sắp xếp của cursor dưới dạng chuỗi, câu truy vấn sẽ tự ép kiểu theo sort.
+func (p pagination) CursorValue() *string {
+	if p.cursor == nil {
+		return nil
+	}
+	
+	return &p.cursor.Value
+}
+
+// CursorTime dùng cho các câu truy vấn sắp xếp theo cột thời gian (created_at, updated_at, ...).
+func (p pagination) CursorTime() (*time.Time, error) {
+	if p.cursor == nil {
+		return nil, nil
+	}
+	
+	t, err := time.Parse(time.RFC3339Nano, p.cursor.Value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	
+	return &t, nil
+}
+
+// TimeUUIDCursor giải mã cursor cho các câu truy vấn phân trang theo (cột thời gian, id kiểu uuid).
+func (p pagination) TimeUUIDCursor() (*time.Time, *uuid.UUID, error) {
+	cursorTime, err := p.CursorTime()
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	cursorID, err := p.CursorUUID()
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return cursorTime, cursorID, nil
+}
+
+func (p pagination) CursorUUID() (*uuid.UUID, error) {
+	if p.cursor == nil {
+		return nil, nil
+	}
+	
+	id, err := uuid.Parse(p.cursor.ID)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	
+	return &id, nil
+}
+
+func (p pagination) CursorInt64() (*int64, error) {
+	if p.cursor == nil {
+		return nil, nil
+	}
+	
+	id, err := strconv.ParseInt(p.cursor.ID, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	
+	return &id, nil
+}
+
+// pageInfo là thông tin về trang tiếp theo sau khi đã cắt bỏ dòng lấy thừa.
+type pageInfo struct {
+	NextCursor *string
+	HasMore    bool
+}
+
+// paginate cắt bỏ dòng lấy thừa (xem FetchLimit) và tạo next_cursor từ dòng cuối cùng của trang.
+// cursorOf trả về giá trị cột sắp xếp và ID của một dòng theo kiểu sắp xếp hiện tại.
+func paginate[R any](rows []R, p pagination, cursorOf func(row R) (value string, id string)) ([]R, pageInfo) {
+	var info pageInfo
+	if int32(len(rows)) <= p.Limit {
+		return rows, info
+	}
+	
+	rows = rows[:p.Limit]
+	value, id := cursorOf(rows[len(rows)-1])
+	next := pageCursor{
+		Sort:  p.Sort,
+		Value: value,
+		ID:    id,
+	}.encode()
+	info.NextCursor = &next
+	info.HasMore = true
+	
+	return rows, info
+}
+
+// pageResponse là envelope chung cho mọi endpoint danh sách có phân trang.
+type pageResponse[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
+
+func newPageResponse[T any](data []T, info pageInfo) pageResponse[T] {
+	if data == nil {
+		data = []T{}
+	}
+	
+	return pageResponse[T]{
+		Data:       data,
+		NextCursor: info.NextCursor,
+		HasMore:    info.HasMore,
+	}
+}
+
+// Các hàm định dạng giá trị cursor, phải khớp với kiểu mà câu truy vấn ép từ text.
+
+func timeCursorValue(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func int64CursorValue(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func float32CursorValue(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
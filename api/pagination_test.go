@@ -0,0 +1,204 @@
+package api
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor pageCursor
+	}{
+		{
+			name:   "TimeAndUUID",
+			cursor: pageCursor{Sort: sortNewest, Value: timeCursorValue(time.Date(2025, 3, 1, 8, 30, 0, 123456789, time.UTC)), ID: uuid.NewString()},
+		},
+		{
+			name:   "Int64",
+			cursor: pageCursor{Sort: "price_desc", Value: int64CursorValue(1_500_000), ID: "42"},
+		},
+		{
+			name:   "Float32",
+			cursor: pageCursor{Sort: "rating", Value: float32CursorValue(4.75), ID: "7"},
+		},
+		{
+			name:   "EmptyValue",
+			cursor: pageCursor{Sort: sortOldest, ID: "1"},
+		},
+		{
+			name:   "UnicodeValue",
+			cursor: pageCursor{Sort: "name", Value: "Gundam Ðặc biệt \"RX\"/78", ID: "3"},
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded := tc.cursor.encode()
+			require.NotContains(t, encoded, "=")
+			require.NotContains(t, encoded, "+")
+			require.NotContains(t, encoded, "/")
+			
+			decoded, err := decodePageCursor(encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.cursor, *decoded)
+		})
+	}
+}
+
+func TestDecodePageCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	
+	testCases := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "NotBase64", cursor: "not a cursor!"},
+		{name: "PaddedBase64", cursor: base64.URLEncoding.EncodeToString([]byte(`{"s":"newest","v":"","i":"1"}`))},
+		{name: "NotJSON", cursor: encode("newest|1")},
+		{name: "MissingID", cursor: encode(`{"s":"newest","v":"2025-01-01T00:00:00Z"}`)},
+		{name: "WrongType", cursor: encode(`{"s":"newest","v":1,"i":"1"}`)},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cursor, err := decodePageCursor(tc.cursor)
+			require.ErrorIs(t, err, errInvalidCursor)
+			require.Nil(t, cursor)
+		})
+	}
+}
+
+func TestPageQueryParse(t *testing.T) {
+	allowedSorts := []string{sortNewest, sortOldest, sortRecentlyUpdated}
+	newestCursor := pageCursor{Sort: sortNewest, Value: timeCursorValue(time.Now()), ID: uuid.NewString()}
+	
+	testCases := []struct {
+		name        string
+		query       pageQuery
+		defaultSort string
+		wantErr     bool
+		wantSort    string
+		wantLimit   int32
+		wantCursor  *pageCursor
+	}{
+		{
+			name:      "Defaults",
+			query:     pageQuery{},
+			wantSort:  sortNewest,
+			wantLimit: defaultPageLimit,
+		},
+		{
+			name:        "DefaultSort",
+			query:       pageQuery{},
+			defaultSort: sortRecentlyUpdated,
+			wantSort:    sortRecentlyUpdated,
+			wantLimit:   defaultPageLimit,
+		},
+		{
+			name:      "LimitCapped",
+			query:     pageQuery{Sort: sortOldest, Limit: maxPageLimit + 1},
+			wantSort:  sortOldest,
+			wantLimit: maxPageLimit,
+		},
+		{
+			name:    "UnknownSort",
+			query:   pageQuery{Sort: "price"},
+			wantErr: true,
+		},
+		{
+			name:       "Cursor",
+			query:      pageQuery{Sort: sortNewest, Limit: 10, Cursor: newestCursor.encode()},
+			wantSort:   sortNewest,
+			wantLimit:  10,
+			wantCursor: &newestCursor,
+		},
+		{
+			name:    "CursorOfOtherSort",
+			query:   pageQuery{Sort: sortOldest, Cursor: newestCursor.encode()},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidCursor",
+			query:   pageQuery{Cursor: "garbage"},
+			wantErr: true,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.query.parse(allowedSorts, tc.defaultSort)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSort, p.Sort)
+			require.Equal(t, tc.wantLimit, p.Limit)
+			require.Equal(t, tc.wantCursor, p.cursor)
+			require.Equal(t, tc.wantLimit+1, *p.FetchLimit())
+		})
+	}
+}
+
+func TestPaginationCursorValues(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 8, 30, 0, 123456789, time.FixedZone("ICT", 7*60*60))
+	id := uuid.New()
+	
+	p := pagination{cursor: &pageCursor{Value: timeCursorValue(createdAt), ID: id.String()}}
+	cursorTime, cursorID, err := p.TimeUUIDCursor()
+	require.NoError(t, err)
+	require.True(t, createdAt.Equal(*cursorTime))
+	require.Equal(t, id, *cursorID)
+	
+	p = pagination{cursor: &pageCursor{Value: "not a time", ID: "not a uuid"}}
+	_, err = p.CursorTime()
+	require.ErrorIs(t, err, errInvalidCursor)
+	_, err = p.CursorUUID()
+	require.ErrorIs(t, err, errInvalidCursor)
+	_, err = p.CursorInt64()
+	require.ErrorIs(t, err, errInvalidCursor)
+	
+	// Trang đầu tiên không có cursor
+	p = pagination{}
+	cursorTime, cursorID, err = p.TimeUUIDCursor()
+	require.NoError(t, err)
+	require.Nil(t, cursorTime)
+	require.Nil(t, cursorID)
+	require.Nil(t, p.CursorValue())
+}
+
+func TestPaginate(t *testing.T) {
+	rows := []int64{50, 40, 30, 20, 10}
+	cursorOf := func(row int64) (string, string) {
+		return int64CursorValue(row), int64CursorValue(row)
+	}
+	
+	t.Run("LastPage", func(t *testing.T) {
+		page, info := paginate(rows, pagination{Sort: sortNewest, Limit: 5}, cursorOf)
+		require.Equal(t, rows, page)
+		require.False(t, info.HasMore)
+		require.Nil(t, info.NextCursor)
+	})
+	
+	t.Run("HasMore", func(t *testing.T) {
+		page, info := paginate(rows, pagination{Sort: sortNewest, Limit: 4}, cursorOf)
+		require.Equal(t, []int64{50, 40, 30, 20}, page)
+		require.True(t, info.HasMore)
+		require.NotNil(t, info.NextCursor)
+		
+		next, err := pageQuery{Sort: sortNewest, Cursor: *info.NextCursor}.parse([]string{sortNewest}, "")
+		require.NoError(t, err)
+		cursorID, err := next.CursorInt64()
+		require.NoError(t, err)
+		require.Equal(t, int64(20), *cursorID)
+		require.Equal(t, "20", *next.CursorValue())
+	})
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/katatrina/gundam-BE/internal/validator"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrPasswordResetIdentifier = errors.New("exactly one of email or phone_number is required")
+	ErrInvalidPasswordResetOTP = errors.New("invalid or expired password reset code")
+)
+
+type forgotPasswordRequest struct {
+	Email       *string `json:"email" binding:"omitempty,email"`
+	PhoneNumber *string `json:"phone_number"`
+}
+
+//	@Summary		Request a password reset code
+//	@Description	Sends a single-use password reset code to the email address or phone number of the account.
+//	@Description	Provide exactly one of email or phone_number. The response is the same whether or not an account exists.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	forgotPasswordRequest	true	"Forgot password request"
+//	@Success		200		"Password reset code sent if the account exists"
+//	@Failure		400		"Invalid request body"
+//	@Router			/auth/password/forgot [post]
+func (server *Server) forgotPassword(ctx *gin.Context) {
+	req := new(forgotPasswordRequest)
+	
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if (req.Email == nil) == (req.PhoneNumber == nil) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrPasswordResetIdentifier))
+		return
+	}
+	
+	if req.PhoneNumber != nil && !util.IsValidVietnamesePhoneNumber(*req.PhoneNumber) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid phone number format")))
+		return
+	}
+	
+	// Không tiết lộ tài khoản có tồn tại hay không: lỗi khi tìm tài khoản hoặc gửi mã (kể cả đang trong thời gian chờ gửi lại)
+	// chỉ được ghi log, response luôn giống nhau
+	message := gin.H{"message": "If an account exists, a password reset code has been sent"}
+	
+	_, err := server.getUserForPasswordReset(ctx, req.Email, req.PhoneNumber)
+	if err != nil {
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			log.Err(err).Msg("failed to get user")
+		}
+		
+		ctx.JSON(http.StatusOK, message)
+		return
+	}
+	
+	if req.Email != nil {
+		_, _, err = server.sendOTPEmail(ctx, server.emailResetOTPService, mailer.TemplatePasswordReset, *req.Email)
+	} else {
+		_, err = server.phoneNumberService.SendPasswordResetOTP(ctx, *req.PhoneNumber, ctx.ClientIP())
+	}
+	if err != nil {
+		log.Err(err).Msg("failed to send password reset code")
+	}
+	
+	ctx.JSON(http.StatusOK, message)
+}
+
+type resetPasswordRequest struct {
+	Email       *string `json:"email" binding:"omitempty,email"`
+	PhoneNumber *string `json:"phone_number"`
+	OTPCode     string  `json:"otp_code" binding:"required,len=6"`
+	NewPassword string  `json:"new_password" binding:"required"`
+}
+
+//	@Summary		Reset password
+//	@Description	Sets a new password using the code sent by /auth/password/forgot.
+//	@Description	Accounts created with Google can use this to set a password for the first time.
+//	@Description	All existing sessions of the user are revoked, so every device has to log in again.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	resetPasswordRequest	true	"Reset password request"
+//	@Success		200		"Password reset successfully"
+//	@Failure		400		{object}	otpErrorResponse	"Invalid request body or invalid reset code"
+//	@Failure		422		"Validation error"
+//	@Failure		429		{object}	otpErrorResponse	"Too many failed attempts"
+//	@Failure		500		"Internal server error"
+//	@Router			/auth/password/reset [post]
+func (server *Server) resetPassword(ctx *gin.Context) {
+	req := new(resetPasswordRequest)
+	
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if (req.Email == nil) == (req.PhoneNumber == nil) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrPasswordResetIdentifier))
+		return
+	}
+	
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, failedValidationError([]*FieldViolation{fieldViolation("new_password", err)}))
+		return
+	}
+	
+	var (
+		valid bool
+		err   error
+	)
+	if req.Email != nil {
+		valid, err = server.emailResetOTPService.VerifyOTP(ctx, *req.Email, req.OTPCode, ctx.ClientIP())
+	} else {
+		valid, err = server.phoneNumberService.VerifyPasswordResetOTP(ctx, *req.PhoneNumber, req.OTPCode, ctx.ClientIP())
+	}
+	if err != nil {
+		handleOTPError(ctx, err, "failed to verify password reset code")
+		return
+	}
+	
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPasswordResetOTP))
+		return
+	}
+	
+	user, err := server.getUserForPasswordReset(ctx, req.Email, req.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidPasswordResetOTP))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		log.Err(err).Msg("failed to hash password")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	tokenVersion, err := server.dbStore.ResetUserPasswordTx(ctx, db.ResetUserPasswordTxParams{
+		UserID:         user.ID,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to reset password")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Thu hồi tất cả phiên đăng nhập: access token (qua token version) và refresh token
+	err = server.tokenVersionStore.Set(ctx, user.ID, tokenVersion)
+	if err != nil {
+		log.Err(err).Msg("failed to set token version")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.revokeAllSessions(ctx, user.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to revoke sessions")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+func (server *Server) getUserForPasswordReset(ctx *gin.Context, email *string, phoneNumber *string) (db.User, error) {
+	if email != nil {
+		return server.dbStore.GetUserByEmail(ctx, *email)
+	}
+	
+	return server.dbStore.GetUserByPhoneNumber(ctx, phoneNumber)
+}
+
+type changePasswordRequest struct {
+	CurrentPassword *string `json:"current_password"` // Bỏ trống nếu tài khoản chưa có mật khẩu (đăng ký bằng Google)
+	NewPassword     string  `json:"new_password" binding:"required"`
+}
+
+//	@Summary		Change password
+//	@Description	Changes the password of the current user. current_password is required when the account already has a password.
+//	@Description	Accounts created with Google can omit current_password to set a password for the first time.
+//	@Description	All other sessions of the user are revoked, the current device receives a new token pair.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		changePasswordRequest		true	"Change password request"
+//	@Success		200		{object}	refreshAccessTokenResponse	"Password changed successfully, new token pair for the current device"
+//	@Failure		400		"Invalid request body"
+//	@Failure		401		"Incorrect current password"
+//	@Failure		404		"User not found"
+//	@Failure		422		"Validation error"
+//	@Failure		500		"Internal server error"
+//	@Router			/users/me/password [put]
+func (server *Server) changePassword(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	userID := authPayload.Subject
+	
+	req := new(changePasswordRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if err := validator.ValidatePassword(req.NewPassword); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, failedValidationError([]*FieldViolation{fieldViolation("new_password", err)}))
+		return
+	}
+	
+	user, err := server.dbStore.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user ID %s not found", userID)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Tài khoản Google chưa có mật khẩu thì được đặt mật khẩu lần đầu mà không cần mật khẩu hiện tại
+	if user.HashedPassword != nil {
+		if req.CurrentPassword == nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("current_password is required")))
+			return
+		}
+		
+		if err = util.CheckPassword(*req.CurrentPassword, *user.HashedPassword); err != nil {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("incorrect current password")))
+			return
+		}
+	}
+	
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		log.Err(err).Msg("failed to hash password")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	tokenVersion, err := server.dbStore.ResetUserPasswordTx(ctx, db.ResetUserPasswordTxParams{
+		UserID:         user.ID,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to update password")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Thu hồi tất cả phiên đăng nhập giống như khi đặt lại mật khẩu, sau đó cấp phiên mới cho thiết bị hiện tại
+	err = server.tokenVersionStore.Set(ctx, user.ID, tokenVersion)
+	if err != nil {
+		log.Err(err).Msg("failed to set token version")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.revokeAllSessions(ctx, user.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to revoke sessions")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	user.TokenVersion = tokenVersion
+	tokens, err := server.createUserTokens(ctx, user, token.NewFamilyID(), authPayload.MFA)
+	if err != nil {
+		log.Err(err).Msg("failed to create user tokens")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, refreshAccessTokenResponse{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	})
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+// maxSavedSearchesPerUser giới hạn số tìm kiếm đã lưu của mỗi người dùng,
+// vì mỗi Gundam được đăng bán đều phải so khớp với toàn bộ tìm kiếm đã lưu.
+const maxSavedSearchesPerUser = 20
+
+// savedSearchRequest là nội dung của một tìm kiếm đã lưu, bộ lọc nào bỏ trống thì khớp với mọi giá trị.
+type savedSearchRequest struct {
+	Name      string  `json:"name" binding:"required,max=100"`
+	Keyword   *string `json:"keyword" binding:"omitempty,min=1,max=255"`
+	GradeID   *int64  `json:"grade_id" binding:"omitempty,gt=0"`
+	Scale     *string `json:"scale" binding:"omitempty,oneof=1/144 1/100 1/60 1/48"`
+	MaxPrice  *int64  `json:"max_price" binding:"omitempty,gt=0"`
+	Condition *string `json:"condition" binding:"omitempty,oneof=new 'open box' used"`
+}
+
+func (req *savedSearchRequest) nullScale() db.NullGundamScale {
+	if req.Scale == nil {
+		return db.NullGundamScale{}
+	}
+	
+	return db.NullGundamScale{
+		GundamScale: db.GundamScale(*req.Scale),
+		Valid:       true,
+	}
+}
+
+func (req *savedSearchRequest) nullCondition() db.NullGundamCondition {
+	if req.Condition == nil {
+		return db.NullGundamCondition{}
+	}
+	
+	return db.NullGundamCondition{
+		GundamCondition: db.GundamCondition(*req.Condition),
+		Valid:           true,
+	}
+}
+
+//	@Summary		Create a saved search
+//	@Description	Save a search so the authenticated user is notified when a matching Gundam is published for sale or scheduled for auction.
+//	@Description	Keyword is matched against the Gundam name (accent-insensitive), max_price against the sale price or the auction starting price.
+//	@Description	Notifications are grouped into at most one digest per user per hour.
+//	@Tags			saved-searches
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		savedSearchRequest	true	"Saved search filters"
+//	@Success		201		{object}	db.SavedSearch		"Created saved search"
+//	@Failure		400		"Bad Request - Invalid input data"
+//	@Failure		404		"Not Found - Grade does not exist"
+//	@Failure		409		"Conflict - Saved search limit reached"
+//	@Failure		500		"Internal Server Error - Failed to create saved search"
+//	@Router			/users/me/saved-searches [post]
+func (server *Server) createSavedSearch(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req savedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.GradeID != nil && !server.checkGradeExists(c, *req.GradeID) {
+		return
+	}
+	
+	count, err := server.dbStore.CountSavedSearchesByUserID(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to count saved searches")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if count >= maxSavedSearchesPerUser {
+		err = fmt.Errorf("user ID %s already has %d saved searches", userID, maxSavedSearchesPerUser)
+		c.JSON(http.StatusConflict, errorResponse(err))
+		return
+	}
+	
+	search, err := server.dbStore.CreateSavedSearch(c.Request.Context(), db.CreateSavedSearchParams{
+		UserID:    userID,
+		Name:      req.Name,
+		Keyword:   req.Keyword,
+		GradeID:   req.GradeID,
+		Scale:     req.nullScale(),
+		MaxPrice:  req.MaxPrice,
+		Condition: req.nullCondition(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create saved search")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusCreated, search)
+}
+
+//	@Summary		List saved searches
+//	@Description	List the saved searches of the authenticated user, newest first.
+//	@Tags			saved-searches
+//	@Produce		json
+//	@Security		accessToken
+//	@Success		200	{array}	db.SavedSearch	"Saved searches"
+//	@Failure		500	"Internal Server Error - Failed to list saved searches"
+//	@Router			/users/me/saved-searches [get]
+func (server *Server) listSavedSearches(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	searches, err := server.dbStore.ListSavedSearchesByUserID(c.Request.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list saved searches")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, searches)
+}
+
+//	@Summary		Update a saved search
+//	@Description	Replace the name and filters of a saved search. Filters that are omitted are cleared and match any value.
+//	@Tags			saved-searches
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			searchID	path		integer				true	"Saved search ID"
+//	@Param			request		body		savedSearchRequest	true	"Saved search filters"
+//	@Success		200			{object}	db.SavedSearch		"Updated saved search"
+//	@Failure		400			"Bad Request - Invalid input data"
+//	@Failure		404			"Not Found - Saved search or grade does not exist"
+//	@Failure		500			"Internal Server Error - Failed to update saved search"
+//	@Router			/users/me/saved-searches/{searchID} [put]
+func (server *Server) updateSavedSearch(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	searchID, err := strconv.ParseInt(c.Param("searchID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid saved search ID %s", c.Param("searchID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	var req savedSearchRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.GradeID != nil && !server.checkGradeExists(c, *req.GradeID) {
+		return
+	}
+	
+	search, err := server.dbStore.UpdateSavedSearch(c.Request.Context(), db.UpdateSavedSearchParams{
+		ID:        searchID,
+		UserID:    userID,
+		Name:      req.Name,
+		Keyword:   req.Keyword,
+		GradeID:   req.GradeID,
+		Scale:     req.nullScale(),
+		MaxPrice:  req.MaxPrice,
+		Condition: req.nullCondition(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("saved search ID %d not found", searchID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to update saved search")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.JSON(http.StatusOK, search)
+}
+
+//	@Summary		Delete a saved search
+//	@Description	Delete a saved search of the authenticated user.
+//	@Tags			saved-searches
+//	@Security		accessToken
+//	@Param			searchID	path	integer	true	"Saved search ID"
+//	@Success		204			"Saved search deleted"
+//	@Failure		400			"Bad Request - Invalid saved search ID"
+//	@Failure		404			"Not Found - Saved search does not exist"
+//	@Failure		500			"Internal Server Error - Failed to delete saved search"
+//	@Router			/users/me/saved-searches/{searchID} [delete]
+func (server *Server) deleteSavedSearch(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	searchID, err := strconv.ParseInt(c.Param("searchID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid saved search ID %s", c.Param("searchID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	deleted, err := server.dbStore.DeleteSavedSearch(c.Request.Context(), db.DeleteSavedSearchParams{
+		ID:     searchID,
+		UserID: userID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete saved search")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if deleted == 0 {
+		err = fmt.Errorf("saved search ID %d not found", searchID)
+		c.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	
+	c.Status(http.StatusNoContent)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
@@ -22,7 +23,8 @@ import (
 )
 
 //	@Summary		Become a seller
-//	@Description	Upgrade the user's role to seller and create the trial subscription
+//	@Description	Upgrade the user's role to seller and create the trial subscription.
+//	@Description	Existing access tokens are revoked, the client must call /auth/refresh to get an access token with the seller role.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -60,6 +62,14 @@ func (server *Server) becomeSeller(ctx *gin.Context) {
 		return
 	}
 	
+	// Role đã thay đổi, vô hiệu hóa các access token cũ (vẫn mang role member)
+	err = server.tokenVersionStore.Set(ctx, seller.ID, seller.TokenVersion)
+	if err != nil {
+		log.Err(err).Msg("failed to set token version")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
 	ctx.JSON(http.StatusOK, seller)
 }
 
@@ -110,9 +120,9 @@ func (server *Server) getSellerProfile(c *gin.Context) {
 //	@Success		200	{object}	SubscriptionDetailsResponse	"Current active subscription details"
 //	@Router			/sellers/{sellerID}/subscriptions/active [get]
 func (server *Server) getCurrentActiveSubscription(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
-	subscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c, seller.ID)
+	subscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c, seller.Subject)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "no active subscription found"})
@@ -132,6 +142,7 @@ func (server *Server) getCurrentActiveSubscription(c *gin.Context) {
 
 //	@Summary		Publish a gundam for sale
 //	@Description	Publish a gundam for sale for the specified seller. This endpoint checks the gundam's status before proceeding.
+//	@Description	The response includes a suggested price range (p25 - p75 of recent completed orders and auctions of the same model kit or slug family, exchanges are not counted), or null when there is not enough sales data.
 //	@Tags			sellers
 //	@Accept			json
 //	@Produce		json
@@ -141,7 +152,7 @@ func (server *Server) getCurrentActiveSubscription(c *gin.Context) {
 //	@Success		200	{object}	map[string]interface{}	"Successfully published gundam"
 //	@Router			/sellers/{sellerID}/gundams/{gundamID}/publish [patch]
 func (server *Server) publishGundam(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	gundamID, err := strconv.ParseInt(c.Param("gundamID"), 10, 64)
 	if err != nil {
@@ -163,8 +174,8 @@ func (server *Server) publishGundam(c *gin.Context) {
 	}
 	
 	// Kiểm tra quyền sở hữu và trạng thái Gundam
-	if gundam.OwnerID != seller.ID {
-		err = fmt.Errorf("gundam ID %d does not belong to seller ID %s", gundam.ID, seller.ID)
+	if gundam.OwnerID != seller.Subject {
+		err = fmt.Errorf("gundam ID %d does not belong to seller ID %s", gundam.ID, seller.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -181,7 +192,7 @@ func (server *Server) publishGundam(c *gin.Context) {
 		return
 	}
 	
-	userSubscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c, seller.ID)
+	userSubscription, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c, seller.Subject)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "no active subscription found"})
@@ -211,7 +222,7 @@ func (server *Server) publishGundam(c *gin.Context) {
 	
 	arg := db.PublishGundamTxParams{
 		GundamID:             gundam.ID,
-		SellerID:             seller.ID,
+		SellerID:             seller.Subject,
 		ActiveSubscriptionID: userSubscription.ID,
 		ListingsUsed:         userSubscription.ListingsUsed + 1,
 	}
@@ -222,11 +233,31 @@ func (server *Server) publishGundam(c *gin.Context) {
 		return
 	}
 	
+	// Báo cho những người đang theo dõi Gundam này, lỗi chỉ được ghi log vì Gundam đã được đăng bán thành công
+	err = worker.DistributeMatchWatchers(c, server.taskDistributor, &worker.PayloadMatchWatchers{
+		GundamID: gundam.ID,
+		Price:    *gundam.Price,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("gundam_id", gundam.ID).Msg("failed to distribute match watchers task")
+	}
+	
+	// Gợi ý khoảng giá dựa trên giá bán thực tế, chỉ mang tính tham khảo nên lỗi không làm hỏng việc đăng bán
+	var suggestedRange *suggestedPriceRange
+	market, err := server.getMarketPrice(c, gundam, time.Now().AddDate(0, -defaultMarketPriceMonths, 0), marketPriceSources(false))
+	if err != nil {
+		log.Error().Err(err).Int64("gundam_id", gundam.ID).Msg("failed to get market price")
+	} else {
+		suggestedRange = market.suggestPriceRange(gundam.Condition)
+	}
+	
 	// Phản hồi thành công với thông tin chi tiết sản phẩm
 	c.JSON(http.StatusOK, gin.H{
-		"message":   "gundam is now listed for sale",
-		"gundam_id": gundam.ID,
-		"status":    db.GundamStatusPublished,
+		"message":               "gundam is now listed for sale",
+		"gundam_id":             gundam.ID,
+		"status":                db.GundamStatusPublished,
+		"price":                 gundam.Price,
+		"suggested_price_range": suggestedRange,
 	})
 }
 
@@ -246,7 +277,7 @@ func (server *Server) publishGundam(c *gin.Context) {
 //	@Failure		500	{object}	map[string]string		"Internal server error"
 //	@Router			/sellers/{sellerID}/gundams/{gundamID}/unpublish [patch]
 func (server *Server) unpublishGundam(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	gundamID, err := strconv.ParseInt(c.Param("gundamID"), 10, 64)
 	if err != nil {
@@ -267,8 +298,8 @@ func (server *Server) unpublishGundam(c *gin.Context) {
 		return
 	}
 	
-	if gundam.OwnerID != seller.ID {
-		err = fmt.Errorf("gundam ID %d does not belong to seller ID %s", gundam.ID, seller.ID)
+	if gundam.OwnerID != seller.Subject {
+		err = fmt.Errorf("gundam ID %d does not belong to seller ID %s", gundam.ID, seller.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -296,16 +327,20 @@ func (server *Server) unpublishGundam(c *gin.Context) {
 
 //	@Summary		List all sales orders (excluding exchange orders) for a specific seller
 //	@Description	Get all sales orders that belong to the specified seller ID
+//	@Description	Note: the recently_updated cursor is keyed on updated_at, so an order updated while paging may be skipped or returned twice. Use newest or oldest for a stable traversal.
 //	@Tags			sellers
 //	@Accept			json
 //	@Produce		json
 //	@Security		accessToken
-//	@Param			sellerID	path	string				true	"Seller ID"
-//	@Param			status		query	string				false	"Filter by order status"	Enums(pending, packaging, delivering, delivered, completed, canceled, failed)
-//	@Success		200			array	db.SalesOrderInfo	"List of sales orders"
+//	@Param			sellerID	path		string							true	"Seller ID"
+//	@Param			status		query		string							false	"Filter by order status"					Enums(pending, packaging, delivering, delivered, completed, canceled, failed)
+//	@Param			sort		query		string							false	"Sort order (default: recently_updated)"	Enums(recently_updated, newest, oldest)
+//	@Param			cursor		query		string							false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit		query		integer							false	"Page size (default: 20, max: 100)"
+//	@Success		200			{object}	pageResponse[db.SalesOrderInfo]	"List of sales orders"
 //	@Router			/sellers/{sellerID}/orders [get]
 func (server *Server) listSalesOrders(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	status := c.Query("status")
 	
 	if status != "" {
@@ -316,23 +351,41 @@ func (server *Server) listSalesOrders(c *gin.Context) {
 		}
 	}
 	
+	page, err := parsePageQuery(c, orderSorts, sortRecentlyUpdated)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, cursorID, err := page.TimeUUIDCursor()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
 	var resp []db.SalesOrderInfo
 	
 	arg := db.ListSalesOrdersParams{
-		SellerID: user.ID,
+		SellerID: user.Subject,
 		Status: db.NullOrderStatus{
 			OrderStatus: db.OrderStatus(status),
 			Valid:       status != "",
 		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	}
 	
-	orders, err := server.dbStore.ListSalesOrders(c, arg)
+	rows, err := server.dbStore.ListSalesOrders(c, arg)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to list orders by user")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 	
+	orders, info := paginate(rows, page, orderCursorOf(page.Sort))
+	
 	for _, order := range orders {
 		var orderInfo db.SalesOrderInfo
 		orderItems, err := server.dbStore.ListOrderItems(c, order.ID)
@@ -347,7 +400,7 @@ func (server *Server) listSalesOrders(c *gin.Context) {
 		resp = append(resp, orderInfo)
 	}
 	
-	c.JSON(http.StatusOK, resp)
+	c.JSON(http.StatusOK, newPageResponse(resp, info))
 }
 
 type confirmOrderRequestParams struct {
@@ -366,7 +419,7 @@ type confirmOrderRequestParams struct {
 //	@Success		200	{object}	db.ConfirmOrderTxResult	"Successfully confirmed order"
 //	@Router			/sellers/{sellerID}/orders/:orderID/confirm [patch]
 func (server *Server) confirmOrder(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	orderID, err := uuid.Parse(c.Param("orderID"))
 	if err != nil {
@@ -377,7 +430,7 @@ func (server *Server) confirmOrder(c *gin.Context) {
 	
 	order, err := server.dbStore.GetSalesOrder(c.Request.Context(), db.GetSalesOrderParams{
 		OrderID:  orderID,
-		SellerID: user.ID,
+		SellerID: user.Subject,
 	})
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -392,8 +445,8 @@ func (server *Server) confirmOrder(c *gin.Context) {
 	}
 	
 	// Kiểm tra quyền sở hữu đơn hàng
-	if order.SellerID != user.ID {
-		err = fmt.Errorf("order %s does not belong to seller ID %s", order.Code, user.ID)
+	if order.SellerID != user.Subject {
+		err = fmt.Errorf("order %s does not belong to seller ID %s", order.Code, user.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -407,7 +460,7 @@ func (server *Server) confirmOrder(c *gin.Context) {
 	
 	result, err := server.dbStore.ConfirmOrderBySellerTx(c, db.ConfirmOrderTxParams{
 		Order:    &order,
-		SellerID: user.ID,
+		SellerID: user.Subject,
 	})
 	if err != nil {
 		log.Error().Err(err).Msg("failed to confirm order")
@@ -427,7 +480,7 @@ func (server *Server) confirmOrder(c *gin.Context) {
 	err = server.taskDistributor.DistributeTaskSendNotification(c.Request.Context(), &worker.PayloadSendNotification{
 		RecipientID: result.Order.BuyerID,
 		Title:       fmt.Sprintf("Đơn hàng #%s đã được xác nhận", result.Order.Code),
-		Message:     fmt.Sprintf("Đơn hàng #%s của bạn đã được người bán xác nhận và đang được chuẩn bị. Chúng tôi sẽ thông báo khi đơn hàng được giao cho đơn vị vận chuyển."),
+		Message:     fmt.Sprintf("Đơn hàng #%s của bạn đã được người bán xác nhận và đang được chuẩn bị. Chúng tôi sẽ thông báo khi đơn hàng được giao cho đơn vị vận chuyển.", result.Order.Code),
 		Type:        "order",
 		ReferenceID: result.Order.Code,
 	}, opts...)
@@ -447,7 +500,7 @@ func (server *Server) confirmOrder(c *gin.Context) {
 	if err != nil {
 		log.Err(err).Msg("failed to send notification to seller")
 	}
-	log.Info().Msgf("Notification sent to seller: %s", user.ID)
+	log.Info().Msgf("Notification sent to seller: %s", user.Subject)
 	
 	c.JSON(http.StatusOK, result)
 }
@@ -527,12 +580,17 @@ func (server *Server) packageOrder(c *gin.Context) {
 	arg := db.PackageOrderTxParams{
 		Order:               &order,
 		PackageImages:       req.PackageImages,
-		UploadImagesFunc:    server.uploadFileToCloudinary,
+		UploadImagesFunc:    server.uploadImages,
 		CreateDeliveryOrder: server.deliveryService.CreateOrder,
 	}
 	
 	result, err := server.dbStore.PackageOrderTx(c.Request.Context(), arg)
 	if err != nil {
+		if errors.Is(err, storage.ErrInvalidImage) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		
 		log.Error().Err(err).Msg("Failed to package order")
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -624,7 +682,7 @@ func (server *Server) updateSellerProfile(c *gin.Context) {
 //	@Success		200	{object}	db.SalesOrderDetails	"Sales order details"
 //	@Router			/sellers/{sellerID}/orders/:orderID [get]
 func (server *Server) getSalesOrderDetails(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	orderID, err := uuid.Parse(c.Param("orderID"))
 	if err != nil {
@@ -637,7 +695,7 @@ func (server *Server) getSalesOrderDetails(c *gin.Context) {
 	
 	order, err := server.dbStore.GetSalesOrder(c.Request.Context(), db.GetSalesOrderParams{
 		OrderID:  orderID,
-		SellerID: user.ID,
+		SellerID: user.Subject,
 	})
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -652,8 +710,8 @@ func (server *Server) getSalesOrderDetails(c *gin.Context) {
 	}
 	
 	// Kiểm tra xem người dùng có quyền truy cập đơn hàng không
-	if user.ID != order.SellerID {
-		err = fmt.Errorf("order ID %s does not belong to user %s", order.ID, user.ID)
+	if user.Subject != order.SellerID {
+		err = fmt.Errorf("order ID %s does not belong to user %s", order.ID, user.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -661,7 +719,7 @@ func (server *Server) getSalesOrderDetails(c *gin.Context) {
 	resp.Order = order
 	
 	// Lấy thông tin người mua
-	receiver, err := server.dbStore.GetUserByID(c.Request.Context(), order.BuyerID)
+	receiver, err := server.dbStore.GetUserByIDIncludingDeleted(c.Request.Context(), order.BuyerID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			err = fmt.Errorf("receiver ID %s not found", order.BuyerID)
@@ -742,7 +800,7 @@ func (server *Server) getSalesOrderDetails(c *gin.Context) {
 //	@Security		accessToken
 //	@Router			/sellers/{sellerID}/orders/{orderID}/cancel [patch]
 func (server *Server) cancelOrderBySeller(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	orderID, err := uuid.Parse(c.Param("orderID"))
 	if err != nil {
@@ -760,7 +818,7 @@ func (server *Server) cancelOrderBySeller(c *gin.Context) {
 	
 	order, err := server.dbStore.GetSalesOrder(c.Request.Context(), db.GetSalesOrderParams{
 		OrderID:  orderID,
-		SellerID: seller.ID,
+		SellerID: seller.Subject,
 	})
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -774,8 +832,8 @@ func (server *Server) cancelOrderBySeller(c *gin.Context) {
 		return
 	}
 	
-	if order.SellerID != seller.ID {
-		err = fmt.Errorf("order ID %s does not belong to seller ID %s", order.ID, seller.ID)
+	if order.SellerID != seller.Subject {
+		err = fmt.Errorf("order ID %s does not belong to seller ID %s", order.ID, seller.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -846,7 +904,7 @@ func (server *Server) cancelOrderBySeller(c *gin.Context) {
 //	@Security		accessToken
 //	@Router			/sellers/{sellerID}/auction-requests [get]
 func (server *Server) listSellerAuctionRequests(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	status := c.Query("status")
 	if status != "" {
@@ -857,7 +915,7 @@ func (server *Server) listSellerAuctionRequests(c *gin.Context) {
 	}
 	
 	auctionRequests, err := server.dbStore.ListSellerAuctionRequests(c.Request.Context(), db.ListSellerAuctionRequestsParams{
-		SellerID: user.ID,
+		SellerID: user.Subject,
 		Status: db.NullAuctionRequestStatus{
 			AuctionRequestStatus: db.AuctionRequestStatus(status),
 			Valid:                status != "",
@@ -882,7 +940,7 @@ func (server *Server) listSellerAuctionRequests(c *gin.Context) {
 //	@Success		204			"Successfully deleted auction request"
 //	@Router			/sellers/{sellerID}/auction-requests/{requestID} [delete]
 func (server *Server) deleteAuctionRequest(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	requestID, err := uuid.Parse(c.Param("requestID"))
 	if err != nil {
@@ -905,8 +963,8 @@ func (server *Server) deleteAuctionRequest(c *gin.Context) {
 	}
 	
 	// Kiểm tra quyền sở hữu yêu cầu
-	if request.SellerID != user.ID {
-		err = fmt.Errorf("auction request ID %s does not belong to seller ID %s", request.ID, user.ID)
+	if request.SellerID != user.Subject {
+		err = fmt.Errorf("auction request ID %s does not belong to seller ID %s", request.ID, user.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -939,7 +997,7 @@ func (server *Server) deleteAuctionRequest(c *gin.Context) {
 //	@Security		accessToken
 //	@Router			/sellers/{sellerID}/auctions [get]
 func (server *Server) listSellerAuctions(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	status := c.Query("status")
 	if status != "" {
@@ -950,7 +1008,7 @@ func (server *Server) listSellerAuctions(c *gin.Context) {
 	}
 	
 	auctions, err := server.dbStore.ListSellerAuctions(c.Request.Context(), db.ListSellerAuctionsParams{
-		SellerID: user.ID,
+		SellerID: user.Subject,
 		Status: db.NullAuctionStatus{
 			AuctionStatus: db.AuctionStatus(status),
 			Valid:         status != "",
@@ -1002,7 +1060,7 @@ func (server *Server) listSellerAuctions(c *gin.Context) {
 //	@Security		accessToken
 //	@Router			/sellers/{sellerID}/auctions/{auctionID} [get]
 func (server *Server) getSellerAuctionDetails(c *gin.Context) {
-	user := c.MustGet(sellerPayloadKey).(*db.User)
+	user := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	auctionID, err := uuid.Parse(c.Param("auctionID"))
 	if err != nil {
@@ -1023,8 +1081,8 @@ func (server *Server) getSellerAuctionDetails(c *gin.Context) {
 		return
 	}
 	
-	if auction.SellerID != user.ID {
-		err = fmt.Errorf("auction ID %s does not belong to seller ID %s", auction.ID, user.ID)
+	if auction.SellerID != user.Subject {
+		err = fmt.Errorf("auction ID %s does not belong to seller ID %s", auction.ID, user.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -1081,7 +1139,7 @@ type cancelAuctionRequest struct {
 //	@Success		200			{object}	db.Auction				"Successfully cancelled auction with updated details"
 //	@Router			/sellers/{sellerID}/auctions/{auctionID}/cancel [patch]
 func (server *Server) cancelAuctionBySeller(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	var req cancelAuctionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -1110,8 +1168,8 @@ func (server *Server) cancelAuctionBySeller(c *gin.Context) {
 	}
 	
 	// Kiểm tra quyền sở hữu đấu giá
-	if auction.SellerID != seller.ID {
-		err = fmt.Errorf("auction ID %s does not belong to seller ID %s", auction.ID, seller.ID)
+	if auction.SellerID != seller.Subject {
+		err = fmt.Errorf("auction ID %s does not belong to seller ID %s", auction.ID, seller.Subject)
 		c.JSON(http.StatusForbidden, errorResponse(err))
 		return
 	}
@@ -1126,7 +1184,7 @@ func (server *Server) cancelAuctionBySeller(c *gin.Context) {
 	// Thực hiện hủy đấu giá trong transaction
 	arg := db.CancelAuctionTxParams{
 		Auction:    auction,
-		CanceledBy: seller.ID,
+		CanceledBy: seller.Subject,
 		Reason:     req.Reason,
 	}
 	
@@ -1183,7 +1241,7 @@ type upgradeSubscriptionRequest struct {
 //	@Security		accessToken
 //	@Router			/sellers/{sellerID}/subscriptions/upgrade [post]
 func (server *Server) upgradeSubscription(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	var req upgradeSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -1192,7 +1250,7 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 	}
 	
 	// Get current subscription details
-	currentSubDetails, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c.Request.Context(), seller.ID)
+	currentSubDetails, err := server.dbStore.GetCurrentActiveSubscriptionDetailsForSeller(c.Request.Context(), seller.Subject)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			err = fmt.Errorf("no active subscription found")
@@ -1232,7 +1290,7 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 	
 	// Cannot upgrade to the same plan
 	if currentPlan.ID == targetPlan.ID {
-		err = fmt.Errorf("seller ID %s is already subscribed to plan %s", seller.ID, targetPlan.Name)
+		err = fmt.Errorf("seller ID %s is already subscribed to plan %s", seller.Subject, targetPlan.Name)
 		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
 		return
 	}
@@ -1253,10 +1311,10 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 	
 	// Validate wallet balance
 	if targetPlan.Price > 0 {
-		wallet, err := server.dbStore.GetWalletByUserID(c.Request.Context(), seller.ID)
+		wallet, err := server.dbStore.GetWalletByUserID(c.Request.Context(), seller.Subject)
 		if err != nil {
 			if errors.Is(err, db.ErrRecordNotFound) {
-				err = fmt.Errorf("wallet not found for user ID %s", seller.ID)
+				err = fmt.Errorf("wallet not found for user ID %s", seller.Subject)
 				c.JSON(http.StatusNotFound, errorResponse(err))
 				return
 			}
@@ -1275,7 +1333,7 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 	// All business rules passed - Execute the upgrade transaction
 	
 	txParams := db.UpgradeSubscriptionTxParams{
-		SellerID:          seller.ID,
+		SellerID:          seller.Subject,
 		OldSubscriptionID: currentSubDetails.ID,
 		NewPlanID:         req.PlanID,
 		NewPlanPrice:      targetPlan.Price,
@@ -1296,15 +1354,15 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 		err = server.taskDistributor.DistributeTaskSendNotification(
 			context.Background(),
 			&worker.PayloadSendNotification{
-				RecipientID: seller.ID,
+				RecipientID: seller.Subject,
 				Title:       "Nâng cấp gói đăng ký thành công",
 				Message:     message,
 				Type:        "subscription_upgrade",
-				ReferenceID: seller.ID,
+				ReferenceID: seller.Subject,
 			},
 		)
 		if err != nil {
-			log.Error().Err(err).Str("seller_id", seller.ID).Msg("Failed to send upgrade notification")
+			log.Error().Err(err).Str("seller_id", seller.Subject).Msg("Failed to send upgrade notification")
 		}
 	}()
 	
@@ -1320,7 +1378,7 @@ func (server *Server) upgradeSubscription(c *gin.Context) {
 //	@Success		200			{object}	db.SellerDashboard	"Dashboard statistics"
 //	@Router			/sellers/{sellerID}/dashboard [get]
 func (server *Server) getSellerDashboard(c *gin.Context) {
-	seller := c.MustGet(sellerPayloadKey).(*db.User)
+	seller := c.MustGet(sellerPayloadKey).(*token.Payload)
 	
 	var resp db.SellerDashboard
 	
@@ -1328,7 +1386,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 1: Get published gundams count
 	g.Go(func() error {
-		publishedCount, err := server.dbStore.GetSellerPublishedGundamsCount(ctx, seller.ID)
+		publishedCount, err := server.dbStore.GetSellerPublishedGundamsCount(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get published gundams count: %w", err)
 		}
@@ -1338,7 +1396,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 2: Get total income
 	g.Go(func() error {
-		totalIncome, err := server.dbStore.GetSellerTotalIncome(ctx, seller.ID)
+		totalIncome, err := server.dbStore.GetSellerTotalIncome(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get total income: %w", err)
 		}
@@ -1348,7 +1406,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 3: Get completed orders count
 	g.Go(func() error {
-		completedOrders, err := server.dbStore.GetSellerCompletedOrdersCount(ctx, seller.ID)
+		completedOrders, err := server.dbStore.GetSellerCompletedOrdersCount(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get completed orders count: %w", err)
 		}
@@ -1358,7 +1416,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 4: Get processing orders count
 	g.Go(func() error {
-		processingOrders, err := server.dbStore.GetSellerProcessingOrdersCount(ctx, seller.ID)
+		processingOrders, err := server.dbStore.GetSellerProcessingOrdersCount(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get processing orders count: %w", err)
 		}
@@ -1368,7 +1426,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 5: Get income this month
 	g.Go(func() error {
-		incomeThisMonth, err := server.dbStore.GetSellerIncomeThisMonth(ctx, seller.ID)
+		incomeThisMonth, err := server.dbStore.GetSellerIncomeThisMonth(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get income this month: %w", err)
 		}
@@ -1378,7 +1436,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 6: Get active auctions count
 	g.Go(func() error {
-		activeAuctions, err := server.dbStore.GetSellerActiveAuctionsCount(ctx, seller.ID)
+		activeAuctions, err := server.dbStore.GetSellerActiveAuctionsCount(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get active auctions count: %w", err)
 		}
@@ -1388,7 +1446,7 @@ func (server *Server) getSellerDashboard(c *gin.Context) {
 	
 	// Goroutine 7: Get pending auction requests count
 	g.Go(func() error {
-		pendingAuctionRequests, err := server.dbStore.GetSellerPendingAuctionRequestsCount(ctx, seller.ID)
+		pendingAuctionRequests, err := server.dbStore.GetSellerPendingAuctionRequestsCount(ctx, seller.Subject)
 		if err != nil {
 			return fmt.Errorf("failed to get pending auction requests count: %w", err)
 		}
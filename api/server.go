@@ -3,14 +3,17 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 	
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
 	"github.com/katatrina/gundam-BE/internal/delivery"
 	"github.com/katatrina/gundam-BE/internal/event"
-	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/otp"
 	"github.com/katatrina/gundam-BE/internal/phone_number"
+	"github.com/katatrina/gundam-BE/internal/ratelimit"
 	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/token"
 	"github.com/katatrina/gundam-BE/internal/util"
@@ -29,10 +32,15 @@ type Server struct {
 	dbStore                db.Store
 	fileStore              storage.FileStore
 	tokenMaker             token.Maker
+	refreshTokenStore      *token.RefreshTokenStore
+	tokenVersionStore      *token.TokenVersionStore
+	sessionStore           *token.SessionStore
+	rateLimiter            *ratelimit.Limiter
 	config                 *util.Config
 	googleIDTokenValidator *idtoken.Validator
 	phoneNumberService     *phone_number.PhoneNumberService
-	mailService            *mailer.GmailSender
+	emailOTPService        *otp.OTPService // OTP xác thực email
+	emailResetOTPService   *otp.OTPService // OTP đặt lại mật khẩu qua email, tách riêng với OTP xác thực email
 	taskDistributor        worker.TaskDistributor
 	taskInspector          worker.TaskInspector
 	zalopayService         *zalopay.ZalopayService
@@ -42,23 +50,38 @@ type Server struct {
 }
 
 // NewServer creates a new HTTP server and set up routing.
-func NewServer(store db.Store, redisClient *redis.Client, taskDistributor worker.TaskDistributor, taskInspector worker.TaskInspector, config *util.Config, mailer *mailer.GmailSender, deliveryService delivery.IDeliveryProvider, eventSender event.EventSender) (*Server, error) {
+func NewServer(store db.Store, redisClient *redis.Client, taskDistributor worker.TaskDistributor, taskInspector worker.TaskInspector, config *util.Config, deliveryService delivery.IDeliveryProvider, eventSender event.EventSender) (*Server, error) {
 	// Create a new JWT token maker
-	tokenMaker, err := token.NewJWTMaker(config.TokenSecretKey)
+	tokenMaker, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token maker: %w", err)
 	}
 	log.Info().Msg("Token maker created successfully ✅")
 	
+	// Create a new refresh token store
+	refreshTokenStore := token.NewRefreshTokenStore(redisClient)
+	
+	// Create a new token version store
+	tokenVersionStore := token.NewTokenVersionStore(redisClient, config.RefreshTokenDuration)
+	
+	// Create a new session store
+	sessionStore := token.NewSessionStore(redisClient)
+	
+	// Create a new rate limiter
+	rateLimiter := ratelimit.NewLimiter(redisClient)
+	
 	// Create a new Google ID token validator
 	googleIDTokenValidator, err := idtoken.NewValidator(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create google id token validator: %w", err)
 	}
 	
-	// Create a new Cloudinary instance
-	fileStore := storage.NewCloudinaryStore(config.CloudinaryURL)
-	log.Info().Msg("Cloudinary store created successfully ✅")
+	// Create a new file store (Cloudinary, local disk or S3) based on the configuration
+	fileStore, err := storage.NewFileStoreFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file store: %w", err)
+	}
+	log.Info().Str("provider", config.FileStoreProvider).Msg("File store created successfully ✅")
 	
 	// Create a new OTP service
 	phoneNumberService, err := phone_number.NewPhoneService(config, redisClient)
@@ -67,6 +90,16 @@ func NewServer(store db.Store, redisClient *redis.Client, taskDistributor worker
 	}
 	log.Info().Msg("Phone service created successfully ✅")
 	
+	// Create OTP services for email
+	emailOTPService := otp.NewOTPService(redisClient,
+		otp.WithPrefix("otp:email"),
+		otp.WithLimits(otp.LimitsFromConfig(config)),
+	)
+	emailResetOTPService := otp.NewOTPService(redisClient,
+		otp.WithPrefix("otp:password_reset:email"),
+		otp.WithLimits(otp.LimitsFromConfig(config)),
+	)
+	
 	// Create a new ZaloPay service
 	zalopayService := zalopay.NewZalopayService(store, config)
 	log.Info().Msg("ZaloPay service created successfully ✅")
@@ -78,11 +111,16 @@ func NewServer(store db.Store, redisClient *redis.Client, taskDistributor worker
 	server := &Server{
 		dbStore:                store,
 		tokenMaker:             tokenMaker,
+		refreshTokenStore:      refreshTokenStore,
+		tokenVersionStore:      tokenVersionStore,
+		sessionStore:           sessionStore,
+		rateLimiter:            rateLimiter,
 		config:                 config,
 		googleIDTokenValidator: googleIDTokenValidator,
 		fileStore:              fileStore,
 		phoneNumberService:     phoneNumberService,
-		mailService:            mailer,
+		emailOTPService:        emailOTPService,
+		emailResetOTPService:   emailResetOTPService,
 		taskDistributor:        taskDistributor,
 		taskInspector:          taskInspector,
 		zalopayService:         zalopayService,
@@ -95,6 +133,18 @@ func NewServer(store db.Store, redisClient *redis.Client, taskDistributor worker
 	return server, nil
 }
 
+// Giới hạn request cho các nhóm route dễ bị lạm dụng.
+// Route chưa xác thực được giới hạn theo IP, route đã xác thực được giới hạn theo user ID.
+var (
+	loginRateLimit         = ratelimit.Policy{Name: "login", Limit: 10, Window: time.Minute}
+	passwordResetRateLimit = ratelimit.Policy{Name: "password_reset", Limit: 10, Window: 15 * time.Minute}
+	otpRateLimit           = ratelimit.Policy{Name: "otp", Limit: 20, Window: time.Hour}
+	checkEmailRateLimit    = ratelimit.Policy{Name: "check_email", Limit: 30, Window: time.Minute}
+	placeBidRateLimit      = ratelimit.Policy{Name: "place_bid", Limit: 30, Window: time.Minute}
+	exchangeOfferRateLimit = ratelimit.Policy{Name: "exchange_offer", Limit: 10, Window: time.Minute}
+	twoFactorRateLimit     = ratelimit.Policy{Name: "two_factor", Limit: 10, Window: 15 * time.Minute}
+)
+
 // setupRouter configures the HTTP server routes.
 func (server *Server) setupRouter() *gin.Engine {
 	gin.ForceConsoleColor()
@@ -102,7 +152,8 @@ func (server *Server) setupRouter() *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     server.config.AllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-TOTP-Code", "X-Device-Name", storage.LocalContentSHA256Header},
+		ExposeHeaders:    []string{"Retry-After", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"},
 		AllowCredentials: true,
 	}))
 	router.Use(func(c *gin.Context) {
@@ -111,12 +162,35 @@ func (server *Server) setupRouter() *gin.Engine {
 		c.Next()
 	})
 	
+	router.GET("/.well-known/jwks.json", server.getJWKS)
+	
+	// Phục vụ file của local file store, quyền truy cập được kiểm tra bằng chữ ký trong URL
+	if localStore, ok := server.fileStore.(*storage.LocalStore); ok {
+		filesHandler := gin.WrapH(http.StripPrefix(storage.LocalFilesRoute+"/", localStore))
+		router.GET(storage.LocalFilesRoute+"/*key", filesHandler)
+		router.HEAD(storage.LocalFilesRoute+"/*key", filesHandler)
+		router.PUT(storage.LocalFilesRoute+"/*key", filesHandler)
+	}
+	
 	v1 := router.Group("/v1")
 	
 	v1.POST("/tokens/verify", server.verifyAccessToken)
 	
-	v1.POST("/auth/login", server.loginUser)
-	v1.POST("/auth/google-login", server.loginUserWithGoogle)
+	loginGroup := v1.Group("/auth", rateLimitMiddleware(server.rateLimiter, loginRateLimit))
+	{
+		loginGroup.POST("/login", server.loginUser)
+		loginGroup.POST("/google-login", server.loginUserWithGoogle)
+		loginGroup.POST("/login/2fa", server.loginWithTwoFactor)
+	}
+	
+	v1.POST("/auth/refresh", server.refreshAccessToken)
+	v1.POST("/auth/logout", server.logoutUser)
+	
+	passwordResetGroup := v1.Group("/auth/password", rateLimitMiddleware(server.rateLimiter, passwordResetRateLimit))
+	{
+		passwordResetGroup.POST("/forgot", server.forgotPassword)
+		passwordResetGroup.POST("/reset", server.resetPassword)
+	}
 	
 	// API cho member thông thường
 	userGroup := v1.Group("/users")
@@ -132,8 +206,20 @@ func (server *Server) setupRouter() *gin.Engine {
 		userGroup.PUT(":id/addresses/:address_id", server.updateUserAddress)
 		userGroup.DELETE(":id/addresses/:address_id", server.deleteUserAddress)
 		
-		userGroup.Use(authMiddleware(server.tokenMaker))
+		userGroup.Use(authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionManageProfile))
 		userGroup.POST("become-seller", server.becomeSeller)
+		userGroup.PUT("me/password", server.changePassword)
+		userGroup.GET("me/export", server.exportUserData)
+		userGroup.DELETE("me", server.deleteUserAccount)
+		userGroup.GET("me/2fa", server.getTwoFactorStatus)
+		userGroup.POST("me/2fa/totp/setup", server.setupTOTP)
+		userGroup.POST("me/2fa/totp/enable", server.enableTOTP)
+		userGroup.DELETE("me/2fa/totp", server.disableTOTP)
+		userGroup.POST("me/2fa/recovery-codes", server.regenerateRecoveryCodes)
+		userGroup.GET("me/sessions", server.listUserSessions)
+		userGroup.DELETE("me/sessions/:sessionID", server.revokeUserSession)
+		userGroup.POST("me/uploads", server.presignUpload)
+		userGroup.POST("me/uploads/finalize", server.finalizeUpload)
 		
 		userGroup.GET(":id/wallet", server.getUserWallet)
 		
@@ -151,7 +237,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 		
 		// API cho bài đăng trao đổi của người dùng (đã đăng nhập)
-		userExchangePostGroup := userGroup.Group("/me/exchange-posts")
+		userExchangePostGroup := userGroup.Group("/me/exchange-posts", requiredPermissions(token.PermissionTradeExchanges))
 		{
 			// Liệt kê thông tin chi tiết của các bài đăng trao đổi
 			userExchangePostGroup.GET("", server.listUserExchangePosts) // ✅
@@ -184,7 +270,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 		
 		// API cho đề xuất trao đổi của người dùng đã đăng nhập
-		userOffersGroup := userGroup.Group("/me/exchange-offers")
+		userOffersGroup := userGroup.Group("/me/exchange-offers", requiredPermissions(token.PermissionTradeExchanges))
 		{
 			// Liệt kê tất cả đề xuất trao đổi mà người dùng đã gửi
 			userOffersGroup.GET("", server.listUserExchangeOffers) // ✅
@@ -193,7 +279,7 @@ func (server *Server) setupRouter() *gin.Engine {
 			userOffersGroup.GET(":offerID", server.getUserExchangeOffer) // ✅
 			
 			// Tạo đề xuất trao đổi cho một bài đăng
-			userOffersGroup.POST("", server.createExchangeOffer) // ✅
+			userOffersGroup.POST("", rateLimitMiddleware(server.rateLimiter, exchangeOfferRateLimit), server.createExchangeOffer) // ✅
 			
 			// Thêm endpoint cập nhật đề xuất (phản hồi thương lượng)
 			userOffersGroup.PATCH("/:offerID", server.updateExchangeOffer) // ✅
@@ -204,11 +290,12 @@ func (server *Server) setupRouter() *gin.Engine {
 	}
 	
 	// Nhóm các API liên quan đến cuộc trao đổi
-	exchangeGroup := v1.Group("/exchanges", authMiddleware(server.tokenMaker))
+	exchangeGroup := v1.Group("/exchanges", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionTradeExchanges))
 	{
 		exchangeGroup.GET("", server.listUserExchanges)                                              // ✅ Liệt kê các giao dịch trao đổi của người dùng
 		exchangeGroup.GET(":exchangeID", server.getExchangeDetails)                                  // ✅ Lấy chi tiết giao dịch trao đổi
 		exchangeGroup.PUT(":exchangeID/delivery-addresses", server.provideExchangeDeliveryAddresses) // ✅ Cung cấp địa chỉ gửi và nhận hàng
+		exchangeGroup.GET(":exchangeID/delivery-quote", server.getExchangeDeliveryQuote)             // Báo giá phí vận chuyển mà người dùng phải trả
 		exchangeGroup.POST(":exchangeID/pay-delivery-fee", server.payExchangeDeliveryFee)            // ✅ Thanh toán phí vận chuyển
 		exchangeGroup.PATCH(":exchangeID/cancel", server.cancelExchange)                             // ✅ Hủy giao dịch trao đổi
 	}
@@ -217,18 +304,19 @@ func (server *Server) setupRouter() *gin.Engine {
 	exchangePostPublicGroup := v1.Group("/exchange-posts")
 	{
 		// Liệt kê các bài post trao đổi đang mở trên nền tảng
-		exchangePostPublicGroup.GET("", optionalAuthMiddleware(server.tokenMaker), server.listOpenExchangePosts) // ✅
+		exchangePostPublicGroup.GET("", optionalAuthMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), server.listOpenExchangePosts) // ✅
 		
 		// Lấy chi tiết một bài post trao đổi (bỏ - không cần thiết)
 		// exchangePostPublicGroup.GET("/:id", server.getExchangePostDetails)
 	}
 	
 	// Nhóm api cho các đơn hàng thông thường và đơn hàng trao đổi
-	orderGroup := v1.Group("/orders", authMiddleware(server.tokenMaker))
+	orderGroup := v1.Group("/orders", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionPlaceOrders))
 	{
-		// Tạo đơn hàng mua thông thường khi user thanh toán thành công
-		// Client cần gọi api này nhiều lần để tạo nhiều đơn hàng nếu có nhiều sản phẩm thuộc nhiều seller khác nhau trong giỏ hàng
+		// Tạo đơn hàng mua thông thường cho các sản phẩm của một seller
+		// Để thanh toán giỏ hàng có sản phẩm của nhiều seller, dùng POST /checkout thay vì gọi api này nhiều lần
 		orderGroup.POST("", server.createOrder)                        // ✅ Tạo đơn hàng thông thường
+		orderGroup.POST("delivery-quote", server.quoteOrderDelivery)   // Báo giá phí vận chuyển và thời gian giao hàng dự kiến của đơn hàng
 		orderGroup.GET("", server.listMemberOrders)                    // ✅ Liệt kê tất cả đơn hàng thông thường và đơn hàng trao đổi trong tab "Đơn hàng" trong trang "Tài khoản của tôi"
 		orderGroup.GET(":orderID", server.getMemberOrderDetails)       // ✅ Lấy thông tin chi tiết của một đơn hàng thông thường hoặc đơn hàng trao đổi
 		orderGroup.PATCH(":orderID/package", server.packageOrder)      // ✅ Người gửi đóng gói đơn hàng
@@ -236,6 +324,10 @@ func (server *Server) setupRouter() *gin.Engine {
 		orderGroup.PATCH(":orderID/cancel", server.cancelOrderByBuyer) // ✅ Người mua hủy đơn hàng
 	}
 	
+	// Thanh toán giỏ hàng: tạo đơn hàng cho từng seller trong cùng một transaction,
+	// giá sản phẩm và phí vận chuyển được tính ở server, gửi lại cùng checkout_id không tạo thêm đơn hàng
+	v1.POST("/checkout", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionPlaceOrders), server.checkout)
+	
 	// API công khai cho phiên đấu giá (không cần đăng nhập)
 	auctionPublicGroup := v1.Group("/auctions")
 	{
@@ -249,13 +341,13 @@ func (server *Server) setupRouter() *gin.Engine {
 	}
 	
 	// API cho người dùng tham gia đấu giá (cần đăng nhập)
-	userAuctionGroup := v1.Group("/users/me/auctions", authMiddleware(server.tokenMaker))
+	userAuctionGroup := v1.Group("/users/me/auctions", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionBidAuctions))
 	{
 		// Tham gia đấu giá (đặt cọc)
 		userAuctionGroup.POST("/:auctionID/participate", server.participateInAuction) // ✅
 		
 		// Đặt giá
-		userAuctionGroup.POST("/:auctionID/bids", server.placeBid) // ✅
+		userAuctionGroup.POST("/:auctionID/bids", rateLimitMiddleware(server.rateLimiter, placeBidRateLimit), server.placeBid) // ✅
 		
 		// Xem danh sách các lượt đặt giá của bản thân của một phiên đấu giá cụ thể
 		userAuctionGroup.GET("/:auctionID/bids", server.listUserBids) // ✅
@@ -268,13 +360,13 @@ func (server *Server) setupRouter() *gin.Engine {
 	}
 	
 	// Nhóm các API chỉ dành cho seller
-	sellerGroup := v1.Group("/sellers/:sellerID", authMiddleware(server.tokenMaker), requiredSellerRole(server.dbStore))
+	sellerGroup := v1.Group("/sellers/:sellerID", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredSellerRole())
 	{
 		// API thống kê cho dashboard của người bán
-		sellerGroup.GET("/dashboard", server.getSellerDashboard)
+		sellerGroup.GET("/dashboard", requiredPermissions(token.PermissionSellGundams), server.getSellerDashboard)
 		
 		// Nhóm các API chỉ liên quan đến đơn bán (không bao gồm đơn hàng trao đổi)
-		sellerOrderGroup := sellerGroup.Group("orders")
+		sellerOrderGroup := sellerGroup.Group("orders", requiredPermissions(token.PermissionManageSalesOrders))
 		{
 			sellerOrderGroup.GET("", server.listSalesOrders)                      // ✅ Liệt kê tất cả đơn bán
 			sellerOrderGroup.GET(":orderID", server.getSalesOrderDetails)         // ✅ Lấy thông tin chi tiết của một đơn bán
@@ -283,14 +375,14 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 		
 		// Nhóm các API liên quan đến việc quản lý sản phẩm của người bán
-		gundamGroup := sellerGroup.Group("gundams")
+		gundamGroup := sellerGroup.Group("gundams", requiredPermissions(token.PermissionSellGundams))
 		{
 			gundamGroup.PATCH(":gundamID/publish", server.publishGundam)     // ✅
 			gundamGroup.PATCH(":gundamID/unpublish", server.unpublishGundam) // ✅
 		}
 		
 		// Nhóm các API liên quan đến việc quản lý gói đăng ký của người bán
-		subscriptionGroup := sellerGroup.Group("subscriptions")
+		subscriptionGroup := sellerGroup.Group("subscriptions", requiredPermissions(token.PermissionSellGundams))
 		{
 			// Lấy thông tin gói đăng ký hiện tại của người bán
 			subscriptionGroup.GET("active", server.getCurrentActiveSubscription) // ✅
@@ -300,7 +392,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 		
 		// Nhóm các API cho yêu cầu đấu giá
-		auctionRequestGroup := sellerGroup.Group("auction-requests")
+		auctionRequestGroup := sellerGroup.Group("auction-requests", requiredPermissions(token.PermissionRequestAuctions))
 		{
 			// Tạo yêu cầu đấu giá
 			auctionRequestGroup.POST("", server.createAuctionRequest) // ✅
@@ -316,7 +408,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 		
 		// API cho phiên đấu giá của seller
-		sellerAuctionGroup := sellerGroup.Group("auctions")
+		sellerAuctionGroup := sellerGroup.Group("auctions", requiredPermissions(token.PermissionRequestAuctions))
 		{
 			// Xem danh sách phiên đấu giá của mình
 			sellerAuctionGroup.GET("", server.listSellerAuctions) // ✅
@@ -329,7 +421,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 	}
 	
-	walletGroup := v1.Group("/wallet", authMiddleware(server.tokenMaker))
+	walletGroup := v1.Group("/wallet", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionManageWallet))
 	{
 		zalopayGroup := walletGroup.Group("/zalopay")
 		{
@@ -337,7 +429,7 @@ func (server *Server) setupRouter() *gin.Engine {
 		}
 	}
 	
-	userWalletGroup := v1.Group("/users/me/wallet", authMiddleware(server.tokenMaker))
+	userWalletGroup := v1.Group("/users/me/wallet", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionManageWallet))
 	{
 		// Liệt kê tất cả các bút toán ví của người dùng
 		userWalletGroup.GET("/entries", server.listUserWalletEntries)
@@ -347,16 +439,40 @@ func (server *Server) setupRouter() *gin.Engine {
 		userWalletGroup.PATCH("/withdrawal-requests/:requestID/cancel", server.cancelWithdrawalRequest) // ✅
 	}
 	
-	userBankAccountGroup := v1.Group("/users/me/bank-accounts", authMiddleware(server.tokenMaker))
+	userBankAccountGroup := v1.Group("/users/me/bank-accounts", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionManageWallet))
 	{
 		userBankAccountGroup.POST("", server.addBankAccount)                    // ✅
 		userBankAccountGroup.GET("", server.listUserBankAccounts)               // ✅
 		userBankAccountGroup.DELETE(":accountID", server.deleteUserBankAccount) // ✅
 	}
 	
+	// Danh sách yêu thích của người mua
+	userWishlistGroup := v1.Group("/users/me/wishlist", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore))
+	{
+		userWishlistGroup.POST("", server.addWishlistItem)
+		userWishlistGroup.GET("", server.listWishlistItems)
+		userWishlistGroup.DELETE(":gundamID", server.deleteWishlistItem)
+	}
+	
+	// Tìm kiếm đã lưu của người mua, được thông báo khi có Gundam khớp được đăng bán hoặc đưa lên đấu giá
+	userSavedSearchGroup := v1.Group("/users/me/saved-searches", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore))
+	{
+		userSavedSearchGroup.POST("", server.createSavedSearch)
+		userSavedSearchGroup.GET("", server.listSavedSearches)
+		userSavedSearchGroup.PUT(":searchID", server.updateSavedSearch)
+		userSavedSearchGroup.DELETE(":searchID", server.deleteSavedSearch)
+	}
+	
 	v1.GET("/grades", server.listGundamGrades)                  // Liệt kê tất cả các cấp độ Gundam
 	v1.GET("/subscription-plans", server.listSubscriptionPlans) // Liệt kê tất cả gói subscription
 	
+	// API public cho danh mục model kit
+	modelKitGroup := v1.Group("/model-kits")
+	{
+		modelKitGroup.GET("", server.listModelKits)
+		modelKitGroup.GET(":kitID", server.getModelKit)
+	}
+	
 	sellerProfileGroup := v1.Group("/seller/profile")
 	{
 		sellerProfileGroup.POST("", server.createSellerProfile)
@@ -369,16 +485,18 @@ func (server *Server) setupRouter() *gin.Engine {
 		gundamGroup.GET("", server.listGundams)
 		gundamGroup.GET(":gundamID", server.getGundamDetails)
 		gundamGroup.GET("/by-slug/:slug", server.getGundamBySlug)
+		gundamGroup.GET(":gundamID/market-price", server.getGundamMarketPrice)
+		gundamGroup.GET(":gundamID/price-history", server.getGundamPriceHistory)
 	}
 	
-	cartGroup := v1.Group("/cart", authMiddleware(server.tokenMaker))
+	cartGroup := v1.Group("/cart", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredPermissions(token.PermissionPlaceOrders))
 	{
 		cartGroup.POST("/items", server.addCartItem)
 		cartGroup.GET("/items", server.listCartItems)
 		cartGroup.DELETE("/items/:id", server.deleteCartItem)
 	}
 	
-	otpGroup := v1.Group("/otp")
+	otpGroup := v1.Group("/otp", rateLimitMiddleware(server.rateLimiter, otpRateLimit))
 	{
 		otpGroup.POST("/phone-number/generate", server.generatePhoneNumberOTP)
 		otpGroup.POST("/phone-number/verify", server.verifyPhoneNumberOTP)
@@ -387,14 +505,14 @@ func (server *Server) setupRouter() *gin.Engine {
 		otpGroup.POST("/email/verify", server.verifyEmailOTP)
 	}
 	
-	v1.POST("/check-email", server.checkEmailExists)
+	v1.POST("/check-email", rateLimitMiddleware(server.rateLimiter, checkEmailRateLimit), server.checkEmailExists)
 	
 	// API cho moderator
-	moderatorGroup := v1.Group("/mod", authMiddleware(server.tokenMaker), requiredModeratorRole(server.dbStore))
+	moderatorGroup := v1.Group("/mod", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredModeratorRole())
 	{
 		moderatorGroup.GET("/dashboard", server.getModeratorDashboard) // ✅
 		
-		moderatorAuctionRequestGroup := moderatorGroup.Group("auction-requests")
+		moderatorAuctionRequestGroup := moderatorGroup.Group("auction-requests", requiredPermissions(token.PermissionModerateAuctions))
 		{
 			// Xem tất cả yêu cầu đấu giá (pending, approved, rejected)
 			moderatorAuctionRequestGroup.GET("", server.listAuctionRequestsForModerator) // ✅
@@ -406,7 +524,7 @@ func (server *Server) setupRouter() *gin.Engine {
 			moderatorAuctionRequestGroup.PATCH(":requestID/reject", server.rejectAuctionRequest) // ✅
 		}
 		
-		moderatorAuctionGroup := moderatorGroup.Group("auctions")
+		moderatorAuctionGroup := moderatorGroup.Group("auctions", requiredPermissions(token.PermissionModerateAuctions))
 		{
 			// Liệt kê tất cả các phiên đấu giá cho moderator
 			moderatorAuctionGroup.GET("", server.listAuctionsForModerator) // ✅
@@ -415,17 +533,40 @@ func (server *Server) setupRouter() *gin.Engine {
 			moderatorAuctionGroup.PATCH(":auctionID", server.updateAuctionDetailsByModerator) // ✅
 		}
 		
-		moderatorWithdrawalRequestGroup := moderatorGroup.Group("withdrawal-requests")
+		moderatorWithdrawalRequestGroup := moderatorGroup.Group("withdrawal-requests", requiredPermissions(token.PermissionProcessWithdrawals))
 		{
-			moderatorWithdrawalRequestGroup.GET("", server.listWithdrawalRequests)                         // ✅
-			moderatorWithdrawalRequestGroup.PATCH(":requestID/complete", server.completeWithdrawalRequest) // ✅
-			moderatorWithdrawalRequestGroup.PATCH(":requestID/reject", server.rejectWithdrawalRequest)     // ✅
+			moderatorWithdrawalRequestGroup.GET("", server.listWithdrawalRequests)                                                           // ✅
+			moderatorWithdrawalRequestGroup.PATCH(":requestID/complete", server.requiredTwoFactorStepUp(), server.completeWithdrawalRequest) // ✅
+			moderatorWithdrawalRequestGroup.PATCH(":requestID/reject", server.rejectWithdrawalRequest)                                       // ✅
+		}
+		
+		// Quản lý danh mục model kit
+		moderatorModelKitGroup := moderatorGroup.Group("model-kits", requiredPermissions(token.PermissionModerateCatalog))
+		{
+			moderatorModelKitGroup.POST("", server.createModelKit)
+			moderatorModelKitGroup.PATCH(":kitID", server.updateModelKit)
+			moderatorModelKitGroup.PATCH(":kitID/image", server.updateModelKitImage)
+			moderatorModelKitGroup.DELETE(":kitID", server.deleteModelKit)
+		}
+		
+		// Các cặp ảnh đăng bán gần giống nhau (nghi bị lấy cắp hoặc dùng lại) chờ moderator xem xét
+		moderatorImageFlagGroup := moderatorGroup.Group("image-flags", requiredPermissions(token.PermissionModerateCatalog))
+		{
+			moderatorImageFlagGroup.GET("", server.listImageFlags)
+			moderatorImageFlagGroup.PATCH(":flagID", server.reviewImageFlag)
 		}
 	}
 	
-	adminGroup := v1.Group("/admin", authMiddleware(server.tokenMaker), requiredAdminRole(server.dbStore))
+	adminGroup := v1.Group("/admin", authMiddleware(server.tokenMaker, server.tokenVersionStore, server.sessionStore), requiredAdminRole())
 	{
-		adminGroup.GET("/dashboard", server.getAdminDashboard) // ✅
+		adminGroup.GET("/dashboard", requiredPermissions(token.PermissionViewPlatformMetrics), server.getAdminDashboard) // ✅
+		
+		adminImageCleanupGroup := adminGroup.Group("image-cleanup-runs", requiredPermissions(token.PermissionManageFileStorage))
+		{
+			adminImageCleanupGroup.POST("", server.createImageCleanupRun)
+			adminImageCleanupGroup.GET("", server.listImageCleanupRuns)
+			adminImageCleanupGroup.GET(":runID", server.getImageCleanupRun)
+		}
 	}
 	
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	sessionIDKey = "sessionID"
+	
+	// Ứng dụng di động có thể gửi tên thiết bị, nếu không sẽ suy ra từ User-Agent
+	deviceNameHeaderKey = "X-Device-Name"
+	maxDeviceNameLength = 100
+)
+
+// sessionClient lấy thông tin thiết bị của request hiện tại để ghi vào phiên đăng nhập
+func sessionClient(ctx *gin.Context) token.SessionClient {
+	userAgent := ctx.Request.UserAgent()
+	
+	device := strings.TrimSpace(ctx.GetHeader(deviceNameHeaderKey))
+	if device == "" {
+		device = describeDevice(userAgent)
+	}
+	if len(device) > maxDeviceNameLength {
+		device = device[:maxDeviceNameLength]
+	}
+	
+	return token.SessionClient{
+		Device:    device,
+		UserAgent: userAgent,
+		IPAddress: ctx.ClientIP(),
+	}
+}
+
+// describeDevice trả về mô tả ngắn gọn của thiết bị từ User-Agent, ví dụ: "Chrome on Windows"
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	
+	var os string
+	switch {
+	case strings.Contains(ua, "iphone"):
+		os = "iPhone"
+	case strings.Contains(ua, "ipad"):
+		os = "iPad"
+	case strings.Contains(ua, "android"):
+		os = "Android"
+	case strings.Contains(ua, "windows"):
+		os = "Windows"
+	case strings.Contains(ua, "mac os"), strings.Contains(ua, "macintosh"):
+		os = "macOS"
+	case strings.Contains(ua, "linux"):
+		os = "Linux"
+	}
+	
+	var client string
+	switch {
+	case strings.Contains(ua, "edg/"):
+		client = "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		client = "Opera"
+	case strings.Contains(ua, "coc_coc"):
+		client = "Cốc Cốc"
+	case strings.Contains(ua, "firefox"):
+		client = "Firefox"
+	case strings.Contains(ua, "chrome"), strings.Contains(ua, "crios"):
+		client = "Chrome"
+	case strings.Contains(ua, "safari"):
+		client = "Safari"
+	case strings.Contains(ua, "okhttp"), strings.Contains(ua, "dart"), strings.Contains(ua, "cfnetwork"):
+		client = "Mobile app"
+	case strings.Contains(ua, "postman"):
+		client = "Postman"
+	case strings.Contains(ua, "curl"):
+		client = "curl"
+	}
+	
+	switch {
+	case client != "" && os != "":
+		return client + " on " + os
+	case client != "":
+		return client
+	case os != "":
+		return os
+	default:
+		return "Unknown device"
+	}
+}
+
+// revokeSession thu hồi một phiên đăng nhập: refresh token family và mọi access token của phiên
+func (server *Server) revokeSession(ctx context.Context, userID, sessionID string) error {
+	if err := server.sessionStore.Revoke(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	
+	return server.refreshTokenStore.RevokeFamily(ctx, userID, sessionID)
+}
+
+// revokeAllSessions thu hồi tất cả phiên đăng nhập của người dùng (đăng xuất khỏi tất cả thiết bị)
+func (server *Server) revokeAllSessions(ctx context.Context, userID string) error {
+	if err := server.sessionStore.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	
+	return server.refreshTokenStore.RevokeAllForUser(ctx, userID)
+}
+
+type userSessionResponse struct {
+	token.Session
+	Current bool `json:"current"` // Phiên của request hiện tại
+}
+
+//	@Summary		List active sessions
+//	@Description	Lists the devices where the current user is logged in, most recently active first.
+//	@Tags			users
+//	@Produce		json
+//	@Security		accessToken
+//	@Success		200	{array}	userSessionResponse
+//	@Failure		500	"Internal server error"
+//	@Router			/users/me/sessions [get]
+func (server *Server) listUserSessions(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	currentSessionID := ctx.GetString(sessionIDKey)
+	
+	sessions, err := server.sessionStore.List(ctx, userID)
+	if err != nil {
+		log.Err(err).Msg("failed to list sessions")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	resp := make([]userSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, userSessionResponse{
+			Session: session,
+			Current: session.ID == currentSessionID,
+		})
+	}
+	
+	ctx.JSON(http.StatusOK, resp)
+}
+
+//	@Summary		Revoke a session
+//	@Description	Logs the current user out of one device. Access tokens and refresh tokens of the session stop working immediately.
+//	@Description	Revoking the current session logs out the current device.
+//	@Tags			users
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			sessionID	path	string	true	"Session ID"
+//	@Success		204			"Session revoked"
+//	@Failure		404			"Session not found"
+//	@Failure		500			"Internal server error"
+//	@Router			/users/me/sessions/{sessionID} [delete]
+func (server *Server) revokeUserSession(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	sessionID := ctx.Param("sessionID")
+	
+	err := server.revokeSession(ctx, userID, sessionID)
+	if err != nil {
+		if errors.Is(err, token.ErrSessionNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to revoke session")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.Status(http.StatusNoContent)
+}
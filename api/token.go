@@ -1,11 +1,48 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 	
 	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
 )
 
+// newTokenMaker tạo token maker theo thuật toán ký được cấu hình (TOKEN_SIGNING_METHOD)
+func newTokenMaker(config *util.Config) (token.Maker, error) {
+	if config.TokenSigningMethod == token.SigningMethodHS256 {
+		return token.NewJWTMaker(config.TokenSecretKey)
+	}
+	
+	keys, err := token.LoadSigningKeys(config.TokenSigningKeysDir)
+	if err != nil {
+		return nil, err
+	}
+	
+	return token.NewAsymmetricMaker(config.TokenSigningMethod, keys, config.TokenActiveKeyID)
+}
+
+// getJWKS công bố public key dùng để ký token tại /.well-known/jwks.json (nằm ngoài /v1)
+// để các service khác tự xác thực token theo "kid" trong header.
+// Key đã rotate vẫn nằm trong danh sách cho đến khi được xóa khỏi TOKEN_SIGNING_KEYS_DIR.
+// Danh sách rỗng nếu token được ký bằng secret key (HS256).
+func (server *Server) getJWKS(ctx *gin.Context) {
+	keySet := token.JSONWebKeySet{
+		Keys: []token.JSONWebKey{},
+	}
+	if provider, ok := server.tokenMaker.(token.JWKSProvider); ok {
+		keySet = provider.JWKS()
+	}
+	
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, keySet)
+}
+
 type verifyAccessTokenRequest struct {
 	AccessToken string `json:"access_token"`
 }
@@ -28,12 +65,17 @@ func (server *Server) verifyAccessToken(c *gin.Context) {
 		return
 	}
 	
-	claims, err := server.tokenMaker.VerifyToken(req.AccessToken)
+	claims, err := server.tokenMaker.VerifyToken(req.AccessToken, token.TokenTypeAccessToken)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 	
+	if _, err = server.sessionStore.Touch(c, claims, c.ClientIP()); err != nil {
+		c.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	
 	user, err := server.dbStore.GetUserByID(c, claims.Subject)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, errorResponse(err))
@@ -42,3 +84,208 @@ func (server *Server) verifyAccessToken(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, user)
 }
+
+// userTokens chứa cặp access token và refresh token được cấp cho người dùng
+type userTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+// createUserTokens tạo access token và refresh token mới cho người dùng.
+// Access token mang role và bộ quyền hiện tại của người dùng.
+// Refresh token được lưu vào Redis theo familyID (mỗi lần đăng nhập là một family).
+// mfa cho biết phiên đăng nhập đã qua xác thực hai lớp.
+func (server *Server) createUserTokens(ctx *gin.Context, user db.User, familyID string, mfa bool) (userTokens, error) {
+	claims := token.UserClaims{
+		UserID:       user.ID,
+		Role:         string(user.Role),
+		TokenVersion: user.TokenVersion,
+		MFA:          mfa,
+	}
+	
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(claims, token.TokenTypeAccessToken, server.config.AccessTokenDuration)
+	if err != nil {
+		return userTokens{}, fmt.Errorf("failed to create access token: %w", err)
+	}
+	
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(claims, token.TokenTypeRefreshToken, server.config.RefreshTokenDuration)
+	if err != nil {
+		return userTokens{}, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	
+	err = server.refreshTokenStore.Save(ctx, refreshPayload, familyID)
+	if err != nil {
+		return userTokens{}, err
+	}
+	
+	err = server.tokenVersionStore.Set(ctx, user.ID, user.TokenVersion)
+	if err != nil {
+		return userTokens{}, err
+	}
+	
+	// Mỗi family refresh token là một phiên đăng nhập, các token được cấp khi refresh thuộc cùng phiên
+	err = server.sessionStore.Track(ctx, familyID, sessionClient(ctx), accessPayload, refreshPayload)
+	if err != nil {
+		return userTokens{}, err
+	}
+	
+	return userTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessPayload.ExpiresAt.Time,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshPayload.ExpiresAt.Time,
+	}, nil
+}
+
+type refreshAccessTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type refreshAccessTokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+//	@Summary		Refresh access token
+//	@Description	Exchanges a refresh token for a new access token and a new refresh token (rotation).
+//	@Description	Each refresh token can only be used once. Reusing a refresh token revokes every token issued from the same login.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		refreshAccessTokenRequest	true	"Refresh token request"
+//	@Success		200		{object}	refreshAccessTokenResponse	"New token pair"
+//	@Failure		400		"Invalid request body"
+//	@Failure		401		"Invalid, expired, revoked or reused refresh token"
+//	@Failure		500		"Internal server error"
+//	@Router			/auth/refresh [post]
+func (server *Server) refreshAccessToken(ctx *gin.Context) {
+	req := new(refreshAccessTokenRequest)
+	
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	
+	familyID, err := server.refreshTokenStore.Rotate(ctx, refreshPayload)
+	if err != nil {
+		switch {
+		case errors.Is(err, token.ErrRefreshTokenReused):
+			log.Warn().Str("user_id", refreshPayload.Subject).Str("token_id", refreshPayload.ID).Msg("refresh token reuse detected, token family revoked")
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		case errors.Is(err, token.ErrRefreshTokenRevoked):
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		default:
+			log.Err(err).Msg("failed to rotate refresh token")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	
+	// Đọc lại người dùng để access token mới mang role và token version mới nhất
+	user, err := server.dbStore.GetUserByID(ctx, refreshPayload.Subject)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user %s not found", refreshPayload.Subject)
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	tokens, err := server.createUserTokens(ctx, user, familyID, refreshPayload.MFA)
+	if err != nil {
+		log.Err(err).Msg("failed to create user tokens")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, refreshAccessTokenResponse{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	})
+}
+
+type logoutUserRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+	AllDevices   bool   `json:"all_devices"` // Đăng xuất khỏi tất cả thiết bị
+}
+
+//	@Summary		Logout user
+//	@Description	Revokes the session of the current login, including its access tokens and refresh tokens.
+//	@Description	Set all_devices to true to revoke every session of the user.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	logoutUserRequest	true	"Logout request"
+//	@Success		204		"Successfully logged out"
+//	@Failure		400		"Invalid request body"
+//	@Failure		401		"Invalid or expired refresh token"
+//	@Failure		500		"Internal server error"
+//	@Router			/auth/logout [post]
+func (server *Server) logoutUser(ctx *gin.Context) {
+	req := new(logoutUserRequest)
+	
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.RefreshToken, token.TokenTypeRefreshToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	
+	if req.AllDevices {
+		err = server.revokeAllSessions(ctx, refreshPayload.Subject)
+		if err != nil {
+			log.Err(err).Msg("failed to revoke all sessions")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	
+	familyID, err := server.refreshTokenStore.FamilyID(ctx, refreshPayload)
+	if err != nil {
+		if errors.Is(err, token.ErrRefreshTokenRevoked) {
+			// Token đã bị thu hồi trước đó, coi như đã đăng xuất
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		
+		log.Err(err).Msg("failed to get refresh token family")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.revokeSession(ctx, refreshPayload.Subject, familyID)
+	if errors.Is(err, token.ErrSessionNotFound) {
+		// Phiên đã hết hạn trong session store nhưng refresh token family vẫn còn
+		err = server.refreshTokenStore.RevokeFamily(ctx, refreshPayload.Subject, familyID)
+	}
+	if err != nil {
+		log.Err(err).Msg("failed to revoke session")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,613 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/totp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	totpIssuer           = "Gundam Platform"
+	mfaChallengeDuration = 5 * time.Minute
+	
+	// Header chứa mã TOTP khi thực hiện các thao tác nhạy cảm (step-up authentication)
+	stepUpCodeHeaderKey = "X-TOTP-Code"
+)
+
+var (
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor authentication has not been set up, call /users/me/2fa/totp/setup first")
+	ErrTwoFactorCodeRequired   = errors.New("either code or recovery_code is required")
+	ErrTwoFactorRequired       = errors.New("two-factor authentication is required for this account, enroll at /users/me/2fa/totp/setup and log in again")
+	ErrTwoFactorMandatory      = errors.New("two-factor authentication cannot be disabled for moderator and admin accounts")
+	ErrStepUpRequired          = fmt.Errorf("this action requires a two-factor authentication code in the %s header", stepUpCodeHeaderKey)
+)
+
+// twoFactorThrottledError được trả về khi người dùng nhập sai mã quá nhiều lần
+type twoFactorThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *twoFactorThrottledError) Error() string {
+	return "too many two-factor authentication attempts, please try again later"
+}
+
+// isTwoFactorRequired cho biết role có bắt buộc phải bật xác thực hai lớp hay không
+func isTwoFactorRequired(role db.UserRole) bool {
+	return role == db.UserRoleModerator || role == db.UserRoleAdmin
+}
+
+type twoFactorChallengeResponse struct {
+	MFARequired       bool      `json:"mfa_required"`
+	MFAToken          string    `json:"mfa_token"` // Gửi kèm mã TOTP tới /auth/login/2fa
+	MFATokenExpiresAt time.Time `json:"mfa_token_expires_at"`
+}
+
+// completeLogin cấp token cho người dùng đã xác thực bước 1 (mật khẩu hoặc Google).
+// Nếu tài khoản đã bật xác thực hai lớp, trả về 202 kèm mfa_token để client hoàn tất đăng nhập tại /auth/login/2fa.
+func (server *Server) completeLogin(ctx *gin.Context, user db.User) {
+	enabled, err := server.isTwoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to get two-factor status")
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	
+	if enabled {
+		mfaToken, payload, err := server.tokenMaker.CreateToken(token.UserClaims{
+			UserID:       user.ID,
+			Role:         string(user.Role),
+			TokenVersion: user.TokenVersion,
+		}, token.TokenTypeMFAChallenge, mfaChallengeDuration)
+		if err != nil {
+			log.Err(err).Msg("failed to create mfa challenge token")
+			ctx.Status(http.StatusInternalServerError)
+			return
+		}
+		
+		ctx.JSON(http.StatusAccepted, twoFactorChallengeResponse{
+			MFARequired:       true,
+			MFAToken:          mfaToken,
+			MFATokenExpiresAt: payload.ExpiresAt.Time,
+		})
+		return
+	}
+	
+	tokens, err := server.createUserTokens(ctx, user, token.NewFamilyID(), false)
+	if err != nil {
+		log.Err(err).Msg("failed to create user tokens")
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, loginUserResponse{
+		AccessToken:                 tokens.AccessToken,
+		AccessTokenExpiresAt:        tokens.AccessTokenExpiresAt,
+		RefreshToken:                tokens.RefreshToken,
+		RefreshTokenExpiresAt:       tokens.RefreshTokenExpiresAt,
+		User:                        user,
+		TwoFactorEnrollmentRequired: isTwoFactorRequired(user.Role),
+	})
+}
+
+type loginWithTwoFactorRequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code"`          // Mã TOTP 6 chữ số từ ứng dụng xác thực
+	RecoveryCode string `json:"recovery_code"` // Dùng khi mất thiết bị xác thực, mỗi mã chỉ dùng được một lần
+}
+
+//	@Summary		Complete login with two-factor authentication
+//	@Description	Exchanges the mfa_token returned by /auth/login or /auth/google-login (status 202) and a TOTP code for an access token and a refresh token.
+//	@Description	A recovery code can be used instead of the TOTP code. Each recovery code can only be used once.
+//	@Tags			authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		loginWithTwoFactorRequest	true	"Second factor"
+//	@Success		200		{object}	loginUserResponse
+//	@Failure		400		"Invalid request body"
+//	@Failure		401		"Invalid or expired mfa_token, or invalid code"
+//	@Failure		429		"Too many attempts"
+//	@Failure		500		"Internal server error"
+//	@Router			/auth/login/2fa [post]
+func (server *Server) loginWithTwoFactor(ctx *gin.Context) {
+	req := new(loginWithTwoFactorRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	payload, err := server.tokenMaker.VerifyToken(req.MFAToken, token.TokenTypeMFAChallenge)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+	
+	user, err := server.dbStore.GetUserByID(ctx, payload.Subject)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user %s not found", payload.Subject)
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Mật khẩu hoặc quyền đã thay đổi sau khi mfa_token được cấp
+	if payload.TokenVersion < user.TokenVersion {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(token.ErrTokenVersionRevoked))
+		return
+	}
+	
+	err = server.verifySecondFactor(ctx, user.ID, req.Code, req.RecoveryCode)
+	if err != nil {
+		handleTwoFactorError(ctx, err)
+		return
+	}
+	
+	tokens, err := server.createUserTokens(ctx, user, token.NewFamilyID(), true)
+	if err != nil {
+		log.Err(err).Msg("failed to create user tokens")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, loginUserResponse{
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+		User:                  user,
+	})
+}
+
+type twoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	Required               bool       `json:"required"` // Bắt buộc với moderator và admin
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
+
+//	@Summary	Get two-factor authentication status
+//	@Tags		users
+//	@Produce	json
+//	@Security	accessToken
+//	@Success	200	{object}	twoFactorStatusResponse
+//	@Failure	500	"Internal server error"
+//	@Router		/users/me/2fa [get]
+func (server *Server) getTwoFactorStatus(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	
+	resp := twoFactorStatusResponse{
+		Required: isTwoFactorRequired(db.UserRole(authPayload.Role)),
+	}
+	
+	credential, err := server.dbStore.GetUserTOTPCredential(ctx, authPayload.Subject)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		log.Err(err).Msg("failed to get totp credential")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if err == nil && credential.EnabledAt != nil {
+		resp.Enabled = true
+		resp.EnabledAt = credential.EnabledAt
+		
+		resp.RecoveryCodesRemaining, err = server.dbStore.CountUnusedUserRecoveryCodes(ctx, authPayload.Subject)
+		if err != nil {
+			log.Err(err).Msg("failed to count recovery codes")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+	
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type setupTOTPResponse struct {
+	Secret          string `json:"secret"`           // Nhập thủ công nếu không quét được QR code
+	ProvisioningURI string `json:"provisioning_uri"` // URI otpauth:// để hiển thị dưới dạng QR code
+}
+
+//	@Summary		Start TOTP enrollment
+//	@Description	Generates a new TOTP secret and returns it with an otpauth:// provisioning URI to be rendered as a QR code.
+//	@Description	The secret is not active until it is confirmed with /users/me/2fa/totp/enable.
+//	@Description	Calling this again before confirming replaces the pending secret.
+//	@Tags			users
+//	@Produce		json
+//	@Security		accessToken
+//	@Success		200	{object}	setupTOTPResponse
+//	@Failure		409	"Two-factor authentication is already enabled"
+//	@Failure		500	"Internal server error"
+//	@Router			/users/me/2fa/totp/setup [post]
+func (server *Server) setupTOTP(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	user, err := server.dbStore.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user ID %s not found", userID)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Err(err).Msg("failed to generate totp secret")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	_, err = server.dbStore.UpsertUserTOTPSecret(ctx, db.UpsertUserTOTPSecretParams{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		// Không có bản ghi nào được ghi đè nghĩa là TOTP đã được bật
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrTwoFactorAlreadyEnabled))
+			return
+		}
+		
+		log.Err(err).Msg("failed to save totp secret")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, setupTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(totpIssuer, user.Email, secret),
+	})
+}
+
+type enableTOTPRequest struct {
+	Code string `json:"code" binding:"required,len=6"`
+}
+
+type enableTOTPResponse struct {
+	// Chỉ được hiển thị một lần, người dùng cần lưu lại ở nơi an toàn
+	RecoveryCodes []string `json:"recovery_codes"`
+	
+	// Token mới đã qua xác thực hai lớp, thay cho token hiện tại
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+//	@Summary		Confirm TOTP enrollment
+//	@Description	Activates two-factor authentication with the first code from the authenticator app.
+//	@Description	Returns one-time recovery codes, which are only shown once, and a new token pair marked as two-factor authenticated.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		enableTOTPRequest	true	"TOTP code"
+//	@Success		200		{object}	enableTOTPResponse
+//	@Failure		400		"Invalid request body or enrollment not started"
+//	@Failure		401		"Invalid code"
+//	@Failure		409		"Two-factor authentication is already enabled"
+//	@Failure		429		"Too many attempts"
+//	@Failure		500		"Internal server error"
+//	@Router			/users/me/2fa/totp/enable [post]
+func (server *Server) enableTOTP(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	req := new(enableTOTPRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	credential, err := server.dbStore.GetUserTOTPCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrTwoFactorNotSetUp))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get totp credential")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if credential.EnabledAt != nil {
+		ctx.JSON(http.StatusConflict, errorResponse(ErrTwoFactorAlreadyEnabled))
+		return
+	}
+	
+	if err = server.allowTwoFactorAttempt(ctx, userID); err != nil {
+		handleTwoFactorError(ctx, err)
+		return
+	}
+	
+	step, err := totp.Validate(credential.Secret, req.Code, time.Now())
+	if err != nil {
+		handleTwoFactorError(ctx, err)
+		return
+	}
+	
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		log.Err(err).Msg("failed to generate recovery codes")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	_, err = server.dbStore.EnableUserTOTPTx(ctx, db.EnableUserTOTPTxParams{
+		UserID:             userID,
+		Step:               step,
+		RecoveryCodeHashes: hashRecoveryCodes(recoveryCodes),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrTwoFactorAlreadyEnabled))
+			return
+		}
+		
+		log.Err(err).Msg("failed to enable totp")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	user, err := server.dbStore.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	tokens, err := server.createUserTokens(ctx, user, token.NewFamilyID(), true)
+	if err != nil {
+		log.Err(err).Msg("failed to create user tokens")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, enableTOTPResponse{
+		RecoveryCodes:         recoveryCodes,
+		AccessToken:           tokens.AccessToken,
+		AccessTokenExpiresAt:  tokens.AccessTokenExpiresAt,
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	})
+}
+
+type twoFactorCodeRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type regenerateRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+//	@Summary		Regenerate recovery codes
+//	@Description	Replaces all recovery codes with a new set. Previous recovery codes stop working immediately.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		twoFactorCodeRequest	true	"Current TOTP code or a recovery code"
+//	@Success		200		{object}	regenerateRecoveryCodesResponse
+//	@Failure		400		"Invalid request body or two-factor authentication not enabled"
+//	@Failure		401		"Invalid code"
+//	@Failure		429		"Too many attempts"
+//	@Failure		500		"Internal server error"
+//	@Router			/users/me/2fa/recovery-codes [post]
+func (server *Server) regenerateRecoveryCodes(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	req := new(twoFactorCodeRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	err := server.verifySecondFactor(ctx, userID, req.Code, req.RecoveryCode)
+	if err != nil {
+		handleTwoFactorError(ctx, err)
+		return
+	}
+	
+	recoveryCodes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		log.Err(err).Msg("failed to generate recovery codes")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.dbStore.ReplaceUserRecoveryCodesTx(ctx, db.ReplaceUserRecoveryCodesTxParams{
+		UserID:             userID,
+		RecoveryCodeHashes: hashRecoveryCodes(recoveryCodes),
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to replace recovery codes")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, regenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+//	@Summary		Disable two-factor authentication
+//	@Description	Disables TOTP and deletes all recovery codes. Not allowed for moderator and admin accounts.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body	twoFactorCodeRequest	true	"Current TOTP code or a recovery code"
+//	@Success		204		"Two-factor authentication disabled"
+//	@Failure		400		"Invalid request body or two-factor authentication not enabled"
+//	@Failure		401		"Invalid code"
+//	@Failure		403		"Two-factor authentication is mandatory for this role"
+//	@Failure		429		"Too many attempts"
+//	@Failure		500		"Internal server error"
+//	@Router			/users/me/2fa/totp [delete]
+func (server *Server) disableTOTP(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	
+	req := new(twoFactorCodeRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if isTwoFactorRequired(db.UserRole(authPayload.Role)) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrTwoFactorMandatory))
+		return
+	}
+	
+	err := server.verifySecondFactor(ctx, authPayload.Subject, req.Code, req.RecoveryCode)
+	if err != nil {
+		handleTwoFactorError(ctx, err)
+		return
+	}
+	
+	err = server.dbStore.DisableUserTOTPTx(ctx, authPayload.Subject)
+	if err != nil {
+		log.Err(err).Msg("failed to disable totp")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.Status(http.StatusNoContent)
+}
+
+// isTwoFactorEnabled cho biết người dùng đã bật xác thực hai lớp hay chưa
+func (server *Server) isTwoFactorEnabled(ctx context.Context, userID string) (bool, error) {
+	credential, err := server.dbStore.GetUserTOTPCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return false, nil
+		}
+		
+		return false, err
+	}
+	
+	return credential.EnabledAt != nil, nil
+}
+
+// verifySecondFactor xác thực mã TOTP hoặc mã khôi phục của người dùng.
+// Mỗi mã TOTP chỉ được chấp nhận một lần, mỗi mã khôi phục bị vô hiệu hóa sau khi dùng.
+func (server *Server) verifySecondFactor(ctx context.Context, userID, code, recoveryCode string) error {
+	if code == "" && recoveryCode == "" {
+		return ErrTwoFactorCodeRequired
+	}
+	
+	if err := server.allowTwoFactorAttempt(ctx, userID); err != nil {
+		return err
+	}
+	
+	credential, err := server.dbStore.GetUserTOTPCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ErrTwoFactorNotEnabled
+		}
+		
+		return err
+	}
+	
+	if credential.EnabledAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+	
+	if code == "" {
+		used, err := server.dbStore.UseUserRecoveryCode(ctx, db.UseUserRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: totp.HashRecoveryCode(recoveryCode),
+		})
+		if err != nil {
+			return err
+		}
+		
+		if used == 0 {
+			return totp.ErrInvalidCode
+		}
+		
+		log.Info().Str("user_id", userID).Msg("recovery code used for two-factor authentication")
+		return nil
+	}
+	
+	step, err := totp.Validate(credential.Secret, code, time.Now())
+	if err != nil {
+		return err
+	}
+	
+	// Mã đã được dùng trước đó (replay) nếu bước thời gian không lớn hơn bước đã dùng gần nhất
+	updated, err := server.dbStore.UpdateUserTOTPLastUsedStep(ctx, db.UpdateUserTOTPLastUsedStepParams{
+		Step:   step,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	
+	if updated == 0 {
+		return totp.ErrInvalidCode
+	}
+	
+	return nil
+}
+
+// allowTwoFactorAttempt giới hạn số lần nhập mã của mỗi người dùng để chống dò mã.
+// Nếu Redis gặp lỗi, lượt thử vẫn được cho qua giống rateLimitMiddleware.
+func (server *Server) allowTwoFactorAttempt(ctx context.Context, userID string) error {
+	result, err := server.rateLimiter.Allow(ctx, twoFactorRateLimit, "user:"+userID)
+	if err != nil {
+		log.Err(err).Str("policy", twoFactorRateLimit.Name).Msg("failed to check rate limit")
+		return nil
+	}
+	
+	if !result.Allowed {
+		return &twoFactorThrottledError{RetryAfter: result.RetryAfter}
+	}
+	
+	return nil
+}
+
+// handleTwoFactorError trả về response tương ứng với lỗi khi xác thực hai lớp
+func handleTwoFactorError(ctx *gin.Context, err error) {
+	var throttledErr *twoFactorThrottledError
+	switch {
+	case errors.As(err, &throttledErr):
+		retryAfter := max(ceilSeconds(throttledErr.RetryAfter), 1)
+		ctx.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+		ctx.AbortWithStatusJSON(http.StatusTooManyRequests, errorResponse(err))
+	case errors.Is(err, totp.ErrInvalidCode):
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+	case errors.Is(err, ErrTwoFactorNotEnabled), errors.Is(err, ErrTwoFactorCodeRequired):
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+	default:
+		log.Err(err).Msg("failed to verify two-factor authentication code")
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+	}
+}
+
+func hashRecoveryCodes(codes []string) []string {
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+	
+	return hashes
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	"github.com/katatrina/gundam-BE/internal/storage"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+// presignedUploadExpiry là thời hạn của presigned upload, client cần tải file lên ngay sau khi nhận URL
+const presignedUploadExpiry = 5 * time.Minute
+
+// uploadRefType là loại đối tượng sở hữu ảnh khi ghi dấu vân tay của ảnh tải lên qua presigned upload,
+// ref_id là ID của người tải lên vì ảnh chưa gắn với Gundam hay bài đăng nào.
+const uploadRefType = "upload"
+
+type presignUploadRequest struct {
+	ContentType string `json:"content_type" binding:"required,oneof=image/jpeg image/png image/gif"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+	SHA256      string `json:"sha256" binding:"required,len=64,hexadecimal,lowercase"`
+}
+
+//	@Summary		Create a presigned upload
+//	@Description	Returns a short-lived URL for uploading one image directly to the file store, without sending it through the API.
+//	@Description	The client sends the image with the returned method and headers; the body must have exactly the declared size and SHA-256.
+//	@Description	The file lands in a staging folder and is never served: call POST /users/me/uploads/finalize to check and store it.
+//	@Description	Returns 501 when the configured file store cannot enforce the size and content of the upload (Cloudinary).
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		presignUploadRequest	true	"Content type, size in bytes and lowercase hex SHA-256 of the image"
+//	@Success		200		{object}	storage.PresignedUpload	"Presigned upload"
+//	@Failure		400		"Bad Request - Invalid parameters or image is too large"
+//	@Failure		501		"Not Implemented - The file store does not support presigned uploads"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/users/me/uploads [post]
+func (server *Server) presignUpload(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req presignUploadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if req.Size > int64(storage.DefaultImageOptions.MaxBytes) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(storage.ErrImageTooLarge))
+		return
+	}
+	
+	upload, err := server.fileStore.PresignUpload(ctx, storage.NewStagingKey(userID), storage.UploadConstraints{
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		SHA256:      req.SHA256,
+	}, presignedUploadExpiry)
+	if err != nil {
+		if errors.Is(err, storage.ErrPresignNotSupported) {
+			ctx.JSON(http.StatusNotImplemented, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to presign upload")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, upload)
+}
+
+type finalizeUploadRequest struct {
+	Key    string `json:"key" binding:"required"`
+	Folder string `json:"folder" binding:"required,oneof=gundams exchanges orders avatars"`
+}
+
+type finalizeUploadResponse struct {
+	Key          string `json:"key"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+//	@Summary		Finalize a presigned upload
+//	@Description	Downloads an image uploaded through a presigned upload of the current user, checks and re-encodes it
+//	@Description	(file type, size, pixel limit, EXIF removal) and stores it in the folder under a name derived from its content.
+//	@Description	The staging file is deleted afterwards, also when the image is rejected.
+//	@Description	The returned URL is not attached to anything yet; images that are not referenced are removed by the image cleanup job.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body		finalizeUploadRequest	true	"Key of the presigned upload and destination folder"
+//	@Success		200		{object}	finalizeUploadResponse	"Stored image"
+//	@Failure		400		"Bad Request - Invalid parameters or invalid image"
+//	@Failure		403		"Forbidden - The key is not a presigned upload of the current user"
+//	@Failure		404		"Not Found - The file was not uploaded"
+//	@Failure		500		"Internal Server Error"
+//	@Router			/users/me/uploads/finalize [post]
+func (server *Server) finalizeUpload(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req finalizeUploadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	if !storage.IsStagingKeyOf(req.Key, userID) {
+		err := fmt.Errorf("key %s is not a presigned upload of user ID %s", req.Key, userID)
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+	
+	data, err := server.fileStore.DownloadFile(ctx, req.Key, int64(storage.DefaultImageOptions.MaxBytes))
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrFileNotFound):
+			err = fmt.Errorf("file %s has not been uploaded", req.Key)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, storage.ErrFileTooLarge):
+			server.deleteStagingFile(ctx, req.Key)
+			ctx.JSON(http.StatusBadRequest, errorResponse(storage.ErrImageTooLarge))
+		default:
+			log.Err(err).Str("key", req.Key).Msg("failed to download staging file")
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	
+	// Kiểm tra magic bytes và số điểm ảnh, xóa EXIF/GPS, mã hóa lại và đặt tên file theo nội dung ảnh
+	processed, err := storage.ProcessImage(data, storage.DefaultImageOptions)
+	if err != nil {
+		server.deleteStagingFile(ctx, req.Key)
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	uploadedFile, err := server.fileStore.UploadFile(ctx, processed.Data, processed.Filename(), req.Folder)
+	if err != nil {
+		log.Err(err).Msg("failed to upload file")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	thumbnail, err := server.fileStore.UploadFile(ctx, processed.Thumbnail, processed.ThumbnailFilename(), req.Folder)
+	if err != nil {
+		log.Err(err).Msg("failed to upload thumbnail")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	server.recordImageFingerprint(ctx, uploadRefType, userID, req.Folder, uploadedFile.URL, thumbnail.URL, processed)
+	server.deleteStagingFile(ctx, req.Key)
+	
+	ctx.JSON(http.StatusOK, finalizeUploadResponse{
+		Key:          uploadedFile.Key,
+		URL:          uploadedFile.URL,
+		ThumbnailURL: thumbnail.URL,
+	})
+}
+
+// deleteStagingFile xóa file tạm của presigned upload, lỗi chỉ được ghi log vì job dọn ảnh sẽ xóa file tạm còn sót lại.
+func (server *Server) deleteStagingFile(ctx *gin.Context, key string) {
+	if err := server.fileStore.DeleteFile(ctx, key); err != nil {
+		log.Err(err).Str("key", key).Msg("failed to delete staging file")
+	}
+}
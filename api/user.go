@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/validator"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/api/idtoken"
@@ -117,22 +118,31 @@ type loginUserRequest struct {
 }
 
 type loginUserResponse struct {
-	User                 db.User   `json:"user"`
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	User                  db.User   `json:"user"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	
+	// Moderator và admin chưa bật xác thực hai lớp phải bật trước khi dùng các API quản trị
+	TwoFactorEnrollmentRequired bool `json:"two_factor_enrollment_required,omitempty"`
 }
 
 //	@Summary		Login user
-//	@Description	Authenticate a user and return access token
+//	@Description	Authenticate a user and return a short-lived access token and a refresh token.
+//	@Description	If the account has two-factor authentication enabled, status 202 is returned with an mfa_token instead,
+//	@Description	which must be exchanged at /auth/login/2fa together with a TOTP code.
 //	@Tags			authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		loginUserRequest	true	"Login credentials"
 //	@Success		200		{object}	loginUserResponse
+//	@Success		202		{object}	twoFactorChallengeResponse	"Two-factor authentication required"
 //	@Failure		400		"Invalid request parameters"
 //	@Failure		401		"Incorrect password"
 //	@Failure		404		"Email not found"
 //	@Failure		500		"Internal server error"
+//	@Failure		429		"Too many requests"
 //	@Router			/auth/login [post]
 func (server *Server) loginUser(ctx *gin.Context) {
 	req := new(loginUserRequest)
@@ -155,27 +165,21 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 	
-	err = util.CheckPassword(req.Password, *user.HashedPassword)
-	if err != nil {
-		err = errors.New("incorrect password")
+	// Tài khoản đăng ký bằng Google chưa có mật khẩu
+	if user.HashedPassword == nil {
+		err = errors.New("this account does not have a password, please login with Google or reset the password")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 	
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.ID, server.config.AccessTokenDuration)
+	err = util.CheckPassword(req.Password, *user.HashedPassword)
 	if err != nil {
-		log.Err(err).Msg("failed to create access token")
-		ctx.Status(http.StatusInternalServerError)
+		err = errors.New("incorrect password")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
 	
-	resp := loginUserResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiresAt.Time,
-		User:                 user,
-	}
-	
-	ctx.JSON(http.StatusOK, resp)
+	server.completeLogin(ctx, user)
 }
 
 type loginUserWithGoogleRequest struct {
@@ -189,9 +193,11 @@ type loginUserWithGoogleRequest struct {
 //	@Produce		json
 //	@Param			request	body		loginUserWithGoogleRequest	true	"Google ID Token"
 //	@Success		200		{object}	loginUserResponse			"Successfully logged in"
+//	@Success		202		{object}	twoFactorChallengeResponse	"Two-factor authentication required"
 //	@Failure		400		"Invalid request body"
 //	@Failure		401		"Invalid Google ID token"
 //	@Failure		500		"Internal server error"
+//	@Failure		429		"Too many requests"
 //	@Router			/auth/google-login [post]
 func (server *Server) loginUserWithGoogle(ctx *gin.Context) {
 	req := new(loginUserWithGoogleRequest)
@@ -217,19 +223,7 @@ func (server *Server) loginUserWithGoogle(ctx *gin.Context) {
 		return
 	}
 	
-	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.ID, server.config.AccessTokenDuration)
-	if err != nil {
-		log.Err(err).Msg("failed to create access token")
-		ctx.Status(http.StatusInternalServerError)
-		return
-	}
-	
-	resp := loginUserResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessPayload.ExpiresAt.Time,
-		User:                 *user,
-	}
-	ctx.JSON(http.StatusOK, resp)
+	server.completeLogin(ctx, *user)
 }
 
 func (server *Server) getOrCreateGoogleUser(ctx *gin.Context, payload *idtoken.Payload) (*db.User, error) {
@@ -393,10 +387,15 @@ func (server *Server) updateAvatar(ctx *gin.Context) {
 		return
 	}
 	
-	fileName := fmt.Sprintf("user_%s_%d", userID, time.Now().Unix())
+	// Kiểm tra ảnh, xóa EXIF/GPS và đặt tên file theo nội dung ảnh
+	avatar, err := storage.ProcessImage(fileBytes, storage.DefaultImageOptions)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 	
-	// Upload new avatar to cloudinary
-	uploadedFileURL, err := server.fileStore.UploadFile(fileBytes, fileName, util.FolderAvatars)
+	// Upload new avatar to file store
+	uploadedFile, err := server.fileStore.UploadFile(ctx, avatar.Data, avatar.Filename(), util.FolderAvatars)
 	if err != nil {
 		log.Err(err).Msg("failed to upload file")
 		ctx.Status(http.StatusInternalServerError)
@@ -404,17 +403,13 @@ func (server *Server) updateAvatar(ctx *gin.Context) {
 	}
 	
 	arg := db.UpdateUserParams{
-		AvatarURL: &uploadedFileURL,
+		AvatarURL: &uploadedFile.URL,
 		UserID:    userID,
 	}
 	
 	user, err = server.dbStore.UpdateUser(ctx, arg)
 	if err != nil {
-		// Delete newly uploaded avatar if update fails
-		if deleteErr := server.fileStore.DeleteFile(fileName, util.FolderAvatars); deleteErr != nil {
-			log.Err(deleteErr).Msg("failed to delete new avatar after update failure")
-		}
-		
+		// Không xóa ảnh vừa tải lên vì ảnh được lưu theo nội dung, có thể người dùng khác đang dùng cùng ảnh
 		log.Err(err).Msg("failed to update user avatar")
 		ctx.Status(http.StatusInternalServerError)
 		return
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+type deleteUserAccountRequest struct {
+	CurrentPassword *string `json:"current_password"` // Bỏ trống nếu tài khoản chưa có mật khẩu (đăng ký bằng Google)
+}
+
+type deleteUserAccountBlockedResponse struct {
+	Error    string                        `json:"error"`
+	Blockers db.GetUserDeletionBlockersRow `json:"blockers"`
+}
+
+//	@Summary		Delete the current user's account
+//	@Description	Permanently deletes the account of the current user.
+//	@Description	Personal data (name, email, phone number, avatar, addresses, bank accounts) is anonymized or removed,
+//	@Description	published gundams are unpublished and every session is revoked. Orders and wallet entries are kept for accounting.
+//	@Description	The request is refused while the user has open orders, exchanges, exchange posts or offers, ongoing auctions,
+//	@Description	pending withdrawal requests or a non-zero wallet balance. Use GET /users/me/export first to download a copy of the data.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body	deleteUserAccountRequest	true	"Delete account request"
+//	@Success		200		"Account deleted successfully"
+//	@Failure		400		"Invalid request body"
+//	@Failure		401		"Incorrect current password"
+//	@Failure		404		"User not found"
+//	@Failure		409		{object}	deleteUserAccountBlockedResponse	"Account has unfinished activities"
+//	@Failure		500		"Internal server error"
+//	@Router			/users/me [delete]
+func (server *Server) deleteUserAccount(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	req := new(deleteUserAccountRequest)
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	user, err := server.dbStore.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user ID %s not found", userID)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Tài khoản có mật khẩu phải xác nhận lại mật khẩu trước khi xóa
+	if user.HashedPassword != nil {
+		if req.CurrentPassword == nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("current_password is required")))
+			return
+		}
+		
+		if err = util.CheckPassword(*req.CurrentPassword, *user.HashedPassword); err != nil {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("incorrect current password")))
+			return
+		}
+	}
+	
+	deletedUser, err := server.dbStore.DeleteUserTx(ctx, user.ID)
+	if err != nil {
+		var blockedErr *db.UserDeletionBlockedError
+		if errors.As(err, &blockedErr) {
+			ctx.JSON(http.StatusConflict, deleteUserAccountBlockedResponse{
+				Error:    blockedErr.Error(),
+				Blockers: blockedErr.Blockers,
+			})
+			return
+		}
+		
+		log.Err(err).Msg("failed to delete user account")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	// Thu hồi tất cả phiên đăng nhập: access token (qua token version) và refresh token
+	err = server.tokenVersionStore.Set(ctx, deletedUser.ID, deletedUser.TokenVersion)
+	if err != nil {
+		log.Err(err).Msg("failed to set token version")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	err = server.revokeAllSessions(ctx, deletedUser.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to revoke sessions")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	ctx.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
+
+// userDataExport là bản sao dữ liệu của người dùng để tải về
+type userDataExport struct {
+	ExportedAt    time.Time                   `json:"exported_at"`
+	User          db.User                     `json:"user"`
+	Addresses     []db.UserAddress            `json:"addresses"`
+	BankAccounts  []db.UserBankAccount        `json:"bank_accounts"`
+	Orders        []db.Order                  `json:"orders"`       // Đơn mua và đơn trao đổi
+	SalesOrders   []db.Order                  `json:"sales_orders"` // Đơn bán (chỉ có với seller)
+	Wallet        db.Wallet                   `json:"wallet"`
+	WalletEntries []db.WalletEntry            `json:"wallet_entries"`
+	Gundams       []db.ListGundamsByUserIDRow `json:"gundams"`
+}
+
+//	@Summary		Export the current user's data
+//	@Description	Returns a JSON archive with the profile, addresses, bank accounts, orders, wallet entries and gundams of the current user.
+//	@Description	The response is sent as a file download.
+//	@Tags			users
+//	@Produce		json
+//	@Security		accessToken
+//	@Success		200	{object}	userDataExport	"User data archive"
+//	@Failure		404	"User not found"
+//	@Failure		500	"Internal server error"
+//	@Router			/users/me/export [get]
+func (server *Server) exportUserData(ctx *gin.Context) {
+	userID := ctx.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	user, err := server.dbStore.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("user ID %s not found", userID)
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Err(err).Msg("failed to get user")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export := userDataExport{
+		ExportedAt: time.Now(),
+		User:       user,
+	}
+	
+	export.Addresses, err = server.dbStore.ListUserAddresses(ctx, user.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to list user addresses")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.BankAccounts, err = server.dbStore.ListUserBankAccounts(ctx, user.ID)
+	if err != nil {
+		log.Err(err).Msg("failed to list user bank accounts")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.Orders, err = server.dbStore.ListMemberOrders(ctx, db.ListMemberOrdersParams{
+		BuyerID: user.ID,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to list member orders")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.SalesOrders, err = server.dbStore.ListSalesOrders(ctx, db.ListSalesOrdersParams{
+		SellerID: user.ID,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to list sales orders")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.Wallet, err = server.dbStore.GetWalletByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		log.Err(err).Msg("failed to get wallet")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.WalletEntries, err = server.dbStore.ListUserWalletEntries(ctx, db.ListUserWalletEntriesParams{
+		WalletID: user.ID,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to list wallet entries")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	export.Gundams, err = server.dbStore.ListGundamsByUserID(ctx, db.ListGundamsByUserIDParams{
+		OwnerID: user.ID,
+	})
+	if err != nil {
+		log.Err(err).Msg("failed to list gundams")
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	filename := fmt.Sprintf("gundam-data-%s-%s.json", user.ID, export.ExportedAt.Format("20060102"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.JSON(http.StatusOK, export)
+}
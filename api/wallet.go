@@ -44,14 +44,19 @@ func (server *Server) getUserWallet(c *gin.Context) {
 	c.JSON(http.StatusOK, wallet)
 }
 
+var walletEntrySorts = []string{sortNewest, sortOldest}
+
 //	@Summary		List user wallet entries
 //	@Description	List user wallet entries
 //	@Tags			wallet
 //	@Accept			json
 //	@Produce		json
 //	@Security		accessToken
-//	@Param			status	query	string			false	"Filter by wallet entry status"
-//	@Success		200		{array}	db.WalletEntry	"List of wallet entries"
+//	@Param			status	query		string							false	"Filter by wallet entry status"
+//	@Param			sort	query		string							false	"Sort order (default: newest)"	Enums(newest, oldest)
+//	@Param			cursor	query		string							false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer							false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.WalletEntry]	"List of wallet entries"
 //	@Router			/users/me/wallet/entries [get]
 func (server *Server) listUserWalletEntries(c *gin.Context) {
 	// Lấy thông tin người dùng từ token
@@ -66,12 +71,34 @@ func (server *Server) listUserWalletEntries(c *gin.Context) {
 		}
 	}
 	
-	entries, err := server.dbStore.ListUserWalletEntries(c, db.ListUserWalletEntriesParams{
+	page, err := parsePageQuery(c, walletEntrySorts, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorTime, err := page.CursorTime()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListUserWalletEntries(c, db.ListUserWalletEntriesParams{
 		WalletID: userID,
 		Status: db.NullWalletEntryStatus{
 			WalletEntryStatus: db.WalletEntryStatus(status),
 			Valid:             status != "",
 		},
+		CursorID:    cursorID,
+		Sort:        page.Sort,
+		CursorValue: cursorTime,
+		Limit:       page.FetchLimit(),
 	})
 	if err != nil {
 		err = fmt.Errorf("failed to list wallet entries for user ID %s: %w", userID, err)
@@ -79,5 +106,9 @@ func (server *Server) listUserWalletEntries(c *gin.Context) {
 		return
 	}
 	
-	c.JSON(http.StatusOK, entries)
+	entries, info := paginate(rows, page, func(entry db.WalletEntry) (string, string) {
+		return timeCursorValue(entry.CreatedAt), int64CursorValue(entry.ID)
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(entries, info))
 }
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	
+	"github.com/gin-gonic/gin"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/token"
+	"github.com/rs/zerolog/log"
+)
+
+// wishlistSorts là các kiểu sắp xếp của danh sách yêu thích, chỉ hỗ trợ mới thêm trước.
+var wishlistSorts = []string{sortNewest}
+
+type addWishlistItemRequest struct {
+	GundamID int64 `json:"gundam_id" binding:"required"`
+}
+
+//	@Summary		Add a Gundam to wishlist
+//	@Description	Add a Gundam to the wishlist of the authenticated user. The Gundam does not need to be published:
+//	@Description	the user is notified when it is published for sale or scheduled for auction. Adding the same Gundam twice is a no-op.
+//	@Tags			wishlist
+//	@Accept			json
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			request	body	addWishlistItemRequest	true	"Gundam to add"
+//	@Success		204		"Gundam added to wishlist"
+//	@Failure		400		"Bad Request - Invalid input data or the Gundam belongs to the user"
+//	@Failure		404		"Not Found - Gundam does not exist"
+//	@Failure		500		"Internal Server Error - Failed to add Gundam to wishlist"
+//	@Router			/users/me/wishlist [post]
+func (server *Server) addWishlistItem(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	var req addWishlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	gundam, err := server.dbStore.GetGundamByID(c.Request.Context(), req.GundamID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			err = fmt.Errorf("gundam ID %d not found", req.GundamID)
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		
+		log.Error().Err(err).Msg("failed to get gundam")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if gundam.OwnerID == userID {
+		err = fmt.Errorf("gundam ID %d belongs to user ID %s", gundam.ID, userID)
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	err = server.dbStore.AddWishlistItem(c.Request.Context(), db.AddWishlistItemParams{
+		UserID:   userID,
+		GundamID: gundam.ID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to add wishlist item")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	c.Status(http.StatusNoContent)
+}
+
+//	@Summary		List wishlist
+//	@Description	List the Gundams in the wishlist of the authenticated user, most recently added first.
+//	@Tags			wishlist
+//	@Produce		json
+//	@Security		accessToken
+//	@Param			cursor	query		string									false	"Opaque cursor from next_cursor of the previous page"
+//	@Param			limit	query		integer									false	"Page size (default: 20, max: 100)"
+//	@Success		200		{object}	pageResponse[db.ListWishlistItemsRow]	"Wishlist items"
+//	@Failure		400		"Bad Request - Invalid query parameters"
+//	@Failure		500		"Internal Server Error - Failed to list wishlist"
+//	@Router			/users/me/wishlist [get]
+func (server *Server) listWishlistItems(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	page, err := parsePageQuery(c, wishlistSorts, sortNewest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	cursorID, err := page.CursorInt64()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	rows, err := server.dbStore.ListWishlistItems(c.Request.Context(), db.ListWishlistItemsParams{
+		UserID:      userID,
+		CursorID:    cursorID,
+		CursorValue: page.CursorValue(),
+		Limit:       page.FetchLimit(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list wishlist items")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	items, info := paginate(rows, page, func(row db.ListWishlistItemsRow) (string, string) {
+		return timeCursorValue(row.CreatedAt), int64CursorValue(row.GundamID)
+	})
+	
+	c.JSON(http.StatusOK, newPageResponse(items, info))
+}
+
+//	@Summary		Remove a Gundam from wishlist
+//	@Description	Remove a Gundam from the wishlist of the authenticated user.
+//	@Tags			wishlist
+//	@Security		accessToken
+//	@Param			gundamID	path	integer	true	"Gundam ID"
+//	@Success		204			"Gundam removed from wishlist"
+//	@Failure		400			"Bad Request - Invalid Gundam ID"
+//	@Failure		404			"Not Found - Gundam is not in the wishlist"
+//	@Failure		500			"Internal Server Error - Failed to remove Gundam from wishlist"
+//	@Router			/users/me/wishlist/{gundamID} [delete]
+func (server *Server) deleteWishlistItem(c *gin.Context) {
+	userID := c.MustGet(authorizationPayloadKey).(*token.Payload).Subject
+	
+	gundamID, err := strconv.ParseInt(c.Param("gundamID"), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid gundam ID %s", c.Param("gundamID"))
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	
+	deleted, err := server.dbStore.DeleteWishlistItem(c.Request.Context(), db.DeleteWishlistItemParams{
+		UserID:   userID,
+		GundamID: gundamID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete wishlist item")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	
+	if deleted == 0 {
+		err = fmt.Errorf("gundam ID %d is not in the wishlist", gundamID)
+		c.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	
+	c.Status(http.StatusNoContent)
+}
@@ -40,9 +40,140 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/image-cleanup-runs": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List scheduled and admin-requested image cleanup runs, newest first, without the orphaned image lists.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List orphaned image cleanup runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of cleanup runs",
+                        "schema": {
+                            "$ref": "#/definitions/api.pageResponse-db_ListImageCleanupRunsRow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Reconcile stored images with the image URLs referenced in the database and delete images that are no longer used.\nOnly images uploaded more than the grace period (72 hours) ago are considered orphaned.\nA dry run only records the orphaned images in the report without deleting them.\nThe cleanup runs in the background, poll GET /admin/image-cleanup-runs/{runID} for the report.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Start an orphaned image cleanup",
+                "parameters": [
+                    {
+                        "description": "Cleanup options",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createImageCleanupRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Queued cleanup run",
+                        "schema": {
+                            "$ref": "#/definitions/db.ImageCleanupRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/admin/image-cleanup-runs/{runID}": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get a cleanup run with its counts and the list of orphaned images (at most 1000 entries).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get an orphaned image cleanup report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cleanup run ID",
+                        "name": "runID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cleanup report",
+                        "schema": {
+                            "$ref": "#/definitions/db.ImageCleanupRun"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "404": {
+                        "description": "Not Found - Cleanup run does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/auctions": {
             "get": {
-                "description": "Retrieves upcoming and ongoing auctions from the platform.",
+                "description": "Retrieves upcoming and ongoing auctions from the platform with cursor pagination.",
                 "produces": [
                     "application/json"
                 ],
@@ -60,17 +191,41 @@ const docTemplate = `{
                         "description": "Filter by status",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest",
+                            "ending_soon",
+                            "starting_soon"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: ending_soon for active, starting_soon for scheduled, newest otherwise)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "List of auctions",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionDetails"
-                            }
+                            "$ref": "#/definitions/api.pageResponse-db_AuctionDetails"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid query parameters"
                     }
                 }
             }
@@ -170,12 +325,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.loginUserResponse"
                         }
                     },
+                    "202": {
+                        "description": "Two-factor authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/api.twoFactorChallengeResponse"
+                        }
+                    },
                     "400": {
                         "description": "Invalid request body"
                     },
                     "401": {
                         "description": "Invalid Google ID token"
                     },
+                    "429": {
+                        "description": "Too many requests"
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
@@ -184,7 +348,7 @@ const docTemplate = `{
         },
         "/auth/login": {
             "post": {
-                "description": "Authenticate a user and return access token",
+                "description": "Authenticate a user and return a short-lived access token and a refresh token.\nIf the account has two-factor authentication enabled, status 202 is returned with an mfa_token instead,\nwhich must be exchanged at /auth/login/2fa together with a TOTP code.",
                 "consumes": [
                     "application/json"
                 ],
@@ -213,6 +377,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.loginUserResponse"
                         }
                     },
+                    "202": {
+                        "description": "Two-factor authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/api.twoFactorChallengeResponse"
+                        }
+                    },
                     "400": {
                         "description": "Invalid request parameters"
                     },
@@ -222,46 +392,64 @@ const docTemplate = `{
                     "404": {
                         "description": "Email not found"
                     },
+                    "429": {
+                        "description": "Too many requests"
+                    },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
         },
-        "/cart/items": {
-            "get": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Exchanges the mfa_token returned by /auth/login or /auth/google-login (status 202) and a TOTP code for an access token and a refresh token.\nA recovery code can be used instead of the TOTP code. Each recovery code can only be used once.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves all items in the user's shopping cart with detailed information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cart"
+                    "authentication"
+                ],
+                "summary": "Complete login with two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "Second factor",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.loginWithTwoFactorRequest"
+                        }
+                    }
                 ],
-                "summary": "List Cart Items",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved cart items",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.ListCartItemsWithDetailsRow"
-                            }
+                            "$ref": "#/definitions/api.loginUserResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "401": {
+                        "description": "Invalid or expired mfa_token, or invalid code"
+                    },
+                    "429": {
+                        "description": "Too many attempts"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/auth/logout": {
             "post": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Adds a Gundam model to the user's shopping cart",
+                "description": "Revokes the session of the current login, including its access tokens and refresh tokens.\nSet all_devices to true to revoke every session of the user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -269,62 +457,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cart"
+                    "authentication"
                 ],
-                "summary": "Add Item to Cart",
+                "summary": "Logout user",
                 "parameters": [
                     {
-                        "description": "Gundam to add to cart",
+                        "description": "Logout request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.addCartItemRequest"
+                            "$ref": "#/definitions/api.logoutUserRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully added item to cart",
-                        "schema": {
-                            "$ref": "#/definitions/db.AddCartItemRow"
-                        }
+                    "204": {
+                        "description": "Successfully logged out"
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/cart/items/{id}": {
-            "delete": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Sends a single-use password reset code to the email address or phone number of the account.\nProvide exactly one of email or phone_number. The response is the same whether or not an account exists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Removes a specific item from the user's shopping cart",
                 "tags": [
-                    "cart"
+                    "authentication"
                 ],
-                "summary": "Delete Cart Item",
+                "summary": "Request a password reset code",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "1",
-                        "description": "Cart Item ID to delete",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Forgot password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.forgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted cart item"
+                    "200": {
+                        "description": "Password reset code sent if the account exists"
+                    },
+                    "400": {
+                        "description": "Invalid request body"
                     }
                 }
             }
         },
-        "/check-email": {
+        "/auth/password/reset": {
             "post": {
-                "description": "Checks if an email already exists in the database",
+                "description": "Sets a new password using the code sent by /auth/password/forgot.\nAccounts created with Google can use this to set a password for the first time.\nAll existing sessions of the user are revoked, so every device has to log in again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -334,34 +533,283 @@ const docTemplate = `{
                 "tags": [
                     "authentication"
                 ],
-                "summary": "Check Email Exists",
+                "summary": "Reset password",
                 "parameters": [
                     {
-                        "description": "Check email request",
+                        "description": "Reset password request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.checkEmailRequest"
+                            "$ref": "#/definitions/api.resetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Password reset successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request body or invalid reset code",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "boolean"
-                            }
+                            "$ref": "#/definitions/api.otpErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error"
+                    },
+                    "429": {
+                        "description": "Too many failed attempts",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token for a new access token and a new refresh token (rotation).\nEach refresh token can only be used once. Reusing a refresh token revokes every token issued from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.refreshAccessTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New token pair",
+                        "schema": {
+                            "$ref": "#/definitions/api.refreshAccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "401": {
+                        "description": "Invalid, expired, revoked or reused refresh token"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/cart/items": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Retrieves all items in the user's shopping cart with detailed information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "List Cart Items",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved cart items",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListCartItemsWithDetailsRow"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Adds a Gundam model to the user's shopping cart. If the Gundam is already in the cart, its quantity is increased.\nThe total quantity in the cart cannot exceed the units of the Gundam still available for purchase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Add Item to Cart",
+                "parameters": [
+                    {
+                        "description": "Gundam to add to cart",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.addCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully added item to cart",
+                        "schema": {
+                            "$ref": "#/definitions/db.AddCartItemRow"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Not enough units available"
+                    }
+                }
+            }
+        },
+        "/cart/items/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Removes a specific item from the user's shopping cart",
+                "tags": [
+                    "cart"
+                ],
+                "summary": "Delete Cart Item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1",
+                        "description": "Cart Item ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted cart item"
+                    }
+                }
+            }
+        },
+        "/check-email": {
+            "post": {
+                "description": "Checks if an email already exists in the database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Check Email Exists",
+                "parameters": [
+                    {
+                        "description": "Check email request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.checkEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests"
+                    }
+                }
+            }
+        },
+        "/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Create one order per seller for the items in the cart in a single transaction: either all orders are created or none.\nPrices are taken from the current Gundam prices and delivery fees and expected delivery times are quoted by the delivery provider, the client does not send any amount.\nThe request is idempotent per checkout_id: retrying with the same checkout_id returns the orders that were already created with status 200.\nChecked out items are removed from the cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Check out the cart",
+                "parameters": [
+                    {
+                        "description": "Checkout details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.checkoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checkout was already processed, the created orders are returned",
+                        "schema": {
+                            "$ref": "#/definitions/db.CheckoutTxResult"
+                        }
+                    },
+                    "201": {
+                        "description": "Orders created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.CheckoutTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "409": {
+                        "description": "Conflict - Checkout ID is used by another buyer"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Empty cart, unavailable Gundam, not enough units or insufficient balance"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
         "/exchange-posts": {
             "get": {
-                "description": "List all open exchange posts.",
+                "description": "List open exchange posts with cursor pagination.",
                 "produces": [
                     "application/json"
                 ],
@@ -369,15 +817,39 @@ const docTemplate = `{
                     "exchanges"
                 ],
                 "summary": "List all open exchange posts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: newest)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "List of open exchange posts",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.OpenExchangePostInfo"
-                            }
+                            "$ref": "#/definitions/api.pageResponse-db_OpenExchangePostInfo"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid pagination parameters"
                     }
                 }
             }
@@ -506,7 +978,7 @@ const docTemplate = `{
                         "accessToken": []
                     }
                 ],
-                "description": "Provides shipping addresses (from and to) for an exchange transaction. Both participants must provide their addresses before proceeding.",
+                "description": "Provides shipping addresses (from and to) for an exchange transaction. Both participants must provide their addresses before proceeding.\nWhen delivery_fee or expected_delivery_time is provided, the partner's sender address must already be known and\nthe values must match the delivery provider's quote for the user's receiver address, otherwise the request is rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,24 +1017,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/exchanges/{exchangeID}/pay-delivery-fee": {
-            "post": {
+        "/exchanges/{exchangeID}/delivery-quote": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Pays the delivery fee for an exchange transaction. When both parties have paid, the system creates two orders.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the delivery fee and the expected delivery time quoted by the delivery provider for the delivery the user pays for:\nthe partner's Gundams sent from the partner's sender address to the user's receiver address.\nBoth parties must have provided their delivery addresses.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "exchanges"
                 ],
-                "summary": "Pay delivery fee for exchange",
+                "summary": "Quote the delivery fee of an exchange",
                 "parameters": [
                     {
                         "type": "string",
@@ -570,26 +1039,78 @@ const docTemplate = `{
                         "name": "exchangeID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Delivery fee information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.payExchangeDeliveryFeeRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Delivery quote",
                         "schema": {
-                            "$ref": "#/definitions/db.PayExchangeDeliveryFeeTxResult"
+                            "$ref": "#/definitions/api.deliveryQuote"
                         }
-                    }
-                }
-            }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid exchange ID"
+                    },
+                    "403": {
+                        "description": "Forbidden - User is not a participant of the exchange"
+                    },
+                    "404": {
+                        "description": "Not Found - Exchange does not exist"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Delivery addresses have not been provided"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchangeID}/pay-delivery-fee": {
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Pays the delivery fee for an exchange transaction. When both parties have paid, the system creates two orders.\nThe payment is rejected when delivery_fee does not match the fee quoted by the delivery provider.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "Pay delivery fee for exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange ID",
+                        "name": "exchangeID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery fee information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.payExchangeDeliveryFeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.PayExchangeDeliveryFeeTxResult"
+                        }
+                    }
+                }
+            }
         },
         "/grades": {
             "get": {
@@ -619,7 +1140,7 @@ const docTemplate = `{
         },
         "/gundams": {
             "get": {
-                "description": "Retrieves a list of selling Gundams, optionally filtered by grade",
+                "description": "Full-text search over the Gundam catalog (name, series, version, description) with filters, sorting, pagination and facet counts.\nSearch is accent-insensitive and matches word prefixes, e.g. \"rx 78\" matches \"RX-78-2 Gundam\".",
                 "produces": [
                     "application/json"
                 ],
@@ -630,8 +1151,14 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "YR-04 Fire Lord",
-                        "description": "Filter by Gundam name",
+                        "example": "rx 78",
+                        "description": "Search keywords",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated: use q",
                         "name": "name",
                         "in": "query"
                     },
@@ -642,6 +1169,36 @@ const docTemplate = `{
                         "name": "grade",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "1/144",
+                            "1/100",
+                            "1/60",
+                            "1/48"
+                        ],
+                        "type": "string",
+                        "description": "Filter by scale",
+                        "name": "scale",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "open box",
+                            "used"
+                        ],
+                        "type": "string",
+                        "description": "Filter by condition",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Bandai",
+                        "description": "Filter by manufacturer (case-insensitive)",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "in store",
@@ -654,16 +1211,62 @@ const docTemplate = `{
                         "description": "Filter by Gundam status",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price (inclusive)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price (exclusive)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by release year",
+                        "name": "release_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only listings linked to this model kit",
+                        "name": "model_kit_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "newest",
+                            "oldest",
+                            "price_asc",
+                            "price_desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: relevance when q is set, newest otherwise)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successfully retrieved list of Gundams",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.GundamDetails"
-                            }
+                            "$ref": "#/definitions/api.listGundamsResponse"
                         }
                     },
                     "400": {
@@ -753,6 +1356,108 @@ const docTemplate = `{
                 }
             }
         },
+        "/gundams/{gundamID}/market-price": {
+            "get": {
+                "description": "Estimate the market price of a Gundam from completed orders and auctions of the same model kit,\nor of Gundams with the same slug family (slug without its random suffix) when it is not linked to a kit.\nReturns median, p25 and p75 overall and per condition, a monthly median trend and a suggested price range.\nThe condition of each sale is the condition of the Gundam at the time it was sold.\nCompleted exchanges with compensation are only counted when include_exchanges is true: each Gundam of the side\nreceiving the compensation counts as a sale at compensation_amount, which is only the difference between the two sides.\nThe sources field lists the kinds of sales that were counted (order, auction, exchange).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gundams"
+                ],
+                "summary": "Get market price of a Gundam",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Look-back window in months (default: 12, max: 36)",
+                        "name": "months",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "open box",
+                            "used"
+                        ],
+                        "type": "string",
+                        "description": "Only include sales of this condition in the trend",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count completed exchanges with compensation (default: false)",
+                        "name": "include_exchanges",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Market price of the Gundam",
+                        "schema": {
+                            "$ref": "#/definitions/api.gundamMarketPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "404": {
+                        "description": "Not Found - Gundam does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to estimate market price"
+                    }
+                }
+            }
+        },
+        "/gundams/{gundamID}/price-history": {
+            "get": {
+                "description": "List every price change of a Gundam, newest first. The first entry of a Gundam has no previous price.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gundams"
+                ],
+                "summary": "Get Gundam price history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price history of the Gundam",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GundamPriceHistory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid Gundam ID"
+                    },
+                    "404": {
+                        "description": "Not Found - Gundam does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to list price history"
+                    }
+                }
+            }
+        },
         "/mod/auction-requests": {
             "get": {
                 "security": [
@@ -779,16 +1484,35 @@ const docTemplate = `{
                         "description": "Filter by auction request status",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: newest)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "List of auction requests",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionRequest"
-                            }
+                            "$ref": "#/definitions/api.pageResponse-db_AuctionRequest"
                         }
                     }
                 }
@@ -881,7 +1605,7 @@ const docTemplate = `{
                         "accessToken": []
                     }
                 ],
-                "description": "List all auctions with optional status filter.",
+                "description": "List all auctions with optional status filter and cursor pagination.",
                 "produces": [
                     "application/json"
                 ],
@@ -903,16 +1627,37 @@ const docTemplate = `{
                         "description": "Filter by status",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest",
+                            "ending_soon",
+                            "starting_soon"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: newest)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "List of auctions",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionDetails"
-                            }
+                            "$ref": "#/definitions/api.pageResponse-db_AuctionDetails"
                         }
                     }
                 }
@@ -989,58 +1734,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/mod/withdrawal-requests": {
+        "/mod/image-flags": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "List all withdrawal requests for moderators",
+                "description": "List pairs of near-duplicate listing images uploaded for different Gundams or exchange posts.\nA flag means a newly uploaded image looks like an image that already belongs to another listing,\nwhich may be a stolen or reused photo.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "moderator"
                 ],
-                "summary": "List withdrawal requests",
+                "summary": "List flagged duplicate images",
                 "parameters": [
                     {
                         "enum": [
                             "pending",
-                            "approved",
-                            "rejected",
-                            "completed",
-                            "rejected",
-                            "canceled"
+                            "dismissed",
+                            "confirmed"
                         ],
                         "type": "string",
-                        "description": "Filter by withdrawal request status",
+                        "description": "Filter by flag status",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of withdrawal requests",
+                        "description": "List of flagged images",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.WithdrawalRequestDetails"
-                            }
+                            "$ref": "#/definitions/api.pageResponse-db_ListImageFlagsRow"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/mod/withdrawal-requests/{requestID}/complete": {
+        "/mod/image-flags/{flagID}": {
             "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Complete a withdrawal request with transaction reference from bank.\nThe request must be in pending or approved status.",
+                "description": "Mark a flagged pair of images as dismissed (not a problem) or confirmed (stolen or reused photo).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1050,45 +1807,54 @@ const docTemplate = `{
                 "tags": [
                     "moderator"
                 ],
-                "summary": "Complete withdrawal request",
+                "summary": "Review a flagged duplicate image",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Withdrawal Request ID",
-                        "name": "requestID",
+                        "type": "integer",
+                        "description": "Image flag ID",
+                        "name": "flagID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request body",
+                        "description": "Review result",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.completeWithdrawalRequestRequest"
+                            "$ref": "#/definitions/api.reviewImageFlagRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated withdrawal request details",
+                        "description": "Reviewed image flag",
                         "schema": {
-                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                            "$ref": "#/definitions/db.ImageFlag"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters"
+                    },
+                    "404": {
+                        "description": "Not Found - Image flag does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/mod/withdrawal-requests/{requestID}/reject": {
-            "patch": {
+        "/mod/model-kits": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Reject a withdrawal request with reason from moderator. The request must be in pending status.",
+                "description": "Add a model kit to the curated catalog. Sellers can link their listings to it when creating a Gundam.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -1096,86 +1862,140 @@ const docTemplate = `{
                 "tags": [
                     "moderator"
                 ],
-                "summary": "Reject withdrawal request",
+                "summary": "Create a model kit",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Withdrawal Request ID",
-                        "name": "requestID",
-                        "in": "path",
+                        "example": "RX-78-2 Gundam",
+                        "description": "Kit name",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "Request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.rejectWithdrawalRequestRequest"
-                        }
+                        "type": "integer",
+                        "description": "Gundam grade ID",
+                        "name": "grade_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1/144",
+                            "1/100",
+                            "1/60",
+                            "1/48"
+                        ],
+                        "type": "string",
+                        "description": "Scale",
+                        "name": "scale",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Mobile Suit Gundam",
+                        "description": "Series",
+                        "name": "series",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Bandai",
+                        "description": "Manufacturer",
+                        "name": "manufacturer",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Ver.Ka",
+                        "description": "Version",
+                        "name": "version",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Official release year",
+                        "name": "release_year",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Manufacturer's suggested retail price in VND",
+                        "name": "msrp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Canonical image of the kit",
+                        "name": "image",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated withdrawal request details",
+                    "201": {
+                        "description": "Created model kit",
                         "schema": {
-                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                            "$ref": "#/definitions/db.ModelKit"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "404": {
+                        "description": "Not Found - Grade does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to create model kit"
                     }
                 }
             }
         },
-        "/orders": {
-            "get": {
+        "/mod/model-kits/{kitID}": {
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "List all orders of a member with optional filtering by order status",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete a model kit from the catalog. Listings linked to it are kept and simply unlinked.",
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "List all orders of a member",
+                "summary": "Delete a model kit",
                 "parameters": [
                     {
-                        "enum": [
-                            "pending",
-                            "packaging",
-                            "delivering",
-                            "delivered",
-                            "completed",
-                            "canceled",
-                            "failed"
-                        ],
-                        "type": "string",
-                        "description": "Filter by order status",
-                        "name": "status",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Model kit ID",
+                        "name": "kitID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of orders",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.MemberOrderInfo"
-                            }
-                        }
+                    "204": {
+                        "description": "Model kit deleted"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid model kit ID"
+                    },
+                    "404": {
+                        "description": "Not Found - Model kit does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to delete model kit"
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Create a new order for purchasing Gundam models",
+                "description": "Update the reference data of a model kit. Only the provided fields are changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1183,273 +2003,461 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "Create a new order",
+                "summary": "Update a model kit",
                 "parameters": [
                     {
-                        "description": "FailedOrder details",
+                        "type": "integer",
+                        "description": "Model kit ID",
+                        "name": "kitID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createOrderRequest"
+                            "$ref": "#/definitions/api.updateModelKitRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "FailedOrder created successfully",
+                    "200": {
+                        "description": "Updated model kit",
                         "schema": {
-                            "$ref": "#/definitions/db.CreateOrderTxResult"
+                            "$ref": "#/definitions/db.ModelKit"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "404": {
+                        "description": "Not Found - Model kit or grade does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update model kit"
                     }
                 }
             }
         },
-        "/orders/{orderID}": {
-            "get": {
+        "/mod/model-kits/{kitID}/image": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get details of a specific order for a member",
+                "description": "Replace the canonical image of a model kit.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "Get order details for a member",
+                "summary": "Update model kit image",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "123e4567-e89b-12d3-a456-426614174000",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
+                        "type": "integer",
+                        "description": "Model kit ID",
+                        "name": "kitID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "New canonical image of the kit",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "FailedOrder details",
+                        "description": "Updated model kit",
                         "schema": {
-                            "$ref": "#/definitions/db.MemberOrderDetails"
+                            "$ref": "#/definitions/db.ModelKit"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "404": {
+                        "description": "Not Found - Model kit does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update model kit image"
                     }
                 }
             }
         },
-        "/orders/{orderID}/cancel": {
-            "patch": {
+        "/mod/withdrawal-requests": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Allows the buyer to cancel a regular order that is only in pending.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List all withdrawal requests for moderators",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "Cancel order by buyer",
+                "summary": "List withdrawal requests",
                 "parameters": [
                     {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "completed",
+                            "rejected",
+                            "canceled"
+                        ],
                         "type": "string",
-                        "example": "123e4567-e89b-12d3-a456-426614174000",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by withdrawal request status",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Cancel order request",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/api.cancelOrderRequest"
-                        }
+                        "enum": [
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: newest)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "FailedOrder canceled successfully",
+                        "description": "List of withdrawal requests",
                         "schema": {
-                            "$ref": "#/definitions/db.CancelOrderByBuyerTxResult"
+                            "$ref": "#/definitions/api.pageResponse-db_WithdrawalRequestDetails"
                         }
                     }
                 }
             }
         },
-        "/orders/{orderID}/complete": {
+        "/mod/withdrawal-requests/{requestID}/complete": {
             "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Confirm that the receiver has received the order. For regular orders, it completes the transaction and transfers payment to seller. For exchange orders, it updates exchange status and may complete the exchange if both parties have confirmed. For auction orders, it completes the auction transaction.",
+                "description": "Complete a withdrawal request with transaction reference from bank.\nThe request must be in pending or approved status.\nA fresh TOTP code is required in the X-TOTP-Code header.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "Confirm order received",
+                "summary": "Complete withdrawal request",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "123e4567-e89b-12d3-a456-426614174000",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
-                        "in": "path",
+                        "description": "Current TOTP code",
+                        "name": "X-TOTP-Code",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Withdrawal Request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.completeWithdrawalRequestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "FailedOrder completed successfully"
+                        "description": "Updated withdrawal request details",
+                        "schema": {
+                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid TOTP code"
+                    },
+                    "403": {
+                        "description": "Two-factor authentication required"
+                    },
+                    "429": {
+                        "description": "Too many attempts"
                     }
                 }
             }
         },
-        "/orders/{orderID}/package": {
+        "/mod/withdrawal-requests/{requestID}/reject": {
             "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Upload package images, create a delivery order, and update order status for a specified order.\nHandles packaging for regular orders, exchange orders, and auction orders (future).",
+                "description": "Reject a withdrawal request with reason from moderator. The request must be in pending status.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "moderator"
                 ],
-                "summary": "Package an order for delivery",
+                "summary": "Reject withdrawal request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "FailedOrder ID in UUID format",
-                        "name": "orderID",
+                        "description": "Withdrawal Request ID",
+                        "name": "requestID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Package images (at least one image required)",
-                        "name": "package_images",
-                        "in": "formData",
-                        "required": true
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.rejectWithdrawalRequestRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully packaged order with delivery details",
+                        "description": "Updated withdrawal request details",
                         "schema": {
-                            "$ref": "#/definitions/db.PackageOrderTxResult"
+                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
                         }
                     }
                 }
             }
         },
-        "/otp/email/generate": {
-            "post": {
-                "description": "Generates and sends an OTP to the specified email address",
-                "consumes": [
-                    "application/json"
-                ],
+        "/model-kits": {
+            "get": {
+                "description": "List the curated model kit catalog. Each kit includes the number of published listings linked to it and their lowest price.\nUse GET /gundams?model_kit_id={id} to list every listing of a kit.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "model-kits"
                 ],
-                "summary": "Generate a One-Time Password (OTP) for email",
+                "summary": "List model kits",
                 "parameters": [
                     {
-                        "description": "OTP Generation Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.GenerateEmailOTPRequest"
-                        }
+                        "type": "string",
+                        "example": "rx-78",
+                        "description": "Search by kit name (accent-insensitive)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "master-grade",
+                        "description": "Filter by Gundam grade slug",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1/144",
+                            "1/100",
+                            "1/60",
+                            "1/48"
+                        ],
+                        "type": "string",
+                        "description": "Filter by scale",
+                        "name": "scale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by series (case-insensitive)",
+                        "name": "series",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "newest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: name)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP generated successfully",
+                        "description": "List of model kits",
                         "schema": {
-                            "$ref": "#/definitions/api.GenerateEmailOTPResponse"
+                            "$ref": "#/definitions/api.pageResponse-db_ListModelKitsRow"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input"
-                    },
-                    "429": {
-                        "description": "Too Many Requests - OTP request rate limit exceeded"
+                        "description": "Bad Request - Invalid query parameters"
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal Server Error - Failed to list model kits"
                     }
                 }
             }
         },
-        "/otp/email/verify": {
-            "post": {
-                "description": "Verifies the OTP sent to a user's email address and updates the user's email if valid",
-                "consumes": [
-                    "application/json"
-                ],
+        "/model-kits/{kitID}": {
+            "get": {
+                "description": "Get a model kit with the number of published listings linked to it and their lowest price.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "model-kits"
                 ],
-                "summary": "Verify One-Time Password (OTP) via email",
+                "summary": "Get model kit details",
                 "parameters": [
                     {
-                        "description": "OTP Verification Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.VerifyEmailOTPRequest"
-                        }
+                        "type": "integer",
+                        "description": "Model kit ID",
+                        "name": "kitID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP verified successfully"
+                        "description": "Model kit details",
+                        "schema": {
+                            "$ref": "#/definitions/db.GetModelKitDetailsRow"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input or OTP verification failed"
+                        "description": "Bad Request - Invalid model kit ID"
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid OTP code"
+                    "404": {
+                        "description": "Not Found - Model kit does not exist"
                     },
                     "500": {
-                        "description": "Internal Server Error - Failed to update user information"
+                        "description": "Internal Server Error - Failed to get model kit"
                     }
                 }
             }
         },
-        "/otp/phone-number/generate": {
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List all orders of a member with optional filtering by order status\nNote: the recently_updated cursor is keyed on updated_at, so an order updated while paging may be skipped or returned twice. Use newest or oldest for a stable traversal.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "List all orders of a member",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "packaging",
+                            "delivering",
+                            "delivered",
+                            "completed",
+                            "canceled",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "recently_updated",
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: recently_updated)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of orders",
+                        "schema": {
+                            "$ref": "#/definitions/api.pageResponse-db_MemberOrderInfo"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Generates and sends an OTP to the specified phone number. The OTP will be valid for 10 minutes.",
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Create a new order for purchasing Gundam models. The ordered units are reserved until the order is completed, canceled or failed.\nThe order is rejected when a Gundam does not have enough units available.\nThe delivery fee is quoted by the delivery provider from the seller's pickup address, the buyer's address and the item weights, the order is rejected when delivery_fee or expected_delivery_time does not match the quote.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1457,39 +2465,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "orders"
                 ],
-                "summary": "Generate a One-Time Password (OTP) for phone number",
+                "summary": "Create a new order",
                 "parameters": [
                     {
-                        "description": "OTP Generation Request",
+                        "description": "FailedOrder details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.GeneratePhoneOTPRequest"
+                            "$ref": "#/definitions/api.createOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OTP generated successfully",
+                    "201": {
+                        "description": "FailedOrder created successfully",
                         "schema": {
-                            "$ref": "#/definitions/api.GeneratePhoneOTPResponse"
+                            "$ref": "#/definitions/db.CreateOrderTxResult"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request - Invalid phone number format"
-                    },
-                    "500": {
-                        "description": "Internal Server Error - Failed to generate or send OTP"
                     }
                 }
             }
         },
-        "/otp/phone-number/verify": {
+        "/orders/delivery-quote": {
             "post": {
-                "description": "Verifies the OTP sent to a user's phone number and updates the user's phone number if valid",
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get the delivery fee and the expected delivery time quoted by the delivery provider for an order,\nfrom the seller's pickup address to the buyer's address, based on the total weight of the items.\nThe delivery_fee sent to POST /orders must match the quoted fee.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1497,78 +2504,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "orders"
                 ],
-                "summary": "Verify One-Time Password (OTP) via phone number",
+                "summary": "Quote the delivery of an order",
                 "parameters": [
                     {
-                        "description": "OTP Verification Request",
+                        "description": "Order to quote",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.VerifyPhoneOTPRequest"
+                            "$ref": "#/definitions/api.quoteOrderDeliveryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP verified successfully"
+                        "description": "Delivery quote",
+                        "schema": {
+                            "$ref": "#/definitions/api.deliveryQuote"
+                        }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid input or OTP verification failed"
-                    },
-                    "401": {
-                        "description": "Unauthorized - Invalid OTP code"
+                        "description": "Bad Request - Invalid parameters"
                     },
                     "404": {
-                        "description": "Not Found - User not found"
+                        "description": "Not Found - Gundam does not exist"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity - Gundam does not belong to the seller or address not found"
                     },
                     "500": {
-                        "description": "Internal Server Error - Failed to update user information"
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/seller/profile": {
+        "/orders/{orderID}": {
             "get": {
-                "description": "Get detailed information about a specific seller",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "accessToken": []
+                    }
                 ],
+                "description": "Get details of a specific order for a member",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "seller profile"
+                    "orders"
                 ],
-                "summary": "Get seller profile",
+                "summary": "Get order details for a member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "query",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Seller profile details",
+                        "description": "FailedOrder details",
                         "schema": {
-                            "$ref": "#/definitions/db.GetSellerDetailByIDRow"
+                            "$ref": "#/definitions/db.MemberOrderDetails"
                         }
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new seller profile",
+            }
+        },
+        "/orders/{orderID}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Allows the buyer to cancel a regular order that is only in pending.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1576,134 +2590,116 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "seller profile"
+                    "orders"
                 ],
-                "summary": "Create a seller profile",
+                "summary": "Cancel order by buyer",
                 "parameters": [
                     {
-                        "description": "Seller profile creation request",
+                        "type": "string",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancel order request",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createSellerProfileRequest"
+                            "$ref": "#/definitions/api.cancelOrderRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Seller profile created successfully",
+                    "200": {
+                        "description": "FailedOrder canceled successfully",
                         "schema": {
-                            "$ref": "#/definitions/db.SellerProfile"
+                            "$ref": "#/definitions/db.CancelOrderByBuyerTxResult"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request format"
-                    },
-                    "409": {
-                        "description": "Seller profile already exists"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/orders/{orderID}/complete": {
             "patch": {
-                "description": "Update the seller's profile information",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "accessToken": []
+                    }
                 ],
+                "description": "Confirm that the receiver has received the order. For regular orders, it completes the transaction and transfers payment to seller. For exchange orders, it updates exchange status and may complete the exchange if both parties have confirmed. For auction orders, it completes the auction transaction.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "seller profile"
+                    "orders"
                 ],
-                "summary": "Update seller profile",
+                "summary": "Confirm order received",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.updateSellerProfileRequest"
-                        }
+                        "type": "string",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated seller profile",
-                        "schema": {
-                            "$ref": "#/definitions/db.SellerProfile"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body"
-                    },
-                    "404": {
-                        "description": "Seller not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
+                        "description": "FailedOrder completed successfully"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/auction-requests": {
-            "get": {
+        "/orders/{orderID}/package": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "List all auction requests that belong to the specified seller, optionally filtered by status",
+                "description": "Upload package images, create a delivery order, and update order status for a specified order.\nHandles packaging for regular orders, exchange orders, and auction orders (future).",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "orders"
                 ],
-                "summary": "List auction requests of a seller",
+                "summary": "Package an order for delivery",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
+                        "description": "FailedOrder ID in UUID format",
+                        "name": "orderID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "pending",
-                            "approved",
-                            "rejected"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "type": "file",
+                        "description": "Package images (at least one image required)",
+                        "name": "package_images",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of auction requests",
+                        "description": "Successfully packaged order with delivery details",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionRequest"
-                            }
+                            "$ref": "#/definitions/db.PackageOrderTxResult"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/otp/email/generate": {
             "post": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Create a new auction request for a Gundam model",
+                "description": "Generates and sends an OTP to the specified email address. The OTP is only delivered by email, it is not returned in the response.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1711,175 +2707,192 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "authentication"
                 ],
-                "summary": "Create a new auction request by seller",
+                "summary": "Generate a One-Time Password (OTP) for email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Auction request details",
+                        "description": "OTP Generation Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createAuctionRequestBody"
+                            "$ref": "#/definitions/api.GenerateEmailOTPRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created auction request",
+                    "200": {
+                        "description": "OTP generated successfully",
                         "schema": {
-                            "$ref": "#/definitions/db.AuctionRequest"
+                            "$ref": "#/definitions/api.GenerateEmailOTPResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input"
+                    },
+                    "429": {
+                        "description": "Too Many Requests - Resend cooldown (OTP_RESEND_COOLDOWN) or temporary lockout (OTP_LOCKED, OTP_IP_LOCKED, OTP_TOO_MANY_RESENDS)",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/auction-requests/{requestID}": {
-            "delete": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
+        "/otp/email/verify": {
+            "post": {
+                "description": "Verifies the OTP sent to a user's email address and updates the user's email if valid",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Delete an auction request. Only requests with 'pending' or 'rejected' status can be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "authentication"
                 ],
-                "summary": "Delete an auction request by seller",
+                "summary": "Verify One-Time Password (OTP) via email",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Auction Request ID (UUID format)",
-                        "name": "requestID",
-                        "in": "path",
-                        "required": true
+                        "description": "OTP Verification Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.VerifyEmailOTPRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted auction request"
+                    "200": {
+                        "description": "OTP verified successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or OTP verification failed (OTP_INVALID, OTP_EXPIRED, OTP_INVALID_FORMAT)",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid OTP code"
+                    },
+                    "429": {
+                        "description": "Too Many Requests - Too many failed attempts (OTP_TOO_MANY_ATTEMPTS, OTP_LOCKED, OTP_IP_LOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update user information"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/auctions": {
-            "get": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
+        "/otp/phone-number/generate": {
+            "post": {
+                "description": "Generates and sends an OTP to the specified phone number. The OTP will be valid for 10 minutes and is only delivered by SMS, it is not returned in the response.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "List all auctions that belong to the specified seller, optionally filtered by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "authentication"
                 ],
-                "summary": "List all auctions of a seller",
+                "summary": "Generate a One-Time Password (OTP) for phone number",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "scheduled",
-                            "active",
-                            "ended",
-                            "completed",
-                            "failed",
-                            "canceled"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "OTP Generation Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.GeneratePhoneOTPRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of auctions",
+                        "description": "OTP generated successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionDetails"
-                            }
+                            "$ref": "#/definitions/api.GeneratePhoneOTPResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid phone number format"
+                    },
+                    "429": {
+                        "description": "Too Many Requests - Resend cooldown (OTP_RESEND_COOLDOWN) or temporary lockout (OTP_LOCKED, OTP_IP_LOCKED, OTP_TOO_MANY_RESENDS)",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to generate or send OTP"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/auctions/{auctionID}": {
-            "get": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
+        "/otp/phone-number/verify": {
+            "post": {
+                "description": "Verifies the OTP sent to a user's phone number and updates the user's phone number if valid",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get details of a specific auction for the seller",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "authentication"
                 ],
-                "summary": "Get auction details by seller",
+                "summary": "Verify One-Time Password (OTP) via phone number",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Auction ID (UUID format)",
-                        "name": "auctionID",
-                        "in": "path",
-                        "required": true
+                        "description": "OTP Verification Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.VerifyPhoneOTPRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Auction details",
+                        "description": "OTP verified successfully"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input or OTP verification failed (OTP_INVALID, OTP_EXPIRED, OTP_INVALID_FORMAT)",
                         "schema": {
-                            "$ref": "#/definitions/db.AuctionDetails"
+                            "$ref": "#/definitions/api.otpErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid OTP code"
+                    },
+                    "404": {
+                        "description": "Not Found - User not found"
+                    },
+                    "429": {
+                        "description": "Too Many Requests - Too many failed attempts (OTP_TOO_MANY_ATTEMPTS, OTP_LOCKED, OTP_IP_LOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/api.otpErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update user information"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/auctions/{auctionID}/cancel": {
-            "patch": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Cancel an auction that is in 'scheduled' status (has not started yet). This endpoint allows sellers to cancel their own auctions before they begin.\n\n**Quy tắc nghiệp vụ:**\n- Chỉ chủ sở hữu phiên đấu giá (người bán) mới có thể hủy\n- Chỉ có thể hủy phiên đấu giá có trạng thái 'scheduled' (chưa bắt đầu)\n- Không thể hủy phiên đấu giá đang 'active', 'ended', 'completed', hoặc 'failed'\n- Lý do hủy là tùy chọn (không bắt buộc)\n\n**Kết quả sau khi hủy:**\n1. Trạng thái đấu giá chuyển từ 'scheduled' sang 'canceled'\n2. Trạng thái Gundam chuyển từ 'auctioning' về 'in store' (có thể đăng bán lại)\n3. Hoàn trả 1 lượt đăng đấu giá trong gói đăng ký của người bán\n4. Người bán có thể tạo yêu cầu đấu giá mới (nếu còn quota)",
+        "/seller/profile": {
+            "get": {
+                "description": "Get detailed information about a specific seller",
                 "consumes": [
                     "application/json"
                 ],
@@ -1887,60 +2900,131 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "seller profile"
                 ],
-                "summary": "Cancel a scheduled auction",
+                "summary": "Get seller profile",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Auction ID (UUID format)",
-                        "name": "auctionID",
-                        "in": "path",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seller profile details",
+                        "schema": {
+                            "$ref": "#/definitions/db.GetSellerDetailByIDRow"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found"
                     },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new seller profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seller profile"
+                ],
+                "summary": "Create a seller profile",
+                "parameters": [
                     {
-                        "type": "string",
-                        "example": "s123e456-e789-45d0-9876-54321abcdef",
-                        "description": "Seller ID",
-                        "name": "sellerID",
-                        "in": "path",
-                        "required": true
+                        "description": "Seller profile creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createSellerProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Seller profile created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.SellerProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format"
                     },
+                    "409": {
+                        "description": "Seller profile already exists"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the seller's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seller profile"
+                ],
+                "summary": "Update seller profile",
+                "parameters": [
                     {
-                        "description": "Cancellation request with optional reason",
+                        "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.cancelAuctionRequest"
+                            "$ref": "#/definitions/api.updateSellerProfileRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully cancelled auction with updated details",
+                        "description": "Successfully updated seller profile",
                         "schema": {
-                            "$ref": "#/definitions/db.Auction"
+                            "$ref": "#/definitions/db.SellerProfile"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "404": {
+                        "description": "Seller not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/dashboard": {
+        "/sellers/{sellerID}/auction-requests": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get comprehensive dashboard statistics for a seller including gundam counts, income, orders, and auction data",
+                "description": "List all auction requests that belong to the specified seller, optionally filtered by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "Get seller dashboard statistics",
+                "summary": "List auction requests of a seller",
                 "parameters": [
                     {
                         "type": "string",
@@ -1948,26 +3032,38 @@ const docTemplate = `{
                         "name": "sellerID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Dashboard statistics",
+                        "description": "List of auction requests",
                         "schema": {
-                            "$ref": "#/definitions/db.SellerDashboard"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.AuctionRequest"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/sellers/{sellerID}/gundams/{gundamID}/publish": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Publish a gundam for sale for the specified seller. This endpoint checks the gundam's status before proceeding.",
+                "description": "Create a new auction request for a Gundam model",
                 "consumes": [
                     "application/json"
                 ],
@@ -1975,144 +3071,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "Publish a gundam for sale",
+                "summary": "Create a new auction request by seller",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Seller ID",
                         "name": "sellerID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully published gundam",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                    },
+                    {
+                        "description": "Auction request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createAuctionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created auction request",
+                        "schema": {
+                            "$ref": "#/definitions/db.AuctionRequest"
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/gundams/{gundamID}/unpublish": {
-            "patch": {
+        "/sellers/{sellerID}/auction-requests/{requestID}": {
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Unpublish a gundam for the specified seller. This endpoint checks the gundam's status before proceeding.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an auction request. Only requests with 'pending' or 'rejected' status can be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "Unpublish a gundam",
+                "summary": "Delete an auction request by seller",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "sellerID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
+                        "description": "Auction Request ID (UUID format)",
+                        "name": "requestID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully unsold gundam with details",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid gundam ID",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Seller does not own this gundam",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Seller not found\u003cbr/\u003eGundam not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "409": {
-                        "description": "Gundam is not currently listed for sale",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                    "204": {
+                        "description": "Successfully deleted auction request"
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/orders": {
+        "/sellers/{sellerID}/auctions": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get all sales orders that belong to the specified seller ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List all auctions that belong to the specified seller, optionally filtered by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "List all sales orders (excluding exchange orders) for a specific seller",
+                "summary": "List all auctions of a seller",
                 "parameters": [
                     {
                         "type": "string",
@@ -2123,51 +3165,47 @@ const docTemplate = `{
                     },
                     {
                         "enum": [
-                            "pending",
-                            "packaging",
-                            "delivering",
-                            "delivered",
+                            "scheduled",
+                            "active",
+                            "ended",
                             "completed",
-                            "canceled",
-                            "failed"
+                            "failed",
+                            "canceled"
                         ],
                         "type": "string",
-                        "description": "Filter by order status",
+                        "description": "Filter by status",
                         "name": "status",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of sales orders",
+                        "description": "List of auctions",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.SalesOrderInfo"
+                                "$ref": "#/definitions/db.AuctionDetails"
                             }
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/orders/:orderID": {
+        "/sellers/{sellerID}/auctions/{auctionID}": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get details of a specific sales order for the seller",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get details of a specific auction for the seller",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "Get sales order details (excluding exchange orders)",
+                "summary": "Get auction details by seller",
                 "parameters": [
                     {
                         "type": "string",
@@ -2178,30 +3216,30 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
+                        "description": "Auction ID (UUID format)",
+                        "name": "auctionID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Sales order details",
+                        "description": "Auction details",
                         "schema": {
-                            "$ref": "#/definitions/db.SalesOrderDetails"
+                            "$ref": "#/definitions/db.AuctionDetails"
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/orders/:orderID/confirm": {
+        "/sellers/{sellerID}/auctions/{auctionID}/cancel": {
             "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Confirm an order for the specified seller. This endpoint checks the order's status before proceeding.",
+                "description": "Cancel an auction that is in 'scheduled' status (has not started yet). This endpoint allows sellers to cancel their own auctions before they begin.\n\n**Quy tắc nghiệp vụ:**\n- Chỉ chủ sở hữu phiên đấu giá (người bán) mới có thể hủy\n- Chỉ có thể hủy phiên đấu giá có trạng thái 'scheduled' (chưa bắt đầu)\n- Không thể hủy phiên đấu giá đang 'active', 'ended', 'completed', hoặc 'failed'\n- Lý do hủy là tùy chọn (không bắt buộc)\n\n**Kết quả sau khi hủy:**\n1. Trạng thái đấu giá chuyển từ 'scheduled' sang 'canceled'\n2. Trạng thái Gundam chuyển từ 'auctioning' về 'in store' (có thể đăng bán lại)\n3. Hoàn trả 1 lượt đăng đấu giá trong gói đăng ký của người bán\n4. Người bán có thể tạo yêu cầu đấu giá mới (nếu còn quota)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2209,105 +3247,105 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sellers"
+                    "auctions"
                 ],
-                "summary": "Confirm an order",
+                "summary": "Cancel a scheduled auction",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Seller ID",
-                        "name": "sellerID",
+                        "description": "Auction ID (UUID format)",
+                        "name": "auctionID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
+                        "example": "s123e456-e789-45d0-9876-54321abcdef",
+                        "description": "Seller ID",
+                        "name": "sellerID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Cancellation request with optional reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.cancelAuctionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully confirmed order",
+                        "description": "Successfully cancelled auction with updated details",
                         "schema": {
-                            "$ref": "#/definitions/db.ConfirmOrderTxResult"
+                            "$ref": "#/definitions/db.Auction"
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/orders/{orderID}/cancel": {
-            "patch": {
+        "/sellers/{sellerID}/dashboard": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Cancel a pending order by the seller",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get comprehensive dashboard statistics for a seller including gundam counts, income, orders, and auction data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "sellers"
                 ],
-                "summary": "Cancel order by seller",
+                "summary": "Get seller dashboard statistics",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "s123e456-e789-45d0-9876-54321abcdef",
                         "description": "Seller ID",
                         "name": "sellerID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "123e4567-e89b-12d3-a456-426614174000",
-                        "description": "FailedOrder ID",
-                        "name": "orderID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Cancellation reason",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/api.cancelOrderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "FailedOrder canceled successfully",
+                        "description": "Dashboard statistics",
                         "schema": {
-                            "$ref": "#/definitions/db.CancelOrderBySellerTxResult"
+                            "$ref": "#/definitions/db.SellerDashboard"
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/subscriptions/active": {
-            "get": {
+        "/sellers/{sellerID}/gundams/{gundamID}/publish": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get the current active subscription details for the specified seller",
+                "description": "Publish a gundam for sale for the specified seller. This endpoint checks the gundam's status before proceeding.\nThe response includes a suggested price range (p25 - p75 of recent completed orders and auctions of the same model kit or slug family, exchanges are not counted), or null when there is not enough sales data.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "sellers"
                 ],
-                "summary": "Get current active subscription",
+                "summary": "Publish a gundam for sale",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Seller ID",
@@ -2318,22 +3356,23 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Current active subscription details",
+                        "description": "Successfully published gundam",
                         "schema": {
-                            "$ref": "#/definitions/api.SubscriptionDetailsResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/sellers/{sellerID}/subscriptions/upgrade": {
-            "post": {
+        "/sellers/{sellerID}/gundams/{gundamID}/unpublish": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Upgrade seller's subscription to a higher tier plan with the following business rules:\n\n**Business Rules:**\n1. **Same Plan Rule**: Không thể nâng cấp lên chính gói đang sử dụng\n2. **Free Plan Downgrade Rule**: Không thể hạ cấp từ bất kỳ gói trả phí nào về gói miễn phí\n3. **Paid Plan Downgrade Rule**: Không thể hạ cấp từ gói trả phí cao hơn xuống gói trả phí thấp hơn (ví dụ: từ \"GÓI KHÔNG GIỚI HẠN\" xuống \"GÓI NÂNG CẤP\")\n4. **Balance Rule**: Phải có đủ số dư trong ví để thanh toán **toàn bộ** giá gói đích (không tính phần hoàn lại từ gói cũ)\n\n**Các trường hợp nâng cấp hợp lệ:**\n- Từ \"GÓI DÙNG THỬ\" (miễn phí) lên \"GÓI NÂNG CẤP\" (359.000 VND) - **Thanh toán: 359.000 VND**\n- Từ \"GÓI DÙNG THỬ\" (miễn phí) lên \"GÓI KHÔNG GIỚI HẠN\" (1.049.000 VND) - **Thanh toán: 1.049.000 VND**\n- Từ \"GÓI NÂNG CẤP\" (359.000 VND) lên \"GÓI KHÔNG GIỚI HẠN\" (1.049.000 VND) - **Thanh toán: 1.049.000 VND**\n\n**Chính sách thanh toán:**\n- **Thanh toán toàn bộ**: Người dùng sẽ thanh toán 100% giá trị của gói đích, không có hoàn tiền từ gói cũ\n- **Lý do**: Đảm bảo tính đơn giản trong hệ thống và người dùng được hưởng đầy đủ benefits mới ngay lập tức\n- **Ví dụ**: Nâng cấp từ GÓI NÂNG CẤP (đã dùng 75% thời hạn) lên GÓI KHÔNG GIỚI HẠN vẫn phải trả đủ 1.049.000 VND\n\n**Tác động khi nâng cấp thành công:**\n- Gói đăng ký cũ sẽ bị vô hiệu hóa ngay lập tức (không hoàn tiền phần chưa sử dụng)\n- Gói đăng ký mới được kích hoạt với hạn mức hoàn toàn mới (listings_used = 0, open_auctions_used = 0)\n- Chu kỳ đăng ký mới bắt đầu từ thời điểm nâng cấp với đầy đủ thời hạn của gói mới\n- Số tiền được trừ từ ví là 100% giá trị gói đích\n- Gửi thông báo cho người bán về việc nâng cấp thành công\n\n**Lưu ý quan trọng:**\n- Không có chính sách hoàn tiền cho gói cũ chưa hết hạn\n- Nên cân nhắc thời điểm nâng cấp để tối ưu chi phí\n- Sau khi nâng cấp, tất cả các hạn mức sử dụng sẽ được reset về 0",
+                "description": "Unpublish a gundam for the specified seller. This endpoint checks the gundam's status before proceeding.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2343,61 +3382,87 @@ const docTemplate = `{
                 "tags": [
                     "sellers"
                 ],
-                "summary": "Upgrade subscription plan",
+                "summary": "Unpublish a gundam",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Seller ID",
                         "name": "sellerID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Upgrade subscription request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.upgradeSubscriptionRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Upgrade successfully",
+                        "description": "Successfully unsold gundam with details",
                         "schema": {
-                            "$ref": "#/definitions/db.UpgradeSubscriptionTxResult"
+                            "type": "object",
+                            "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/subscription-plans": {
-            "get": {
-                "description": "Get a list of all available subscription plans.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "sellers"
-                ],
-                "summary": "List all subscription plans",
-                "responses": {
-                    "200": {
-                        "description": "All available subscription plans",
+                    },
+                    "400": {
+                        "description": "Invalid gundam ID",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.SubscriptionPlan"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Seller does not own this gundam",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Seller not found\u003cbr/\u003eGundam not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Gundam is not currently listed for sale",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
         },
-        "/tokens/verify": {
-            "post": {
-                "description": "Verifies a JWT access token and returns the associated user",
+        "/sellers/{sellerID}/orders": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get all sales orders that belong to the specified seller ID\nNote: the recently_updated cursor is keyed on updated_at, so an order updated while paging may be skipped or returned twice. Use newest or oldest for a stable traversal.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2405,39 +3470,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "sellers"
                 ],
-                "summary": "Verify access token",
+                "summary": "List all sales orders (excluding exchange orders) for a specific seller",
                 "parameters": [
                     {
-                        "description": "Token verification request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.verifyAccessTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "sellerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "packaging",
+                            "delivering",
+                            "delivered",
+                            "completed",
+                            "canceled",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Filter by order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "recently_updated",
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: recently_updated)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User information",
+                        "description": "List of sales orders",
                         "schema": {
-                            "$ref": "#/definitions/db.User"
+                            "$ref": "#/definitions/api.pageResponse-db_SalesOrderInfo"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request format"
-                    },
-                    "401": {
-                        "description": "Invalid or expired token"
                     }
                 }
             }
         },
-        "/users": {
-            "post": {
-                "description": "Create a new user with email and password",
+        "/sellers/{sellerID}/orders/:orderID": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get details of a specific sales order for the seller",
                 "consumes": [
                     "application/json"
                 ],
@@ -2445,50 +3545,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "sellers"
                 ],
-                "summary": "Create a new user",
+                "summary": "Get sales order details (excluding exchange orders)",
                 "parameters": [
                     {
-                        "description": "User creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.createUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "sellerID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created user",
+                    "200": {
+                        "description": "Sales order details",
                         "schema": {
-                            "$ref": "#/definitions/db.User"
+                            "$ref": "#/definitions/db.SalesOrderDetails"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request body"
-                    },
-                    "409": {
-                        "description": "Email already exists"
-                    },
-                    "422": {
-                        "description": "Validation error"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/:id/gundams": {
-            "get": {
+        "/sellers/{sellerID}/orders/:orderID/confirm": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get all gundams that belong to the specified user ID",
+                "description": "Confirm an order for the specified seller. This endpoint checks the order's status before proceeding.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2496,292 +3589,235 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "sellers"
                 ],
-                "summary": "List all gundams for a specific user",
+                "summary": "Confirm an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Seller ID",
+                        "name": "sellerID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Gundam name to filter by",
-                        "name": "name",
-                        "in": "query"
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved list of gundams",
+                        "description": "Successfully confirmed order",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.GundamDetails"
-                            }
+                            "$ref": "#/definitions/db.ConfirmOrderTxResult"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/sellers/{sellerID}/orders/{orderID}/cancel": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Create a new Gundam model with images and accessories",
+                "description": "Cancel a pending order by the seller",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "sellers"
                 ],
-                "summary": "Create a new Gundam model",
+                "summary": "Cancel order by seller",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "example": "s123e456-e789-45d0-9876-54321abcdef",
+                        "description": "Seller ID",
+                        "name": "sellerID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Gundam name",
-                        "name": "name",
-                        "in": "formData",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "description": "FailedOrder ID",
+                        "name": "orderID",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Gundam grade ID",
-                        "name": "grade_id",
-                        "in": "formData",
-                        "required": true
-                    },
+                        "description": "Cancellation reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.cancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "FailedOrder canceled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.CancelOrderBySellerTxResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{sellerID}/subscriptions/active": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Gundam series name",
-                        "name": "series",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Total number of parts",
-                        "name": "parts_total",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gundam material",
-                        "name": "material",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gundam version",
-                        "name": "version",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "new",
-                            "open box",
-                            "used"
-                        ],
-                        "type": "string",
-                        "description": "Condition of the Gundam",
-                        "name": "condition",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Manufacturer name",
-                        "name": "manufacturer",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "1/144",
-                            "1/100",
-                            "1/60"
-                        ],
-                        "type": "string",
-                        "description": "Gundam scale",
-                        "name": "scale",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Weight in grams",
-                        "name": "weight",
-                        "in": "formData",
-                        "required": true
-                    },
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get the current active subscription details for the specified seller",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sellers"
+                ],
+                "summary": "Get current active subscription",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Detailed description",
-                        "name": "description",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Price in VND",
-                        "name": "price",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Release year",
-                        "name": "release_year",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Primary image of the Gundam",
-                        "name": "primary_image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Secondary images of the Gundam",
-                        "name": "secondary_images",
-                        "in": "formData",
+                        "description": "Seller ID",
+                        "name": "sellerID",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Additional details about condition",
-                        "name": "condition_description",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Accessory as JSON object. Add multiple accessories by repeating this field with different values.",
-                        "name": "accessory",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created Gundam",
+                    "200": {
+                        "description": "Current active subscription details",
                         "schema": {
-                            "$ref": "#/definitions/db.GundamDetails"
+                            "$ref": "#/definitions/api.SubscriptionDetailsResponse"
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request - Invalid input data"
-                    },
-                    "403": {
-                        "description": "Forbidden - User is not authorized to create Gundam for this user"
-                    },
-                    "404": {
-                        "description": "Not Found - User with specified ID does not exist"
-                    },
-                    "500": {
-                        "description": "Internal Server Error - Failed to create Gundam"
                     }
                 }
             }
         },
-        "/users/:id/gundams/:gundamID": {
-            "get": {
+        "/sellers/{sellerID}/subscriptions/upgrade": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific Gundam model",
+                "description": "Upgrade seller's subscription to a higher tier plan with the following business rules:\n\n**Business Rules:**\n1. **Same Plan Rule**: Không thể nâng cấp lên chính gói đang sử dụng\n2. **Free Plan Downgrade Rule**: Không thể hạ cấp từ bất kỳ gói trả phí nào về gói miễn phí\n3. **Paid Plan Downgrade Rule**: Không thể hạ cấp từ gói trả phí cao hơn xuống gói trả phí thấp hơn (ví dụ: từ \"GÓI KHÔNG GIỚI HẠN\" xuống \"GÓI NÂNG CẤP\")\n4. **Balance Rule**: Phải có đủ số dư trong ví để thanh toán **toàn bộ** giá gói đích (không tính phần hoàn lại từ gói cũ)\n\n**Các trường hợp nâng cấp hợp lệ:**\n- Từ \"GÓI DÙNG THỬ\" (miễn phí) lên \"GÓI NÂNG CẤP\" (359.000 VND) - **Thanh toán: 359.000 VND**\n- Từ \"GÓI DÙNG THỬ\" (miễn phí) lên \"GÓI KHÔNG GIỚI HẠN\" (1.049.000 VND) - **Thanh toán: 1.049.000 VND**\n- Từ \"GÓI NÂNG CẤP\" (359.000 VND) lên \"GÓI KHÔNG GIỚI HẠN\" (1.049.000 VND) - **Thanh toán: 1.049.000 VND**\n\n**Chính sách thanh toán:**\n- **Thanh toán toàn bộ**: Người dùng sẽ thanh toán 100% giá trị của gói đích, không có hoàn tiền từ gói cũ\n- **Lý do**: Đảm bảo tính đơn giản trong hệ thống và người dùng được hưởng đầy đủ benefits mới ngay lập tức\n- **Ví dụ**: Nâng cấp từ GÓI NÂNG CẤP (đã dùng 75% thời hạn) lên GÓI KHÔNG GIỚI HẠN vẫn phải trả đủ 1.049.000 VND\n\n**Tác động khi nâng cấp thành công:**\n- Gói đăng ký cũ sẽ bị vô hiệu hóa ngay lập tức (không hoàn tiền phần chưa sử dụng)\n- Gói đăng ký mới được kích hoạt với hạn mức hoàn toàn mới (listings_used = 0, open_auctions_used = 0)\n- Chu kỳ đăng ký mới bắt đầu từ thời điểm nâng cấp với đầy đủ thời hạn của gói mới\n- Số tiền được trừ từ ví là 100% giá trị gói đích\n- Gửi thông báo cho người bán về việc nâng cấp thành công\n\n**Lưu ý quan trọng:**\n- Không có chính sách hoàn tiền cho gói cũ chưa hết hạn\n- Nên cân nhắc thời điểm nâng cấp để tối ưu chi phí\n- Sau khi nâng cấp, tất cả các hạn mức sử dụng sẽ được reset về 0",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "gundams"
+                    "sellers"
                 ],
-                "summary": "Get Gundam details",
+                "summary": "Upgrade subscription plan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Seller ID",
+                        "name": "sellerID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
+                        "description": "Upgrade subscription request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.upgradeSubscriptionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved Gundam details",
+                        "description": "Upgrade successfully",
                         "schema": {
-                            "$ref": "#/definitions/db.GundamDetails"
+                            "$ref": "#/definitions/db.UpgradeSubscriptionTxResult"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "accessToken": []
+            }
+        },
+        "/subscription-plans": {
+            "get": {
+                "description": "Get a list of all available subscription plans.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sellers"
+                ],
+                "summary": "List all subscription plans",
+                "responses": {
+                    "200": {
+                        "description": "All available subscription plans",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.SubscriptionPlan"
+                            }
+                        }
                     }
+                }
+            }
+        },
+        "/tokens/verify": {
+            "post": {
+                "description": "Verifies a JWT access token and returns the associated user",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Hard delete a Gundam model by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "gundams"
+                    "authentication"
                 ],
-                "summary": "Hard delete Gundam",
+                "summary": "Verify access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
+                        "description": "Token verification request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.verifyAccessTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted Gundam"
+                    "200": {
+                        "description": "User information",
+                        "schema": {
+                            "$ref": "#/definitions/db.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format"
+                    },
+                    "401": {
+                        "description": "Invalid or expired token"
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Update the basic information of a Gundam model",
+            }
+        },
+        "/users": {
+            "post": {
+                "description": "Create a new user with email and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -2789,166 +3825,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "gundams"
+                    "authentication"
                 ],
-                "summary": "Update Gundam basis info",
+                "summary": "Create a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Gundam name",
-                        "name": "name",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Gundam grade ID",
-                        "name": "grade_id",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Gundam series name",
-                        "name": "series",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Total number of parts",
-                        "name": "parts_total",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "Gundam material",
-                        "name": "material",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Gundam version",
-                        "name": "version",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Condition of the Gundam",
-                        "name": "condition",
-                        "in": "body",
-                        "schema": {
-                            "type": "string",
-                            "enum": [
-                                "new",
-                                "open box",
-                                "used"
-                            ]
-                        }
-                    },
-                    {
-                        "description": "Additional details about condition",
-                        "name": "condition_description",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Manufacturer name",
-                        "name": "manufacturer",
+                        "description": "User creation request",
+                        "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.createUserRequest"
                         }
-                    },
-                    {
-                        "description": "Weight in grams",
-                        "name": "weight",
-                        "in": "body",
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created user",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/db.User"
                         }
                     },
-                    {
-                        "description": "Gundam scale",
-                        "name": "scale",
-                        "in": "body",
-                        "schema": {
-                            "type": "string",
-                            "enum": [
-                                "1/144",
-                                "1/100",
-                                "1/60",
-                                "1/48"
-                            ]
-                        }
+                    "400": {
+                        "description": "Invalid request body"
                     },
-                    {
-                        "description": "Detailed description",
-                        "name": "description",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
+                    "409": {
+                        "description": "Email already exists"
                     },
-                    {
-                        "description": "Price in VND",
-                        "name": "price",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
+                    "422": {
+                        "description": "Validation error"
                     },
-                    {
-                        "description": "Release year",
-                        "name": "release_year",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully updated Gundam",
-                        "schema": {
-                            "$ref": "#/definitions/db.GundamDetails"
-                        }
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/:id/gundams/:gundamID/accessories": {
-            "put": {
+        "/users/:id/gundams": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Update the accessories of a Gundam model",
+                "description": "Get all gundams that belong to the specified user ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2956,9 +3876,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "gundams"
+                    "users"
                 ],
-                "summary": "Update Gundam accessories",
+                "summary": "List all gundams for a specific user",
                 "parameters": [
                     {
                         "type": "string",
@@ -2969,42 +3889,30 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Array of Gundam accessories",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.GundamAccessoryDTO"
-                            }
-                        }
+                        "description": "Gundam name to filter by",
+                        "name": "name",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated Gundam details",
+                        "description": "Successfully retrieved list of gundams",
                         "schema": {
-                            "$ref": "#/definitions/db.GundamDetails"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GundamDetails"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/users/:id/gundams/:gundamID/images": {
+            },
             "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Add secondary images to a Gundam model",
+                "description": "Create a new Gundam model with images and accessories",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -3012,9 +3920,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "gundams"
+                    "users"
                 ],
-                "summary": "Add secondary images to Gundam",
+                "summary": "Create a new Gundam model",
                 "parameters": [
                     {
                         "type": "string",
@@ -3024,119 +3932,141 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Model kit the Gundam is a listing of. Empty name, grade_id, scale, series, manufacturer, version and release_year are prefilled from the kit",
+                        "name": "model_kit_id",
+                        "in": "formData"
                     },
                     {
-                        "type": "file",
-                        "description": "Array of secondary images",
-                        "name": "images",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully added secondary images"
-                    }
-                }
-            },
-            "delete": {
-                "security": [
+                        "type": "string",
+                        "description": "Gundam name (required without model_kit_id)",
+                        "name": "name",
+                        "in": "formData"
+                    },
                     {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Delete a secondary image from a Gundam model",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "gundams"
-                ],
-                "summary": "Delete secondary image from Gundam",
-                "parameters": [
+                        "type": "integer",
+                        "description": "Gundam grade ID (required without model_kit_id)",
+                        "name": "grade_id",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Gundam series name (required without model_kit_id)",
+                        "name": "series",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Total number of parts",
+                        "name": "parts_total",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
+                        "description": "Gundam material",
+                        "name": "material",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "URL of the secondary image to delete",
-                        "name": "image_url",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "Successfully deleted secondary image"
-                    }
-                }
-            }
-        },
-        "/users/:id/gundams/:gundamID/primary-image": {
-            "patch": {
-                "security": [
+                        "type": "string",
+                        "description": "Gundam version (required unless the model kit has one)",
+                        "name": "version",
+                        "in": "formData"
+                    },
                     {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Update the primary image of a Gundam model",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "gundams"
-                ],
-                "summary": "Update Gundam primary image",
-                "parameters": [
+                        "enum": [
+                            "new",
+                            "open box",
+                            "used"
+                        ],
+                        "type": "string",
+                        "description": "Condition of the Gundam",
+                        "name": "condition",
+                        "in": "formData",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Manufacturer name (required without model_kit_id)",
+                        "name": "manufacturer",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "1/144",
+                            "1/100",
+                            "1/60",
+                            "1/48"
+                        ],
+                        "type": "string",
+                        "description": "Gundam scale (required without model_kit_id)",
+                        "name": "scale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Weight in grams",
+                        "name": "weight",
+                        "in": "formData",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Number of units in stock, e.g. several sealed kits sold under one listing (default: 1)",
+                        "name": "quantity",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "description": "Gundam ID",
-                        "name": "gundamID",
-                        "in": "path",
+                        "description": "Detailed description",
+                        "name": "description",
+                        "in": "formData",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "Price in VND",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Release year",
+                        "name": "release_year",
+                        "in": "formData"
+                    },
                     {
                         "type": "file",
-                        "description": "New primary image of the Gundam",
+                        "description": "Primary image of the Gundam",
                         "name": "primary_image",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Secondary images of the Gundam",
+                        "name": "secondary_images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Additional details about condition",
+                        "name": "condition_description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Accessory as JSON object. Add multiple accessories by repeating this field with different values.",
+                        "name": "accessory",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully updated Gundam details",
+                    "201": {
+                        "description": "Successfully created Gundam",
                         "schema": {
                             "$ref": "#/definitions/db.GundamDetails"
                         }
@@ -3145,35 +4075,32 @@ const docTemplate = `{
                         "description": "Bad Request - Invalid input data"
                     },
                     "403": {
-                        "description": "Forbidden - User is not authorized to update Gundam for this user"
+                        "description": "Forbidden - User is not authorized to create Gundam for this user"
                     },
                     "404": {
-                        "description": "Not Found - User or Gundam with specified ID does not exist"
+                        "description": "Not Found - User or model kit with specified ID does not exist"
                     },
                     "500": {
-                        "description": "Internal Server Error - Failed to update Gundam primary image"
+                        "description": "Internal Server Error - Failed to create Gundam"
                     }
                 }
             }
         },
-        "/users/:id/wallet": {
+        "/users/:id/gundams/:gundamID": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get user wallet information details",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves detailed information about a specific Gundam model",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "gundams"
                 ],
-                "summary": "Get user wallet information details",
+                "summary": "Get Gundam details",
                 "parameters": [
                     {
                         "type": "string",
@@ -3181,246 +4108,344 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User wallet information",
+                        "description": "Successfully retrieved Gundam details",
                         "schema": {
-                            "$ref": "#/definitions/db.Wallet"
+                            "$ref": "#/definitions/db.GundamDetails"
                         }
-                    },
-                    "400": {
-                        "description": "Bad request"
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/users/become-seller": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Upgrade the user's role to seller and create the trial subscription",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Become a seller",
-                "responses": {
-                    "200": {
-                        "description": "Successfully became seller",
-                        "schema": {
-                            "$ref": "#/definitions/db.User"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "409": {
-                        "description": "User is already a seller"
-                    },
-                    "500": {
-                        "description": "Internal server error"
-                    }
-                }
-            }
-        },
-        "/users/by-phone": {
-            "get": {
-                "description": "Get user details using a phone_number number as a query parameter",
+                "description": "Hard delete a Gundam model by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "gundams"
                 ],
-                "summary": "Retrieve a user by phone_number number",
+                "summary": "Hard delete Gundam",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Phone Number",
-                        "name": "phone_number",
-                        "in": "query",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved user",
-                        "schema": {
-                            "$ref": "#/definitions/db.User"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
+                    "204": {
+                        "description": "Successfully deleted Gundam"
                     }
                 }
-            }
-        },
-        "/users/me/auctions": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Retrieves a list of auctions the user has participated in. Each auction includes the auction information and the user's participation information.",
+                "description": "Update the basic information of a Gundam model. A published Gundam can only change its price and quantity.\nThe quantity cannot be lower than the units reserved for pending orders.\nPrice changes are recorded in the price history, and users watching a published Gundam are notified when its price drops.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "gundams"
                 ],
-                "summary": "List user participated auctions",
-                "responses": {
-                    "200": {
-                        "description": "List of participated auctions",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.ListUserParticipatedAuctionsRow"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/users/me/auctions/:auctionID/bids": {
-            "get": {
-                "security": [
+                "summary": "Update Gundam basis info",
+                "parameters": [
                     {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Retrieves a list of bids made by the user in a participated auction.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auctions"
-                ],
-                "summary": "List user bids",
-                "parameters": [
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "Auction ID",
-                        "name": "auctionID",
-                        "in": "query",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Gundam name",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Gundam grade ID",
+                        "name": "grade_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Gundam series name",
+                        "name": "series",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Total number of parts",
+                        "name": "parts_total",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Gundam material",
+                        "name": "material",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Gundam version",
+                        "name": "version",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Condition of the Gundam",
+                        "name": "condition",
+                        "in": "body",
+                        "schema": {
+                            "type": "string",
+                            "enum": [
+                                "new",
+                                "open box",
+                                "used"
+                            ]
+                        }
+                    },
+                    {
+                        "description": "Additional details about condition",
+                        "name": "condition_description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Manufacturer name",
+                        "name": "manufacturer",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Weight in grams",
+                        "name": "weight",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Number of units in stock",
+                        "name": "quantity",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Gundam scale",
+                        "name": "scale",
+                        "in": "body",
+                        "schema": {
+                            "type": "string",
+                            "enum": [
+                                "1/144",
+                                "1/100",
+                                "1/60",
+                                "1/48"
+                            ]
+                        }
+                    },
+                    {
+                        "description": "Detailed description",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Price in VND",
+                        "name": "price",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Release year",
+                        "name": "release_year",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of user bids",
+                        "description": "Successfully updated Gundam",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.AuctionBid"
-                            }
+                            "$ref": "#/definitions/db.GundamDetails"
                         }
                     }
                 }
             }
         },
-        "/users/me/auctions/{auctionID}/bids": {
-            "post": {
+        "/users/:id/gundams/:gundamID/accessories": {
+            "put": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "User places a bid in an active auction they have participated in.",
+                "description": "Update the accessories of a Gundam model",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "gundams"
                 ],
-                "summary": "Place a bid in an auction",
+                "summary": "Update Gundam accessories",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Auction ID",
-                        "name": "auctionID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request body containing bid amount",
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Array of Gundam accessories",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.placeBidRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.GundamAccessoryDTO"
+                            }
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful bid placement"
+                        "description": "Successfully updated Gundam details",
+                        "schema": {
+                            "$ref": "#/definitions/db.GundamDetails"
+                        }
                     }
                 }
             }
         },
-        "/users/me/auctions/{auctionID}/participate": {
+        "/users/:id/gundams/:gundamID/images": {
             "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "User deposits money to participate in an active auction.",
+                "description": "Add secondary images to a Gundam model",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "gundams"
                 ],
-                "summary": "Participate in an auction",
+                "summary": "Add secondary images to Gundam",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Auction ID",
-                        "name": "auctionID",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Array of secondary images",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Participation result",
-                        "schema": {
-                            "$ref": "#/definitions/db.ParticipateInAuctionTxResult"
-                        }
+                        "description": "Successfully added secondary images"
                     }
                 }
-            }
-        },
-        "/users/me/auctions/{auctionID}/payment": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Pay the remaining amount after deposit for a winning auction.",
+                "description": "Delete a secondary image from a Gundam model",
                 "consumes": [
                     "application/json"
                 ],
@@ -3428,71 +4453,112 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auctions"
+                    "gundams"
                 ],
-                "summary": "Pay for winning auction bid",
+                "summary": "Delete secondary image from Gundam",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Auction ID",
-                        "name": "auctionID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Payment request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.payAuctionWinningBidRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Payment result",
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "URL of the secondary image to delete",
+                        "name": "image_url",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/db.PayAuctionWinningBidTxResult"
+                            "type": "string"
                         }
                     }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted secondary image"
+                    }
                 }
             }
         },
-        "/users/me/bank-accounts": {
-            "get": {
+        "/users/:id/gundams/:gundamID/primary-image": {
+            "patch": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get all bank accounts for the authenticated user (for withdrawals)",
+                "description": "Update the primary image of a Gundam model",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "gundams"
+                ],
+                "summary": "Update Gundam primary image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "New primary image of the Gundam",
+                        "name": "primary_image",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "List user bank accounts",
                 "responses": {
                     "200": {
-                        "description": "List of user bank accounts",
+                        "description": "Successfully updated Gundam details",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.UserBankAccount"
-                            }
+                            "$ref": "#/definitions/db.GundamDetails"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "403": {
+                        "description": "Forbidden - User is not authorized to update Gundam for this user"
+                    },
+                    "404": {
+                        "description": "Not Found - User or Gundam with specified ID does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update Gundam primary image"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/users/:id/wallet": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Add a new bank account for the authenticated user (for withdrawals)",
+                "description": "Get user wallet information details",
                 "consumes": [
                     "application/json"
                 ],
@@ -3502,96 +4568,115 @@ const docTemplate = `{
                 "tags": [
                     "wallet"
                 ],
-                "summary": "Add bank account",
+                "summary": "Get user wallet information details",
                 "parameters": [
                     {
-                        "description": "Bank account information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.addBankAccountRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully added bank account",
+                    "200": {
+                        "description": "User wallet information",
                         "schema": {
-                            "$ref": "#/definitions/db.UserBankAccount"
+                            "$ref": "#/definitions/db.Wallet"
                         }
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/me/bank-accounts/{accountID}": {
-            "delete": {
+        "/users/become-seller": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Delete a bank account for the authenticated user (for withdrawals)",
+                "description": "Upgrade the user's role to seller and create the trial subscription.\nExisting access tokens are revoked, the client must call /auth/refresh to get an access token with the seller role.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
-                ],
-                "summary": "Delete user bank account",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bank account ID",
-                        "name": "accountID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "users"
                 ],
+                "summary": "Become a seller",
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted bank account",
+                        "description": "Successfully became seller",
                         "schema": {
-                            "$ref": "#/definitions/db.UserBankAccount"
+                            "$ref": "#/definitions/db.User"
                         }
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "409": {
+                        "description": "User is already a seller"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/me/exchange-offers": {
+        "/users/by-phone": {
             "get": {
-                "security": [
-                    {
-                        "accessToken": []
-                    }
-                ],
-                "description": "Get a list of all exchange offers created by the authenticated user, including details about the exchange posts, items, and negotiation notes.",
+                "description": "Get user details using a phone_number number as a query parameter",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
+                ],
+                "summary": "Retrieve a user by phone_number number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone Number",
+                        "name": "phone_number",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "List user's exchange offers",
                 "responses": {
                     "200": {
-                        "description": "List of user's exchange offers",
+                        "description": "Successfully retrieved user",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.UserExchangeOfferDetails"
-                            }
+                            "$ref": "#/definitions/db.User"
                         }
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/users/me": {
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Create a new exchange offer for trading multiple Gundams between users with optional compensation.\n\n**Business Rules:**\n- Không thể tạo offer cho bài đăng của chính mình\n- Mỗi user chỉ có 1 offer cho mỗi bài đăng\n- Gundam của chủ bài đăng phải có status \"for exchange\"\n- Gundam của người đề xuất phải có status \"in store\" (sẽ được chuyển thành \"for exchange\" sau khi tạo offer)\n- Nếu có compensation, người trả phải có đủ số dư (chỉ kiểm tra nếu người đề xuất là người trả)\n- Compensation chỉ được trừ tiền khi offer được chấp nhận, không trừ ngay",
+                "description": "Permanently deletes the account of the current user.\nPersonal data (name, email, phone number, avatar, addresses, bank accounts) is anonymized or removed,\npublished gundams are unpublished and every session is revoked. Orders and wallet entries are kept for accounting.\nThe request is refused while the user has open orders, exchanges, exchange posts or offers, ongoing auctions,\npending withdrawal requests or a non-zero wallet balance. Use GET /users/me/export first to download a copy of the data.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3599,118 +4684,131 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
                 ],
-                "summary": "Create an exchange offer",
+                "summary": "Delete the current user's account",
                 "parameters": [
                     {
-                        "description": "Create exchange offer request",
+                        "description": "Delete account request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createExchangeOfferRequest"
+                            "$ref": "#/definitions/api.deleteUserAccountRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Exchange offer created successfully",
+                    "200": {
+                        "description": "Account deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "401": {
+                        "description": "Incorrect current password"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "409": {
+                        "description": "Account has unfinished activities",
                         "schema": {
-                            "$ref": "#/definitions/db.CreateExchangeOfferTxResult"
+                            "$ref": "#/definitions/api.deleteUserAccountBlockedResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/me/exchange-offers/{offerID}": {
+        "/users/me/2fa": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Retrieves detailed information about a specific exchange offer created by the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
-                ],
-                "summary": "Get user's exchange offer details",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Exchange Offer ID",
-                        "name": "offerID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "users"
                 ],
+                "summary": "Get two-factor authentication status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/db.UserExchangeOfferDetails"
+                            "$ref": "#/definitions/api.twoFactorStatusResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid offer ID",
-                        "schema": {}
-                    },
-                    "403": {
-                        "description": "Unauthorized access",
-                        "schema": {}
-                    },
-                    "404": {
-                        "description": "Offer not found",
-                        "schema": {}
-                    },
                     "500": {
-                        "description": "Internal server error",
-                        "schema": {}
+                        "description": "Internal server error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/users/me/2fa/recovery-codes": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Delete an exchange offer created by the authenticated user.",
+                "description": "Replaces all recovery codes with a new set. Previous recovery codes stop working immediately.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
                 ],
-                "summary": "Delete an exchange offer",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Exchange Offer ID",
-                        "name": "offerID",
-                        "in": "path",
-                        "required": true
+                        "description": "Current TOTP code or a recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.twoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deleted offer response",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/db.ExchangeOfferInfo"
+                            "$ref": "#/definitions/api.regenerateRecoveryCodesResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body or two-factor authentication not enabled"
+                    },
+                    "401": {
+                        "description": "Invalid code"
+                    },
+                    "429": {
+                        "description": "Too many attempts"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/users/me/2fa/totp": {
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "As an offerer, update exchange offer details. Only allowed when a negotiation is requested by the post owner.",
+                "description": "Disables TOTP and deletes all recovery codes. Not allowed for moderator and admin accounts.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3718,241 +4816,277 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
                 ],
-                "summary": "Update an exchange offer",
+                "summary": "Disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Exchange Offer OfferID",
-                        "name": "offerID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update offer request",
+                        "description": "Current TOTP code or a recovery code",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.updateExchangeOfferRequest"
+                            "$ref": "#/definitions/api.twoFactorCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated offer response",
-                        "schema": {
-                            "$ref": "#/definitions/db.UpdateExchangeOfferTxResult"
-                        }
+                    "204": {
+                        "description": "Two-factor authentication disabled"
+                    },
+                    "400": {
+                        "description": "Invalid request body or two-factor authentication not enabled"
+                    },
+                    "401": {
+                        "description": "Invalid code"
+                    },
+                    "403": {
+                        "description": "Two-factor authentication is mandatory for this role"
+                    },
+                    "429": {
+                        "description": "Too many attempts"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/me/exchange-posts": {
-            "get": {
+        "/users/me/2fa/totp/enable": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "List all exchange posts created by the current authenticated user.",
+                "description": "Activates two-factor authentication with the first code from the authenticator app.\nReturns one-time recovery codes, which are only shown once, and a new token pair marked as two-factor authenticated.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
                 ],
-                "summary": "List user's exchange posts",
+                "summary": "Confirm TOTP enrollment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by status (open, closed)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.enableTOTPRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of user's exchange posts",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.UserExchangePostDetails"
-                            }
+                            "$ref": "#/definitions/api.enableTOTPResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid request body or enrollment not started"
+                    },
+                    "401": {
+                        "description": "Invalid code"
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled"
+                    },
+                    "429": {
+                        "description": "Too many attempts"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/users/me/2fa/totp/setup": {
             "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Create a new exchange post.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Generates a new TOTP secret and returns it with an otpauth:// provisioning URI to be rendered as a QR code.\nThe secret is not active until it is confirmed with /users/me/2fa/totp/enable.\nCalling this again before confirming replaces the pending secret.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "users"
                 ],
-                "summary": "Create a new exchange post",
-                "parameters": [
-                    {
-                        "description": "Create exchange post request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "Start TOTP enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.createExchangePostRequest"
+                            "$ref": "#/definitions/api.setupTOTPResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/me/auctions": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
                     }
                 ],
+                "description": "Retrieves a list of auctions the user has participated in. Each auction includes the auction information and the user's participation information.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auctions"
+                ],
+                "summary": "List user participated auctions",
                 "responses": {
-                    "201": {
-                        "description": "Create exchange post response",
+                    "200": {
+                        "description": "List of participated auctions",
                         "schema": {
-                            "$ref": "#/definitions/db.CreateExchangePostTxResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListUserParticipatedAuctionsRow"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/me/exchange-posts/{id}": {
-            "delete": {
+        "/users/me/auctions/:auctionID/bids": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Deletes an exchange post and resets the status of associated gundams. Only the post owner can delete it.",
+                "description": "Retrieves a list of bids made by the user in a participated auction.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "auctions"
                 ],
-                "summary": "Delete an exchange post",
+                "summary": "List user bids",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Exchange Post ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Auction ID",
+                        "name": "auctionID",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Delete exchange post response",
+                        "description": "List of user bids",
                         "schema": {
-                            "$ref": "#/definitions/db.DeleteExchangePostTxResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.AuctionBid"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/me/exchange-posts/{postID}": {
-            "get": {
+        "/users/me/auctions/{auctionID}/bids": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Get detailed information about a specific exchange post owned by the authenticated user, including items and offers.",
+                "description": "User places a bid in an active auction they have participated in.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "auctions"
                 ],
-                "summary": "Get user's exchange post details",
+                "summary": "Place a bid in an auction",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Exchange Post ID",
-                        "name": "postID",
+                        "description": "Auction ID",
+                        "name": "auctionID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by status (open, closed)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Request body containing bid amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.placeBidRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User exchange post details",
-                        "schema": {
-                            "$ref": "#/definitions/db.UserExchangePostDetails"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid post ID or status",
-                        "schema": {}
+                        "description": "Successful bid placement"
                     },
-                    "404": {
-                        "description": "Post not found",
-                        "schema": {}
+                    "429": {
+                        "description": "Too many requests"
                     }
                 }
             }
         },
-        "/users/me/exchange-posts/{postID}/offers/{offerID}/accept": {
-            "patch": {
+        "/users/me/auctions/{auctionID}/participate": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "As a post owner, accept an exchange offer. This will create an exchange transaction and related orders.",
+                "description": "User deposits money to participate in an active auction.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "auctions"
                 ],
-                "summary": "Accept an exchange offer",
+                "summary": "Participate in an auction",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Exchange Post ID",
-                        "name": "postID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Exchange Offer ID",
-                        "name": "offerID",
+                        "description": "Auction ID",
+                        "name": "auctionID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Accepted offer response",
+                        "description": "Participation result",
                         "schema": {
-                            "$ref": "#/definitions/db.AcceptExchangeOfferTxResult"
+                            "$ref": "#/definitions/db.ParticipateInAuctionTxResult"
                         }
                     }
                 }
             }
         },
-        "/users/me/exchange-posts/{postID}/offers/{offerID}/negotiate": {
-            "patch": {
+        "/users/me/auctions/{auctionID}/payment": {
+            "post": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "As a post owner, request negotiation with an offerer.",
+                "description": "Pay the remaining amount after deposit for a winning auction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3960,51 +5094,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exchanges"
+                    "auctions"
                 ],
-                "summary": "Request negotiation for an exchange offer",
+                "summary": "Pay for winning auction bid",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Exchange Post ID",
-                        "name": "postID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Exchange Offer ID",
-                        "name": "offerID",
+                        "description": "Auction ID",
+                        "name": "auctionID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Negotiation request",
+                        "description": "Payment request",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.requestNegotiationForOfferRequest"
+                            "$ref": "#/definitions/api.payAuctionWinningBidRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Negotiation request response",
+                        "description": "Payment result",
                         "schema": {
-                            "$ref": "#/definitions/db.RequestNegotiationForOfferTxResult"
+                            "$ref": "#/definitions/db.PayAuctionWinningBidTxResult"
                         }
                     }
                 }
             }
         },
-        "/users/me/wallet/entries": {
+        "/users/me/bank-accounts": {
             "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "List user wallet entries",
+                "description": "Get all bank accounts for the authenticated user (for withdrawals)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "List user bank accounts",
+                "responses": {
+                    "200": {
+                        "description": "List of user bank accounts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.UserBankAccount"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Add a new bank account for the authenticated user (for withdrawals)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4014,65 +5168,84 @@ const docTemplate = `{
                 "tags": [
                     "wallet"
                 ],
-                "summary": "List user wallet entries",
+                "summary": "Add bank account",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter by wallet entry status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Bank account information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.addBankAccountRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of wallet entries",
+                    "201": {
+                        "description": "Successfully added bank account",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.WalletEntry"
-                            }
+                            "$ref": "#/definitions/db.UserBankAccount"
                         }
                     }
                 }
             }
         },
-        "/users/me/wallet/withdrawal-requests": {
-            "get": {
+        "/users/me/bank-accounts/{accountID}": {
+            "delete": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Retrieve all withdrawal requests for the authenticated user",
+                "description": "Delete a bank account for the authenticated user (for withdrawals)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "wallet"
                 ],
-                "summary": "List user withdrawal requests",
+                "summary": "Delete user bank account",
                 "parameters": [
                     {
-                        "enum": [
-                            "pending",
-                            "approved",
-                            "completed",
-                            "rejected",
-                            "canceled"
-                        ],
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Bank account ID",
+                        "name": "accountID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of withdrawal requests",
+                        "description": "Successfully deleted bank account",
+                        "schema": {
+                            "$ref": "#/definitions/db.UserBankAccount"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/exchange-offers": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Get a list of all exchange offers created by the authenticated user, including details about the exchange posts, items, and negotiation notes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchanges"
+                ],
+                "summary": "List user's exchange offers",
+                "responses": {
+                    "200": {
+                        "description": "List of user's exchange offers",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                                "$ref": "#/definitions/db.UserExchangeOfferDetails"
                             }
                         }
                     }
@@ -4084,7 +5257,7 @@ const docTemplate = `{
                         "accessToken": []
                     }
                 ],
-                "description": "Create a new withdrawal request to transfer money from user's wallet to their bank account\n\n**Quy định nghiệp vụ:**\n- Người dùng phải có đủ số dư trong ví (số tiền sẽ bị trừ ngay lập tức)\n- Tài khoản ngân hàng phải thuộc về người dùng đã xác thực\n- Số tiền rút phải trong khoảng từ 50,000 VNĐ đến 5,000,000 VNĐ mỗi lần\n- Yêu cầu rút tiền được xử lý thủ công bởi moderator trong vòng 24 giờ (ngày làm việc)\n- Sau khi tạo, yêu cầu rút tiền không thể hủy bởi người dùng\n\n**Quy trình xử lý:**\n1. Hệ thống kiểm tra yêu cầu và số dư ví\n2. Tiền được trừ ngay lập tức khỏi ví người dùng\n3. Yêu cầu rút tiền được tạo với trạng thái \"đang xử lý\"\n4. ModeratorID xem xét và xử lý yêu cầu thủ công\n5. Trạng thái cập nhật thành \"hoàn thành\" sau khi chuyển tiền\n\n**Các trường hợp lỗi:**\n- 400: Dữ liệu yêu cầu không hợp lệ hoặc không đủ số dư\n- 404: Không tìm thấy tài khoản ngân hàng hoặc không thuộc về người dùng\n- 422: Vi phạm quy tắc nghiệp vụ (giới hạn số tiền, v.v.)",
+                "description": "Create a new exchange offer for trading multiple Gundams between users with optional compensation.\n\n**Business Rules:**\n- Không thể tạo offer cho bài đăng của chính mình\n- Mỗi user chỉ có 1 offer cho mỗi bài đăng\n- Gundam của chủ bài đăng phải có status \"for exchange\"\n- Gundam của người đề xuất phải có status \"in store\" (sẽ được chuyển thành \"for exchange\" sau khi tạo offer)\n- Nếu có compensation, người trả phải có đủ số dư (chỉ kiểm tra nếu người đề xuất là người trả)\n- Compensation chỉ được trừ tiền khi offer được chấp nhận, không trừ ngay",
                 "consumes": [
                     "application/json"
                 ],
@@ -4092,50 +5265,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "exchanges"
                 ],
-                "summary": "Create withdrawal request",
+                "summary": "Create an exchange offer",
                 "parameters": [
                     {
-                        "description": "Withdrawal request details",
+                        "description": "Create exchange offer request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createWithdrawalRequestBody"
+                            "$ref": "#/definitions/api.createExchangeOfferRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Withdrawal request created successfully",
+                        "description": "Exchange offer created successfully",
                         "schema": {
-                            "$ref": "#/definitions/db.WithdrawalRequest"
+                            "$ref": "#/definitions/db.CreateExchangeOfferTxResult"
                         }
+                    },
+                    "429": {
+                        "description": "Too many requests"
                     }
                 }
             }
         },
-        "/users/me/wallet/withdrawal-requests/{requestID}/cancel": {
-            "patch": {
+        "/users/me/exchange-offers/{offerID}": {
+            "get": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Cancel a pending withdrawal request and refund the amount back to user's wallet\n\n**Quy định nghiệp vụ:**\n- Chỉ có thể hủy những yêu cầu rút tiền đang ở trạng thái chờ xử lý\n- Chỉ người tạo yêu cầu mới có thể hủy yêu cầu của mình\n- Số tiền sẽ được hoàn trả ngay lập tức vào ví của người dùng\n\n**Các trường hợp lỗi:**\n- 400: ID yêu cầu không đúng định dạng\n- 403: Yêu cầu không thuộc về người dùng\n- 404: Không tìm thấy yêu cầu\n- 422: Không thể hủy yêu cầu (không ở trạng thái chờ xử lý)",
+                "description": "Retrieves detailed information about a specific exchange offer created by the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "exchanges"
                 ],
-                "summary": "Cancel withdrawal request",
+                "summary": "Get user's exchange offer details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Withdrawal request ID",
-                        "name": "requestID",
+                        "description": "Exchange Offer ID",
+                        "name": "offerID",
                         "in": "path",
                         "required": true
                     }
@@ -4144,48 +5320,66 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                            "$ref": "#/definitions/db.UserExchangeOfferDetails"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid offer ID",
+                        "schema": {}
+                    },
+                    "403": {
+                        "description": "Unauthorized access",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {}
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
-                "description": "Get detailed information about a specific user",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Delete an exchange offer created by the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Retrieve a user by ID",
+                "summary": "Delete an exchange offer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Exchange Offer ID",
+                        "name": "offerID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved user",
+                        "description": "Deleted offer response",
                         "schema": {
-                            "$ref": "#/definitions/db.User"
+                            "$ref": "#/definitions/db.ExchangeOfferInfo"
                         }
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             },
-            "put": {
-                "description": "Update specific user details by user ID",
+            "patch": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "As an offerer, update exchange offer details. Only allowed when a negotiation is requested by the post owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4193,139 +5387,129 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Update a user's information",
+                "summary": "Update an exchange offer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Exchange Offer OfferID",
+                        "name": "offerID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User update request",
+                        "description": "Update offer request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.updateUserRequest"
+                            "$ref": "#/definitions/api.updateExchangeOfferRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated user",
+                        "description": "Updated offer response",
                         "schema": {
-                            "$ref": "#/definitions/db.User"
+                            "$ref": "#/definitions/db.UpdateExchangeOfferTxResult"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request body"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/{id}/addresses": {
+        "/users/me/exchange-posts": {
             "get": {
-                "description": "Get all addresses for a specific user",
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List all exchange posts created by the current authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Retrieve user addresses",
+                "summary": "List user's exchange posts",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status (open, closed)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved user addresses",
+                        "description": "List of user's exchange posts",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.UserAddress"
+                                "$ref": "#/definitions/db.UserExchangePostDetails"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             },
             "post": {
-                "description": "Add a new address for a specific user",
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Create a new exchange post.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Create a new user address",
+                "summary": "Create a new exchange post",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Address creation request",
+                        "description": "Create exchange post request",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createUserAddressRequest"
+                            "$ref": "#/definitions/api.createExchangePostRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Address created successfully",
+                        "description": "Create exchange post response",
                         "schema": {
-                            "$ref": "#/definitions/db.UserAddress"
+                            "$ref": "#/definitions/db.CreateExchangePostTxResult"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request body"
-                    },
-                    "404": {
-                        "description": "User not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/{id}/addresses/pickup": {
-            "get": {
-                "description": "Get the pickup address of a specific user",
+        "/users/me/exchange-posts/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Deletes an exchange post and resets the status of associated gundams. Only the post owner can delete it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Get user pickup address",
+                "summary": "Delete an exchange post",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Exchange Post ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4333,146 +5517,176 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved user pickup address",
+                        "description": "Delete exchange post response",
                         "schema": {
-                            "$ref": "#/definitions/db.UserAddress"
+                            "$ref": "#/definitions/db.DeleteExchangePostTxResult"
                         }
-                    },
-                    "404": {
-                        "description": "Pickup address not found"
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/users/{id}/addresses/{address_id}": {
-            "put": {
-                "description": "Update an existing address information for a specific user",
-                "consumes": [
-                    "application/json"
+        "/users/me/exchange-posts/{postID}": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
                 ],
+                "description": "Get detailed information about a specific exchange post owned by the authenticated user, including items and offers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Update user address",
+                "summary": "Get user's exchange post details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Address ID",
-                        "name": "address_id",
+                        "description": "Exchange Post ID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Address information to update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.updateUserAddressRequest"
-                        }
+                        "type": "string",
+                        "description": "Filter by status (open, closed)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Address updated successfully",
+                        "description": "User exchange post details",
                         "schema": {
-                            "$ref": "#/definitions/db.UserAddress"
+                            "$ref": "#/definitions/db.UserExchangePostDetails"
                         }
+                    },
+                    "400": {
+                        "description": "Invalid post ID or status",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {}
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an address of a user",
+            }
+        },
+        "/users/me/exchange-posts/{postID}/offers/{offerID}/accept": {
+            "patch": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "As a post owner, accept an exchange offer. This will create an exchange transaction and related orders.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Delete user address",
+                "summary": "Accept an exchange offer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Exchange Post ID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Address ID",
-                        "name": "address_id",
+                        "type": "string",
+                        "description": "Exchange Offer ID",
+                        "name": "offerID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Address deleted successfully"
-                    },
-                    "400": {
-                        "description": "Invalid request"
-                    },
-                    "404": {
-                        "description": "Address not found"
-                    },
-                    "409": {
-                        "description": "Cannot delete primary or pickup address"
-                    },
-                    "500": {
-                        "description": "Internal server error"
+                    "200": {
+                        "description": "Accepted offer response",
+                        "schema": {
+                            "$ref": "#/definitions/db.AcceptExchangeOfferTxResult"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}/avatar": {
+        "/users/me/exchange-posts/{postID}/offers/{offerID}/negotiate": {
             "patch": {
-                "description": "Upload and update a user's profile avatar",
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "As a post owner, request negotiation with an offerer.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exchanges"
                 ],
-                "summary": "Update user avatar",
+                "summary": "Request negotiation for an exchange offer",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Exchange Post ID",
+                        "name": "postID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Avatar image file",
-                        "name": "avatar",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Exchange Offer ID",
+                        "name": "offerID",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Negotiation request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.requestNegotiationForOfferRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated avatar",
+                        "description": "Negotiation request response",
                         "schema": {
-                            "$ref": "#/definitions/api.updateAvatarResponse"
+                            "$ref": "#/definitions/db.RequestNegotiationForOfferTxResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Returns a JSON archive with the profile, addresses, bank accounts, orders, wallet entries and gundams of the current user.\nThe response is sent as a file download.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export the current user's data",
+                "responses": {
+                    "200": {
+                        "description": "User data archive",
+                        "schema": {
+                            "$ref": "#/definitions/api.userDataExport"
                         }
-                    },
-                    "400": {
-                        "description": "Invalid request"
                     },
                     "404": {
                         "description": "User not found"
@@ -4483,14 +5697,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/wallet/zalopay/create": {
-            "post": {
+        "/users/me/password": {
+            "put": {
                 "security": [
                     {
                         "accessToken": []
                     }
                 ],
-                "description": "Create a ZaloPay order",
+                "description": "Changes the password of the current user. current_password is required when the account already has a password.\nAccounts created with Google can omit current_password to set a password for the first time.\nAll other sessions of the user are revoked, the current device receives a new token pair.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4498,2250 +5712,5108 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "wallet"
+                    "users"
                 ],
-                "summary": "Create a ZaloPay order",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "description": "Create ZaloPay order request",
+                        "description": "Change password request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.createZalopayOrderRequest"
+                            "$ref": "#/definitions/api.changePasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Create ZaloPay order response",
+                        "description": "Password changed successfully, new token pair for the current device",
                         "schema": {
-                            "$ref": "#/definitions/zalopay.CreateOrderZalopayResponse"
+                            "$ref": "#/definitions/api.refreshAccessTokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request"
+                        "description": "Invalid request body"
+                    },
+                    "401": {
+                        "description": "Incorrect current password"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "422": {
+                        "description": "Validation error"
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.GenerateEmailOTPRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                }
-            }
         },
-        "api.GenerateEmailOTPResponse": {
-            "type": "object",
-            "required": [
-                "created_at",
-                "email",
-                "expires_at",
-                "otp_code"
-            ],
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
+        "/users/me/saved-searches": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List the saved searches of the authenticated user, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "Saved searches",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.SavedSearch"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to list saved searches"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Save a search so the authenticated user is notified when a matching Gundam is published for sale or scheduled for auction.\nKeyword is matched against the Gundam name (accent-insensitive), max_price against the sale price or the auction starting price.\nNotifications are grouped into at most one digest per user per hour.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Create a saved search",
+                "parameters": [
+                    {
+                        "description": "Saved search filters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.savedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created saved search",
+                        "schema": {
+                            "$ref": "#/definitions/db.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "404": {
+                        "description": "Not Found - Grade does not exist"
+                    },
+                    "409": {
+                        "description": "Conflict - Saved search limit reached"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to create saved search"
+                    }
+                }
+            }
+        },
+        "/users/me/saved-searches/{searchID}": {
+            "put": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Replace the name and filters of a saved search. Filters that are omitted are cleared and match any value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Update a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "searchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Saved search filters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.savedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated saved search",
+                        "schema": {
+                            "$ref": "#/definitions/db.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data"
+                    },
+                    "404": {
+                        "description": "Not Found - Saved search or grade does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to update saved search"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Delete a saved search of the authenticated user.",
+                "tags": [
+                    "saved-searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Saved search ID",
+                        "name": "searchID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Saved search deleted"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid saved search ID"
+                    },
+                    "404": {
+                        "description": "Not Found - Saved search does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to delete saved search"
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Lists the devices where the current user is logged in, most recently active first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.userSessionResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{sessionID}": {
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Logs the current user out of one device. Access tokens and refresh tokens of the session stop working immediately.\nRevoking the current session logs out the current device.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session revoked"
+                    },
+                    "404": {
+                        "description": "Session not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/me/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Returns a short-lived URL for uploading one image directly to the file store, without sending it through the API.\nThe client sends the image with the returned method and headers; the body must have exactly the declared size and SHA-256.\nThe file lands in a staging folder and is never served: call POST /users/me/uploads/finalize to check and store it.\nReturns 501 when the configured file store cannot enforce the size and content of the upload (Cloudinary).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a presigned upload",
+                "parameters": [
+                    {
+                        "description": "Content type, size in bytes and lowercase hex SHA-256 of the image",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.presignUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Presigned upload",
+                        "schema": {
+                            "$ref": "#/definitions/storage.PresignedUpload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters or image is too large"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    },
+                    "501": {
+                        "description": "Not Implemented - The file store does not support presigned uploads"
+                    }
+                }
+            }
+        },
+        "/users/me/uploads/finalize": {
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Downloads an image uploaded through a presigned upload of the current user, checks and re-encodes it\n(file type, size, pixel limit, EXIF removal) and stores it in the folder under a name derived from its content.\nThe staging file is deleted afterwards, also when the image is rejected.\nThe returned URL is not attached to anything yet; images that are not referenced are removed by the image cleanup job.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Finalize a presigned upload",
+                "parameters": [
+                    {
+                        "description": "Key of the presigned upload and destination folder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.finalizeUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stored image",
+                        "schema": {
+                            "$ref": "#/definitions/api.finalizeUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid parameters or invalid image"
+                    },
+                    "403": {
+                        "description": "Forbidden - The key is not a presigned upload of the current user"
+                    },
+                    "404": {
+                        "description": "Not Found - The file was not uploaded"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/users/me/wallet/entries": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List user wallet entries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "List user wallet entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by wallet entry status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default: newest)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of wallet entries",
+                        "schema": {
+                            "$ref": "#/definitions/api.pageResponse-db_WalletEntry"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/wallet/withdrawal-requests": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Retrieve all withdrawal requests for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "List user withdrawal requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "completed",
+                            "rejected",
+                            "canceled"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of withdrawal requests",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Create a new withdrawal request to transfer money from user's wallet to their bank account\n\n**Quy định nghiệp vụ:**\n- Người dùng phải có đủ số dư trong ví (số tiền sẽ bị trừ ngay lập tức)\n- Tài khoản ngân hàng phải thuộc về người dùng đã xác thực\n- Số tiền rút phải trong khoảng từ 50,000 VNĐ đến 5,000,000 VNĐ mỗi lần\n- Yêu cầu rút tiền được xử lý thủ công bởi moderator trong vòng 24 giờ (ngày làm việc)\n- Sau khi tạo, yêu cầu rút tiền không thể hủy bởi người dùng\n\n**Quy trình xử lý:**\n1. Hệ thống kiểm tra yêu cầu và số dư ví\n2. Tiền được trừ ngay lập tức khỏi ví người dùng\n3. Yêu cầu rút tiền được tạo với trạng thái \"đang xử lý\"\n4. ModeratorID xem xét và xử lý yêu cầu thủ công\n5. Trạng thái cập nhật thành \"hoàn thành\" sau khi chuyển tiền\n\n**Các trường hợp lỗi:**\n- 400: Dữ liệu yêu cầu không hợp lệ hoặc không đủ số dư\n- 404: Không tìm thấy tài khoản ngân hàng hoặc không thuộc về người dùng\n- 422: Vi phạm quy tắc nghiệp vụ (giới hạn số tiền, v.v.)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Create withdrawal request",
+                "parameters": [
+                    {
+                        "description": "Withdrawal request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createWithdrawalRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Withdrawal request created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.WithdrawalRequest"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/wallet/withdrawal-requests/{requestID}/cancel": {
+            "patch": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Cancel a pending withdrawal request and refund the amount back to user's wallet\n\n**Quy định nghiệp vụ:**\n- Chỉ có thể hủy những yêu cầu rút tiền đang ở trạng thái chờ xử lý\n- Chỉ người tạo yêu cầu mới có thể hủy yêu cầu của mình\n- Số tiền sẽ được hoàn trả ngay lập tức vào ví của người dùng\n\n**Các trường hợp lỗi:**\n- 400: ID yêu cầu không đúng định dạng\n- 403: Yêu cầu không thuộc về người dùng\n- 404: Không tìm thấy yêu cầu\n- 422: Không thể hủy yêu cầu (không ở trạng thái chờ xử lý)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Cancel withdrawal request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Withdrawal request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/wishlist": {
+            "get": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "List the Gundams in the wishlist of the authenticated user, most recently added first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "List wishlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wishlist items",
+                        "schema": {
+                            "$ref": "#/definitions/api.pageResponse-db_ListWishlistItemsRow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid query parameters"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to list wishlist"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Add a Gundam to the wishlist of the authenticated user. The Gundam does not need to be published:\nthe user is notified when it is published for sale or scheduled for auction. Adding the same Gundam twice is a no-op.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Add a Gundam to wishlist",
+                "parameters": [
+                    {
+                        "description": "Gundam to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.addWishlistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Gundam added to wishlist"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid input data or the Gundam belongs to the user"
+                    },
+                    "404": {
+                        "description": "Not Found - Gundam does not exist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to add Gundam to wishlist"
+                    }
+                }
+            }
+        },
+        "/users/me/wishlist/{gundamID}": {
+            "delete": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Remove a Gundam from the wishlist of the authenticated user.",
+                "tags": [
+                    "wishlist"
+                ],
+                "summary": "Remove a Gundam from wishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gundam ID",
+                        "name": "gundamID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Gundam removed from wishlist"
+                    },
+                    "400": {
+                        "description": "Bad Request - Invalid Gundam ID"
+                    },
+                    "404": {
+                        "description": "Not Found - Gundam is not in the wishlist"
+                    },
+                    "500": {
+                        "description": "Internal Server Error - Failed to remove Gundam from wishlist"
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Retrieve a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved user",
+                        "schema": {
+                            "$ref": "#/definitions/db.User"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "put": {
+                "description": "Update specific user details by user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user's information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated user",
+                        "schema": {
+                            "$ref": "#/definitions/db.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/{id}/addresses": {
+            "get": {
+                "description": "Get all addresses for a specific user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Retrieve user addresses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved user addresses",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.UserAddress"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a new address for a specific user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address creation request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/api.createUserAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Address created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.UserAddress"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/{id}/addresses/pickup": {
+            "get": {
+                "description": "Get the pickup address of a specific user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user pickup address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved user pickup address",
+                        "schema": {
+                            "$ref": "#/definitions/db.UserAddress"
+                        }
+                    },
+                    "404": {
+                        "description": "Pickup address not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/{id}/addresses/{address_id}": {
+            "put": {
+                "description": "Update an existing address information for a specific user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Address ID",
+                        "name": "address_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address information to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updateUserAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/db.UserAddress"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an address of a user",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Address ID",
+                        "name": "address_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Address deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "Address not found"
+                    },
+                    "409": {
+                        "description": "Cannot delete primary or pickup address"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/users/{id}/avatar": {
+            "patch": {
+                "description": "Upload and update a user's profile avatar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Avatar image file",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated avatar",
+                        "schema": {
+                            "$ref": "#/definitions/api.updateAvatarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/wallet/zalopay/create": {
+            "post": {
+                "security": [
+                    {
+                        "accessToken": []
+                    }
+                ],
+                "description": "Create a ZaloPay order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallet"
+                ],
+                "summary": "Create a ZaloPay order",
+                "parameters": [
+                    {
+                        "description": "Create ZaloPay order request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createZalopayOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Create ZaloPay order response",
+                        "schema": {
+                            "$ref": "#/definitions/zalopay.CreateOrderZalopayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.GenerateEmailOTPRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GenerateEmailOTPResponse": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "email",
+                "expires_at"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.GeneratePhoneOTPRequest": {
+            "type": "object",
+            "required": [
+                "phone_number"
+            ],
+            "properties": {
+                "phone_number": {
+                    "description": "Số điện thoại cần gửi OTP",
+                    "type": "string"
+                }
+            }
+        },
+        "api.GeneratePhoneOTPResponse": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "expires_at",
+                "phone_number"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "Thời điểm OTP được tạo",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Thời điểm OTP hết hạn",
+                    "type": "string"
+                },
+                "phone_number": {
+                    "description": "Số điện thoại đã gửi OTP",
+                    "type": "string"
+                }
+            }
+        },
+        "api.SubscriptionDetailsResponse": {
+            "type": "object",
+            "required": [
+                "auctions_remaining",
+                "can_upgrade",
+                "days_remaining",
+                "duration_days",
+                "end_date",
+                "id",
+                "is_active",
+                "is_expiring_soon",
+                "is_unlimited",
+                "listings_remaining",
+                "listings_used",
+                "max_listings",
+                "max_open_auctions",
+                "open_auctions_used",
+                "plan_id",
+                "seller_id",
+                "start_date",
+                "subscription_name",
+                "subscription_price"
+            ],
+            "properties": {
+                "auctions_remaining": {
+                    "description": "AuctionsRemaining là số phiên đấu giá còn có thể mở (null nếu unlimited)",
+                    "type": "integer",
+                    "example": 25
+                },
+                "can_upgrade": {
+                    "description": "CanUpgrade cho biết có thể nâng cấp lên gói cao hơn không",
+                    "type": "boolean",
+                    "example": true
+                },
+                "days_remaining": {
+                    "description": "DaysRemaining là số ngày còn lại của subscription (null nếu vĩnh viễn)",
+                    "type": "integer",
+                    "example": 45
+                },
+                "duration_days": {
+                    "description": "DurationDays là thời hạn của gói (ngày), null nếu vĩnh viễn",
+                    "type": "integer",
+                    "example": 90
+                },
+                "end_date": {
+                    "description": "EndDate là thời gian kết thúc subscription (null nếu vĩnh viễn)",
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "id": {
+                    "description": "ID là ID duy nhất của subscription",
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "description": "Plan details\nIsActive cho biết subscription có đang hoạt động không",
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_expiring_soon": {
+                    "description": "Status indicators\nIsExpiringSoon cho biết subscription có sắp hết hạn không (\u003c 7 ngày)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "is_unlimited": {
+                    "description": "IsUnlimited cho biết có phải gói không giới hạn không",
+                    "type": "boolean",
+                    "example": false
+                },
+                "listings_remaining": {
+                    "description": "ListingsRemaining là số lượt đăng bán còn lại (null nếu unlimited)",
+                    "type": "integer",
+                    "example": 38
+                },
+                "listings_used": {
+                    "description": "ListingsUsed là số lượt đăng bán đã sử dụng",
+                    "type": "integer",
+                    "example": 12
+                },
+                "max_listings": {
+                    "description": "Listing information\nMaxListings là số lượt đăng bán tối đa (null nếu unlimited)",
+                    "type": "integer",
+                    "example": 50
+                },
+                "max_open_auctions": {
+                    "description": "Auction information\nMaxOpenAuctions là số phiên đấu giá tối đa có thể mở cùng lúc (null nếu unlimited)",
+                    "type": "integer",
+                    "example": 30
+                },
+                "open_auctions_used": {
+                    "description": "OpenAuctionsUsed là số phiên đấu giá đang mở",
+                    "type": "integer",
+                    "example": 5
+                },
+                "plan_id": {
+                    "description": "PlanID là ID của gói đăng ký",
+                    "type": "integer",
+                    "example": 2
+                },
+                "seller_id": {
+                    "description": "SellerID là ID của người bán",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "start_date": {
+                    "description": "Time information\nStartDate là thời gian bắt đầu subscription",
+                    "type": "string",
+                    "example": "2023-12-01T10:00:00Z"
+                },
+                "subscription_name": {
+                    "description": "SubscriptionName là tên của gói đăng ký",
+                    "type": "string",
+                    "example": "GÓI NÂNG CẤP"
+                },
+                "subscription_price": {
+                    "description": "SubscriptionPrice là giá của gói đăng ký (VND)",
+                    "type": "integer",
+                    "example": 359000
+                }
+            }
+        },
+        "api.VerifyEmailOTPRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "otp_code"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "otp_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.VerifyPhoneOTPRequest": {
+            "type": "object",
+            "required": [
+                "otp_code",
+                "phone_number",
+                "user_id"
+            ],
+            "properties": {
+                "otp_code": {
+                    "description": "Mã OTP",
+                    "type": "string"
+                },
+                "phone_number": {
+                    "description": "Số điện thoại mới",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "ID của user cần cập nhật số điện thoại",
+                    "type": "string"
+                }
+            }
+        },
+        "api.addBankAccountRequest": {
+            "type": "object",
+            "required": [
+                "account_name",
+                "account_number",
+                "bank_code",
+                "bank_name",
+                "bank_short_name"
+            ],
+            "properties": {
+                "account_name": {
+                    "type": "string"
+                },
+                "account_number": {
+                    "type": "string"
+                },
+                "bank_code": {
+                    "type": "string"
+                },
+                "bank_name": {
+                    "type": "string"
+                },
+                "bank_short_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.addCartItemRequest": {
+            "type": "object",
+            "required": [
+                "gundam_id",
+                "quantity"
+            ],
+            "properties": {
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Number of units to add, added to the quantity already in the cart (default: 1)",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "api.addWishlistItemRequest": {
+            "type": "object",
+            "required": [
+                "gundam_id"
+            ],
+            "properties": {
+                "gundam_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.cancelAuctionRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Lý do hủy đấu giá, có thể để trống",
+                    "type": "string"
+                }
+            }
+        },
+        "api.cancelExchangeRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.cancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.changePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "Bỏ trống nếu tài khoản chưa có mật khẩu (đăng ký bằng Google)",
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.checkEmailRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.checkoutRequest": {
+            "type": "object",
+            "required": [
+                "buyer_address_id",
+                "cart_item_ids",
+                "checkout_id",
+                "expected_delivery_time",
+                "notes",
+                "payment_method"
+            ],
+            "properties": {
+                "buyer_address_id": {
+                    "description": "ID of the buyer's chosen address\nexample: 42",
+                    "type": "integer"
+                },
+                "cart_item_ids": {
+                    "description": "IDs of the cart items to check out, all items in the cart are checked out when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "checkout_id": {
+                    "description": "Client-generated ID of the checkout. Sending the same ID again returns the orders created by the first request instead of creating new ones.\nexample: 0195f0c8-8a3e-7d2b-9c41-3f6b2a1d5e77",
+                    "type": "string"
+                },
+                "expected_delivery_time": {
+                    "description": "Deprecated: ignored, the expected delivery time is quoted by the delivery provider\nexample: 2025-04-05T10:00:00Z",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Optional notes for the orders, keyed by seller ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "payment_method": {
+                    "description": "Payment method (wallet: pay via platform wallet, cod: cash on delivery)\nenums: wallet,cod\nexample: wallet",
+                    "type": "string",
+                    "enum": [
+                        "wallet",
+                        "cod"
+                    ]
+                }
+            }
+        },
+        "api.completeWithdrawalRequestRequest": {
+            "type": "object",
+            "required": [
+                "transaction_reference"
+            ],
+            "properties": {
+                "transaction_reference": {
+                    "description": "Mã giao dịch từ ngân hàng để hoàn tất yêu cầu rút tiền",
+                    "type": "string"
+                }
+            }
+        },
+        "api.createAuctionRequestBody": {
+            "type": "object",
+            "required": [
+                "bid_increment",
+                "buy_now_price",
+                "end_time",
+                "gundam_id",
+                "start_time",
+                "starting_price"
+            ],
+            "properties": {
+                "bid_increment": {
+                    "type": "integer"
+                },
+                "buy_now_price": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "starting_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.createExchangeOfferRequest": {
+            "type": "object",
+            "required": [
+                "compensation_amount",
+                "exchange_post_id",
+                "note",
+                "offerer_gundam_ids",
+                "payer_id",
+                "poster_gundam_ids"
+            ],
+            "properties": {
+                "compensation_amount": {
+                    "description": "Số tiền bù theo VND (bắt buộc nếu có payer_id, phải \u003e 0, chỉ trừ tiền khi offer được chấp nhận)",
+                    "type": "integer",
+                    "example": 50000
+                },
+                "exchange_post_id": {
+                    "description": "UUID của bài đăng trao đổi mà bạn muốn tạo offer",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "note": {
+                    "description": "Tin nhắn gửi kèm cho chủ bài đăng (tùy chọn)",
+                    "type": "string",
+                    "example": "Tôi rất thích RG Unicorn của bạn!"
+                },
+                "offerer_gundam_ids": {
+                    "description": "Danh sách ID các Gundam của bạn để đưa ra trao đổi (phải thuộc về bạn và có status \"in store\")",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        789,
+                        321
+                    ]
+                },
+                "payer_id": {
+                    "description": "ID người phải trả tiền bù (chỉ có thể là bạn hoặc chủ bài đăng, để null nếu không có ai bù)",
+                    "type": "string",
+                    "example": "user_abc123"
+                },
+                "poster_gundam_ids": {
+                    "description": "Danh sách ID các Gundam của chủ bài đăng mà bạn muốn nhận về (phải thuộc bài đăng này và có status \"for exchange\")",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        123,
+                        456
+                    ]
+                }
+            }
+        },
+        "api.createExchangePostRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "post_images",
+                "post_item_id"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Nội dung bài post",
+                    "type": "string"
+                },
+                "post_images": {
+                    "description": "Ảnh bài post",
+                    "type": "array",
+                    "maxItems": 5,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/multipart.FileHeader"
+                    }
+                },
+                "post_item_id": {
+                    "description": "ID của các Gundam mà chủ bài post cho phép trao đổi",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "api.createImageCleanupRunRequest": {
+            "type": "object",
+            "required": [
+                "dry_run"
+            ],
+            "properties": {
+                "dry_run": {
+                    "description": "true: chỉ tạo báo cáo các ảnh mồ côi, false: xóa các ảnh mồ côi đã quá grace period",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.createOrderRequest": {
+            "type": "object",
+            "required": [
+                "buyer_address_id",
+                "delivery_fee",
+                "expected_delivery_time",
+                "gundam_ids",
+                "items",
+                "items_subtotal",
+                "note",
+                "payment_method",
+                "seller_id",
+                "total_amount"
+            ],
+            "properties": {
+                "buyer_address_id": {
+                    "description": "ID of the buyer's chosen address\nexample: 42",
+                    "type": "integer"
+                },
+                "delivery_fee": {
+                    "description": "Delivery fee (VND), must match the fee quoted by POST /orders/delivery-quote\nminimum: 0\nexample: 30000",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "expected_delivery_time": {
+                    "description": "Optional, must match the expected delivery time quoted by POST /orders/delivery-quote when provided\nexample: 2025-04-05T10:00:00Z",
+                    "type": "string"
+                },
+                "gundam_ids": {
+                    "description": "Deprecated: use items instead. List of Gundam IDs in the order, one unit of each\nexample: [1, 2, 3]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "items": {
+                    "description": "Gundams in the order with the number of units to buy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.orderItemRequest"
+                    }
+                },
+                "items_subtotal": {
+                    "description": "Total value of all items (excluding delivery fee)\nminimum: 0\nexample: 500000",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "note": {
+                    "description": "Optional note for the order\nmaxLength: 255\nexample: Please deliver in the morning",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "payment_method": {
+                    "description": "Payment method (wallet: pay via platform wallet, cod: cash on delivery)\nenums: wallet,cod\nexample: wallet",
+                    "type": "string",
+                    "enum": [
+                        "wallet",
+                        "cod"
+                    ]
+                },
+                "seller_id": {
+                    "description": "ID of the seller\nexample: user123",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "description": "Total order amount (including delivery fee)\nminimum: 0\nexample: 530000",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "api.createSellerProfileRequest": {
+            "type": "object",
+            "required": [
+                "shop_name",
+                "user_id"
+            ],
+            "properties": {
+                "shop_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createUserAddressRequest": {
+            "type": "object",
+            "required": [
+                "detail",
+                "district_name",
+                "full_name",
+                "ghn_district_id",
+                "ghn_ward_code",
+                "is_pickup_address",
+                "is_primary",
+                "phone_number",
+                "province_name",
+                "ward_name"
+            ],
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "district_name": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "ghn_district_id": {
+                    "type": "integer"
+                },
+                "ghn_ward_code": {
+                    "type": "string"
+                },
+                "is_pickup_address": {
+                    "type": "boolean"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "province_name": {
+                    "type": "string"
+                },
+                "ward_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createWithdrawalRequestBody": {
+            "type": "object",
+            "required": [
+                "amount",
+                "bank_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bank_account_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createZalopayOrderRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "description",
+                "redirect_url"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1000
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "redirect_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.deleteUserAccountBlockedResponse": {
+            "type": "object",
+            "required": [
+                "blockers",
+                "error"
+            ],
+            "properties": {
+                "blockers": {
+                    "$ref": "#/definitions/db.GetUserDeletionBlockersRow"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.deleteUserAccountRequest": {
+            "type": "object",
+            "required": [
+                "current_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "Bỏ trống nếu tài khoản chưa có mật khẩu (đăng ký bằng Google)",
+                    "type": "string"
+                }
+            }
+        },
+        "api.deliveryQuote": {
+            "type": "object",
+            "required": [
+                "delivery_fee",
+                "expected_delivery_time",
+                "service_id"
+            ],
+            "properties": {
+                "delivery_fee": {
+                    "description": "Delivery fee (VND)",
+                    "type": "integer"
+                },
+                "expected_delivery_time": {
+                    "description": "Expected delivery time",
+                    "type": "string"
+                },
+                "service_id": {
+                    "description": "ID of the delivery service used for the quote",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.enableTOTPRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.enableTOTPResponse": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "access_token_expires_at",
+                "recovery_codes",
+                "refresh_token",
+                "refresh_token_expires_at"
+            ],
+            "properties": {
+                "access_token": {
+                    "description": "Token mới đã qua xác thực hai lớp, thay cho token hiện tại",
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "description": "Chỉ được hiển thị một lần, người dùng cần lưu lại ở nơi an toàn",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.finalizeUploadRequest": {
+            "type": "object",
+            "required": [
+                "folder",
+                "key"
+            ],
+            "properties": {
+                "folder": {
+                    "type": "string",
+                    "enum": [
+                        "gundams",
+                        "exchanges",
+                        "orders",
+                        "avatars"
+                    ]
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.finalizeUploadResponse": {
+            "type": "object",
+            "required": [
+                "key",
+                "thumbnail_url",
+                "url"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.forgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "phone_number"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.gundamMarketPriceResponse": {
+            "type": "object",
+            "required": [
+                "basis",
+                "by_condition",
+                "gundam_id",
+                "model_kit_id",
+                "overall",
+                "since",
+                "sources",
+                "suggested_price_range",
+                "trend"
+            ],
+            "properties": {
+                "basis": {
+                    "type": "string"
+                },
+                "by_condition": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.marketPriceStats"
+                    }
+                },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "model_kit_id": {
+                    "type": "integer"
+                },
+                "overall": {
+                    "$ref": "#/definitions/api.marketPriceStats"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "suggested_price_range": {
+                    "$ref": "#/definitions/api.suggestedPriceRange"
+                },
+                "trend": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListMarketPriceTrendRow"
+                    }
+                }
+            }
+        },
+        "api.listGundamsResponse": {
+            "type": "object",
+            "required": [
+                "data",
+                "facets",
+                "has_more",
+                "next_cursor",
+                "total"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.GundamDetails"
+                    }
+                },
+                "facets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/db.GundamFacetValue"
+                        }
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.loginUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.loginUserResponse": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "access_token_expires_at",
+                "refresh_token",
+                "refresh_token_expires_at",
+                "two_factor_enrollment_required",
+                "user"
+            ],
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                },
+                "two_factor_enrollment_required": {
+                    "description": "Moderator và admin chưa bật xác thực hai lớp phải bật trước khi dùng các API quản trị",
+                    "type": "boolean"
+                },
+                "user": {
+                    "$ref": "#/definitions/db.User"
+                }
+            }
+        },
+        "api.loginUserWithGoogleRequest": {
+            "type": "object",
+            "required": [
+                "id_token"
+            ],
+            "properties": {
+                "id_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.loginWithTwoFactorRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token",
+                "recovery_code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Mã TOTP 6 chữ số từ ứng dụng xác thực",
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "recovery_code": {
+                    "description": "Dùng khi mất thiết bị xác thực, mỗi mã chỉ dùng được một lần",
+                    "type": "string"
+                }
+            }
+        },
+        "api.logoutUserRequest": {
+            "type": "object",
+            "required": [
+                "all_devices",
+                "refresh_token"
+            ],
+            "properties": {
+                "all_devices": {
+                    "description": "Đăng xuất khỏi tất cả thiết bị",
+                    "type": "boolean"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.marketPriceStats": {
+            "type": "object",
+            "required": [
+                "condition",
+                "max_price",
+                "median",
+                "min_price",
+                "p25",
+                "p75",
+                "sample_size"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "max_price": {
+                    "type": "integer"
+                },
+                "median": {
+                    "type": "integer"
+                },
+                "min_price": {
+                    "type": "integer"
+                },
+                "p25": {
+                    "type": "integer"
+                },
+                "p75": {
+                    "type": "integer"
+                },
+                "sample_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.orderItemRequest": {
+            "type": "object",
+            "required": [
+                "gundam_id",
+                "quantity"
+            ],
+            "properties": {
+                "gundam_id": {
+                    "description": "ID of the Gundam\nexample: 1",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "description": "Number of units to buy\nminimum: 1\nexample: 2",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "api.otpErrorResponse": {
+            "type": "object",
+            "required": [
+                "code",
+                "error",
+                "remaining_attempts",
+                "retry_after"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Ví dụ: OTP_INVALID, OTP_RESEND_COOLDOWN, OTP_LOCKED",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "remaining_attempts": {
+                    "description": "Số lần nhập sai còn lại",
+                    "type": "integer"
+                },
+                "retry_after": {
+                    "description": "Số giây cần chờ trước khi thử lại",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.pageResponse-db_AuctionDetails": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AuctionDetails"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_AuctionRequest": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AuctionRequest"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_ListImageCleanupRunsRow": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListImageCleanupRunsRow"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_ListImageFlagsRow": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListImageFlagsRow"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_ListModelKitsRow": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListModelKitsRow"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_ListWishlistItemsRow": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListWishlistItemsRow"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_MemberOrderInfo": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.MemberOrderInfo"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_OpenExchangePostInfo": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OpenExchangePostInfo"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_SalesOrderInfo": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.SalesOrderInfo"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_WalletEntry": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WalletEntry"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.pageResponse-db_WithdrawalRequestDetails": {
+            "type": "object",
+            "required": [
+                "data",
+                "has_more",
+                "next_cursor"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WithdrawalRequestDetails"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.payAuctionWinningBidRequest": {
+            "type": "object",
+            "required": [
+                "delivery_fee",
+                "expected_delivery_time",
+                "note",
+                "user_address_id"
+            ],
+            "properties": {
+                "delivery_fee": {
+                    "type": "integer"
+                },
+                "expected_delivery_time": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "user_address_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.payExchangeDeliveryFeeRequest": {
+            "type": "object",
+            "required": [
+                "delivery_fee",
+                "expected_delivery_time",
+                "note"
+            ],
+            "properties": {
+                "delivery_fee": {
+                    "description": "Delivery fee (VND), must match the fee quoted by GET /exchanges/{exchangeID}/delivery-quote",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "expected_delivery_time": {
+                    "description": "Deprecated: ignored, the expected delivery time is quoted by the delivery provider",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.placeBidRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.presignUploadRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "sha256",
+                "size"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "image/jpeg",
+                        "image/png",
+                        "image/gif"
+                    ]
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "api.provideExchangeDeliveryAddressesRequest": {
+            "type": "object",
+            "required": [
+                "delivery_fee",
+                "expected_delivery_time",
+                "from_address_id",
+                "to_address_id"
+            ],
+            "properties": {
+                "delivery_fee": {
+                    "description": "Delivery fee (VND) shown to the user, optional. When provided, it must match the fee quoted for the new receiver address",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "expected_delivery_time": {
+                    "description": "Expected delivery time shown to the user, optional. When provided, it must match the quoted expected delivery time",
+                    "type": "string"
+                },
+                "from_address_id": {
+                    "description": "ID địa chỉ gửi đã được lưu trong bảng user_addresses",
+                    "type": "integer"
+                },
+                "to_address_id": {
+                    "description": "ID địa chỉ nhận đã được lưu trong bảng user_addresses",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.quoteOrderDeliveryRequest": {
+            "type": "object",
+            "required": [
+                "buyer_address_id",
+                "items",
+                "seller_id"
+            ],
+            "properties": {
+                "buyer_address_id": {
+                    "description": "ID of the buyer's chosen address\nexample: 42",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Gundams in the order with the number of units to buy",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/api.orderItemRequest"
+                    }
+                },
+                "seller_id": {
+                    "description": "ID of the seller\nexample: user123",
+                    "type": "string"
+                }
+            }
+        },
+        "api.refreshAccessTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.refreshAccessTokenResponse": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "access_token_expires_at",
+                "refresh_token",
+                "refresh_token_expires_at"
+            ],
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.regenerateRecoveryCodesResponse": {
+            "type": "object",
+            "required": [
+                "recovery_codes"
+            ],
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api.rejectAuctionRequestBody": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.rejectWithdrawalRequestRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Lý do từ chối yêu cầu rút tiền của moderator",
+                    "type": "string"
+                }
+            }
+        },
+        "api.requestNegotiationForOfferRequest": {
+            "type": "object",
+            "required": [
+                "note"
+            ],
+            "properties": {
+                "note": {
+                    "description": "Ghi chú từ người yêu cầu thương lượng, không bắt buộc",
+                    "type": "string"
+                }
+            }
+        },
+        "api.resetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "new_password",
+                "otp_code",
+                "phone_number"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "otp_code": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.reviewImageFlagRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "dismissed: hai ảnh không liên quan hoặc cùng người bán, confirmed: ảnh bị lấy cắp hoặc dùng lại",
+                    "type": "string",
+                    "enum": [
+                        "dismissed",
+                        "confirmed"
+                    ]
+                }
+            }
+        },
+        "api.savedSearchRequest": {
+            "type": "object",
+            "required": [
+                "condition",
+                "grade_id",
+                "keyword",
+                "max_price",
+                "name",
+                "scale"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "open box",
+                        "used"
+                    ]
+                },
+                "grade_id": {
+                    "type": "integer"
+                },
+                "keyword": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "max_price": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scale": {
+                    "type": "string",
+                    "enum": [
+                        "1/144",
+                        "1/100",
+                        "1/60",
+                        "1/48"
+                    ]
+                }
+            }
+        },
+        "api.setupTOTPResponse": {
+            "type": "object",
+            "required": [
+                "provisioning_uri",
+                "secret"
+            ],
+            "properties": {
+                "provisioning_uri": {
+                    "description": "URI otpauth:// để hiển thị dưới dạng QR code",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Nhập thủ công nếu không quét được QR code",
+                    "type": "string"
+                }
+            }
+        },
+        "api.suggestedPriceRange": {
+            "type": "object",
+            "required": [
+                "condition",
+                "high",
+                "low",
+                "median",
+                "sample_size"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string"
                 },
-                "expires_at": {
+                "high": {
+                    "type": "integer"
+                },
+                "low": {
+                    "type": "integer"
+                },
+                "median": {
+                    "type": "integer"
+                },
+                "sample_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.twoFactorChallengeResponse": {
+            "type": "object",
+            "required": [
+                "mfa_required",
+                "mfa_token",
+                "mfa_token_expires_at"
+            ],
+            "properties": {
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "description": "Gửi kèm mã TOTP tới /auth/login/2fa",
                     "type": "string"
                 },
-                "otp_code": {
+                "mfa_token_expires_at": {
                     "type": "string"
                 }
             }
         },
-        "api.GeneratePhoneOTPRequest": {
+        "api.twoFactorCodeRequest": {
             "type": "object",
             "required": [
-                "phone_number"
+                "code",
+                "recovery_code"
             ],
             "properties": {
-                "phone_number": {
-                    "description": "Số điện thoại cần gửi OTP",
+                "code": {
+                    "type": "string"
+                },
+                "recovery_code": {
                     "type": "string"
                 }
             }
         },
-        "api.GeneratePhoneOTPResponse": {
+        "api.twoFactorStatusResponse": {
             "type": "object",
             "required": [
-                "created_at",
-                "expires_at",
-                "otp_code",
-                "phone_number"
+                "enabled",
+                "enabled_at",
+                "recovery_codes_remaining",
+                "required"
             ],
             "properties": {
-                "created_at": {
-                    "description": "Thời điểm OTP được tạo",
-                    "type": "string"
+                "enabled": {
+                    "type": "boolean"
                 },
-                "expires_at": {
-                    "description": "Thời điểm OTP hết hạn",
+                "enabled_at": {
                     "type": "string"
                 },
-                "otp_code": {
-                    "description": "Mã OTP được tạo",
+                "recovery_codes_remaining": {
+                    "type": "integer"
+                },
+                "required": {
+                    "description": "Bắt buộc với moderator và admin",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.updateAuctionDetailsByModeratorBody": {
+            "type": "object",
+            "required": [
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "end_time": {
                     "type": "string"
                 },
-                "phone_number": {
-                    "description": "Số điện thoại đã gửi OTP",
+                "start_time": {
                     "type": "string"
                 }
             }
         },
-        "api.SubscriptionDetailsResponse": {
+        "api.updateAvatarResponse": {
             "type": "object",
             "required": [
-                "auctions_remaining",
-                "can_upgrade",
-                "days_remaining",
-                "duration_days",
-                "end_date",
-                "id",
-                "is_active",
-                "is_expiring_soon",
-                "is_unlimited",
-                "listings_remaining",
-                "listings_used",
-                "max_listings",
-                "max_open_auctions",
-                "open_auctions_used",
-                "plan_id",
-                "seller_id",
-                "start_date",
-                "subscription_name",
-                "subscription_price"
+                "avatar_url"
             ],
             "properties": {
-                "auctions_remaining": {
-                    "description": "AuctionsRemaining là số phiên đấu giá còn có thể mở (null nếu unlimited)",
-                    "type": "integer",
-                    "example": 25
+                "avatar_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.updateExchangeOfferRequest": {
+            "type": "object",
+            "required": [
+                "compensation_amount",
+                "note",
+                "payer_id",
+                "require_compensation"
+            ],
+            "properties": {
+                "compensation_amount": {
+                    "description": "Bắt buộc khi require_compensation=true",
+                    "type": "integer"
                 },
-                "can_upgrade": {
-                    "description": "CanUpgrade cho biết có thể nâng cấp lên gói cao hơn không",
-                    "type": "boolean",
-                    "example": true
+                "note": {
+                    "description": "Tin nhắn thương lượng, không bắt buộc",
+                    "type": "string"
                 },
-                "days_remaining": {
-                    "description": "DaysRemaining là số ngày còn lại của subscription (null nếu vĩnh viễn)",
-                    "type": "integer",
-                    "example": 45
+                "payer_id": {
+                    "description": "ID người trả tiền bù, bắt buộc khi require_compensation=true",
+                    "type": "string"
                 },
-                "duration_days": {
-                    "description": "DurationDays là thời hạn của gói (ngày), null nếu vĩnh viễn",
-                    "type": "integer",
-                    "example": 90
+                "require_compensation": {
+                    "description": "true = yêu cầu bù tiền, false = không yêu cầu bù tiền",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.updateModelKitRequest": {
+            "type": "object",
+            "required": [
+                "grade_id",
+                "manufacturer",
+                "msrp",
+                "name",
+                "release_year",
+                "scale",
+                "series",
+                "version"
+            ],
+            "properties": {
+                "grade_id": {
+                    "type": "integer"
                 },
-                "end_date": {
-                    "description": "EndDate là thời gian kết thúc subscription (null nếu vĩnh viễn)",
+                "manufacturer": {
                     "type": "string",
-                    "example": "2024-03-01T10:00:00Z"
-                },
-                "id": {
-                    "description": "ID là ID duy nhất của subscription",
-                    "type": "integer",
-                    "example": 1
-                },
-                "is_active": {
-                    "description": "Plan details\nIsActive cho biết subscription có đang hoạt động không",
-                    "type": "boolean",
-                    "example": true
-                },
-                "is_expiring_soon": {
-                    "description": "Status indicators\nIsExpiringSoon cho biết subscription có sắp hết hạn không (\u003c 7 ngày)",
-                    "type": "boolean",
-                    "example": false
-                },
-                "is_unlimited": {
-                    "description": "IsUnlimited cho biết có phải gói không giới hạn không",
-                    "type": "boolean",
-                    "example": false
-                },
-                "listings_remaining": {
-                    "description": "ListingsRemaining là số lượt đăng bán còn lại (null nếu unlimited)",
-                    "type": "integer",
-                    "example": 38
-                },
-                "listings_used": {
-                    "description": "ListingsUsed là số lượt đăng bán đã sử dụng",
-                    "type": "integer",
-                    "example": 12
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "max_listings": {
-                    "description": "Listing information\nMaxListings là số lượt đăng bán tối đa (null nếu unlimited)",
-                    "type": "integer",
-                    "example": 50
-                },
-                "max_open_auctions": {
-                    "description": "Auction information\nMaxOpenAuctions là số phiên đấu giá tối đa có thể mở cùng lúc (null nếu unlimited)",
+                "msrp": {
                     "type": "integer",
-                    "example": 30
+                    "minimum": 0
                 },
-                "open_auctions_used": {
-                    "description": "OpenAuctionsUsed là số phiên đấu giá đang mở",
-                    "type": "integer",
-                    "example": 5
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "plan_id": {
-                    "description": "PlanID là ID của gói đăng ký",
-                    "type": "integer",
-                    "example": 2
+                "release_year": {
+                    "type": "integer"
                 },
-                "seller_id": {
-                    "description": "SellerID là ID của người bán",
+                "scale": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "enum": [
+                        "1/144",
+                        "1/100",
+                        "1/60",
+                        "1/48"
+                    ]
                 },
-                "start_date": {
-                    "description": "Time information\nStartDate là thời gian bắt đầu subscription",
+                "series": {
                     "type": "string",
-                    "example": "2023-12-01T10:00:00Z"
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "subscription_name": {
-                    "description": "SubscriptionName là tên của gói đăng ký",
+                "version": {
                     "type": "string",
-                    "example": "GÓI NÂNG CẤP"
-                },
-                "subscription_price": {
-                    "description": "SubscriptionPrice là giá của gói đăng ký (VND)",
-                    "type": "integer",
-                    "example": 359000
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "api.VerifyEmailOTPRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "otp_code"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "otp_code": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.VerifyPhoneOTPRequest": {
+        "api.updateSellerProfileRequest": {
             "type": "object",
             "required": [
-                "otp_code",
-                "phone_number",
+                "shop_name",
                 "user_id"
             ],
             "properties": {
-                "otp_code": {
-                    "description": "Mã OTP",
-                    "type": "string"
-                },
-                "phone_number": {
-                    "description": "Số điện thoại mới",
+                "shop_name": {
                     "type": "string"
                 },
                 "user_id": {
-                    "description": "ID của user cần cập nhật số điện thoại",
                     "type": "string"
                 }
             }
         },
-        "api.addBankAccountRequest": {
+        "api.updateUserAddressRequest": {
             "type": "object",
             "required": [
-                "account_name",
-                "account_number",
-                "bank_code",
-                "bank_name",
-                "bank_short_name"
+                "detail",
+                "district_name",
+                "full_name",
+                "ghn_district_id",
+                "ghn_ward_code",
+                "is_pickup_address",
+                "is_primary",
+                "phone_number",
+                "province_name",
+                "ward_name"
             ],
             "properties": {
-                "account_name": {
+                "detail": {
+                    "type": "string"
+                },
+                "district_name": {
                     "type": "string"
                 },
-                "account_number": {
+                "full_name": {
                     "type": "string"
                 },
-                "bank_code": {
+                "ghn_district_id": {
+                    "type": "integer"
+                },
+                "ghn_ward_code": {
                     "type": "string"
                 },
-                "bank_name": {
+                "is_pickup_address": {
+                    "type": "boolean"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "phone_number": {
                     "type": "string"
                 },
-                "bank_short_name": {
+                "province_name": {
+                    "type": "string"
+                },
+                "ward_name": {
                     "type": "string"
                 }
             }
         },
-        "api.addCartItemRequest": {
+        "api.updateUserRequest": {
             "type": "object",
             "required": [
-                "gundam_id"
+                "full_name"
             ],
             "properties": {
-                "gundam_id": {
-                    "type": "integer"
+                "full_name": {
+                    "type": "string"
                 }
             }
         },
-        "api.cancelAuctionRequest": {
+        "api.upgradeSubscriptionRequest": {
             "type": "object",
             "required": [
-                "reason"
+                "plan_id"
             ],
             "properties": {
-                "reason": {
-                    "description": "Lý do hủy đấu giá, có thể để trống",
-                    "type": "string"
+                "plan_id": {
+                    "description": "ID of the target subscription plan",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "api.cancelExchangeRequest": {
+        "api.userDataExport": {
             "type": "object",
             "required": [
-                "reason"
+                "addresses",
+                "bank_accounts",
+                "exported_at",
+                "gundams",
+                "orders",
+                "sales_orders",
+                "user",
+                "wallet",
+                "wallet_entries"
             ],
             "properties": {
-                "reason": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.UserAddress"
+                    }
+                },
+                "bank_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.UserBankAccount"
+                    }
+                },
+                "exported_at": {
                     "type": "string"
+                },
+                "gundams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ListGundamsByUserIDRow"
+                    }
+                },
+                "orders": {
+                    "description": "Đơn mua và đơn trao đổi",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Order"
+                    }
+                },
+                "sales_orders": {
+                    "description": "Đơn bán (chỉ có với seller)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Order"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/db.User"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/db.Wallet"
+                },
+                "wallet_entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WalletEntry"
+                    }
                 }
             }
         },
-        "api.cancelOrderRequest": {
+        "api.userSessionResponse": {
             "type": "object",
             "required": [
-                "reason"
+                "created_at",
+                "current",
+                "device",
+                "expires_at",
+                "id",
+                "ip_address",
+                "last_seen_at",
+                "user_agent"
             ],
             "properties": {
-                "reason": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Phiên của request hiện tại",
+                    "type": "boolean"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "api.checkEmailRequest": {
+        "api.verifyAccessTokenRequest": {
             "type": "object",
             "required": [
-                "email"
+                "access_token"
             ],
             "properties": {
-                "email": {
+                "access_token": {
                     "type": "string"
                 }
             }
         },
-        "api.completeWithdrawalRequestRequest": {
+        "db.AcceptExchangeOfferTxResult": {
             "type": "object",
             "required": [
-                "transaction_reference"
+                "exchange"
             ],
             "properties": {
-                "transaction_reference": {
-                    "description": "Mã giao dịch từ ngân hàng để hoàn tất yêu cầu rút tiền",
-                    "type": "string"
+                "exchange": {
+                    "$ref": "#/definitions/db.Exchange"
                 }
             }
         },
-        "api.createAuctionRequestBody": {
+        "db.AddCartItemRow": {
             "type": "object",
             "required": [
-                "bid_increment",
-                "buy_now_price",
-                "end_time",
+                "available_quantity",
+                "cart_item_id",
                 "gundam_id",
-                "start_time",
-                "starting_price"
+                "gundam_image_url",
+                "gundam_name",
+                "gundam_price",
+                "quantity",
+                "seller_avatar_url",
+                "seller_id",
+                "seller_name"
             ],
             "properties": {
-                "bid_increment": {
-                    "type": "integer"
-                },
-                "buy_now_price": {
+                "available_quantity": {
                     "type": "integer"
                 },
-                "end_time": {
+                "cart_item_id": {
                     "type": "string"
                 },
                 "gundam_id": {
                     "type": "integer"
                 },
-                "start_time": {
+                "gundam_image_url": {
                     "type": "string"
                 },
-                "starting_price": {
-                    "type": "integer"
-                }
-            }
-        },
-        "api.createExchangeOfferRequest": {
-            "type": "object",
-            "required": [
-                "compensation_amount",
-                "exchange_post_id",
-                "note",
-                "offerer_gundam_ids",
-                "payer_id",
-                "poster_gundam_ids"
-            ],
-            "properties": {
-                "compensation_amount": {
-                    "description": "Số tiền bù theo VND (bắt buộc nếu có payer_id, phải \u003e 0, chỉ trừ tiền khi offer được chấp nhận)",
-                    "type": "integer",
-                    "example": 50000
-                },
-                "exchange_post_id": {
-                    "description": "UUID của bài đăng trao đổi mà bạn muốn tạo offer",
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "note": {
-                    "description": "Tin nhắn gửi kèm cho chủ bài đăng (tùy chọn)",
-                    "type": "string",
-                    "example": "Tôi rất thích RG Unicorn của bạn!"
+                "gundam_name": {
+                    "type": "string"
                 },
-                "offerer_gundam_ids": {
-                    "description": "Danh sách ID các Gundam của bạn để đưa ra trao đổi (phải thuộc về bạn và có status \"in store\")",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        789,
-                        321
-                    ]
+                "gundam_price": {
+                    "type": "integer"
                 },
-                "payer_id": {
-                    "description": "ID người phải trả tiền bù (chỉ có thể là bạn hoặc chủ bài đăng, để null nếu không có ai bù)",
-                    "type": "string",
-                    "example": "user_abc123"
+                "quantity": {
+                    "type": "integer"
                 },
-                "poster_gundam_ids": {
-                    "description": "Danh sách ID các Gundam của chủ bài đăng mà bạn muốn nhận về (phải thuộc bài đăng này và có status \"for exchange\")",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        123,
-                        456
-                    ]
-                }
-            }
-        },
-        "api.createExchangePostRequest": {
-            "type": "object",
-            "required": [
-                "content",
-                "post_images",
-                "post_item_id"
-            ],
-            "properties": {
-                "content": {
-                    "description": "Nội dung bài post",
+                "seller_avatar_url": {
                     "type": "string"
                 },
-                "post_images": {
-                    "description": "Ảnh bài post",
-                    "type": "array",
-                    "maxItems": 5,
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/multipart.FileHeader"
-                    }
+                "seller_id": {
+                    "type": "string"
                 },
-                "post_item_id": {
-                    "description": "ID của các Gundam mà chủ bài post cho phép trao đổi",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "seller_name": {
+                    "type": "string"
                 }
             }
         },
-        "api.createOrderRequest": {
+        "db.AdminDashboard": {
             "type": "object",
             "required": [
-                "buyer_address_id",
-                "delivery_fee",
-                "expected_delivery_time",
-                "gundam_ids",
-                "items_subtotal",
-                "note",
-                "payment_method",
-                "seller_id",
-                "total_amount"
+                "completed_auctions_this_week",
+                "completed_exchanges_this_month",
+                "new_users_this_week",
+                "total_auction_orders_this_month",
+                "total_business_users",
+                "total_exchange_orders_this_month",
+                "total_published_gundams",
+                "total_regular_orders_this_month",
+                "total_revenue_this_month",
+                "total_wallet_volume_this_week"
             ],
             "properties": {
-                "buyer_address_id": {
-                    "description": "ID of the buyer's chosen address\nexample: 42",
+                "completed_auctions_this_week": {
+                    "description": "Tổng số phiên đấu giá đã hoàn thành trong tuần này",
+                    "type": "integer"
+                },
+                "completed_exchanges_this_month": {
+                    "description": "Tổng số cuộc trao đổi đã hoàn thành trong tháng này",
                     "type": "integer"
                 },
-                "delivery_fee": {
-                    "description": "Delivery fee (VND)\nminimum: 0\nexample: 30000",
-                    "type": "integer",
-                    "minimum": 0
+                "new_users_this_week": {
+                    "description": "Số lượng người dùng mới trong tuần này",
+                    "type": "integer"
                 },
-                "expected_delivery_time": {
-                    "description": "Expected delivery time\nexample: 2025-04-05T10:00:00Z",
-                    "type": "string"
+                "total_auction_orders_this_month": {
+                    "description": "Tổng số đơn hàng đấu giá trong tháng này",
+                    "type": "integer"
                 },
-                "gundam_ids": {
-                    "description": "List of Gundam IDs in the order\nexample: [1, 2, 3]",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "total_business_users": {
+                    "description": "Tổng số người dùng hoạt động trên nền tảng (chỉ tính role member và seller)",
+                    "type": "integer"
                 },
-                "items_subtotal": {
-                    "description": "Total value of all items (excluding delivery fee)\nminimum: 0\nexample: 500000",
-                    "type": "integer",
-                    "minimum": 0
+                "total_exchange_orders_this_month": {
+                    "description": "Tổng số đơn hàng trao đổi trong tháng này",
+                    "type": "integer"
                 },
-                "note": {
-                    "description": "Optional note for the order\nmaxLength: 255\nexample: Please deliver in the morning",
-                    "type": "string",
-                    "maxLength": 255
+                "total_published_gundams": {
+                    "description": "Tổng số gundam đã được đăng bán",
+                    "type": "integer"
                 },
-                "payment_method": {
-                    "description": "Payment method (wallet: pay via platform wallet, cod: cash on delivery)\nenums: wallet,cod\nexample: wallet",
-                    "type": "string",
-                    "enum": [
-                        "wallet",
-                        "cod"
-                    ]
+                "total_regular_orders_this_month": {
+                    "description": "Tổng số đơn hàng thường trong tháng này",
+                    "type": "integer"
                 },
-                "seller_id": {
-                    "description": "ID of the seller\nexample: user123",
-                    "type": "string"
+                "total_revenue_this_month": {
+                    "description": "Tổng doanh thu trong tháng này",
+                    "type": "integer"
                 },
-                "total_amount": {
-                    "description": "Total order amount (including delivery fee)\nminimum: 0\nexample: 530000",
-                    "type": "integer",
-                    "minimum": 0
+                "total_wallet_volume_this_week": {
+                    "description": "Tổng khối lượng giao dịch ví trong tuần này",
+                    "type": "integer"
                 }
             }
         },
-        "api.createSellerProfileRequest": {
+        "db.ApproveAuctionRequestTxResult": {
             "type": "object",
             "required": [
-                "shop_name",
-                "user_id"
+                "created_auction",
+                "updated_request"
             ],
             "properties": {
-                "shop_name": {
-                    "type": "string"
+                "created_auction": {
+                    "$ref": "#/definitions/db.Auction"
                 },
-                "user_id": {
-                    "type": "string"
+                "updated_request": {
+                    "$ref": "#/definitions/db.AuctionRequest"
                 }
             }
         },
-        "api.createUserAddressRequest": {
+        "db.Auction": {
             "type": "object",
             "required": [
-                "detail",
-                "district_name",
-                "full_name",
-                "ghn_district_id",
-                "ghn_ward_code",
-                "is_pickup_address",
-                "is_primary",
-                "phone_number",
-                "province_name",
-                "ward_name"
+                "actual_end_time",
+                "bid_increment",
+                "buy_now_price",
+                "canceled_by",
+                "canceled_reason",
+                "created_at",
+                "current_price",
+                "deposit_amount",
+                "deposit_rate",
+                "end_time",
+                "gundam_id",
+                "gundam_snapshot",
+                "id",
+                "order_id",
+                "request_id",
+                "seller_id",
+                "start_time",
+                "starting_price",
+                "status",
+                "total_bids",
+                "total_participants",
+                "updated_at",
+                "winner_payment_deadline",
+                "winning_bid_id"
             ],
             "properties": {
-                "detail": {
+                "actual_end_time": {
                     "type": "string"
                 },
-                "district_name": {
+                "bid_increment": {
+                    "type": "integer"
+                },
+                "buy_now_price": {
+                    "type": "integer"
+                },
+                "canceled_by": {
                     "type": "string"
                 },
-                "full_name": {
+                "canceled_reason": {
                     "type": "string"
                 },
-                "ghn_district_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_price": {
                     "type": "integer"
                 },
-                "ghn_ward_code": {
+                "deposit_amount": {
+                    "type": "integer"
+                },
+                "deposit_rate": {
+                    "type": "number"
+                },
+                "end_time": {
                     "type": "string"
                 },
-                "is_pickup_address": {
-                    "type": "boolean"
+                "gundam_id": {
+                    "type": "integer"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "gundam_snapshot": {
+                    "$ref": "#/definitions/db.GundamSnapshot"
                 },
-                "phone_number": {
+                "id": {
                     "type": "string"
                 },
-                "province_name": {
+                "order_id": {
                     "type": "string"
                 },
-                "ward_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.createUserRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "full_name",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                "request_id": {
                     "type": "string"
                 },
-                "full_name": {
+                "seller_id": {
                     "type": "string"
                 },
-                "password": {
+                "start_time": {
                     "type": "string"
-                }
-            }
-        },
-        "api.createWithdrawalRequestBody": {
-            "type": "object",
-            "required": [
-                "amount",
-                "bank_account_id"
-            ],
-            "properties": {
-                "amount": {
+                },
+                "starting_price": {
                     "type": "integer"
                 },
-                "bank_account_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.createZalopayOrderRequest": {
-            "type": "object",
-            "required": [
-                "amount",
-                "description",
-                "redirect_url"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "integer",
-                    "minimum": 1000
+                "status": {
+                    "$ref": "#/definitions/db.AuctionStatus"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 256
+                "total_bids": {
+                    "type": "integer"
                 },
-                "redirect_url": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.loginUserRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
+                "total_participants": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.loginUserResponse": {
-            "type": "object",
-            "required": [
-                "access_token",
-                "access_token_expires_at",
-                "user"
-            ],
-            "properties": {
-                "access_token": {
+                "updated_at": {
                     "type": "string"
                 },
-                "access_token_expires_at": {
+                "winner_payment_deadline": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/db.User"
-                }
-            }
-        },
-        "api.loginUserWithGoogleRequest": {
-            "type": "object",
-            "required": [
-                "id_token"
-            ],
-            "properties": {
-                "id_token": {
+                "winning_bid_id": {
                     "type": "string"
                 }
             }
         },
-        "api.payAuctionWinningBidRequest": {
+        "db.AuctionBid": {
             "type": "object",
             "required": [
-                "delivery_fee",
-                "expected_delivery_time",
-                "note",
-                "user_address_id"
+                "amount",
+                "auction_id",
+                "bidder_id",
+                "created_at",
+                "id",
+                "participant_id"
             ],
             "properties": {
-                "delivery_fee": {
+                "amount": {
                     "type": "integer"
                 },
-                "expected_delivery_time": {
+                "auction_id": {
                     "type": "string"
                 },
-                "note": {
+                "bidder_id": {
                     "type": "string"
                 },
-                "user_address_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "api.payExchangeDeliveryFeeRequest": {
-            "type": "object",
-            "required": [
-                "delivery_fee",
-                "expected_delivery_time",
-                "note"
-            ],
-            "properties": {
-                "delivery_fee": {
-                    "type": "integer",
-                    "minimum": 1
+                "created_at": {
+                    "type": "string"
                 },
-                "expected_delivery_time": {
+                "id": {
                     "type": "string"
                 },
-                "note": {
+                "participant_id": {
                     "type": "string"
                 }
             }
         },
-        "api.placeBidRequest": {
+        "db.AuctionDetails": {
             "type": "object",
             "required": [
-                "amount"
+                "auction",
+                "auction_bids",
+                "auction_participants"
             ],
             "properties": {
-                "amount": {
-                    "type": "integer"
+                "auction": {
+                    "description": "Thông tin phiên đấu giá",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Auction"
+                        }
+                    ]
+                },
+                "auction_bids": {
+                    "description": "Danh sách giá đấu",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AuctionBid"
+                    }
+                },
+                "auction_participants": {
+                    "description": "Danh sách người tham gia đấu giá",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AuctionParticipant"
+                    }
                 }
             }
         },
-        "api.provideExchangeDeliveryAddressesRequest": {
+        "db.AuctionParticipant": {
             "type": "object",
             "required": [
-                "from_address_id",
-                "to_address_id"
+                "auction_id",
+                "created_at",
+                "deposit_amount",
+                "deposit_entry_id",
+                "id",
+                "is_refunded",
+                "updated_at",
+                "user_id"
             ],
             "properties": {
-                "from_address_id": {
-                    "description": "ID địa chỉ gửi đã được lưu trong bảng user_addresses",
+                "auction_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_amount": {
                     "type": "integer"
                 },
-                "to_address_id": {
-                    "description": "ID địa chỉ nhận đã được lưu trong bảng user_addresses",
+                "deposit_entry_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "api.rejectAuctionRequestBody": {
-            "type": "object",
-            "required": [
-                "reason"
-            ],
-            "properties": {
-                "reason": {
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "api.rejectWithdrawalRequestRequest": {
-            "type": "object",
-            "required": [
-                "reason"
-            ],
-            "properties": {
-                "reason": {
-                    "description": "Lý do từ chối yêu cầu rút tiền của moderator",
+                },
+                "is_refunded": {
+                    "type": "boolean"
+                },
+                "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "api.requestNegotiationForOfferRequest": {
-            "type": "object",
-            "required": [
-                "note"
-            ],
-            "properties": {
-                "note": {
-                    "description": "Ghi chú từ người yêu cầu thương lượng, không bắt buộc",
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "api.updateAuctionDetailsByModeratorBody": {
+        "db.AuctionRequest": {
             "type": "object",
             "required": [
+                "approved_by",
+                "bid_increment",
+                "buy_now_price",
+                "created_at",
+                "deposit_amount",
+                "deposit_rate",
                 "end_time",
-                "start_time"
+                "gundam_id",
+                "gundam_snapshot",
+                "id",
+                "rejected_by",
+                "rejected_reason",
+                "seller_id",
+                "start_time",
+                "starting_price",
+                "status",
+                "updated_at"
             ],
             "properties": {
+                "approved_by": {
+                    "type": "string"
+                },
+                "bid_increment": {
+                    "type": "integer"
+                },
+                "buy_now_price": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_amount": {
+                    "type": "integer"
+                },
+                "deposit_rate": {
+                    "type": "number"
+                },
                 "end_time": {
                     "type": "string"
                 },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "gundam_snapshot": {
+                    "$ref": "#/definitions/db.GundamSnapshot"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rejected_by": {
+                    "type": "string"
+                },
+                "rejected_reason": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
+                },
+                "starting_price": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.AuctionRequestStatus"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "api.updateAvatarResponse": {
+        "db.AuctionRequestStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "AuctionRequestStatusPending",
+                "AuctionRequestStatusApproved",
+                "AuctionRequestStatusRejected"
+            ]
+        },
+        "db.AuctionStatus": {
+            "type": "string",
+            "enum": [
+                "scheduled",
+                "active",
+                "ended",
+                "completed",
+                "canceled",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "AuctionStatusScheduled",
+                "AuctionStatusActive",
+                "AuctionStatusEnded",
+                "AuctionStatusCompleted",
+                "AuctionStatusCanceled",
+                "AuctionStatusFailed"
+            ]
+        },
+        "db.CancelExchangeTxResult": {
             "type": "object",
             "required": [
-                "avatar_url"
+                "exchange",
+                "refunded_compensation",
+                "refunded_offerer_delivery_fee",
+                "refunded_poster_delivery_fee"
             ],
             "properties": {
-                "avatar_url": {
-                    "type": "string"
+                "exchange": {
+                    "$ref": "#/definitions/db.Exchange"
+                },
+                "refunded_compensation": {
+                    "type": "boolean"
+                },
+                "refunded_offerer_delivery_fee": {
+                    "type": "boolean"
+                },
+                "refunded_poster_delivery_fee": {
+                    "type": "boolean"
                 }
             }
         },
-        "api.updateExchangeOfferRequest": {
+        "db.CancelOrderByBuyerTxResult": {
             "type": "object",
             "required": [
-                "compensation_amount",
-                "note",
-                "payer_id",
-                "require_compensation"
+                "buyer_wallet",
+                "order",
+                "order_transaction",
+                "refund_entry"
             ],
             "properties": {
-                "compensation_amount": {
-                    "description": "Bắt buộc khi require_compensation=true",
-                    "type": "integer"
-                },
-                "note": {
-                    "description": "Tin nhắn thương lượng, không bắt buộc",
-                    "type": "string"
+                "buyer_wallet": {
+                    "$ref": "#/definitions/db.Wallet"
                 },
-                "payer_id": {
-                    "description": "ID người trả tiền bù, bắt buộc khi require_compensation=true",
-                    "type": "string"
+                "order": {
+                    "$ref": "#/definitions/db.Order"
                 },
-                "require_compensation": {
-                    "description": "true = yêu cầu bù tiền, false = không yêu cầu bù tiền",
-                    "type": "boolean"
+                "order_transaction": {
+                    "$ref": "#/definitions/db.OrderTransaction"
+                },
+                "refund_entry": {
+                    "$ref": "#/definitions/db.WalletEntry"
                 }
             }
         },
-        "api.updateSellerProfileRequest": {
+        "db.CancelOrderBySellerTxResult": {
             "type": "object",
             "required": [
-                "shop_name",
-                "user_id"
+                "buyer_wallet",
+                "order",
+                "order_transaction",
+                "refund_entry"
             ],
             "properties": {
-                "shop_name": {
-                    "type": "string"
+                "buyer_wallet": {
+                    "$ref": "#/definitions/db.Wallet"
                 },
-                "user_id": {
-                    "type": "string"
+                "order": {
+                    "$ref": "#/definitions/db.Order"
+                },
+                "order_transaction": {
+                    "$ref": "#/definitions/db.OrderTransaction"
+                },
+                "refund_entry": {
+                    "$ref": "#/definitions/db.WalletEntry"
                 }
             }
         },
-        "api.updateUserAddressRequest": {
+        "db.Checkout": {
             "type": "object",
             "required": [
-                "detail",
-                "district_name",
-                "full_name",
-                "ghn_district_id",
-                "ghn_ward_code",
-                "is_pickup_address",
-                "is_primary",
-                "phone_number",
-                "province_name",
-                "ward_name"
+                "buyer_id",
+                "created_at",
+                "delivery_fee",
+                "id",
+                "items_subtotal",
+                "total_amount"
             ],
             "properties": {
-                "detail": {
-                    "type": "string"
-                },
-                "district_name": {
+                "buyer_id": {
                     "type": "string"
                 },
-                "full_name": {
+                "created_at": {
                     "type": "string"
                 },
-                "ghn_district_id": {
+                "delivery_fee": {
+                    "description": "Tổng phí vận chuyển của tất cả đơn hàng",
                     "type": "integer"
                 },
-                "ghn_ward_code": {
+                "id": {
                     "type": "string"
                 },
-                "is_pickup_address": {
-                    "type": "boolean"
-                },
-                "is_primary": {
-                    "type": "boolean"
+                "items_subtotal": {
+                    "description": "Tổng giá trị sản phẩm của tất cả đơn hàng",
+                    "type": "integer"
                 },
-                "phone_number": {
-                    "type": "string"
+                "total_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.CheckoutOrderDetails": {
+            "type": "object",
+            "required": [
+                "order",
+                "order_delivery",
+                "order_items"
+            ],
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/db.Order"
                 },
-                "province_name": {
-                    "type": "string"
+                "order_delivery": {
+                    "$ref": "#/definitions/db.OrderDelivery"
                 },
-                "ward_name": {
-                    "type": "string"
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OrderItem"
+                    }
                 }
             }
         },
-        "api.updateUserRequest": {
+        "db.CheckoutTxResult": {
             "type": "object",
             "required": [
-                "full_name"
+                "checkout",
+                "orders"
             ],
             "properties": {
-                "full_name": {
-                    "type": "string"
+                "checkout": {
+                    "$ref": "#/definitions/db.Checkout"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.CheckoutOrderDetails"
+                    }
                 }
             }
         },
-        "api.upgradeSubscriptionRequest": {
+        "db.ConfirmOrderTxResult": {
             "type": "object",
             "required": [
-                "plan_id"
+                "order",
+                "order_items",
+                "order_transaction",
+                "seller_entry"
             ],
             "properties": {
-                "plan_id": {
-                    "description": "ID of the target subscription plan",
-                    "type": "integer",
-                    "example": 2
+                "order": {
+                    "description": "Đơn hàng đã được cập nhật",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Order"
+                        }
+                    ]
+                },
+                "order_items": {
+                    "description": "Các mặt hàng trong đơn hàng",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OrderItem"
+                    }
+                },
+                "order_transaction": {
+                    "description": "Giao dịch đơn hàng đã được cập nhật với seller_entry_id",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.OrderTransaction"
+                        }
+                    ]
+                },
+                "seller_entry": {
+                    "description": "Bút toán cộng tiền cho người bán (pending)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.WalletEntry"
+                        }
+                    ]
                 }
             }
         },
-        "api.verifyAccessTokenRequest": {
+        "db.CreateExchangeOfferTxResult": {
             "type": "object",
             "required": [
-                "access_token"
+                "offer",
+                "offer_items"
             ],
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "offer": {
+                    "$ref": "#/definitions/db.ExchangeOffer"
+                },
+                "offer_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ExchangeOfferItem"
+                    }
                 }
             }
         },
-        "db.AcceptExchangeOfferTxResult": {
+        "db.CreateExchangePostTxResult": {
             "type": "object",
             "required": [
-                "exchange"
+                "exchange_post",
+                "exchange_post_items"
             ],
             "properties": {
-                "exchange": {
-                    "$ref": "#/definitions/db.Exchange"
+                "exchange_post": {
+                    "$ref": "#/definitions/db.ExchangePost"
+                },
+                "exchange_post_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ExchangePostItem"
+                    }
                 }
             }
         },
-        "db.AddCartItemRow": {
+        "db.CreateOrderTxResult": {
             "type": "object",
             "required": [
-                "cart_item_id",
-                "gundam_id",
-                "gundam_image_url",
-                "gundam_name",
-                "gundam_price",
-                "seller_avatar_url",
-                "seller_id",
-                "seller_name"
+                "buyer_entry",
+                "order",
+                "order_delivery",
+                "order_items",
+                "order_transaction"
             ],
             "properties": {
-                "cart_item_id": {
-                    "type": "string"
-                },
-                "gundam_id": {
-                    "type": "integer"
-                },
-                "gundam_image_url": {
-                    "type": "string"
+                "buyer_entry": {
+                    "$ref": "#/definitions/db.WalletEntry"
                 },
-                "gundam_name": {
-                    "type": "string"
+                "order": {
+                    "$ref": "#/definitions/db.Order"
                 },
-                "gundam_price": {
-                    "type": "integer"
+                "order_delivery": {
+                    "$ref": "#/definitions/db.OrderDelivery"
                 },
-                "seller_avatar_url": {
-                    "type": "string"
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OrderItem"
+                    }
                 },
-                "seller_id": {
-                    "type": "string"
+                "order_transaction": {
+                    "$ref": "#/definitions/db.OrderTransaction"
+                }
+            }
+        },
+        "db.DeleteExchangePostTxResult": {
+            "type": "object",
+            "required": [
+                "deleted_exchange_post",
+                "deleted_exchange_post_offers"
+            ],
+            "properties": {
+                "deleted_exchange_post": {
+                    "$ref": "#/definitions/db.ExchangePost"
                 },
-                "seller_name": {
-                    "type": "string"
+                "deleted_exchange_post_offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ExchangeOffer"
+                    }
                 }
             }
         },
-        "db.AdminDashboard": {
+        "db.DeliveryInformation": {
             "type": "object",
             "required": [
-                "completed_auctions_this_week",
-                "completed_exchanges_this_month",
-                "new_users_this_week",
-                "total_auction_orders_this_month",
-                "total_business_users",
-                "total_exchange_orders_this_month",
-                "total_published_gundams",
-                "total_regular_orders_this_month",
-                "total_revenue_this_month",
-                "total_wallet_volume_this_week"
+                "created_at",
+                "detail",
+                "district_name",
+                "full_name",
+                "ghn_district_id",
+                "ghn_ward_code",
+                "id",
+                "phone_number",
+                "province_name",
+                "user_id",
+                "ward_name"
             ],
             "properties": {
-                "completed_auctions_this_week": {
-                    "description": "Tổng số phiên đấu giá đã hoàn thành trong tuần này",
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "completed_exchanges_this_month": {
-                    "description": "Tổng số cuộc trao đổi đã hoàn thành trong tháng này",
-                    "type": "integer"
+                "detail": {
+                    "type": "string"
                 },
-                "new_users_this_week": {
-                    "description": "Số lượng người dùng mới trong tuần này",
-                    "type": "integer"
+                "district_name": {
+                    "type": "string"
                 },
-                "total_auction_orders_this_month": {
-                    "description": "Tổng số đơn hàng đấu giá trong tháng này",
-                    "type": "integer"
+                "full_name": {
+                    "type": "string"
                 },
-                "total_business_users": {
-                    "description": "Tổng số người dùng hoạt động trên nền tảng (chỉ tính role member và seller)",
+                "ghn_district_id": {
                     "type": "integer"
                 },
-                "total_exchange_orders_this_month": {
-                    "description": "Tổng số đơn hàng trao đổi trong tháng này",
-                    "type": "integer"
+                "ghn_ward_code": {
+                    "type": "string"
                 },
-                "total_published_gundams": {
-                    "description": "Tổng số gundam đã được đăng bán",
+                "id": {
                     "type": "integer"
                 },
-                "total_regular_orders_this_month": {
-                    "description": "Tổng số đơn hàng thường trong tháng này",
-                    "type": "integer"
+                "phone_number": {
+                    "type": "string"
                 },
-                "total_revenue_this_month": {
-                    "description": "Tổng doanh thu trong tháng này",
-                    "type": "integer"
+                "province_name": {
+                    "type": "string"
                 },
-                "total_wallet_volume_this_week": {
-                    "description": "Tổng khối lượng giao dịch ví trong tuần này",
-                    "type": "integer"
+                "user_id": {
+                    "type": "string"
+                },
+                "ward_name": {
+                    "type": "string"
                 }
             }
         },
-        "db.ApproveAuctionRequestTxResult": {
-            "type": "object",
-            "required": [
-                "created_auction",
-                "updated_request"
+        "db.DeliveryOverralStatus": {
+            "type": "string",
+            "enum": [
+                "picking",
+                "delivering",
+                "delivered",
+                "failed",
+                "return"
             ],
-            "properties": {
-                "created_auction": {
-                    "$ref": "#/definitions/db.Auction"
-                },
-                "updated_request": {
-                    "$ref": "#/definitions/db.AuctionRequest"
-                }
-            }
+            "x-enum-varnames": [
+                "DeliveryOverralStatusPicking",
+                "DeliveryOverralStatusDelivering",
+                "DeliveryOverralStatusDelivered",
+                "DeliveryOverralStatusFailed",
+                "DeliveryOverralStatusReturn"
+            ]
         },
-        "db.Auction": {
+        "db.Exchange": {
             "type": "object",
             "required": [
-                "actual_end_time",
-                "bid_increment",
-                "buy_now_price",
                 "canceled_by",
                 "canceled_reason",
+                "compensation_amount",
+                "completed_at",
                 "created_at",
-                "current_price",
-                "deposit_amount",
-                "deposit_rate",
-                "end_time",
-                "gundam_id",
-                "gundam_snapshot",
                 "id",
-                "order_id",
-                "request_id",
-                "seller_id",
-                "start_time",
-                "starting_price",
+                "offerer_delivery_fee",
+                "offerer_delivery_fee_paid",
+                "offerer_from_delivery_id",
+                "offerer_id",
+                "offerer_order_expected_delivery_time",
+                "offerer_order_id",
+                "offerer_order_note",
+                "offerer_to_delivery_id",
+                "payer_id",
+                "poster_delivery_fee",
+                "poster_delivery_fee_paid",
+                "poster_from_delivery_id",
+                "poster_id",
+                "poster_order_expected_delivery_time",
+                "poster_order_id",
+                "poster_order_note",
+                "poster_to_delivery_id",
                 "status",
-                "total_bids",
-                "total_participants",
-                "updated_at",
-                "winner_payment_deadline",
-                "winning_bid_id"
+                "updated_at"
             ],
             "properties": {
-                "actual_end_time": {
-                    "type": "string"
-                },
-                "bid_increment": {
-                    "type": "integer"
-                },
-                "buy_now_price": {
-                    "type": "integer"
-                },
                 "canceled_by": {
                     "type": "string"
                 },
                 "canceled_reason": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
-                },
-                "current_price": {
+                "compensation_amount": {
                     "type": "integer"
                 },
-                "deposit_amount": {
-                    "type": "integer"
+                "completed_at": {
+                    "type": "string"
                 },
-                "deposit_rate": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "end_time": {
+                "id": {
                     "type": "string"
                 },
-                "gundam_id": {
+                "offerer_delivery_fee": {
                     "type": "integer"
                 },
-                "gundam_snapshot": {
-                    "$ref": "#/definitions/db.GundamSnapshot"
+                "offerer_delivery_fee_paid": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "offerer_from_delivery_id": {
+                    "type": "integer"
                 },
-                "order_id": {
+                "offerer_id": {
                     "type": "string"
                 },
-                "request_id": {
+                "offerer_order_expected_delivery_time": {
                     "type": "string"
                 },
-                "seller_id": {
+                "offerer_order_id": {
                     "type": "string"
                 },
-                "start_time": {
+                "offerer_order_note": {
                     "type": "string"
                 },
-                "starting_price": {
+                "offerer_to_delivery_id": {
                     "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/db.AuctionStatus"
-                },
-                "total_bids": {
-                    "type": "integer"
+                "payer_id": {
+                    "type": "string"
                 },
-                "total_participants": {
+                "poster_delivery_fee": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
-                },
-                "winner_payment_deadline": {
-                    "type": "string"
+                "poster_delivery_fee_paid": {
+                    "type": "boolean"
                 },
-                "winning_bid_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "db.AuctionBid": {
-            "type": "object",
-            "required": [
-                "amount",
-                "auction_id",
-                "bidder_id",
-                "created_at",
-                "id",
-                "participant_id"
-            ],
-            "properties": {
-                "amount": {
+                "poster_from_delivery_id": {
                     "type": "integer"
                 },
-                "auction_id": {
+                "poster_id": {
                     "type": "string"
                 },
-                "bidder_id": {
+                "poster_order_expected_delivery_time": {
                     "type": "string"
                 },
-                "created_at": {
+                "poster_order_id": {
                     "type": "string"
                 },
-                "id": {
+                "poster_order_note": {
                     "type": "string"
                 },
-                "participant_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "db.AuctionDetails": {
-            "type": "object",
-            "required": [
-                "auction",
-                "auction_bids",
-                "auction_participants"
-            ],
-            "properties": {
-                "auction": {
-                    "description": "Thông tin phiên đấu giá",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.Auction"
-                        }
-                    ]
+                "poster_to_delivery_id": {
+                    "type": "integer"
                 },
-                "auction_bids": {
-                    "description": "Danh sách giá đấu",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.AuctionBid"
-                    }
+                "status": {
+                    "$ref": "#/definitions/db.ExchangeStatus"
                 },
-                "auction_participants": {
-                    "description": "Danh sách người tham gia đấu giá",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.AuctionParticipant"
-                    }
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "db.AuctionParticipant": {
+        "db.ExchangeItem": {
             "type": "object",
             "required": [
-                "auction_id",
+                "condition",
                 "created_at",
-                "deposit_amount",
-                "deposit_entry_id",
+                "exchange_id",
+                "grade",
+                "gundam_id",
                 "id",
-                "is_refunded",
-                "updated_at",
-                "user_id"
+                "image_url",
+                "is_from_poster",
+                "name",
+                "owner_id",
+                "quantity",
+                "scale",
+                "slug",
+                "weight"
             ],
             "properties": {
-                "auction_id": {
-                    "type": "string"
+                "condition": {
+                    "$ref": "#/definitions/db.NullGundamCondition"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "deposit_amount": {
-                    "type": "integer"
+                "exchange_id": {
+                    "type": "string"
                 },
-                "deposit_entry_id": {
+                "grade": {
+                    "type": "string"
+                },
+                "gundam_id": {
                     "type": "integer"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_refunded": {
+                "image_url": {
+                    "type": "string"
+                },
+                "is_from_poster": {
                     "type": "boolean"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "owner_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "scale": {
                     "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "db.AuctionRequest": {
+        "db.ExchangeOffer": {
             "type": "object",
             "required": [
-                "approved_by",
-                "bid_increment",
-                "buy_now_price",
+                "compensation_amount",
                 "created_at",
-                "deposit_amount",
-                "deposit_rate",
-                "end_time",
-                "gundam_id",
-                "gundam_snapshot",
                 "id",
-                "rejected_by",
-                "rejected_reason",
-                "seller_id",
-                "start_time",
-                "starting_price",
-                "status",
+                "last_negotiation_at",
+                "max_negotiations",
+                "negotiation_requested",
+                "negotiations_count",
+                "note",
+                "offerer_id",
+                "payer_id",
+                "post_id",
                 "updated_at"
             ],
             "properties": {
-                "approved_by": {
-                    "type": "string"
-                },
-                "bid_increment": {
-                    "type": "integer"
-                },
-                "buy_now_price": {
+                "compensation_amount": {
                     "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "deposit_amount": {
-                    "type": "integer"
-                },
-                "deposit_rate": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "end_time": {
+                "last_negotiation_at": {
                     "type": "string"
                 },
-                "gundam_id": {
+                "max_negotiations": {
                     "type": "integer"
                 },
-                "gundam_snapshot": {
-                    "$ref": "#/definitions/db.GundamSnapshot"
+                "negotiation_requested": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "negotiations_count": {
+                    "type": "integer"
                 },
-                "rejected_by": {
+                "note": {
                     "type": "string"
                 },
-                "rejected_reason": {
+                "offerer_id": {
                     "type": "string"
                 },
-                "seller_id": {
+                "payer_id": {
                     "type": "string"
                 },
-                "start_time": {
+                "post_id": {
                     "type": "string"
                 },
-                "starting_price": {
-                    "type": "integer"
-                },
-                "status": {
-                    "$ref": "#/definitions/db.AuctionRequestStatus"
-                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "db.AuctionRequestStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "approved",
-                "rejected"
-            ],
-            "x-enum-varnames": [
-                "AuctionRequestStatusPending",
-                "AuctionRequestStatusApproved",
-                "AuctionRequestStatusRejected"
-            ]
-        },
-        "db.AuctionStatus": {
-            "type": "string",
-            "enum": [
-                "scheduled",
-                "active",
-                "ended",
-                "completed",
-                "canceled",
-                "failed"
-            ],
-            "x-enum-varnames": [
-                "AuctionStatusScheduled",
-                "AuctionStatusActive",
-                "AuctionStatusEnded",
-                "AuctionStatusCompleted",
-                "AuctionStatusCanceled",
-                "AuctionStatusFailed"
-            ]
-        },
-        "db.CancelExchangeTxResult": {
+        "db.ExchangeOfferInfo": {
             "type": "object",
             "required": [
-                "exchange",
-                "refunded_compensation",
-                "refunded_offerer_delivery_fee",
-                "refunded_poster_delivery_fee"
+                "compensation_amount",
+                "created_at",
+                "id",
+                "last_negotiation_at",
+                "max_negotiations",
+                "negotiation_notes",
+                "negotiation_requested",
+                "negotiations_count",
+                "note",
+                "offerer",
+                "offerer_exchange_items",
+                "payer_id",
+                "post_id",
+                "poster_exchange_items",
+                "updated_at"
             ],
             "properties": {
-                "exchange": {
-                    "$ref": "#/definitions/db.Exchange"
-                },
-                "refunded_compensation": {
-                    "type": "boolean"
-                },
-                "refunded_offerer_delivery_fee": {
-                    "type": "boolean"
+                "compensation_amount": {
+                    "description": "Số tiền bù",
+                    "type": "integer"
                 },
-                "refunded_poster_delivery_fee": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "db.CancelOrderByBuyerTxResult": {
-            "type": "object",
-            "required": [
-                "buyer_wallet",
-                "order",
-                "order_transaction",
-                "refund_entry"
-            ],
-            "properties": {
-                "buyer_wallet": {
-                    "$ref": "#/definitions/db.Wallet"
+                "created_at": {
+                    "description": "Thời gian tạo đề xuất",
+                    "type": "string"
                 },
-                "order": {
-                    "$ref": "#/definitions/db.Order"
+                "id": {
+                    "description": "ID đề xuất",
+                    "type": "string"
                 },
-                "order_transaction": {
-                    "$ref": "#/definitions/db.OrderTransaction"
+                "last_negotiation_at": {
+                    "description": "Thời gian thương lượng gần nhất",
+                    "type": "string"
                 },
-                "refund_entry": {
-                    "$ref": "#/definitions/db.WalletEntry"
-                }
-            }
-        },
-        "db.CancelOrderBySellerTxResult": {
-            "type": "object",
-            "required": [
-                "buyer_wallet",
-                "order",
-                "order_transaction",
-                "refund_entry"
-            ],
-            "properties": {
-                "buyer_wallet": {
-                    "$ref": "#/definitions/db.Wallet"
+                "max_negotiations": {
+                    "description": "Số lần thương lượng tối đa",
+                    "type": "integer"
                 },
-                "order": {
-                    "$ref": "#/definitions/db.Order"
+                "negotiation_notes": {
+                    "description": "Các ghi chú/tin nhắn thương lượng",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ExchangeOfferNote"
+                    }
                 },
-                "order_transaction": {
-                    "$ref": "#/definitions/db.OrderTransaction"
+                "negotiation_requested": {
+                    "description": "Đã yêu cầu thương lượng chưa",
+                    "type": "boolean"
                 },
-                "refund_entry": {
-                    "$ref": "#/definitions/db.WalletEntry"
-                }
-            }
-        },
-        "db.ConfirmOrderTxResult": {
-            "type": "object",
-            "required": [
-                "order",
-                "order_items",
-                "order_transaction",
-                "seller_entry"
-            ],
-            "properties": {
-                "order": {
-                    "description": "Đơn hàng đã được cập nhật",
+                "negotiations_count": {
+                    "description": "Số lần đã thương lượng",
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Ghi chú của đề xuất",
+                    "type": "string"
+                },
+                "offerer": {
+                    "description": "Thông tin người đề xuất",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/db.Order"
+                            "$ref": "#/definitions/db.User"
                         }
                     ]
                 },
-                "order_items": {
-                    "description": "Các mặt hàng trong đơn hàng",
+                "offerer_exchange_items": {
+                    "description": "Danh sách Gundam của người đề xuất",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.OrderItem"
+                        "$ref": "#/definitions/db.GundamDetails"
                     }
                 },
-                "order_transaction": {
-                    "description": "Giao dịch đơn hàng đã được cập nhật với seller_entry_id",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.OrderTransaction"
-                        }
-                    ]
+                "payer_id": {
+                    "description": "ID người bù tiền",
+                    "type": "string"
                 },
-                "seller_entry": {
-                    "description": "Bút toán cộng tiền cho người bán (pending)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.WalletEntry"
-                        }
-                    ]
-                }
-            }
-        },
-        "db.CreateExchangeOfferTxResult": {
-            "type": "object",
-            "required": [
-                "offer",
-                "offer_items"
-            ],
-            "properties": {
-                "offer": {
-                    "$ref": "#/definitions/db.ExchangeOffer"
+                "post_id": {
+                    "description": "ID bài đăng trao đổi",
+                    "type": "string"
                 },
-                "offer_items": {
+                "poster_exchange_items": {
+                    "description": "Danh sách Gundam của người đăng bài mà người đề xuất muốn trao đổi",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.ExchangeOfferItem"
+                        "$ref": "#/definitions/db.GundamDetails"
                     }
+                },
+                "updated_at": {
+                    "description": "Thời gian cập nhật đề xuất gần nhất",
+                    "type": "string"
                 }
             }
         },
-        "db.CreateExchangePostTxResult": {
+        "db.ExchangeOfferItem": {
             "type": "object",
             "required": [
-                "exchange_post",
-                "exchange_post_items"
+                "condition",
+                "created_at",
+                "gundam_id",
+                "id",
+                "is_from_poster",
+                "offer_id"
             ],
             "properties": {
-                "exchange_post": {
-                    "$ref": "#/definitions/db.ExchangePost"
+                "condition": {
+                    "$ref": "#/definitions/db.NullGundamCondition"
                 },
-                "exchange_post_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.ExchangePostItem"
-                    }
+                "created_at": {
+                    "type": "string"
+                },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_from_poster": {
+                    "type": "boolean"
+                },
+                "offer_id": {
+                    "type": "string"
                 }
             }
         },
-        "db.CreateOrderTxResult": {
+        "db.ExchangeOfferNote": {
             "type": "object",
             "required": [
-                "buyer_entry",
-                "order",
-                "order_delivery",
-                "order_items",
-                "order_transaction"
+                "content",
+                "created_at",
+                "id",
+                "offer_id",
+                "user_id"
             ],
             "properties": {
-                "buyer_entry": {
-                    "$ref": "#/definitions/db.WalletEntry"
+                "content": {
+                    "type": "string"
                 },
-                "order": {
-                    "$ref": "#/definitions/db.Order"
+                "created_at": {
+                    "type": "string"
                 },
-                "order_delivery": {
-                    "$ref": "#/definitions/db.OrderDelivery"
+                "id": {
+                    "type": "string"
                 },
-                "order_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.OrderItem"
-                    }
+                "offer_id": {
+                    "type": "string"
                 },
-                "order_transaction": {
-                    "$ref": "#/definitions/db.OrderTransaction"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "db.DeleteExchangePostTxResult": {
+        "db.ExchangePost": {
             "type": "object",
             "required": [
-                "deleted_exchange_post",
-                "deleted_exchange_post_offers"
+                "content",
+                "created_at",
+                "id",
+                "post_image_urls",
+                "status",
+                "updated_at",
+                "user_id"
             ],
             "properties": {
-                "deleted_exchange_post": {
-                    "$ref": "#/definitions/db.ExchangePost"
+                "content": {
+                    "type": "string"
                 },
-                "deleted_exchange_post_offers": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "post_image_urls": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.ExchangeOffer"
+                        "type": "string"
                     }
+                },
+                "status": {
+                    "$ref": "#/definitions/db.ExchangePostStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "db.DeliveryInformation": {
+        "db.ExchangePostItem": {
             "type": "object",
             "required": [
                 "created_at",
-                "detail",
-                "district_name",
-                "full_name",
-                "ghn_district_id",
-                "ghn_ward_code",
+                "gundam_id",
                 "id",
-                "phone_number",
-                "province_name",
-                "user_id",
-                "ward_name"
+                "post_id"
             ],
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "detail": {
-                    "type": "string"
-                },
-                "district_name": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string"
-                },
-                "ghn_district_id": {
+                "gundam_id": {
                     "type": "integer"
                 },
-                "ghn_ward_code": {
-                    "type": "string"
-                },
                 "id": {
-                    "type": "integer"
-                },
-                "phone_number": {
-                    "type": "string"
-                },
-                "province_name": {
-                    "type": "string"
-                },
-                "user_id": {
                     "type": "string"
                 },
-                "ward_name": {
+                "post_id": {
                     "type": "string"
                 }
             }
         },
-        "db.DeliveryOverralStatus": {
+        "db.ExchangePostStatus": {
             "type": "string",
             "enum": [
-                "picking",
+                "open",
+                "closed"
+            ],
+            "x-enum-varnames": [
+                "ExchangePostStatusOpen",
+                "ExchangePostStatusClosed"
+            ]
+        },
+        "db.ExchangeStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "packaging",
                 "delivering",
                 "delivered",
-                "failed",
-                "return"
+                "completed",
+                "canceled",
+                "failed"
             ],
             "x-enum-varnames": [
-                "DeliveryOverralStatusPicking",
-                "DeliveryOverralStatusDelivering",
-                "DeliveryOverralStatusDelivered",
-                "DeliveryOverralStatusFailed",
-                "DeliveryOverralStatusReturn"
+                "ExchangeStatusPending",
+                "ExchangeStatusPackaging",
+                "ExchangeStatusDelivering",
+                "ExchangeStatusDelivered",
+                "ExchangeStatusCompleted",
+                "ExchangeStatusCanceled",
+                "ExchangeStatusFailed"
             ]
         },
-        "db.Exchange": {
-            "type": "object",
-            "required": [
-                "canceled_by",
-                "canceled_reason",
-                "compensation_amount",
-                "completed_at",
-                "created_at",
-                "id",
-                "offerer_delivery_fee",
-                "offerer_delivery_fee_paid",
-                "offerer_from_delivery_id",
-                "offerer_id",
-                "offerer_order_expected_delivery_time",
-                "offerer_order_id",
-                "offerer_order_note",
-                "offerer_to_delivery_id",
-                "payer_id",
-                "poster_delivery_fee",
-                "poster_delivery_fee_paid",
-                "poster_from_delivery_id",
-                "poster_id",
-                "poster_order_expected_delivery_time",
-                "poster_order_id",
-                "poster_order_note",
-                "poster_to_delivery_id",
-                "status",
-                "updated_at"
+        "db.ExchangeUserInfo": {
+            "type": "object",
+            "required": [
+                "avatar_url",
+                "delivery_fee",
+                "delivery_fee_paid",
+                "expected_delivery_time",
+                "from_address",
+                "full_name",
+                "id",
+                "items",
+                "note",
+                "order",
+                "to_address"
             ],
             "properties": {
-                "canceled_by": {
-                    "type": "string"
-                },
-                "canceled_reason": {
-                    "type": "string"
-                },
-                "compensation_amount": {
-                    "type": "integer"
-                },
-                "completed_at": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "avatar_url": {
+                    "description": "URL ảnh đại diện người dùng",
                     "type": "string"
                 },
-                "offerer_delivery_fee": {
+                "delivery_fee": {
+                    "description": "Phí vận chuyển",
                     "type": "integer"
                 },
-                "offerer_delivery_fee_paid": {
+                "delivery_fee_paid": {
+                    "description": "Đã thanh toán phí vận chuyển chưa",
                     "type": "boolean"
                 },
-                "offerer_from_delivery_id": {
-                    "type": "integer"
-                },
-                "offerer_id": {
-                    "type": "string"
-                },
-                "offerer_order_expected_delivery_time": {
-                    "type": "string"
-                },
-                "offerer_order_id": {
-                    "type": "string"
-                },
-                "offerer_order_note": {
-                    "type": "string"
-                },
-                "offerer_to_delivery_id": {
-                    "type": "integer"
-                },
-                "payer_id": {
+                "expected_delivery_time": {
+                    "description": "Thời gian giao hàng dự kiến",
                     "type": "string"
                 },
-                "poster_delivery_fee": {
-                    "type": "integer"
-                },
-                "poster_delivery_fee_paid": {
-                    "type": "boolean"
-                },
-                "poster_from_delivery_id": {
-                    "type": "integer"
+                "from_address": {
+                    "description": "Địa chỉ gửi hàng",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.DeliveryInformation"
+                        }
+                    ]
                 },
-                "poster_id": {
+                "full_name": {
+                    "description": "Tên người dùng",
                     "type": "string"
                 },
-                "poster_order_expected_delivery_time": {
+                "id": {
+                    "description": "Thông tin cơ bản",
                     "type": "string"
                 },
-                "poster_order_id": {
-                    "type": "string"
+                "items": {
+                    "description": "Danh sách Gundam",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ExchangeItem"
+                    }
                 },
-                "poster_order_note": {
+                "note": {
+                    "description": "Ghi chú của người dùng",
                     "type": "string"
                 },
-                "poster_to_delivery_id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "$ref": "#/definitions/db.ExchangeStatus"
+                "order": {
+                    "description": "Thông tin đơn hàng và vận chuyển",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.Order"
+                        }
+                    ]
                 },
-                "updated_at": {
-                    "type": "string"
+                "to_address": {
+                    "description": "Địa chỉ nhận hàng",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/db.DeliveryInformation"
+                        }
+                    ]
                 }
             }
         },
-        "db.ExchangeItem": {
+        "db.GetModelKitDetailsRow": {
             "type": "object",
             "required": [
                 "created_at",
-                "exchange_id",
                 "grade",
-                "gundam_id",
+                "grade_id",
                 "id",
                 "image_url",
-                "is_from_poster",
+                "listing_count",
+                "lowest_price",
+                "manufacturer",
+                "msrp",
                 "name",
-                "owner_id",
-                "quantity",
+                "release_year",
                 "scale",
+                "series",
                 "slug",
-                "weight"
+                "updated_at",
+                "version"
             ],
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "exchange_id": {
-                    "type": "string"
-                },
                 "grade": {
                     "type": "string"
                 },
-                "gundam_id": {
+                "grade_id": {
                     "type": "integer"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "integer"
                 },
                 "image_url": {
                     "type": "string"
                 },
-                "is_from_poster": {
-                    "type": "boolean"
+                "listing_count": {
+                    "type": "integer"
                 },
-                "name": {
+                "lowest_price": {
+                    "type": "integer"
+                },
+                "manufacturer": {
                     "type": "string"
                 },
-                "owner_id": {
+                "msrp": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "quantity": {
+                "release_year": {
                     "type": "integer"
                 },
                 "scale": {
+                    "$ref": "#/definitions/db.GundamScale"
+                },
+                "series": {
                     "type": "string"
                 },
                 "slug": {
                     "type": "string"
                 },
-                "weight": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "db.ExchangeOffer": {
+        "db.GetSellerDetailByIDRow": {
             "type": "object",
             "required": [
-                "compensation_amount",
-                "created_at",
-                "id",
-                "last_negotiation_at",
-                "max_negotiations",
-                "negotiation_requested",
-                "negotiations_count",
-                "note",
-                "offerer_id",
-                "payer_id",
-                "post_id",
-                "updated_at"
+                "seller_profile",
+                "user"
             ],
             "properties": {
-                "compensation_amount": {
+                "seller_profile": {
+                    "$ref": "#/definitions/db.SellerProfile"
+                },
+                "user": {
+                    "$ref": "#/definitions/db.User"
+                }
+            }
+        },
+        "db.GetUserDeletionBlockersRow": {
+            "type": "object",
+            "required": [
+                "open_auctions",
+                "open_exchanges",
+                "open_orders",
+                "pending_withdrawals",
+                "wallet_balance"
+            ],
+            "properties": {
+                "open_auctions": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "type": "string"
+                "open_exchanges": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "open_orders": {
+                    "type": "integer"
                 },
-                "last_negotiation_at": {
-                    "type": "string"
+                "pending_withdrawals": {
+                    "type": "integer"
                 },
-                "max_negotiations": {
+                "wallet_balance": {
                     "type": "integer"
+                }
+            }
+        },
+        "db.GundamAccessoryDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "quantity"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
                 },
-                "negotiation_requested": {
-                    "type": "boolean"
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.GundamCondition": {
+            "type": "string",
+            "enum": [
+                "new",
+                "open box",
+                "used"
+            ],
+            "x-enum-varnames": [
+                "GundamConditionNew",
+                "GundamConditionOpenbox",
+                "GundamConditionUsed"
+            ]
+        },
+        "db.GundamDetails": {
+            "type": "object",
+            "required": [
+                "accessories",
+                "available_quantity",
+                "condition",
+                "condition_description",
+                "created_at",
+                "description",
+                "grade",
+                "gundam_id",
+                "manufacturer",
+                "material",
+                "model_kit_id",
+                "name",
+                "owner_id",
+                "parts_total",
+                "price",
+                "primary_image_url",
+                "quantity",
+                "release_year",
+                "scale",
+                "secondary_image_urls",
+                "series",
+                "slug",
+                "status",
+                "updated_at",
+                "version",
+                "weight"
+            ],
+            "properties": {
+                "accessories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.GundamAccessoryDTO"
+                    }
                 },
-                "negotiations_count": {
+                "available_quantity": {
+                    "description": "Số lượng còn có thể mua (chưa được giữ cho đơn hàng nào)",
                     "type": "integer"
                 },
-                "note": {
+                "condition": {
+                    "type": "string"
+                },
+                "condition_description": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "offerer_id": {
+                "description": {
                     "type": "string"
                 },
-                "payer_id": {
+                "grade": {
                     "type": "string"
                 },
-                "post_id": {
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "manufacturer": {
                     "type": "string"
                 },
-                "updated_at": {
+                "material": {
                     "type": "string"
-                }
-            }
-        },
-        "db.ExchangeOfferInfo": {
-            "type": "object",
-            "required": [
-                "compensation_amount",
-                "created_at",
-                "id",
-                "last_negotiation_at",
-                "max_negotiations",
-                "negotiation_notes",
-                "negotiation_requested",
-                "negotiations_count",
-                "note",
-                "offerer",
-                "offerer_exchange_items",
-                "payer_id",
-                "post_id",
-                "poster_exchange_items",
-                "updated_at"
-            ],
-            "properties": {
-                "compensation_amount": {
-                    "description": "Số tiền bù",
+                },
+                "model_kit_id": {
                     "type": "integer"
                 },
-                "created_at": {
-                    "description": "Thời gian tạo đề xuất",
+                "name": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID đề xuất",
+                "owner_id": {
                     "type": "string"
                 },
-                "last_negotiation_at": {
-                    "description": "Thời gian thương lượng gần nhất",
-                    "type": "string"
+                "parts_total": {
+                    "type": "integer"
                 },
-                "max_negotiations": {
-                    "description": "Số lần thương lượng tối đa",
+                "price": {
                     "type": "integer"
                 },
-                "negotiation_notes": {
-                    "description": "Các ghi chú/tin nhắn thương lượng",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.ExchangeOfferNote"
-                    }
+                "primary_image_url": {
+                    "type": "string"
                 },
-                "negotiation_requested": {
-                    "description": "Đã yêu cầu thương lượng chưa",
-                    "type": "boolean"
+                "quantity": {
+                    "type": "integer"
                 },
-                "negotiations_count": {
-                    "description": "Số lần đã thương lượng",
+                "release_year": {
                     "type": "integer"
                 },
-                "note": {
-                    "description": "Ghi chú của đề xuất",
+                "scale": {
                     "type": "string"
                 },
-                "offerer": {
-                    "description": "Thông tin người đề xuất",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.User"
-                        }
-                    ]
-                },
-                "offerer_exchange_items": {
-                    "description": "Danh sách Gundam của người đề xuất",
+                "secondary_image_urls": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.GundamDetails"
+                        "type": "string"
                     }
                 },
-                "payer_id": {
-                    "description": "ID người bù tiền",
+                "series": {
                     "type": "string"
                 },
-                "post_id": {
-                    "description": "ID bài đăng trao đổi",
+                "slug": {
                     "type": "string"
                 },
-                "poster_exchange_items": {
-                    "description": "Danh sách Gundam của người đăng bài mà người đề xuất muốn trao đổi",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.GundamDetails"
-                    }
+                "status": {
+                    "type": "string"
                 },
                 "updated_at": {
-                    "description": "Thời gian cập nhật đề xuất gần nhất",
                     "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "db.ExchangeOfferItem": {
+        "db.GundamFacetValue": {
             "type": "object",
             "required": [
-                "created_at",
-                "gundam_id",
-                "id",
-                "is_from_poster",
-                "offer_id"
+                "count",
+                "label",
+                "value"
             ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "gundam_id": {
+                "count": {
                     "type": "integer"
                 },
-                "id": {
+                "label": {
                     "type": "string"
                 },
-                "is_from_poster": {
-                    "type": "boolean"
-                },
-                "offer_id": {
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "db.ExchangeOfferNote": {
+        "db.GundamGrade": {
             "type": "object",
             "required": [
-                "content",
                 "created_at",
+                "display_name",
                 "id",
-                "offer_id",
-                "user_id"
+                "name",
+                "slug"
             ],
             "properties": {
-                "content": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "display_name": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "offer_id": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "slug": {
                     "type": "string"
                 }
             }
         },
-        "db.ExchangePost": {
+        "db.GundamPriceHistory": {
             "type": "object",
             "required": [
-                "content",
                 "created_at",
+                "gundam_id",
                 "id",
-                "post_image_urls",
-                "status",
-                "updated_at",
-                "user_id"
+                "previous_price",
+                "price"
             ],
             "properties": {
-                "content": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "post_image_urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "gundam_id": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/db.ExchangePostStatus"
+                "id": {
+                    "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "previous_price": {
+                    "type": "integer"
                 },
-                "user_id": {
-                    "type": "string"
+                "price": {
+                    "type": "integer"
                 }
             }
         },
-        "db.ExchangePostItem": {
+        "db.GundamScale": {
+            "type": "string",
+            "enum": [
+                "1/144",
+                "1/100",
+                "1/60",
+                "1/48"
+            ],
+            "x-enum-varnames": [
+                "GundamScale1144",
+                "GundamScale1100",
+                "GundamScale160",
+                "GundamScale148"
+            ]
+        },
+        "db.GundamSnapshot": {
             "type": "object",
             "required": [
-                "created_at",
-                "gundam_id",
+                "condition",
+                "grade",
                 "id",
-                "post_id"
+                "image_url",
+                "name",
+                "quantity",
+                "scale",
+                "slug",
+                "weight"
             ],
             "properties": {
-                "created_at": {
+                "condition": {
+                    "description": "Condition là tình trạng của Gundam lúc tạo yêu cầu đấu giá, rỗng với các snapshot cũ",
                     "type": "string"
                 },
-                "gundam_id": {
-                    "type": "integer"
+                "grade": {
+                    "type": "string"
                 },
                 "id": {
+                    "type": "integer"
+                },
+                "image_url": {
                     "type": "string"
                 },
-                "post_id": {
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "scale": {
+                    "type": "string"
+                },
+                "slug": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "db.ExchangePostStatus": {
-            "type": "string",
-            "enum": [
-                "open",
-                "closed"
-            ],
-            "x-enum-varnames": [
-                "ExchangePostStatusOpen",
-                "ExchangePostStatusClosed"
-            ]
-        },
-        "db.ExchangeStatus": {
+        "db.GundamStatus": {
             "type": "string",
             "enum": [
-                "pending",
-                "packaging",
-                "delivering",
-                "delivered",
-                "completed",
-                "canceled",
-                "failed"
+                "in store",
+                "published",
+                "processing",
+                "pending_auction_approval",
+                "auctioning",
+                "for exchange",
+                "exchanging"
             ],
             "x-enum-varnames": [
-                "ExchangeStatusPending",
-                "ExchangeStatusPackaging",
-                "ExchangeStatusDelivering",
-                "ExchangeStatusDelivered",
-                "ExchangeStatusCompleted",
-                "ExchangeStatusCanceled",
-                "ExchangeStatusFailed"
+                "GundamStatusInstore",
+                "GundamStatusPublished",
+                "GundamStatusProcessing",
+                "GundamStatusPendingAuctionApproval",
+                "GundamStatusAuctioning",
+                "GundamStatusForexchange",
+                "GundamStatusExchanging"
             ]
         },
-        "db.ExchangeUserInfo": {
+        "db.ImageCleanupOrphan": {
             "type": "object",
             "required": [
-                "avatar_url",
-                "delivery_fee",
-                "delivery_fee_paid",
-                "expected_delivery_time",
-                "from_address",
-                "full_name",
+                "deleted",
+                "key",
+                "last_modified",
+                "size"
+            ],
+            "properties": {
+                "deleted": {
+                    "description": "false nếu là dry run hoặc xóa thất bại",
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_modified": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ImageCleanupRun": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "deleted_count",
+                "dry_run",
+                "error",
+                "failed_count",
+                "finished_at",
+                "grace_period_hours",
                 "id",
-                "items",
-                "note",
-                "order",
-                "to_address"
+                "orphaned_bytes",
+                "orphaned_count",
+                "orphans",
+                "recent_count",
+                "requested_by",
+                "scanned_count",
+                "started_at",
+                "status"
             ],
             "properties": {
-                "avatar_url": {
-                    "description": "URL ảnh đại diện người dùng",
+                "created_at": {
                     "type": "string"
                 },
-                "delivery_fee": {
-                    "description": "Phí vận chuyển",
+                "deleted_count": {
                     "type": "integer"
                 },
-                "delivery_fee_paid": {
-                    "description": "Đã thanh toán phí vận chuyển chưa",
+                "dry_run": {
                     "type": "boolean"
                 },
-                "expected_delivery_time": {
-                    "description": "Thời gian giao hàng dự kiến",
+                "error": {
                     "type": "string"
                 },
-                "from_address": {
-                    "description": "Địa chỉ gửi hàng",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.DeliveryInformation"
-                        }
-                    ]
+                "failed_count": {
+                    "type": "integer"
                 },
-                "full_name": {
-                    "description": "Tên người dùng",
+                "finished_at": {
                     "type": "string"
                 },
+                "grace_period_hours": {
+                    "type": "integer"
+                },
                 "id": {
-                    "description": "Thông tin cơ bản",
-                    "type": "string"
+                    "type": "integer"
                 },
-                "items": {
-                    "description": "Danh sách Gundam",
+                "orphaned_bytes": {
+                    "type": "integer"
+                },
+                "orphaned_count": {
+                    "type": "integer"
+                },
+                "orphans": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/db.ExchangeItem"
+                        "$ref": "#/definitions/db.ImageCleanupOrphan"
                     }
                 },
-                "note": {
-                    "description": "Ghi chú của người dùng",
+                "recent_count": {
+                    "type": "integer"
+                },
+                "requested_by": {
                     "type": "string"
                 },
-                "order": {
-                    "description": "Thông tin đơn hàng và vận chuyển",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.Order"
-                        }
-                    ]
+                "scanned_count": {
+                    "type": "integer"
                 },
-                "to_address": {
-                    "description": "Địa chỉ nhận hàng",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/db.DeliveryInformation"
-                        }
-                    ]
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.ImageCleanupStatus"
                 }
             }
         },
-        "db.GetSellerDetailByIDRow": {
+        "db.ImageCleanupStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImageCleanupStatusPending",
+                "ImageCleanupStatusRunning",
+                "ImageCleanupStatusCompleted",
+                "ImageCleanupStatusFailed"
+            ]
+        },
+        "db.ImageFlag": {
             "type": "object",
             "required": [
-                "seller_profile",
-                "user"
+                "created_at",
+                "distance",
+                "fingerprint_id",
+                "id",
+                "matched_fingerprint_id",
+                "reviewed_at",
+                "reviewed_by",
+                "status"
             ],
             "properties": {
-                "seller_profile": {
-                    "$ref": "#/definitions/db.SellerProfile"
+                "created_at": {
+                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/db.User"
+                "distance": {
+                    "type": "integer"
+                },
+                "fingerprint_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "matched_fingerprint_id": {
+                    "type": "integer"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.ImageFlagStatus"
                 }
             }
         },
-        "db.GundamAccessoryDTO": {
+        "db.ImageFlagStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "dismissed",
+                "confirmed"
+            ],
+            "x-enum-varnames": [
+                "ImageFlagStatusPending",
+                "ImageFlagStatusDismissed",
+                "ImageFlagStatusConfirmed"
+            ]
+        },
+        "db.ListCartItemsWithDetailsRow": {
             "type": "object",
             "required": [
-                "name",
-                "quantity"
+                "available_quantity",
+                "cart_item_id",
+                "gundam_id",
+                "gundam_image_url",
+                "gundam_name",
+                "gundam_price",
+                "quantity",
+                "seller_avatar_url",
+                "seller_id",
+                "seller_name"
             ],
             "properties": {
-                "name": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "cart_item_id": {
+                    "type": "string"
+                },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "gundam_image_url": {
+                    "type": "string"
+                },
+                "gundam_name": {
                     "type": "string"
                 },
+                "gundam_price": {
+                    "type": "integer"
+                },
                 "quantity": {
                     "type": "integer"
+                },
+                "seller_avatar_url": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "seller_name": {
+                    "type": "string"
                 }
             }
         },
-        "db.GundamDetails": {
+        "db.ListGundamsByUserIDRow": {
             "type": "object",
             "required": [
-                "accessories",
                 "condition",
                 "condition_description",
                 "created_at",
                 "description",
                 "grade",
-                "gundam_id",
+                "grade_id",
+                "id",
                 "manufacturer",
                 "material",
+                "model_kit_id",
                 "name",
                 "owner_id",
                 "parts_total",
                 "price",
-                "primary_image_url",
                 "quantity",
                 "release_year",
+                "reserved_quantity",
                 "scale",
-                "secondary_image_urls",
                 "series",
                 "slug",
                 "status",
@@ -6750,14 +10822,8 @@ const docTemplate = `{
                 "weight"
             ],
             "properties": {
-                "accessories": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/db.GundamAccessoryDTO"
-                    }
-                },
                 "condition": {
-                    "type": "string"
+                    "$ref": "#/definitions/db.GundamCondition"
                 },
                 "condition_description": {
                     "type": "string"
@@ -6771,7 +10837,10 @@ const docTemplate = `{
                 "grade": {
                     "type": "string"
                 },
-                "gundam_id": {
+                "grade_id": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "integer"
                 },
                 "manufacturer": {
@@ -6780,6 +10849,9 @@ const docTemplate = `{
                 "material": {
                     "type": "string"
                 },
+                "model_kit_id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -6792,23 +10864,17 @@ const docTemplate = `{
                 "price": {
                     "type": "integer"
                 },
-                "primary_image_url": {
-                    "type": "string"
-                },
                 "quantity": {
                     "type": "integer"
                 },
                 "release_year": {
                     "type": "integer"
                 },
-                "scale": {
-                    "type": "string"
+                "reserved_quantity": {
+                    "type": "integer"
                 },
-                "secondary_image_urls": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "scale": {
+                    "$ref": "#/definitions/db.GundamScale"
                 },
                 "series": {
                     "type": "string"
@@ -6817,7 +10883,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "status": {
-                    "type": "string"
+                    "$ref": "#/definitions/db.GundamStatus"
                 },
                 "updated_at": {
                     "type": "string"
@@ -6830,107 +10896,226 @@ const docTemplate = `{
                 }
             }
         },
-        "db.GundamGrade": {
+        "db.ListImageCleanupRunsRow": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "deleted_count",
+                "dry_run",
+                "error",
+                "failed_count",
+                "finished_at",
+                "grace_period_hours",
+                "id",
+                "orphaned_bytes",
+                "orphaned_count",
+                "recent_count",
+                "requested_by",
+                "scanned_count",
+                "started_at",
+                "status"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_count": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_count": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "grace_period_hours": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "orphaned_bytes": {
+                    "type": "integer"
+                },
+                "orphaned_count": {
+                    "type": "integer"
+                },
+                "recent_count": {
+                    "type": "integer"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "scanned_count": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.ImageCleanupStatus"
+                }
+            }
+        },
+        "db.ListImageFlagsRow": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "distance",
+                "id",
+                "image_url",
+                "matched_image_url",
+                "matched_ref_id",
+                "matched_ref_type",
+                "matched_thumbnail_url",
+                "matched_uploaded_at",
+                "ref_id",
+                "ref_type",
+                "reviewed_at",
+                "reviewed_by",
+                "status",
+                "thumbnail_url"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "distance": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "matched_image_url": {
+                    "type": "string"
+                },
+                "matched_ref_id": {
+                    "type": "string"
+                },
+                "matched_ref_type": {
+                    "type": "string"
+                },
+                "matched_thumbnail_url": {
+                    "type": "string"
+                },
+                "matched_uploaded_at": {
+                    "type": "string"
+                },
+                "ref_id": {
+                    "type": "string"
+                },
+                "ref_type": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.ImageFlagStatus"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ListMarketPriceTrendRow": {
             "type": "object",
             "required": [
-                "created_at",
-                "display_name",
-                "id",
-                "name",
-                "slug"
+                "median",
+                "month",
+                "sample_size"
             ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "display_name": {
-                    "type": "string"
-                },
-                "id": {
+                "median": {
                     "type": "integer"
                 },
-                "name": {
+                "month": {
                     "type": "string"
                 },
-                "slug": {
-                    "type": "string"
+                "sample_size": {
+                    "type": "integer"
                 }
             }
         },
-        "db.GundamSnapshot": {
+        "db.ListModelKitsRow": {
             "type": "object",
             "required": [
+                "created_at",
                 "grade",
+                "grade_id",
                 "id",
                 "image_url",
+                "listing_count",
+                "lowest_price",
+                "manufacturer",
+                "msrp",
                 "name",
-                "quantity",
+                "release_year",
                 "scale",
+                "series",
                 "slug",
-                "weight"
+                "updated_at",
+                "version"
             ],
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "grade": {
                     "type": "string"
                 },
+                "grade_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
                 "image_url": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "quantity": {
+                "listing_count": {
                     "type": "integer"
                 },
-                "scale": {
-                    "type": "string"
+                "lowest_price": {
+                    "type": "integer"
                 },
-                "slug": {
+                "manufacturer": {
                     "type": "string"
                 },
-                "weight": {
+                "msrp": {
                     "type": "integer"
-                }
-            }
-        },
-        "db.ListCartItemsWithDetailsRow": {
-            "type": "object",
-            "required": [
-                "cart_item_id",
-                "gundam_id",
-                "gundam_image_url",
-                "gundam_name",
-                "gundam_price",
-                "seller_avatar_url",
-                "seller_id",
-                "seller_name"
-            ],
-            "properties": {
-                "cart_item_id": {
+                },
+                "name": {
                     "type": "string"
                 },
-                "gundam_id": {
+                "release_year": {
                     "type": "integer"
                 },
-                "gundam_image_url": {
-                    "type": "string"
+                "scale": {
+                    "$ref": "#/definitions/db.GundamScale"
                 },
-                "gundam_name": {
+                "series": {
                     "type": "string"
                 },
-                "gundam_price": {
-                    "type": "integer"
-                },
-                "seller_avatar_url": {
+                "slug": {
                     "type": "string"
                 },
-                "seller_id": {
+                "updated_at": {
                     "type": "string"
                 },
-                "seller_name": {
+                "version": {
                     "type": "string"
                 }
             }
@@ -6950,6 +11135,57 @@ const docTemplate = `{
                 }
             }
         },
+        "db.ListWishlistItemsRow": {
+            "type": "object",
+            "required": [
+                "condition",
+                "created_at",
+                "grade",
+                "gundam_id",
+                "image_url",
+                "name",
+                "owner_id",
+                "price",
+                "scale",
+                "slug",
+                "status"
+            ],
+            "properties": {
+                "condition": {
+                    "$ref": "#/definitions/db.GundamCondition"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grade": {
+                    "type": "string"
+                },
+                "gundam_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "scale": {
+                    "$ref": "#/definitions/db.GundamScale"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.GundamStatus"
+                }
+            }
+        },
         "db.MemberOrderDetails": {
             "type": "object",
             "required": [
@@ -7047,6 +11283,65 @@ const docTemplate = `{
                 }
             }
         },
+        "db.ModelKit": {
+            "type": "object",
+            "required": [
+                "created_at",
+                "grade_id",
+                "id",
+                "image_url",
+                "manufacturer",
+                "msrp",
+                "name",
+                "release_year",
+                "scale",
+                "series",
+                "slug",
+                "updated_at",
+                "version"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "grade_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "manufacturer": {
+                    "type": "string"
+                },
+                "msrp": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "release_year": {
+                    "type": "integer"
+                },
+                "scale": {
+                    "$ref": "#/definitions/db.GundamScale"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
         "db.ModeratorDashboard": {
             "type": "object",
             "required": [
@@ -7090,6 +11385,38 @@ const docTemplate = `{
                 }
             }
         },
+        "db.NullGundamCondition": {
+            "type": "object",
+            "required": [
+                "gundam_condition",
+                "valid"
+            ],
+            "properties": {
+                "gundam_condition": {
+                    "$ref": "#/definitions/db.GundamCondition"
+                },
+                "valid": {
+                    "description": "Valid is true if GundamCondition is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "db.NullGundamScale": {
+            "type": "object",
+            "required": [
+                "gundam_scale",
+                "valid"
+            ],
+            "properties": {
+                "gundam_scale": {
+                    "$ref": "#/definitions/db.GundamScale"
+                },
+                "valid": {
+                    "description": "Valid is true if GundamScale is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
         "db.OpenExchangePostInfo": {
             "type": "object",
             "required": [
@@ -7260,6 +11587,7 @@ const docTemplate = `{
         "db.OrderItem": {
             "type": "object",
             "required": [
+                "condition",
                 "created_at",
                 "grade",
                 "gundam_id",
@@ -7274,6 +11602,9 @@ const docTemplate = `{
                 "weight"
             ],
             "properties": {
+                "condition": {
+                    "$ref": "#/definitions/db.NullGundamCondition"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -7612,6 +11943,53 @@ const docTemplate = `{
                 }
             }
         },
+        "db.SavedSearch": {
+            "type": "object",
+            "required": [
+                "condition",
+                "created_at",
+                "grade_id",
+                "id",
+                "keyword",
+                "max_price",
+                "name",
+                "scale",
+                "updated_at",
+                "user_id"
+            ],
+            "properties": {
+                "condition": {
+                    "$ref": "#/definitions/db.NullGundamCondition"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "grade_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "max_price": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scale": {
+                    "$ref": "#/definitions/db.NullGundamScale"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "db.SellerDashboard": {
             "type": "object",
             "required": [
@@ -8529,6 +12907,36 @@ const docTemplate = `{
                 }
             }
         },
+        "storage.PresignedUpload": {
+            "type": "object",
+            "required": [
+                "expires_at",
+                "headers",
+                "key",
+                "method",
+                "url"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "textproto.MIMEHeader": {
             "type": "object",
             "additionalProperties": {
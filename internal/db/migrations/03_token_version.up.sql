@@ -0,0 +1,3 @@
+-- Token version dùng để vô hiệu hóa access token cũ khi quyền của người dùng thay đổi (ví dụ: trở thành seller)
+ALTER TABLE "users"
+    ADD COLUMN "token_version" bigint NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,27 @@
+-- Xác thực hai lớp (TOTP, RFC 6238), bắt buộc với tài khoản moderator và admin
+CREATE TABLE "user_totp_credentials"
+(
+    "user_id"        text PRIMARY KEY,
+    "secret"         text        NOT NULL,
+    "enabled_at"     timestamptz,
+    "last_used_step" bigint      NOT NULL DEFAULT 0,
+    "created_at"     timestamptz NOT NULL DEFAULT (now()),
+    "updated_at"     timestamptz NOT NULL DEFAULT (now())
+);
+
+-- Mã khôi phục dùng một lần, chỉ lưu giá trị băm
+CREATE TABLE "user_recovery_codes"
+(
+    "id"         bigserial PRIMARY KEY,
+    "user_id"    text        NOT NULL,
+    "code_hash"  text        NOT NULL,
+    "used_at"    timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "user_recovery_codes" ("user_id", "code_hash");
+
+ALTER TABLE "user_totp_credentials"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "user_recovery_codes"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
\ No newline at end of file
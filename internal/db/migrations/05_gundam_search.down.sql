@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "gundams_price_idx";
+DROP INDEX IF EXISTS "gundams_status_created_at_idx";
+DROP INDEX IF EXISTS "gundams_search_idx";
+
+DROP FUNCTION IF EXISTS gundam_search_vector(text, text, text, text);
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
+
+DROP EXTENSION IF EXISTS unaccent;
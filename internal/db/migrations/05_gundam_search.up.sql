@@ -0,0 +1,34 @@
+-- Tìm kiếm toàn văn cho danh mục Gundam.
+-- Dùng cấu hình 'simple' (không stemming) kết hợp unaccent vì tên và mô tả được viết bằng tiếng Việt và tiếng Nhật,
+-- nhờ vậy "do" khớp với "đỏ" và người dùng không cần gõ dấu.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() chỉ là STABLE nên không dùng được trong index, bọc lại với dictionary cố định để đánh dấu IMMUTABLE
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+    STRICT
+AS
+$$
+SELECT public.unaccent('public.unaccent'::regdictionary, $1)
+$$;
+
+-- Văn bản tìm kiếm của một Gundam: tên có trọng số cao nhất, sau đó đến series/version, cuối cùng là mô tả
+CREATE OR REPLACE FUNCTION gundam_search_vector(name text, series text, version text, description text) RETURNS tsvector
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+AS
+$$
+SELECT setweight(to_tsvector('simple', immutable_unaccent(coalesce(name, ''))), 'A') ||
+       setweight(to_tsvector('simple', immutable_unaccent(coalesce(series, ''))), 'B') ||
+       setweight(to_tsvector('simple', immutable_unaccent(coalesce(version, ''))), 'B') ||
+       setweight(to_tsvector('simple', immutable_unaccent(coalesce(description, ''))), 'C')
+$$;
+
+CREATE INDEX "gundams_search_idx" ON "gundams" USING GIN (gundam_search_vector("name", "series", "version", "description"));
+
+-- Index cho các bộ lọc thường dùng khi duyệt danh mục
+CREATE INDEX ON "gundams" ("status", "created_at" DESC);
+CREATE INDEX ON "gundams" ("price");
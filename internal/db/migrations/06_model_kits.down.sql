@@ -0,0 +1,4 @@
+ALTER TABLE "gundams"
+    DROP COLUMN IF EXISTS "model_kit_id";
+
+DROP TABLE IF EXISTS "model_kits";
@@ -0,0 +1,32 @@
+-- Danh mục mô hình (model kit) chuẩn do moderator quản lý.
+-- Mỗi kit được xác định bởi grade, scale và series; các Gundam do seller đăng bán có thể liên kết tới một kit
+-- để trang danh mục gom tất cả các tin đăng của cùng một kit lại với nhau.
+CREATE TABLE "model_kits"
+(
+    "id"           bigserial PRIMARY KEY,
+    "name"         text         NOT NULL,
+    "slug"         text UNIQUE  NOT NULL,
+    "grade_id"     bigint       NOT NULL,
+    "scale"        gundam_scale NOT NULL,
+    "series"       text         NOT NULL,
+    "manufacturer" text         NOT NULL,
+    "version"      text,
+    "release_year" bigint,
+    "msrp"         bigint, -- Giá bán lẻ đề xuất của nhà sản xuất (VND)
+    "image_url"    text,
+    "created_at"   timestamptz  NOT NULL DEFAULT (now()),
+    "updated_at"   timestamptz  NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "model_kits"
+    ADD FOREIGN KEY ("grade_id") REFERENCES "gundam_grades" ("id");
+
+CREATE INDEX ON "model_kits" ("grade_id", "scale", "series");
+
+ALTER TABLE "gundams"
+    ADD COLUMN "model_kit_id" bigint;
+
+ALTER TABLE "gundams"
+    ADD FOREIGN KEY ("model_kit_id") REFERENCES "model_kits" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "gundams" ("model_kit_id");
@@ -0,0 +1,7 @@
+DROP VIEW IF EXISTS "gundam_sales";
+
+DROP INDEX IF EXISTS "auctions_slug_family_idx";
+DROP INDEX IF EXISTS "order_items_slug_family_idx";
+DROP INDEX IF EXISTS "orders_completed_at_idx";
+
+DROP FUNCTION IF EXISTS slug_family(text);
@@ -0,0 +1,48 @@
+-- Lịch sử giá bán thực tế, dùng để ước tính giá thị trường cho từng model kit hoặc họ slug.
+
+-- Slug được tạo bởi util.GenerateRandomSlug có dạng "<tên>-<8 ký tự ngẫu nhiên>",
+-- bỏ phần hậu tố ngẫu nhiên để gom các tin đăng cùng tên (ví dụ "rx-78-2-gundam-a1b2c3d4" => "rx-78-2-gundam").
+CREATE OR REPLACE FUNCTION slug_family(slug text) RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+    STRICT
+AS
+$$
+SELECT regexp_replace(slug, '-[[:alnum:]]{8}$', '')
+$$;
+
+-- Mỗi dòng là một lần bán thành công:
+-- - Đơn hàng thường đã hoàn tất (giá của order item).
+-- - Phiên đấu giá đã hoàn tất (giá thắng cuối cùng). Đơn hàng loại 'auction' không được tính lại.
+-- Giao dịch trao đổi không được tính vì compensation_amount chỉ là phần chênh lệch giữa hai bên, không phải giá của một món hàng.
+CREATE VIEW "gundam_sales" AS
+SELECT 'order'::text         AS source,
+       oi.gundam_id,
+       g.model_kit_id,
+       slug_family(oi.slug)  AS slug_family,
+       g.condition,
+       oi.price,
+       o.completed_at        AS sold_at
+FROM order_items oi
+         JOIN orders o ON oi.order_id = o.id
+         LEFT JOIN gundams g ON oi.gundam_id = g.id
+WHERE o.type = 'regular'
+  AND o.status = 'completed'
+  AND o.completed_at IS NOT NULL
+UNION ALL
+SELECT 'auction'::text                            AS source,
+       a.gundam_id,
+       g.model_kit_id,
+       slug_family(a.gundam_snapshot ->> 'slug') AS slug_family,
+       g.condition,
+       a.current_price                            AS price,
+       coalesce(a.actual_end_time, a.end_time)    AS sold_at
+FROM auctions a
+         LEFT JOIN gundams g ON a.gundam_id = g.id
+WHERE a.status = 'completed';
+
+-- Index cho các điều kiện lọc của view
+CREATE INDEX "orders_completed_at_idx" ON "orders" ("completed_at") WHERE "type" = 'regular' AND "status" = 'completed';
+CREATE INDEX "order_items_slug_family_idx" ON "order_items" (slug_family("slug"));
+CREATE INDEX "auctions_slug_family_idx" ON "auctions" (slug_family("gundam_snapshot" ->> 'slug')) WHERE "status" = 'completed';
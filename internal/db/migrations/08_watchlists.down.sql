@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "watch_matches";
+
+DROP TABLE IF EXISTS "saved_searches";
+
+DROP TABLE IF EXISTS "wishlist_items";
@@ -0,0 +1,71 @@
+-- Danh sách yêu thích: người mua theo dõi các Gundam cụ thể để được thông báo khi chúng được đăng bán hoặc đưa lên đấu giá.
+CREATE TABLE "wishlist_items"
+(
+    "user_id"    text        NOT NULL,
+    "gundam_id"  bigint      NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("user_id", "gundam_id")
+);
+
+ALTER TABLE "wishlist_items"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "wishlist_items"
+    ADD FOREIGN KEY ("gundam_id") REFERENCES "gundams" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "wishlist_items" ("gundam_id");
+
+-- Tìm kiếm đã lưu của người mua, bộ lọc nào để NULL thì khớp với mọi giá trị.
+CREATE TABLE "saved_searches"
+(
+    "id"         bigserial PRIMARY KEY,
+    "user_id"    text        NOT NULL,
+    "name"       text        NOT NULL,
+    "keyword"    text, -- Từ khóa tìm trong tên Gundam, không phân biệt dấu
+    "grade_id"   bigint,
+    "scale"      gundam_scale,
+    "max_price"  bigint, -- Giá tối đa (VND), với phiên đấu giá thì so với giá khởi điểm
+    "condition"  gundam_condition,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "saved_searches"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "saved_searches"
+    ADD FOREIGN KEY ("grade_id") REFERENCES "gundam_grades" ("id");
+
+CREATE INDEX ON "saved_searches" ("user_id", "created_at");
+
+-- Các Gundam khớp với danh sách yêu thích hoặc tìm kiếm đã lưu của người dùng, chờ được gửi trong thông báo tổng hợp.
+-- Mỗi người dùng nhận tối đa một thông báo tổng hợp mỗi giờ, notified_at là thời điểm dòng được đưa vào thông báo.
+CREATE TABLE "watch_matches"
+(
+    "id"              bigserial PRIMARY KEY,
+    "user_id"         text        NOT NULL,
+    "gundam_id"       bigint      NOT NULL,
+    "auction_id"      uuid,   -- Khác NULL nếu Gundam được đưa lên đấu giá thay vì đăng bán
+    "saved_search_id" bigint, -- NULL nếu Gundam nằm trong danh sách yêu thích
+    "price"           bigint      NOT NULL, -- Giá bán, hoặc giá khởi điểm nếu là phiên đấu giá
+    "created_at"      timestamptz NOT NULL DEFAULT (now()),
+    "notified_at"     timestamptz
+);
+
+ALTER TABLE "watch_matches"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "watch_matches"
+    ADD FOREIGN KEY ("gundam_id") REFERENCES "gundams" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "watch_matches"
+    ADD FOREIGN KEY ("auction_id") REFERENCES "auctions" ("id") ON DELETE CASCADE;
+
+-- Người dùng xóa tìm kiếm đã lưu thì cũng không còn muốn nhận thông báo cho các Gundam khớp với nó
+ALTER TABLE "watch_matches"
+    ADD FOREIGN KEY ("saved_search_id") REFERENCES "saved_searches" ("id") ON DELETE CASCADE;
+
+-- Mỗi Gundam chỉ có một dòng chờ thông báo cho mỗi người dùng, dù khớp với nhiều tìm kiếm đã lưu
+CREATE UNIQUE INDEX "watch_matches_pending_idx" ON "watch_matches" ("user_id", "gundam_id") WHERE "notified_at" IS NULL;
+
+CREATE INDEX ON "watch_matches" ("user_id", "notified_at");
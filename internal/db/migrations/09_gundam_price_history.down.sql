@@ -0,0 +1,4 @@
+ALTER TABLE "watch_matches"
+    DROP COLUMN IF EXISTS "previous_price";
+
+DROP TABLE IF EXISTS "gundam_price_history";
@@ -0,0 +1,17 @@
+-- Lịch sử giá của Gundam, mỗi lần giá thay đổi (kể cả lần đặt giá đầu tiên) là một dòng.
+CREATE TABLE "gundam_price_history"
+(
+    "id"             bigserial PRIMARY KEY,
+    "gundam_id"      bigint      NOT NULL,
+    "previous_price" bigint, -- NULL nếu trước đó Gundam chưa có giá
+    "price"          bigint      NOT NULL,
+    "created_at"     timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "gundam_price_history"
+    ADD FOREIGN KEY ("gundam_id") REFERENCES "gundams" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "gundam_price_history" ("gundam_id", "created_at");
+
+ALTER TABLE "watch_matches"
+    ADD COLUMN "previous_price" bigint; -- Giá trước khi giảm, khác NULL nếu Gundam đang bán vừa được giảm giá
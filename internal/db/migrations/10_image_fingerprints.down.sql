@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "image_flags";
+
+DROP TYPE IF EXISTS "image_flag_status";
+
+DROP TABLE IF EXISTS "image_fingerprints";
@@ -0,0 +1,51 @@
+-- Dấu vân tay của các ảnh đã tải lên (sau khi qua bước xử lý ảnh), dùng để phát hiện ảnh đăng bán bị trùng hoặc bị lấy cắp.
+-- Ảnh được lưu theo nội dung nên nhiều đối tượng có thể dùng chung một URL, mỗi đối tượng là một dòng.
+CREATE TABLE "image_fingerprints"
+(
+    "id"              bigserial PRIMARY KEY,
+    "url"             text        NOT NULL,
+    "thumbnail_url"   text        NOT NULL,
+    "folder"          text        NOT NULL,
+    "ref_type"        text        NOT NULL, -- Loại đối tượng sở hữu ảnh: gundam, exchange_post, packaging_image, model_kit
+    "ref_id"          text        NOT NULL, -- Slug, mã hoặc ID của đối tượng sở hữu ảnh
+    "content_hash"    text        NOT NULL, -- SHA-256 của ảnh sau khi xử lý, cũng là tên file trên file store
+    "perceptual_hash" bigint      NOT NULL, -- dHash 64 bit, hai ảnh gần giống nhau có ít bit khác nhau
+    "width"           int         NOT NULL,
+    "height"          int         NOT NULL,
+    "created_at"      timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "image_fingerprints" ("url", "ref_type", "ref_id");
+
+CREATE INDEX ON "image_fingerprints" ("folder");
+
+CREATE TYPE "image_flag_status" AS ENUM (
+  'pending',
+  'dismissed',
+  'confirmed'
+);
+
+-- Cặp ảnh gần giống nhau của hai đối tượng khác nhau, chờ moderator xem xét.
+CREATE TABLE "image_flags"
+(
+    "id"                     bigserial PRIMARY KEY,
+    "fingerprint_id"         bigint            NOT NULL, -- Ảnh vừa được tải lên
+    "matched_fingerprint_id" bigint            NOT NULL, -- Ảnh đã có từ trước
+    "distance"               int               NOT NULL, -- Khoảng cách Hamming giữa hai perceptual hash
+    "status"                 image_flag_status NOT NULL DEFAULT 'pending',
+    "reviewed_by"            text,
+    "reviewed_at"            timestamptz,
+    "created_at"             timestamptz       NOT NULL DEFAULT (now()),
+    UNIQUE ("fingerprint_id", "matched_fingerprint_id")
+);
+
+ALTER TABLE "image_flags"
+    ADD FOREIGN KEY ("fingerprint_id") REFERENCES "image_fingerprints" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "image_flags"
+    ADD FOREIGN KEY ("matched_fingerprint_id") REFERENCES "image_fingerprints" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "image_flags"
+    ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "image_flags" ("status", "id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "image_cleanup_runs";
+
+DROP TYPE IF EXISTS "image_cleanup_status";
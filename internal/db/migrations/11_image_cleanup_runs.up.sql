@@ -0,0 +1,31 @@
+CREATE TYPE "image_cleanup_status" AS ENUM (
+  'pending',
+  'running',
+  'completed',
+  'failed'
+);
+
+-- Các lần đối soát file trên file store với database để dọn ảnh không còn được dùng (ảnh mồ côi).
+-- Lần chạy dry run chỉ ghi nhận ảnh mồ côi vào báo cáo, không xóa file.
+CREATE TABLE "image_cleanup_runs"
+(
+    "id"                 bigserial PRIMARY KEY,
+    "dry_run"            bool                 NOT NULL,
+    "status"             image_cleanup_status NOT NULL DEFAULT 'pending',
+    "grace_period_hours" int                  NOT NULL, -- File mới tải lên trong khoảng này chưa bị xem là mồ côi
+    "scanned_count"      int                  NOT NULL DEFAULT 0,
+    "recent_count"       int                  NOT NULL DEFAULT 0, -- File không được tham chiếu nhưng còn trong grace period
+    "orphaned_count"     int                  NOT NULL DEFAULT 0,
+    "orphaned_bytes"     bigint               NOT NULL DEFAULT 0,
+    "deleted_count"      int                  NOT NULL DEFAULT 0,
+    "failed_count"       int                  NOT NULL DEFAULT 0,
+    "orphans"            jsonb                NOT NULL DEFAULT '[]', -- Danh sách ảnh mồ côi, giới hạn số lượng để báo cáo không quá lớn
+    "error"              text,
+    "requested_by"       text,                                       -- NULL nếu được lên lịch tự động
+    "started_at"         timestamptz,
+    "finished_at"        timestamptz,
+    "created_at"         timestamptz          NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "image_cleanup_runs"
+    ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("id") ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+ALTER TABLE "order_items"
+    DROP CONSTRAINT IF EXISTS "order_items_quantity_check";
+
+ALTER TABLE "cart_items"
+    DROP COLUMN IF EXISTS "quantity";
+
+ALTER TABLE "gundams"
+    DROP CONSTRAINT IF EXISTS "gundams_reserved_quantity_check";
+
+ALTER TABLE "gundams"
+    DROP CONSTRAINT IF EXISTS "gundams_quantity_check";
+
+ALTER TABLE "gundams"
+    DROP COLUMN IF EXISTS "reserved_quantity";
@@ -0,0 +1,30 @@
+-- Tồn kho nhiều đơn vị cho Gundam đăng bán (ví dụ: người bán có 10 hộp kit còn nguyên seal).
+-- quantity là số đơn vị người bán đang có, reserved_quantity là số đơn vị đang được giữ cho các đơn hàng chưa hoàn tất,
+-- số đơn vị còn có thể mua là quantity - reserved_quantity.
+ALTER TABLE "gundams"
+    ADD COLUMN "reserved_quantity" bigint NOT NULL DEFAULT 0;
+
+-- Trước đây mỗi Gundam "processing" của đơn hàng thông thường chưa hoàn tất là toàn bộ số lượng đã được giữ
+UPDATE "gundams" g
+SET "reserved_quantity" = g."quantity"
+FROM "order_items" oi
+         JOIN "orders" o ON oi."order_id" = o."id"
+WHERE oi."gundam_id" = g."id"
+  AND g."status" = 'processing'
+  AND o."type" = 'regular'
+  AND o."status" IN ('pending', 'packaging', 'delivering', 'delivered');
+
+ALTER TABLE "gundams"
+    ADD CONSTRAINT "gundams_quantity_check" CHECK ("quantity" > 0);
+
+ALTER TABLE "gundams"
+    ADD CONSTRAINT "gundams_reserved_quantity_check" CHECK ("reserved_quantity" >= 0 AND "reserved_quantity" <= "quantity");
+
+ALTER TABLE "cart_items"
+    ADD COLUMN "quantity" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "cart_items"
+    ADD CONSTRAINT "cart_items_quantity_check" CHECK ("quantity" > 0);
+
+ALTER TABLE "order_items"
+    ADD CONSTRAINT "order_items_quantity_check" CHECK ("quantity" > 0);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "checkout_orders";
+
+DROP TABLE IF EXISTS "checkouts";
@@ -0,0 +1,28 @@
+-- Mỗi lần người mua thanh toán giỏ hàng tạo ra một checkout gồm các đơn hàng của từng người bán.
+-- ID của checkout do client sinh ra và được dùng làm khóa idempotency: gửi lại cùng một checkout ID
+-- sẽ trả về các đơn hàng đã tạo thay vì tạo đơn hàng mới.
+CREATE TABLE "checkouts"
+(
+    "id"             uuid PRIMARY KEY,
+    "buyer_id"       text        NOT NULL,
+    "items_subtotal" bigint      NOT NULL, -- Tổng giá trị sản phẩm của tất cả đơn hàng
+    "delivery_fee"   bigint      NOT NULL, -- Tổng phí vận chuyển của tất cả đơn hàng
+    "total_amount"   bigint      NOT NULL,
+    "created_at"     timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "checkout_orders"
+(
+    "checkout_id" uuid NOT NULL,
+    "order_id"    uuid UNIQUE NOT NULL,
+    PRIMARY KEY ("checkout_id", "order_id")
+);
+
+ALTER TABLE "checkouts"
+    ADD FOREIGN KEY ("buyer_id") REFERENCES "users" ("id");
+
+ALTER TABLE "checkout_orders"
+    ADD FOREIGN KEY ("checkout_id") REFERENCES "checkouts" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "checkout_orders"
+    ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
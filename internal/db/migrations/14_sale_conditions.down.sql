@@ -0,0 +1,37 @@
+DROP VIEW IF EXISTS "gundam_sales";
+
+DROP INDEX IF EXISTS "exchange_items_slug_family_idx";
+DROP INDEX IF EXISTS "exchanges_completed_at_idx";
+
+-- Khôi phục view của 07_market_prices
+CREATE VIEW "gundam_sales" AS
+SELECT 'order'::text         AS source,
+       oi.gundam_id,
+       g.model_kit_id,
+       slug_family(oi.slug)  AS slug_family,
+       g.condition,
+       oi.price,
+       o.completed_at        AS sold_at
+FROM order_items oi
+         JOIN orders o ON oi.order_id = o.id
+         LEFT JOIN gundams g ON oi.gundam_id = g.id
+WHERE o.type = 'regular'
+  AND o.status = 'completed'
+  AND o.completed_at IS NOT NULL
+UNION ALL
+SELECT 'auction'::text                            AS source,
+       a.gundam_id,
+       g.model_kit_id,
+       slug_family(a.gundam_snapshot ->> 'slug') AS slug_family,
+       g.condition,
+       a.current_price                            AS price,
+       coalesce(a.actual_end_time, a.end_time)    AS sold_at
+FROM auctions a
+         LEFT JOIN gundams g ON a.gundam_id = g.id
+WHERE a.status = 'completed';
+
+ALTER TABLE "exchange_items"
+    DROP COLUMN IF EXISTS "condition";
+
+ALTER TABLE "order_items"
+    DROP COLUMN IF EXISTS "condition";
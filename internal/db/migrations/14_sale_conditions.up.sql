@@ -0,0 +1,84 @@
+-- Tình trạng của Gundam tại thời điểm bán. Thống kê giá thị trường trước đây lấy tình trạng từ dòng gundams hiện tại,
+-- nhưng Gundam có thể đã được chuyển cho người mua, bị sửa tình trạng hoặc bị xóa sau khi bán.
+ALTER TABLE "order_items"
+    ADD COLUMN "condition" gundam_condition;
+
+ALTER TABLE "exchange_items"
+    ADD COLUMN "condition" gundam_condition;
+
+-- Dữ liệu cũ không có snapshot, dùng tình trạng hiện tại của Gundam nếu còn
+UPDATE "order_items" oi
+SET "condition" = g."condition"
+FROM "gundams" g
+WHERE oi."gundam_id" = g."id";
+
+UPDATE "exchange_items" ei
+SET "condition" = g."condition"
+FROM "gundams" g
+WHERE ei."gundam_id" = g."id";
+
+UPDATE "auction_requests" ar
+SET "gundam_snapshot" = ar."gundam_snapshot" || jsonb_build_object('condition', g."condition")
+FROM "gundams" g
+WHERE ar."gundam_id" = g."id"
+  AND NOT ar."gundam_snapshot" ? 'condition';
+
+UPDATE "auctions" a
+SET "gundam_snapshot" = a."gundam_snapshot" || jsonb_build_object('condition', g."condition")
+FROM "gundams" g
+WHERE a."gundam_id" = g."id"
+  AND NOT a."gundam_snapshot" ? 'condition';
+
+DROP VIEW IF EXISTS "gundam_sales";
+
+-- Mỗi dòng là một lần bán thành công, source cho biết nguồn của giá:
+-- - 'order': đơn hàng thường đã hoàn tất (giá của order item).
+-- - 'auction': phiên đấu giá đã hoàn tất (giá thắng cuối cùng). Đơn hàng loại 'auction' không được tính lại.
+-- - 'exchange': giao dịch trao đổi đã hoàn tất có tiền bù, mỗi Gundam của bên nhận tiền bù là một dòng với price là compensation_amount.
+--   Tiền bù chỉ là phần chênh lệch giữa hai bên, không phải giá của một món hàng, nên các truy vấn thống kê phải lọc theo source.
+-- Tình trạng lấy từ snapshot lúc bán (order_items, exchange_items, gundam_snapshot của phiên đấu giá), không lấy từ dòng gundams hiện tại.
+CREATE VIEW "gundam_sales" AS
+SELECT 'order'::text         AS source,
+       oi.gundam_id,
+       g.model_kit_id,
+       slug_family(oi.slug)  AS slug_family,
+       oi.condition,
+       oi.price,
+       o.completed_at        AS sold_at
+FROM order_items oi
+         JOIN orders o ON oi.order_id = o.id
+         LEFT JOIN gundams g ON oi.gundam_id = g.id
+WHERE o.type = 'regular'
+  AND o.status = 'completed'
+  AND o.completed_at IS NOT NULL
+UNION ALL
+SELECT 'auction'::text                                           AS source,
+       a.gundam_id,
+       g.model_kit_id,
+       slug_family(a.gundam_snapshot ->> 'slug')                AS slug_family,
+       (a.gundam_snapshot ->> 'condition')::gundam_condition    AS condition,
+       a.current_price                                           AS price,
+       coalesce(a.actual_end_time, a.end_time)                   AS sold_at
+FROM auctions a
+         LEFT JOIN gundams g ON a.gundam_id = g.id
+WHERE a.status = 'completed'
+UNION ALL
+SELECT 'exchange'::text         AS source,
+       ei.gundam_id,
+       g.model_kit_id,
+       slug_family(ei.slug)     AS slug_family,
+       ei.condition,
+       e.compensation_amount    AS price,
+       e.completed_at           AS sold_at
+FROM exchange_items ei
+         JOIN exchanges e ON ei.exchange_id = e.id
+         LEFT JOIN gundams g ON ei.gundam_id = g.id
+WHERE e.status = 'completed'
+  AND e.completed_at IS NOT NULL
+  AND e.compensation_amount > 0
+  AND e.payer_id IS NOT NULL
+  -- Gundam của bên nhận tiền bù: bên đăng bài nếu người đề xuất trả tiền bù và ngược lại
+  AND ei.is_from_poster = (e.payer_id = e.offerer_id);
+
+CREATE INDEX "exchanges_completed_at_idx" ON "exchanges" ("completed_at") WHERE "status" = 'completed' AND "compensation_amount" > 0;
+CREATE INDEX "exchange_items_slug_family_idx" ON "exchange_items" (slug_family("slug"));
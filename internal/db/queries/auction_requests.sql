@@ -26,10 +26,20 @@ WHERE seller_id = $1
 ORDER BY created_at DESC;
 
 -- name: ListAuctionRequests :many
+-- Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
 SELECT *
 FROM auction_requests
 WHERE status = COALESCE(sqlc.narg('status'), status)
-ORDER BY created_at DESC;
+  AND (sqlc.narg('cursor_id')::uuid IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           ELSE (created_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN created_at END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT sqlc.narg('limit')::int;
 
 -- name: GetAuctionRequestByID :one
 SELECT *
@@ -52,18 +52,26 @@ FROM auctions
 WHERE order_id = $1;
 
 -- name: ListAuctions :many
+-- Phân trang keyset theo (cột sắp xếp, id):
+-- ending_soon theo end_time tăng dần, starting_soon theo start_time tăng dần, oldest/newest theo created_at.
 SELECT *
 FROM auctions
 WHERE status = COALESCE(sqlc.narg('status'), status)
-ORDER BY CASE status
-             -- Phiên đang diễn ra: ưu tiên theo thời gian kết thúc gần nhất
-             WHEN 'active' THEN EXTRACT(EPOCH FROM end_time)
-             -- Phiên sắp diễn ra: ưu tiên theo thời gian bắt đầu sớm nhất
-             WHEN 'scheduled' THEN EXTRACT(EPOCH FROM start_time)
-             -- Các trạng thái khác: sắp xếp theo thời gian tạo mới nhất
-             ELSE EXTRACT(EPOCH FROM created_at) * -1
-             END ASC,
-         created_at DESC;
+  AND seller_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+  AND (sqlc.narg('cursor_id')::uuid IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'ending_soon' THEN (end_time, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           WHEN 'starting_soon' THEN (start_time, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           ELSE (created_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'ending_soon' THEN end_time END,
+         CASE WHEN sqlc.arg('sort')::text = 'starting_soon' THEN start_time END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN created_at END,
+         CASE WHEN sqlc.arg('sort')::text IN ('ending_soon', 'starting_soon', 'oldest') THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT sqlc.narg('limit')::int;
 
 -- name: CheckUserParticipation :one
 SELECT EXISTS(SELECT 1
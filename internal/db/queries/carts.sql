@@ -11,20 +11,26 @@ FROM carts
 WHERE user_id = $1;
 
 -- name: AddCartItem :one
+-- Thêm Gundam vào giỏ hàng, nếu Gundam đã có trong giỏ thì cộng dồn số lượng.
 WITH inserted_item AS (
 INSERT
-INTO cart_items (cart_id, gundam_id)
-VALUES ($1, $2)
-    RETURNING id, cart_id, gundam_id
+INTO cart_items (cart_id, gundam_id, quantity)
+VALUES ($1, $2, $3) ON CONFLICT (cart_id, gundam_id) DO
+UPDATE
+    SET quantity = cart_items.quantity + EXCLUDED.quantity,
+    updated_at = now()
+    RETURNING id, cart_id, gundam_id, quantity
     )
-SELECT ci.id        AS cart_item_id,
-       g.id         AS gundam_id,
-       g.name       AS gundam_name,
-       g.price      AS gundam_price,
-       gi.url       AS gundam_image_url,
-       s.id         AS seller_id,
-       s.full_name  AS seller_name,
-       s.avatar_url AS seller_avatar_url
+SELECT ci.id                                      AS cart_item_id,
+       ci.quantity,
+       g.id                                       AS gundam_id,
+       g.name                                     AS gundam_name,
+       g.price                                    AS gundam_price,
+       (g.quantity - g.reserved_quantity)::bigint AS available_quantity,
+       gi.url                                     AS gundam_image_url,
+       s.id                                       AS seller_id,
+       s.full_name                                AS seller_name,
+       s.avatar_url                               AS seller_avatar_url
 FROM inserted_item ci
          JOIN gundams g ON ci.gundam_id = g.id
          JOIN users s ON g.owner_id = s.id
@@ -33,14 +39,16 @@ FROM inserted_item ci
                   AND gi.is_primary = true;
 
 -- name: ListCartItemsWithDetails :many
-SELECT ci.id        AS cart_item_id,
-       g.id         AS gundam_id,
-       g.name       AS gundam_name,
-       g.price      AS gundam_price,
-       gi.url       AS gundam_image_url,
-       s.id         AS seller_id,
-       s.full_name  AS seller_name,
-       s.avatar_url AS seller_avatar_url
+SELECT ci.id                                      AS cart_item_id,
+       ci.quantity,
+       g.id                                       AS gundam_id,
+       g.name                                     AS gundam_name,
+       g.price                                    AS gundam_price,
+       (g.quantity - g.reserved_quantity)::bigint AS available_quantity,
+       gi.url                                     AS gundam_image_url,
+       s.id                                       AS seller_id,
+       s.full_name                                AS seller_name,
+       s.avatar_url                               AS seller_avatar_url
 FROM cart_items ci
          JOIN gundams g ON ci.gundam_id = g.id
          JOIN users s ON g.owner_id = s.id
@@ -56,8 +64,20 @@ FROM cart_items
 WHERE id = $1
   AND cart_id = $2;
 
--- name: CheckCartItemExists :one
-SELECT EXISTS (SELECT 1
-               FROM cart_items
-               WHERE cart_id = $1
-                 AND gundam_id = $2);
+-- name: GetCartItemQuantity :one
+SELECT quantity
+FROM cart_items
+WHERE cart_id = $1
+  AND gundam_id = $2;
+
+-- name: ListCartItems :many
+SELECT *
+FROM cart_items
+WHERE cart_id = $1
+ORDER BY created_at, id;
+
+-- name: RemoveCartItemsByGundamIDs :exec
+DELETE
+FROM cart_items
+WHERE cart_id = sqlc.arg('cart_id')
+  AND gundam_id = ANY (sqlc.arg('gundam_ids')::bigint[]);
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- name: CreateCheckout :one
+-- Trả về ErrRecordNotFound nếu checkout ID đã tồn tại (checkout đã được xử lý hoặc đang được xử lý bởi request khác).
+INSERT INTO checkouts (id, buyer_id, items_subtotal, delivery_fee, total_amount)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (id) DO NOTHING RETURNING *;
+
+-- name: GetCheckoutByID :one
+SELECT *
+FROM checkouts
+WHERE id = $1;
+
+-- name: AddCheckoutOrder :exec
+INSERT INTO checkout_orders (checkout_id, order_id)
+VALUES ($1, $2);
+
+-- name: ListCheckoutOrders :many
+SELECT o.*
+FROM orders o
+         JOIN checkout_orders co ON co.order_id = o.id
+WHERE co.checkout_id = $1
+ORDER BY o.created_at, o.id;
@@ -10,8 +10,9 @@ INSERT INTO exchange_items (id,
                             weight,
                             image_url,
                             owner_id,
-                            is_from_poster)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING *;
+                            is_from_poster,
+                            condition)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING *;
 
 -- name: ListExchangeItems :many
 SELECT *
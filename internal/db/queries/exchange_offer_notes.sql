@@ -4,6 +4,12 @@ FROM exchange_offer_notes
 WHERE offer_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListExchangeOfferNotesByOfferIDs :many
+SELECT *
+FROM exchange_offer_notes
+WHERE offer_id = ANY (@offer_ids::uuid[])
+ORDER BY created_at DESC;
+
 -- name: CreateExchangeOfferNote :one
 INSERT INTO exchange_offer_notes (id,
                                   offer_id,
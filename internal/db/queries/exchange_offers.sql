@@ -3,6 +3,12 @@ SELECT COUNT(*) AS count
 FROM exchange_offers
 WHERE post_id = $1;
 
+-- name: CountExchangeOffersByPostIDs :many
+SELECT post_id, COUNT(*) AS count
+FROM exchange_offers
+WHERE post_id = ANY (@post_ids::uuid[])
+GROUP BY post_id;
+
 -- name: GetUserExchangeOfferForPost :one
 SELECT *
 FROM exchange_offers
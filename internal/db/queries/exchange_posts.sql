@@ -11,10 +11,21 @@ SET "post_image_urls" = $2
 WHERE "id" = $1 RETURNING *;
 
 -- name: ListExchangePosts :many
+-- Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
 SELECT *
 FROM "exchange_posts"
 WHERE status = coalesce(sqlc.narg('status'), status)
-ORDER BY created_at DESC, updated_at DESC;
+  AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+  AND (sqlc.narg('cursor_id')::uuid IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           ELSE (created_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN created_at END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT sqlc.narg('limit')::int;
 
 -- name: ListUserExchangePosts :many
 SELECT *
@@ -36,11 +47,22 @@ FROM "exchange_post_items"
 WHERE post_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListExchangePostItemsByPostIDs :many
+SELECT *
+FROM "exchange_post_items"
+WHERE post_id = ANY (@post_ids::uuid[])
+ORDER BY created_at DESC;
+
 -- name: GetExchangePost :one
 SELECT *
 FROM "exchange_posts"
 WHERE id = $1;
 
+-- name: ListExchangePostsByIDs :many
+SELECT *
+FROM "exchange_posts"
+WHERE id = ANY (@post_ids::uuid[]);
+
 -- name: DeleteExchangePost :one
 DELETE
 FROM "exchange_posts"
@@ -1,3 +1,11 @@
 -- name: DeleteAllGundamAccessories :exec
 DELETE FROM gundam_accessories
-WHERE gundam_id = $1;
\ No newline at end of file
+WHERE gundam_id = $1;
+
+-- name: CopyGundamAccessories :exec
+INSERT INTO gundam_accessories (name,
+                                gundam_id,
+                                quantity)
+SELECT name, sqlc.arg('to_gundam_id')::bigint, quantity
+FROM gundam_accessories
+WHERE gundam_id = sqlc.arg('from_gundam_id')::bigint;
\ No newline at end of file
@@ -17,6 +17,12 @@ WHERE gundam_id = $1
   AND is_primary = false
 ORDER BY created_at DESC;
 
+-- name: ListGundamImagesByGundamIDs :many
+SELECT *
+FROM gundam_images
+WHERE gundam_id = ANY (@gundam_ids::bigint[])
+ORDER BY gundam_id, created_at DESC;
+
 -- name: UpdateGundamPrimaryImage :exec
 UPDATE gundam_images
 SET url = $2
@@ -27,4 +33,18 @@ WHERE gundam_id = $1
 DELETE
 FROM gundam_images
 WHERE gundam_id = $1
-  AND url = $2;
\ No newline at end of file
+  AND url = $2;
+
+-- name: CountGundamImagesByURL :one
+-- Ảnh được lưu theo nội dung nên nhiều Gundam có thể dùng chung một URL, chỉ xóa file khi không còn Gundam nào dùng.
+SELECT COUNT(*)
+FROM gundam_images
+WHERE url = $1;
+
+-- name: CopyGundamImages :exec
+INSERT INTO gundam_images (gundam_id,
+                           url,
+                           is_primary)
+SELECT sqlc.arg('to_gundam_id')::bigint, url, is_primary
+FROM gundam_images
+WHERE gundam_id = sqlc.arg('from_gundam_id')::bigint;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- name: CreateGundamPriceHistory :one
+INSERT INTO gundam_price_history (gundam_id, previous_price, price)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: ListGundamPriceHistory :many
+SELECT *
+FROM gundam_price_history
+WHERE gundam_id = $1
+ORDER BY created_at DESC, id DESC;
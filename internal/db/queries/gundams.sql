@@ -19,8 +19,9 @@ INSERT INTO gundams (owner_id,
                      scale,
                      description,
                      price,
-                     release_year)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING *;
+                     release_year,
+                     model_kit_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING *;
 
 -- name: StoreGundamImageURL :exec
 INSERT INTO gundam_images (gundam_id,
@@ -34,7 +35,10 @@ INSERT INTO gundam_accessories (name,
                                 quantity)
 VALUES ($1, $2, $3) RETURNING *;
 
--- name: ListGundamsWithFilters :many
+-- name: SearchGundams :many
+-- Tìm kiếm toàn văn và lọc danh mục Gundam.
+-- query là tsquery đã được chuẩn hóa (ví dụ: 'rx:* & 78:*'), khi có query kết quả có thể sắp xếp theo độ liên quan.
+-- Phân trang keyset theo (cột sắp xếp, id), cursor_value là giá trị cột sắp xếp của dòng cuối trang trước ở dạng text.
 SELECT g.id            AS gundam_id,
        g.owner_id,
        g.name,
@@ -55,19 +59,118 @@ SELECT g.id            AS gundam_id,
        g.release_year,
        g.status,
        g.created_at,
-       g.updated_at
+       g.updated_at,
+       r.rank
 FROM gundams g
          JOIN gundam_grades gg ON g.grade_id = gg.id
-WHERE (sqlc.narg('name')::text IS NULL OR g.name ILIKE '%' || sqlc.narg('name')::text || '%')
-  AND gg.slug = COALESCE(sqlc.narg('grade_slug')::text, gg.slug)
+         JOIN users u ON g.owner_id = u.id
+         CROSS JOIN LATERAL (SELECT (CASE
+                                         WHEN sqlc.narg('query')::text IS NULL THEN 0
+                                         ELSE ts_rank_cd(gundam_search_vector(g.name, g.series, g.version, g.description),
+                                                         to_tsquery('simple', immutable_unaccent(sqlc.narg('query')::text)))
+             END)::real AS rank) r
+WHERE u.deleted_at IS NULL
+  AND (sqlc.narg('query')::text IS NULL OR
+       gundam_search_vector(g.name, g.series, g.version, g.description) @@
+       to_tsquery('simple', immutable_unaccent(sqlc.narg('query')::text)))
+  AND (sqlc.narg('grade_slug')::text IS NULL OR gg.slug = sqlc.narg('grade_slug')::text)
+  AND (sqlc.narg('scale')::text IS NULL OR g.scale = sqlc.narg('scale')::gundam_scale)
+  AND (sqlc.narg('condition')::text IS NULL OR g.condition = sqlc.narg('condition')::gundam_condition)
+  AND (sqlc.narg('manufacturer')::text IS NULL OR lower(g.manufacturer) = lower(sqlc.narg('manufacturer')::text))
   AND (sqlc.narg('status')::text IS NULL OR g.status = sqlc.narg('status')::gundam_status)
-ORDER BY g.created_at DESC;
+  AND (sqlc.narg('min_price')::bigint IS NULL OR g.price >= sqlc.narg('min_price')::bigint)
+  AND (sqlc.narg('max_price')::bigint IS NULL OR g.price < sqlc.narg('max_price')::bigint)
+  AND (sqlc.narg('release_year')::bigint IS NULL OR g.release_year = sqlc.narg('release_year')::bigint)
+  AND (sqlc.narg('model_kit_id')::bigint IS NULL OR g.model_kit_id = sqlc.narg('model_kit_id')::bigint)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'price_asc' THEN (COALESCE(g.price, 0), g.id) > (sqlc.narg('cursor_value')::text::bigint, sqlc.narg('cursor_id')::bigint)
+           WHEN 'price_desc' THEN (COALESCE(g.price, 0), g.id) < (sqlc.narg('cursor_value')::text::bigint, sqlc.narg('cursor_id')::bigint)
+           WHEN 'relevance' THEN (r.rank, g.id) < (sqlc.narg('cursor_value')::text::real, sqlc.narg('cursor_id')::bigint)
+           WHEN 'oldest' THEN (g.created_at, g.id) > (sqlc.narg('cursor_value')::text::timestamptz, sqlc.narg('cursor_id')::bigint)
+           ELSE (g.created_at, g.id) < (sqlc.narg('cursor_value')::text::timestamptz, sqlc.narg('cursor_id')::bigint)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'price_asc' THEN COALESCE(g.price, 0) END,
+         CASE WHEN sqlc.arg('sort')::text = 'price_desc' THEN COALESCE(g.price, 0) END DESC,
+         CASE WHEN sqlc.arg('sort')::text = 'relevance' THEN r.rank END DESC,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN g.created_at END,
+         CASE WHEN sqlc.arg('sort')::text IN ('price_asc', 'oldest') THEN g.id END,
+         CASE WHEN sqlc.arg('sort')::text NOT IN ('price_asc', 'price_desc', 'relevance', 'oldest') THEN g.created_at END DESC,
+         g.id DESC
+LIMIT sqlc.narg('limit')::int;
+
+-- name: SearchGundamFacets :many
+-- Đếm số Gundam theo từng giá trị của các facet (grade, scale, condition, manufacturer, price_range, release_year)
+-- trên tập kết quả sau khi áp dụng cùng bộ lọc với SearchGundams.
+WITH matched AS (SELECT gg.slug         AS grade_slug,
+                        gg.display_name AS grade,
+                        g.scale,
+                        g.condition,
+                        g.manufacturer,
+                        g.price,
+                        g.release_year
+                 FROM gundams g
+                          JOIN gundam_grades gg ON g.grade_id = gg.id
+                          JOIN users u ON g.owner_id = u.id
+                 WHERE u.deleted_at IS NULL
+                   AND (sqlc.narg('query')::text IS NULL OR
+                        gundam_search_vector(g.name, g.series, g.version, g.description) @@
+                        to_tsquery('simple', immutable_unaccent(sqlc.narg('query')::text)))
+                   AND (sqlc.narg('grade_slug')::text IS NULL OR gg.slug = sqlc.narg('grade_slug')::text)
+                   AND (sqlc.narg('scale')::text IS NULL OR g.scale = sqlc.narg('scale')::gundam_scale)
+                   AND (sqlc.narg('condition')::text IS NULL OR g.condition = sqlc.narg('condition')::gundam_condition)
+                   AND (sqlc.narg('manufacturer')::text IS NULL OR lower(g.manufacturer) = lower(sqlc.narg('manufacturer')::text))
+                   AND (sqlc.narg('status')::text IS NULL OR g.status = sqlc.narg('status')::gundam_status)
+                   AND (sqlc.narg('min_price')::bigint IS NULL OR g.price >= sqlc.narg('min_price')::bigint)
+                   AND (sqlc.narg('max_price')::bigint IS NULL OR g.price < sqlc.narg('max_price')::bigint)
+                   AND (sqlc.narg('release_year')::bigint IS NULL OR g.release_year = sqlc.narg('release_year')::bigint)
+                   AND (sqlc.narg('model_kit_id')::bigint IS NULL OR g.model_kit_id = sqlc.narg('model_kit_id')::bigint))
+SELECT 'grade'::text AS facet, grade_slug AS value, grade AS label, COUNT(*) AS count
+FROM matched
+GROUP BY grade_slug, grade
+UNION ALL
+SELECT 'scale', scale::text, scale::text, COUNT(*)
+FROM matched
+GROUP BY scale
+UNION ALL
+SELECT 'condition', condition::text, condition::text, COUNT(*)
+FROM matched
+GROUP BY condition
+UNION ALL
+SELECT 'manufacturer', lower(manufacturer), min(manufacturer), COUNT(*)
+FROM matched
+GROUP BY lower(manufacturer)
+UNION ALL
+SELECT 'price_range', bucket, bucket, COUNT(*)
+FROM (SELECT CASE
+                 WHEN price < 500000 THEN '0-500000'
+                 WHEN price < 1000000 THEN '500000-1000000'
+                 WHEN price < 2000000 THEN '1000000-2000000'
+                 WHEN price < 5000000 THEN '2000000-5000000'
+                 ELSE '5000000-'
+                 END AS bucket
+      FROM matched
+      WHERE price IS NOT NULL) AS price_buckets
+GROUP BY bucket
+UNION ALL
+SELECT 'release_year', release_year::text, release_year::text, COUNT(*)
+FROM matched
+WHERE release_year IS NOT NULL
+GROUP BY release_year
+ORDER BY facet, count DESC, value;
 
 -- name: GetGundamByID :one
 SELECT *
 FROM gundams
 WHERE id = $1;
 
+-- name: ListGundamsWithGradeByIDs :many
+SELECT sqlc.embed(g),
+       gg.display_name AS grade
+FROM gundams g
+         JOIN gundam_grades gg ON g.grade_id = gg.id
+WHERE g.id = ANY (@gundam_ids::bigint[]);
+
 -- name: GetGundamBySlug :one
 SELECT g.id            AS gundam_id,
        g.owner_id,
@@ -89,7 +192,9 @@ SELECT g.id            AS gundam_id,
        g.release_year,
        g.status,
        g.created_at,
-       g.updated_at
+       g.updated_at,
+       g.model_kit_id,
+       g.reserved_quantity
 FROM gundams g
          JOIN gundam_grades gg ON g.grade_id = gg.id
 WHERE g.slug = $1
@@ -106,6 +211,12 @@ SELECT *
 FROM gundam_accessories
 WHERE gundam_id = $1;
 
+-- name: ListGundamAccessoriesByGundamIDs :many
+SELECT *
+FROM gundam_accessories
+WHERE gundam_id = ANY (@gundam_ids::bigint[])
+ORDER BY gundam_id, id;
+
 -- name: UpdateGundam :exec
 UPDATE gundams
 SET owner_id              = coalesce(sqlc.narg('owner_id'), owner_id),
@@ -171,4 +282,123 @@ WHERE
 DELETE
 FROM gundams
 WHERE id = $1
-  AND owner_id = $2;
\ No newline at end of file
+  AND owner_id = $2;
+
+-- name: UnpublishAllUserGundams :exec
+UPDATE gundams
+SET status     = 'in store',
+    updated_at = now()
+WHERE owner_id = $1
+  AND status = 'published';
+
+-- name: LockGundamsByIDs :exec
+-- Khóa các Gundam theo thứ tự ID tăng dần, dùng trước khi giữ hàng cho nhiều đơn hàng trong cùng một transaction
+-- để các transaction đồng thời luôn khóa các dòng theo cùng một thứ tự (tránh deadlock).
+SELECT id
+FROM gundams
+WHERE id = ANY (sqlc.arg('gundam_ids')::bigint[])
+ORDER BY id
+    FOR UPDATE;
+
+-- name: ReserveGundamStock :one
+-- Giữ quantity đơn vị của Gundam đang đăng bán cho một đơn hàng, chỉ thành công khi còn đủ hàng.
+-- UPDATE khóa dòng và kiểm tra lại điều kiện sau khi chờ khóa, nên các đơn hàng đồng thời không thể giữ quá số lượng hiện có.
+-- Gundam chuyển sang "processing" khi toàn bộ số lượng đã được giữ, để không thể được thêm vào giỏ hàng hay đặt mua nữa.
+UPDATE gundams
+SET reserved_quantity = reserved_quantity + sqlc.arg('quantity')::bigint,
+    status            = CASE
+                            WHEN quantity - reserved_quantity = sqlc.arg('quantity')::bigint THEN 'processing'::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = sqlc.arg('id')
+  AND status = 'published'
+  AND quantity - reserved_quantity >= sqlc.arg('quantity')::bigint
+RETURNING *;
+
+-- name: ReleaseGundamStock :one
+-- Trả lại quantity đơn vị đã giữ khi đơn hàng bị hủy hoặc thất bại.
+-- Trạng thái chỉ đổi khi không còn đơn hàng nào giữ Gundam (reserved_quantity về 0): Gundam "processing" chuyển sang restore_status.
+-- Nếu vẫn còn đơn hàng khác đang giữ, trạng thái được giữ nguyên để đơn hàng bị hủy hoặc thất bại không ghi đè lên các đơn hàng đó.
+UPDATE gundams
+SET reserved_quantity = reserved_quantity - sqlc.arg('quantity')::bigint,
+    status            = CASE
+                            WHEN status = 'processing' AND reserved_quantity = sqlc.arg('quantity')::bigint
+                                THEN sqlc.arg('restore_status')::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = sqlc.arg('id')
+  AND reserved_quantity >= sqlc.arg('quantity')::bigint
+RETURNING *;
+
+-- name: DeductGundamStock :one
+-- Trừ quantity đơn vị đã giữ khỏi tồn kho của người bán khi đơn hàng hoàn tất,
+-- chỉ áp dụng khi người bán vẫn còn hàng sau khi trừ (xem TransferGundamOwnership).
+-- Gundam "processing" không còn đơn hàng nào giữ (do các đơn hàng khác đã bị hủy hoặc thất bại) được đăng bán lại.
+UPDATE gundams
+SET quantity          = quantity - sqlc.arg('quantity')::bigint,
+    reserved_quantity = reserved_quantity - sqlc.arg('quantity')::bigint,
+    status            = CASE
+                            WHEN status = 'processing' AND reserved_quantity = sqlc.arg('quantity')::bigint
+                                THEN 'published'::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = sqlc.arg('id')
+  AND quantity > sqlc.arg('quantity')::bigint
+  AND reserved_quantity >= sqlc.arg('quantity')::bigint
+RETURNING *;
+
+-- name: TransferGundamOwnership :one
+-- Chuyển quyền sở hữu Gundam cho người mua khi đơn hàng hoàn tất và người mua đã mua toàn bộ số lượng còn lại.
+UPDATE gundams
+SET owner_id          = sqlc.arg('owner_id'),
+    status            = 'in store',
+    reserved_quantity = 0,
+    updated_at        = now()
+WHERE id = sqlc.arg('id')
+  AND quantity <= sqlc.arg('quantity')::bigint
+RETURNING *;
+
+-- name: CloneGundamForOwner :one
+-- Tạo Gundam mới cho người mua với quantity đơn vị từ tin đăng của người bán, dùng khi người mua chỉ mua một phần số lượng.
+INSERT INTO gundams (owner_id,
+                     name,
+                     slug,
+                     grade_id,
+                     series,
+                     parts_total,
+                     material,
+                     version,
+                     quantity,
+                     condition,
+                     condition_description,
+                     manufacturer,
+                     weight,
+                     scale,
+                     description,
+                     price,
+                     release_year,
+                     model_kit_id)
+SELECT sqlc.arg('owner_id')::text,
+       name,
+       sqlc.arg('slug')::text,
+       grade_id,
+       series,
+       parts_total,
+       material,
+       version,
+       sqlc.arg('quantity')::bigint,
+       condition,
+       condition_description,
+       manufacturer,
+       weight,
+       scale,
+       description,
+       price,
+       release_year,
+       model_kit_id
+FROM gundams
+WHERE id = sqlc.arg('id')
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,91 @@
+-- name: CreateImageCleanupRun :one
+INSERT INTO image_cleanup_runs (dry_run, grace_period_hours, requested_by)
+VALUES ($1, $2, $3) RETURNING *;
+
+-- name: GetImageCleanupRun :one
+SELECT *
+FROM image_cleanup_runs
+WHERE id = $1;
+
+-- name: StartImageCleanupRun :one
+-- Chuyển lần chạy sang running, task được thử lại sau khi worker dừng giữa chừng sẽ chạy lại từ đầu.
+UPDATE image_cleanup_runs
+SET status     = 'running',
+    started_at = now()
+WHERE id = $1
+  AND status IN ('pending', 'running') RETURNING *;
+
+-- name: CompleteImageCleanupRun :one
+UPDATE image_cleanup_runs
+SET status         = 'completed',
+    scanned_count  = sqlc.arg('scanned_count'),
+    recent_count   = sqlc.arg('recent_count'),
+    orphaned_count = sqlc.arg('orphaned_count'),
+    orphaned_bytes = sqlc.arg('orphaned_bytes'),
+    deleted_count  = sqlc.arg('deleted_count'),
+    failed_count   = sqlc.arg('failed_count'),
+    orphans        = sqlc.arg('orphans'),
+    finished_at    = now()
+WHERE id = sqlc.arg('id') RETURNING *;
+
+-- name: FailImageCleanupRun :exec
+UPDATE image_cleanup_runs
+SET status      = 'failed',
+    error       = sqlc.arg('error')::text,
+    finished_at = now()
+WHERE id = sqlc.arg('id');
+
+-- name: ListImageCleanupRuns :many
+-- Danh sách các lần dọn ảnh cho admin, mới nhất trước, không kèm danh sách ảnh mồ côi. Phân trang keyset theo id.
+SELECT id,
+       dry_run,
+       status,
+       grace_period_hours,
+       scanned_count,
+       recent_count,
+       orphaned_count,
+       orphaned_bytes,
+       deleted_count,
+       failed_count,
+       error,
+       requested_by,
+       started_at,
+       finished_at,
+       created_at
+FROM image_cleanup_runs
+WHERE (sqlc.narg('cursor_id')::bigint IS NULL OR id < sqlc.narg('cursor_id')::bigint)
+ORDER BY id DESC
+LIMIT sqlc.narg('limit')::int;
+
+-- name: ListReferencedImageURLs :many
+-- Tất cả URL ảnh đang được database tham chiếu, kể cả ảnh trong snapshot của đơn hàng, giao dịch trao đổi và đấu giá.
+SELECT url
+FROM gundam_images
+UNION
+SELECT unnest(post_image_urls)
+FROM exchange_posts
+UNION
+SELECT unnest(packaging_image_urls)
+FROM orders
+UNION
+SELECT avatar_url
+FROM users
+WHERE avatar_url IS NOT NULL
+UNION
+SELECT image_url
+FROM model_kits
+WHERE image_url IS NOT NULL
+UNION
+SELECT image_url
+FROM order_items
+UNION
+SELECT image_url
+FROM exchange_items
+UNION
+SELECT gundam_snapshot ->> 'image_url'
+FROM auction_requests
+WHERE gundam_snapshot ->> 'image_url' IS NOT NULL
+UNION
+SELECT gundam_snapshot ->> 'image_url'
+FROM auctions
+WHERE gundam_snapshot ->> 'image_url' IS NOT NULL;
@@ -0,0 +1,49 @@
+-- name: CreateImageFingerprint :one
+INSERT INTO image_fingerprints (url, thumbnail_url, folder, ref_type, ref_id, content_hash, perceptual_hash, width, height)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (url, ref_type, ref_id) DO
+UPDATE SET thumbnail_url = EXCLUDED.thumbnail_url
+    RETURNING *;
+
+-- name: FlagDuplicateImages :execrows
+-- Đánh dấu các ảnh cùng thư mục của đối tượng khác có khoảng cách Hamming giữa hai perceptual hash không vượt quá max_distance.
+INSERT INTO image_flags (fingerprint_id, matched_fingerprint_id, distance)
+SELECT f.id,
+       m.id,
+       bit_count((f.perceptual_hash # m.perceptual_hash)::bit(64))
+FROM image_fingerprints f
+         JOIN image_fingerprints m ON m.folder = f.folder AND (m.ref_type, m.ref_id) <> (f.ref_type, f.ref_id)
+WHERE f.id = sqlc.arg('fingerprint_id')
+  AND bit_count((f.perceptual_hash # m.perceptual_hash)::bit(64)) <= sqlc.arg('max_distance')::int
+ON CONFLICT (fingerprint_id, matched_fingerprint_id) DO NOTHING;
+
+-- name: ListImageFlags :many
+-- Danh sách các cặp ảnh bị đánh dấu trùng cho moderator, mới nhất trước. Phân trang keyset theo id.
+SELECT fl.id,
+       fl.distance,
+       fl.status,
+       fl.reviewed_by,
+       fl.reviewed_at,
+       fl.created_at,
+       f.url           AS image_url,
+       f.thumbnail_url,
+       f.ref_type,
+       f.ref_id,
+       m.url           AS matched_image_url,
+       m.thumbnail_url AS matched_thumbnail_url,
+       m.ref_type      AS matched_ref_type,
+       m.ref_id        AS matched_ref_id,
+       m.created_at    AS matched_uploaded_at
+FROM image_flags fl
+         JOIN image_fingerprints f ON fl.fingerprint_id = f.id
+         JOIN image_fingerprints m ON fl.matched_fingerprint_id = m.id
+WHERE (sqlc.narg('status')::image_flag_status IS NULL OR fl.status = sqlc.narg('status')::image_flag_status)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL OR fl.id < sqlc.narg('cursor_id')::bigint)
+ORDER BY fl.id DESC
+LIMIT sqlc.narg('limit')::int;
+
+-- name: ReviewImageFlag :one
+UPDATE image_flags
+SET status      = sqlc.arg('status'),
+    reviewed_by = sqlc.arg('reviewed_by'),
+    reviewed_at = now()
+WHERE id = sqlc.arg('id') RETURNING *;
@@ -0,0 +1,34 @@
+-- name: GetMarketPriceStats :many
+-- Thống kê giá bán (min, p25, median, p75, max) của một model kit hoặc các Gundam cùng họ slug (xem slug_family) kể từ thời điểm since.
+-- Mỗi tình trạng (condition) một dòng, cộng thêm một dòng tổng hợp cho mọi tình trạng với is_overall = true.
+-- Chỉ tính các lần bán có nguồn (source của gundam_sales) nằm trong sources.
+SELECT s.condition,
+       GROUPING(s.condition) = 1                                        AS is_overall,
+       COUNT(*)                                                          AS sample_size,
+       MIN(s.price)::bigint                                              AS min_price,
+       (percentile_cont(0.25) WITHIN GROUP (ORDER BY s.price))::bigint AS p25,
+       (percentile_cont(0.5) WITHIN GROUP (ORDER BY s.price))::bigint  AS median,
+       (percentile_cont(0.75) WITHIN GROUP (ORDER BY s.price))::bigint AS p75,
+       MAX(s.price)::bigint                                              AS max_price
+FROM gundam_sales s
+WHERE (sqlc.narg('model_kit_id')::bigint IS NULL OR s.model_kit_id = sqlc.narg('model_kit_id')::bigint)
+  AND (sqlc.narg('slug')::text IS NULL OR s.slug_family = slug_family(sqlc.narg('slug')::text))
+  AND s.sold_at >= sqlc.arg('since')::timestamptz
+  AND s.source = ANY (sqlc.arg('sources')::text[])
+GROUP BY GROUPING SETS ((s.condition), ())
+ORDER BY is_overall DESC, s.condition;
+
+-- name: ListMarketPriceTrend :many
+-- Giá bán trung vị theo từng tháng của một model kit hoặc các Gundam cùng họ slug (xem slug_family), có thể lọc theo tình trạng.
+-- Chỉ tính các lần bán có nguồn (source của gundam_sales) nằm trong sources.
+SELECT date_trunc('month', s.sold_at)::timestamptz                     AS month,
+       COUNT(*)                                                          AS sample_size,
+       (percentile_cont(0.5) WITHIN GROUP (ORDER BY s.price))::bigint  AS median
+FROM gundam_sales s
+WHERE (sqlc.narg('model_kit_id')::bigint IS NULL OR s.model_kit_id = sqlc.narg('model_kit_id')::bigint)
+  AND (sqlc.narg('slug')::text IS NULL OR s.slug_family = slug_family(sqlc.narg('slug')::text))
+  AND (sqlc.narg('condition')::text IS NULL OR s.condition = sqlc.narg('condition')::gundam_condition)
+  AND s.sold_at >= sqlc.arg('since')::timestamptz
+  AND s.source = ANY (sqlc.arg('sources')::text[])
+GROUP BY month
+ORDER BY month;
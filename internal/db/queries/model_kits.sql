@@ -0,0 +1,109 @@
+-- name: CreateModelKit :one
+INSERT INTO model_kits (name,
+                        slug,
+                        grade_id,
+                        scale,
+                        series,
+                        manufacturer,
+                        version,
+                        release_year,
+                        msrp,
+                        image_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *;
+
+-- name: GetModelKitByID :one
+SELECT *
+FROM model_kits
+WHERE id = $1;
+
+-- name: GetModelKitDetails :one
+-- Thông tin một model kit kèm số tin đăng đang bán và giá thấp nhất trong các tin đăng đó.
+SELECT mk.id,
+       mk.name,
+       mk.slug,
+       mk.grade_id,
+       gg.display_name AS grade,
+       mk.scale,
+       mk.series,
+       mk.manufacturer,
+       mk.version,
+       mk.release_year,
+       mk.msrp,
+       mk.image_url,
+       mk.created_at,
+       mk.updated_at,
+       l.listing_count,
+       l.lowest_price
+FROM model_kits mk
+         JOIN gundam_grades gg ON mk.grade_id = gg.id
+         CROSS JOIN LATERAL (SELECT COUNT(*)     AS listing_count,
+                                    MIN(g.price) AS lowest_price
+                             FROM gundams g
+                                      JOIN users u ON g.owner_id = u.id
+                             WHERE g.model_kit_id = mk.id
+                               AND g.status = 'published'
+                               AND u.deleted_at IS NULL) l
+WHERE mk.id = $1;
+
+-- name: ListModelKits :many
+-- Danh sách model kit cho trang danh mục, kèm số tin đăng đang bán và giá thấp nhất của mỗi kit.
+-- Phân trang keyset theo (cột sắp xếp, id), cursor_value là giá trị cột sắp xếp của dòng cuối trang trước ở dạng text.
+SELECT mk.id,
+       mk.name,
+       mk.slug,
+       mk.grade_id,
+       gg.display_name AS grade,
+       mk.scale,
+       mk.series,
+       mk.manufacturer,
+       mk.version,
+       mk.release_year,
+       mk.msrp,
+       mk.image_url,
+       mk.created_at,
+       mk.updated_at,
+       l.listing_count,
+       l.lowest_price
+FROM model_kits mk
+         JOIN gundam_grades gg ON mk.grade_id = gg.id
+         CROSS JOIN LATERAL (SELECT COUNT(*)     AS listing_count,
+                                    MIN(g.price) AS lowest_price
+                             FROM gundams g
+                                      JOIN users u ON g.owner_id = u.id
+                             WHERE g.model_kit_id = mk.id
+                               AND g.status = 'published'
+                               AND u.deleted_at IS NULL) l
+WHERE (sqlc.narg('query')::text IS NULL OR
+       immutable_unaccent(mk.name) ILIKE concat('%', immutable_unaccent(sqlc.narg('query')::text), '%'))
+  AND (sqlc.narg('grade_slug')::text IS NULL OR gg.slug = sqlc.narg('grade_slug')::text)
+  AND (sqlc.narg('scale')::text IS NULL OR mk.scale = sqlc.narg('scale')::gundam_scale)
+  AND (sqlc.narg('series')::text IS NULL OR lower(mk.series) = lower(sqlc.narg('series')::text))
+  AND (sqlc.narg('cursor_id')::bigint IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'name' THEN (lower(mk.name), mk.id) > (lower(sqlc.narg('cursor_value')::text), sqlc.narg('cursor_id')::bigint)
+           ELSE (mk.created_at, mk.id) < (sqlc.narg('cursor_value')::text::timestamptz, sqlc.narg('cursor_id')::bigint)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'name' THEN lower(mk.name) END,
+         CASE WHEN sqlc.arg('sort')::text = 'name' THEN mk.id END,
+         mk.created_at DESC,
+         mk.id DESC
+LIMIT sqlc.narg('limit')::int;
+
+-- name: UpdateModelKit :one
+UPDATE model_kits
+SET name         = coalesce(sqlc.narg('name'), name),
+    grade_id     = coalesce(sqlc.narg('grade_id'), grade_id),
+    scale        = coalesce(sqlc.narg('scale'), scale),
+    series       = coalesce(sqlc.narg('series'), series),
+    manufacturer = coalesce(sqlc.narg('manufacturer'), manufacturer),
+    version      = coalesce(sqlc.narg('version'), version),
+    release_year = coalesce(sqlc.narg('release_year'), release_year),
+    msrp         = coalesce(sqlc.narg('msrp'), msrp),
+    image_url    = coalesce(sqlc.narg('image_url'), image_url),
+    updated_at   = now()
+WHERE id = sqlc.arg('id') RETURNING *;
+
+-- name: DeleteModelKit :exec
+DELETE
+FROM model_kits
+WHERE id = $1;
@@ -8,8 +8,9 @@ INSERT INTO order_items (order_id,
                          price,
                          quantity,
                          weight,
-                         image_url)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *;
+                         image_url,
+                         condition)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
 
 -- name: ListOrderItems :many
 SELECT *
@@ -13,11 +13,24 @@ INSERT INTO orders (id,
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
 
 -- name: ListMemberOrders :many
+-- Phân trang keyset: sort là recently_updated (mặc định), newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+-- Cursor của recently_updated dựa trên updated_at nên đơn hàng được cập nhật trong lúc client đang phân trang có thể bị bỏ qua hoặc trả về hai lần; dùng newest/oldest nếu cần duyệt ổn định.
 SELECT *
 FROM orders
 WHERE (buyer_id = $1 OR (type = 'exchange' AND seller_id = $1))
   AND status = COALESCE(sqlc.narg('status')::order_status, status)
-ORDER BY updated_at DESC, created_at DESC;
+  AND (sqlc.narg('cursor_id')::uuid IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'newest' THEN (created_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           ELSE (updated_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'newest' THEN created_at END DESC,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN created_at END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN id END,
+         CASE WHEN sqlc.arg('sort')::text NOT IN ('newest', 'oldest') THEN updated_at END DESC,
+         id DESC
+LIMIT sqlc.narg('limit')::int;
 
 -- name: ConfirmOrderByID :one
 UPDATE orders
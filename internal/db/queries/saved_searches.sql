@@ -0,0 +1,39 @@
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (user_id,
+                            name,
+                            keyword,
+                            grade_id,
+                            scale,
+                            max_price,
+                            condition)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: CountSavedSearchesByUserID :one
+SELECT COUNT(*)
+FROM saved_searches
+WHERE user_id = $1;
+
+-- name: ListSavedSearchesByUserID :many
+SELECT *
+FROM saved_searches
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateSavedSearch :one
+-- Thay toàn bộ bộ lọc của một tìm kiếm đã lưu, bộ lọc để NULL sẽ khớp với mọi giá trị.
+UPDATE saved_searches
+SET name       = sqlc.arg('name'),
+    keyword    = sqlc.narg('keyword'),
+    grade_id   = sqlc.narg('grade_id'),
+    scale      = sqlc.narg('scale'),
+    max_price  = sqlc.narg('max_price'),
+    condition  = sqlc.narg('condition'),
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+  AND user_id = sqlc.arg('user_id') RETURNING *;
+
+-- name: DeleteSavedSearch :execrows
+DELETE
+FROM saved_searches
+WHERE id = $1
+  AND user_id = $2;
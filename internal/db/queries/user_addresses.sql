@@ -62,4 +62,9 @@ WHERE id = sqlc.arg('address_id')
 SELECT *
 FROM user_addresses
 WHERE user_id = $1
-  AND is_pickup_address = true;
\ No newline at end of file
+  AND is_pickup_address = true;
+
+-- name: DeleteAllUserAddresses :exec
+DELETE
+FROM user_addresses
+WHERE user_id = $1;
\ No newline at end of file
@@ -28,4 +28,11 @@ SET deleted_at = COALESCE(sqlc.narg(deleted_at), deleted_at),
     updated_at = now()
 WHERE id = $1
   AND user_id = $2
-  AND deleted_at IS NULL RETURNING *;
\ No newline at end of file
+  AND deleted_at IS NULL RETURNING *;
+
+-- name: SoftDeleteAllUserBankAccounts :exec
+UPDATE user_bank_accounts
+SET deleted_at = now(),
+    updated_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,56 @@
+-- name: UpsertUserTOTPSecret :one
+-- Tạo secret mới cho lần đăng ký, không ghi đè nếu TOTP đã được bật
+INSERT INTO user_totp_credentials (user_id, secret)
+VALUES ($1, $2) ON CONFLICT (user_id) DO
+UPDATE
+    SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    updated_at = now()
+WHERE user_totp_credentials.enabled_at IS NULL RETURNING *;
+
+-- name: GetUserTOTPCredential :one
+SELECT *
+FROM user_totp_credentials
+WHERE user_id = $1;
+
+-- name: EnableUserTOTP :one
+UPDATE user_totp_credentials
+SET enabled_at = now(),
+    updated_at = now()
+WHERE user_id = $1
+  AND enabled_at IS NULL RETURNING *;
+
+-- name: UpdateUserTOTPLastUsedStep :execrows
+-- Chỉ cập nhật khi bước thời gian mới lớn hơn bước đã dùng, để mỗi mã TOTP chỉ được dùng một lần
+UPDATE user_totp_credentials
+SET last_used_step = sqlc.arg('step'),
+    updated_at     = now()
+WHERE user_id = sqlc.arg('user_id')
+  AND last_used_step < sqlc.arg('step');
+
+-- name: DeleteUserTOTPCredential :exec
+DELETE
+FROM user_totp_credentials
+WHERE user_id = $1;
+
+-- name: CreateUserRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT sqlc.arg('user_id')::text, unnest(sqlc.arg('code_hashes')::text[]);
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE
+FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseUserRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = now()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL;
+
+-- name: CountUnusedUserRecoveryCodes :one
+SELECT COUNT(*)
+FROM user_recovery_codes
+WHERE user_id = $1
+  AND used_at IS NULL;
\ No newline at end of file
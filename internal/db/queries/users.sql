@@ -10,17 +10,26 @@ VALUES ($1, $2, $3, $4, $5) RETURNING *;
 -- name: GetUserByID :one
 SELECT *
 FROM users
-WHERE id = $1;
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: ListUsersByIDs :many
+SELECT *
+FROM users
+WHERE id = ANY (@user_ids::text[])
+  AND deleted_at IS NULL;
 
 -- name: GetUserByEmail :one
 SELECT *
 FROM users
-WHERE email = $1;
+WHERE email = $1
+  AND deleted_at IS NULL;
 
 -- name: GetUserByPhoneNumber :one
 SELECT *
 FROM users
-WHERE phone_number = $1;
+WHERE phone_number = $1
+  AND deleted_at IS NULL;
 
 -- name: UpdateUser :one
 UPDATE users
@@ -30,4 +39,78 @@ SET full_name             = COALESCE(sqlc.narg('full_name'), full_name),
     phone_number_verified = COALESCE(sqlc.narg('phone_number_verified'), phone_number_verified),
     role                  = COALESCE(sqlc.narg('role'), role),
     updated_at            = now()
-WHERE id = sqlc.arg('user_id') RETURNING *;
\ No newline at end of file
+WHERE id = sqlc.arg('user_id') RETURNING *;
+
+-- name: IncrementUserTokenVersion :one
+UPDATE users
+SET token_version = token_version + 1,
+    updated_at    = now()
+WHERE id = $1 RETURNING token_version;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = sqlc.arg('hashed_password'),
+    updated_at      = now()
+WHERE id = sqlc.arg('user_id');
+
+-- name: GetUserByIDIncludingDeleted :one
+-- Dùng để hiển thị thông tin đối tác trong các giao dịch đã kết thúc, kể cả khi tài khoản đã bị xóa
+SELECT *
+FROM users
+WHERE id = $1;
+
+-- name: GetUserDeletionBlockers :one
+-- Đếm các giao dịch chưa kết thúc khiến tài khoản chưa thể bị xóa
+SELECT (SELECT COUNT(*)
+        FROM orders o
+        WHERE (o.buyer_id = sqlc.arg('user_id') OR o.seller_id = sqlc.arg('user_id'))
+          AND o.status NOT IN ('completed', 'failed', 'canceled'))::bigint AS open_orders,
+       ((SELECT COUNT(*)
+         FROM exchanges e
+         WHERE (e.poster_id = sqlc.arg('user_id') OR e.offerer_id = sqlc.arg('user_id'))
+           AND e.status NOT IN ('completed', 'canceled', 'failed')) +
+        (SELECT COUNT(*)
+         FROM exchange_posts ep
+         WHERE ep.user_id = sqlc.arg('user_id')
+           AND ep.status = 'open') +
+        (SELECT COUNT(*)
+         FROM exchange_offers eo
+         WHERE eo.offerer_id = sqlc.arg('user_id')))::bigint AS open_exchanges,
+       ((SELECT COUNT(*)
+         FROM auctions a
+         WHERE a.seller_id = sqlc.arg('user_id')
+           AND a.status IN ('scheduled', 'active', 'ended')) +
+        (SELECT COUNT(*)
+         FROM auction_participants ap
+                  JOIN auctions a ON ap.auction_id = a.id
+         WHERE ap.user_id = sqlc.arg('user_id')
+           AND a.status IN ('scheduled', 'active', 'ended')) +
+        (SELECT COUNT(*)
+         FROM auction_requests ar
+         WHERE ar.seller_id = sqlc.arg('user_id')
+           AND ar.status = 'pending'))::bigint AS open_auctions,
+       (SELECT COUNT(*)
+        FROM withdrawal_requests wr
+        WHERE wr.user_id = sqlc.arg('user_id')
+          AND wr.status IN ('pending', 'approved'))::bigint AS pending_withdrawals,
+       COALESCE((SELECT w.balance + w.non_withdrawable_amount
+                 FROM wallets w
+                 WHERE w.user_id = sqlc.arg('user_id')), 0)::bigint AS wallet_balance;
+
+-- name: AnonymizeUser :one
+-- Xóa thông tin cá nhân và đánh dấu tài khoản đã bị xóa.
+-- Email được thay bằng địa chỉ không tồn tại để giải phóng ràng buộc UNIQUE cho lần đăng ký sau.
+UPDATE users
+SET google_account_id     = NULL,
+    full_name             = 'Deleted user',
+    hashed_password       = NULL,
+    email                 = concat('deleted+', id, '@deleted.invalid'),
+    email_verified        = false,
+    phone_number          = NULL,
+    phone_number_verified = false,
+    avatar_url            = NULL,
+    token_version         = token_version + 1,
+    deleted_at            = now(),
+    updated_at            = now()
+WHERE id = $1
+  AND deleted_at IS NULL RETURNING *;
\ No newline at end of file
@@ -32,8 +32,18 @@ WHERE reference_id = $1
   AND wallet_id = $2 LIMIT 1;
 
 -- name: ListUserWalletEntries :many
+-- Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
 SELECT *
 FROM wallet_entries
 WHERE wallet_id = $1
   AND status = COALESCE(sqlc.narg('status'), status)
-ORDER BY created_at DESC;
\ No newline at end of file
+  AND (sqlc.narg('cursor_id')::bigint IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'oldest' THEN (created_at, id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::bigint)
+           ELSE (created_at, id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::bigint)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN created_at END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT sqlc.narg('limit')::int;
\ No newline at end of file
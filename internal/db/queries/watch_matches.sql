@@ -0,0 +1,76 @@
+-- name: CreateWatchMatches :many
+-- Ghi nhận một Gundam vừa được đăng bán, giảm giá (previous_price khác NULL) hoặc đưa lên đấu giá cho những người dùng
+-- có Gundam này trong danh sách yêu thích hoặc có tìm kiếm đã lưu khớp với nó. Chủ sở hữu Gundam và tài khoản đã bị xóa không được ghi nhận.
+-- Nếu người dùng đã có dòng chờ thông báo cho Gundam thì chỉ cập nhật giá, giữ nguyên giá trước khi giảm của dòng đó.
+-- Trả về ID của những người dùng có dòng chờ thông báo mới hoặc vừa được cập nhật.
+WITH target AS (SELECT id, owner_id, name, grade_id, scale, condition
+                FROM gundams
+                WHERE id = sqlc.arg('gundam_id')),
+     matched AS (SELECT w.user_id, NULL::bigint AS saved_search_id
+                 FROM wishlist_items w
+                          JOIN target t ON w.gundam_id = t.id
+                 UNION ALL
+                 SELECT s.user_id, s.id AS saved_search_id
+                 FROM saved_searches s
+                          CROSS JOIN target t
+                 WHERE (s.keyword IS NULL OR
+                        immutable_unaccent(t.name) ILIKE concat('%', immutable_unaccent(s.keyword), '%'))
+                   AND (s.grade_id IS NULL OR s.grade_id = t.grade_id)
+                   AND (s.scale IS NULL OR s.scale = t.scale)
+                   AND (s.condition IS NULL OR s.condition = t.condition)
+                   AND (s.max_price IS NULL OR s.max_price >= sqlc.arg('price')::bigint))
+INSERT
+INTO watch_matches (user_id, gundam_id, auction_id, saved_search_id, price, previous_price)
+SELECT DISTINCT ON (m.user_id) m.user_id,
+                               t.id,
+                               sqlc.narg('auction_id')::uuid,
+                               m.saved_search_id,
+                               sqlc.arg('price')::bigint,
+                               sqlc.narg('previous_price')::bigint
+FROM matched m
+         CROSS JOIN target t
+         JOIN users u ON m.user_id = u.id
+WHERE m.user_id <> t.owner_id
+  AND u.deleted_at IS NULL
+ORDER BY m.user_id, m.saved_search_id NULLS FIRST
+ON CONFLICT (user_id, gundam_id) WHERE notified_at IS NULL DO UPDATE
+    SET price = EXCLUDED.price
+RETURNING user_id;
+
+-- name: GetLastWatchDigestTime :one
+-- Thời điểm gửi thông báo tổng hợp gần nhất cho người dùng.
+SELECT notified_at
+FROM watch_matches
+WHERE user_id = $1
+  AND notified_at IS NOT NULL
+ORDER BY notified_at DESC
+LIMIT 1;
+
+-- name: ListPendingWatchMatches :many
+-- Các dòng chờ thông báo của người dùng, is_available cho biết Gundam còn đang được bán hoặc phiên đấu giá chưa kết thúc.
+SELECT wm.id,
+       wm.gundam_id,
+       wm.auction_id,
+       wm.saved_search_id,
+       s.name AS saved_search_name,
+       wm.price,
+       wm.previous_price,
+       g.name AS gundam_name,
+       g.slug AS gundam_slug,
+       (CASE
+            WHEN wm.auction_id IS NULL THEN g.status = 'published'
+            ELSE a.status IN ('scheduled', 'active')
+           END)::bool AS is_available,
+       wm.created_at
+FROM watch_matches wm
+         JOIN gundams g ON wm.gundam_id = g.id
+         LEFT JOIN auctions a ON wm.auction_id = a.id
+         LEFT JOIN saved_searches s ON wm.saved_search_id = s.id
+WHERE wm.user_id = $1
+  AND wm.notified_at IS NULL
+ORDER BY wm.created_at, wm.id;
+
+-- name: MarkWatchMatchesNotified :exec
+UPDATE watch_matches
+SET notified_at = now()
+WHERE id = ANY (sqlc.arg('ids')::bigint[]);
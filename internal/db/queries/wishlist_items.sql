@@ -0,0 +1,33 @@
+-- name: AddWishlistItem :exec
+INSERT INTO wishlist_items (user_id, gundam_id)
+VALUES ($1, $2) ON CONFLICT (user_id, gundam_id) DO NOTHING;
+
+-- name: ListWishlistItems :many
+-- Danh sách Gundam trong danh sách yêu thích của người dùng, mới thêm trước.
+-- Phân trang keyset theo (created_at, gundam_id) của danh sách yêu thích.
+SELECT w.gundam_id,
+       g.owner_id,
+       g.name,
+       g.slug,
+       gg.display_name AS grade,
+       g.scale,
+       g.condition,
+       g.price,
+       g.status,
+       gi.url          AS image_url,
+       w.created_at
+FROM wishlist_items w
+         JOIN gundams g ON w.gundam_id = g.id
+         JOIN gundam_grades gg ON g.grade_id = gg.id
+         LEFT JOIN gundam_images gi ON gi.gundam_id = g.id AND gi.is_primary = true
+WHERE w.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('cursor_id')::bigint IS NULL OR
+       (w.created_at, w.gundam_id) < (sqlc.narg('cursor_value')::text::timestamptz, sqlc.narg('cursor_id')::bigint))
+ORDER BY w.created_at DESC, w.gundam_id DESC
+LIMIT sqlc.narg('limit')::int;
+
+-- name: DeleteWishlistItem :execrows
+DELETE
+FROM wishlist_items
+WHERE user_id = $1
+  AND gundam_id = $2;
@@ -16,12 +16,22 @@ WHERE wr.user_id = sqlc.arg('user_id')
 ORDER BY wr.created_at DESC;
 
 -- name: ListWithdrawalRequests :many
+-- Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
 SELECT sqlc.embed(wr),
        sqlc.embed(uba)
 FROM withdrawal_requests wr
          LEFT JOIN user_bank_accounts uba ON wr.bank_account_id = uba.id
 WHERE wr.status = COALESCE(sqlc.narg('status'), wr.status)
-ORDER BY wr.created_at DESC;
+  AND (sqlc.narg('cursor_id')::uuid IS NULL OR
+       CASE sqlc.arg('sort')::text
+           WHEN 'oldest' THEN (wr.created_at, wr.id) > (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           ELSE (wr.created_at, wr.id) < (sqlc.narg('cursor_value')::timestamptz, sqlc.narg('cursor_id')::uuid)
+           END)
+ORDER BY CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN wr.created_at END,
+         CASE WHEN sqlc.arg('sort')::text = 'oldest' THEN wr.id END,
+         wr.created_at DESC,
+         wr.id DESC
+LIMIT sqlc.narg('limit')::int;
 
 -- name: GetWithdrawalRequest :one
 SELECT sqlc.embed(wr),
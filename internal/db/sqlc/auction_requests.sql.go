@@ -139,11 +139,35 @@ const listAuctionRequests = `-- name: ListAuctionRequests :many
 SELECT id, gundam_id, seller_id, gundam_snapshot, starting_price, bid_increment, buy_now_price, deposit_rate, deposit_amount, start_time, end_time, status, rejected_by, rejected_reason, approved_by, created_at, updated_at
 FROM auction_requests
 WHERE status = COALESCE($1, status)
-ORDER BY created_at DESC
+  AND ($2::uuid IS NULL OR
+       CASE $3::text
+           WHEN 'oldest' THEN (created_at, id) > ($4::timestamptz, $2::uuid)
+           ELSE (created_at, id) < ($4::timestamptz, $2::uuid)
+           END)
+ORDER BY CASE WHEN $3::text = 'oldest' THEN created_at END,
+         CASE WHEN $3::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT $5::int
 `
 
-func (q *Queries) ListAuctionRequests(ctx context.Context, status NullAuctionRequestStatus) ([]AuctionRequest, error) {
-	rows, err := q.db.Query(ctx, listAuctionRequests, status)
+type ListAuctionRequestsParams struct {
+	Status      NullAuctionRequestStatus `json:"status"`
+	CursorID    *uuid.UUID               `json:"cursor_id"`
+	Sort        string                   `json:"sort"`
+	CursorValue *time.Time               `json:"cursor_value"`
+	Limit       *int32                   `json:"limit"`
+}
+
+// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
+func (q *Queries) ListAuctionRequests(ctx context.Context, arg ListAuctionRequestsParams) ([]AuctionRequest, error) {
+	rows, err := q.db.Query(ctx, listAuctionRequests,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
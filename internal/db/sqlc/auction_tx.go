@@ -597,6 +597,10 @@ func (store *SQLStore) PayAuctionWinningBidTx(ctx context.Context, arg PayAuctio
 			Quantity: arg.Auction.GundamSnapshot.Quantity,
 			Weight:   arg.Auction.GundamSnapshot.Weight,
 			ImageURL: arg.Auction.GundamSnapshot.ImageURL,
+			Condition: NullGundamCondition{
+				GundamCondition: GundamCondition(arg.Auction.GundamSnapshot.Condition),
+				Valid:           arg.Auction.GundamSnapshot.Condition != "",
+			},
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create order item: %w", err)
@@ -320,19 +320,41 @@ const listAuctions = `-- name: ListAuctions :many
 SELECT id, request_id, gundam_id, seller_id, gundam_snapshot, starting_price, bid_increment, winning_bid_id, buy_now_price, start_time, end_time, actual_end_time, status, current_price, deposit_rate, deposit_amount, total_participants, total_bids, winner_payment_deadline, order_id, canceled_by, canceled_reason, created_at, updated_at
 FROM auctions
 WHERE status = COALESCE($1, status)
-ORDER BY CASE status
-             -- Phiên đang diễn ra: ưu tiên theo thời gian kết thúc gần nhất
-             WHEN 'active' THEN EXTRACT(EPOCH FROM end_time)
-             -- Phiên sắp diễn ra: ưu tiên theo thời gian bắt đầu sớm nhất
-             WHEN 'scheduled' THEN EXTRACT(EPOCH FROM start_time)
-             -- Các trạng thái khác: sắp xếp theo thời gian tạo mới nhất
-             ELSE EXTRACT(EPOCH FROM created_at) * -1
-             END ASC,
-         created_at DESC
+  AND seller_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+  AND ($2::uuid IS NULL OR
+       CASE $3::text
+           WHEN 'ending_soon' THEN (end_time, id) > ($4::timestamptz, $2::uuid)
+           WHEN 'starting_soon' THEN (start_time, id) > ($4::timestamptz, $2::uuid)
+           WHEN 'oldest' THEN (created_at, id) > ($4::timestamptz, $2::uuid)
+           ELSE (created_at, id) < ($4::timestamptz, $2::uuid)
+           END)
+ORDER BY CASE WHEN $3::text = 'ending_soon' THEN end_time END,
+         CASE WHEN $3::text = 'starting_soon' THEN start_time END,
+         CASE WHEN $3::text = 'oldest' THEN created_at END,
+         CASE WHEN $3::text IN ('ending_soon', 'starting_soon', 'oldest') THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT $5::int
 `
 
-func (q *Queries) ListAuctions(ctx context.Context, status NullAuctionStatus) ([]Auction, error) {
-	rows, err := q.db.Query(ctx, listAuctions, status)
+type ListAuctionsParams struct {
+	Status      NullAuctionStatus `json:"status"`
+	CursorID    *uuid.UUID        `json:"cursor_id"`
+	Sort        string            `json:"sort"`
+	CursorValue *time.Time        `json:"cursor_value"`
+	Limit       *int32            `json:"limit"`
+}
+
+// Phân trang keyset theo (cột sắp xếp, id):
+// ending_soon theo end_time tăng dần, starting_soon theo start_time tăng dần, oldest/newest theo created_at.
+func (q *Queries) ListAuctions(ctx context.Context, arg ListAuctionsParams) ([]Auction, error) {
+	rows, err := q.db.Query(ctx, listAuctions,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -12,18 +12,23 @@ import (
 const addCartItem = `-- name: AddCartItem :one
 WITH inserted_item AS (
 INSERT
-INTO cart_items (cart_id, gundam_id)
-VALUES ($1, $2)
-    RETURNING id, cart_id, gundam_id
+INTO cart_items (cart_id, gundam_id, quantity)
+VALUES ($1, $2, $3) ON CONFLICT (cart_id, gundam_id) DO
+UPDATE
+    SET quantity = cart_items.quantity + EXCLUDED.quantity,
+    updated_at = now()
+    RETURNING id, cart_id, gundam_id, quantity
     )
-SELECT ci.id        AS cart_item_id,
-       g.id         AS gundam_id,
-       g.name       AS gundam_name,
-       g.price      AS gundam_price,
-       gi.url       AS gundam_image_url,
-       s.id         AS seller_id,
-       s.full_name  AS seller_name,
-       s.avatar_url AS seller_avatar_url
+SELECT ci.id                                      AS cart_item_id,
+       ci.quantity,
+       g.id                                       AS gundam_id,
+       g.name                                     AS gundam_name,
+       g.price                                    AS gundam_price,
+       (g.quantity - g.reserved_quantity)::bigint AS available_quantity,
+       gi.url                                     AS gundam_image_url,
+       s.id                                       AS seller_id,
+       s.full_name                                AS seller_name,
+       s.avatar_url                               AS seller_avatar_url
 FROM inserted_item ci
          JOIN gundams g ON ci.gundam_id = g.id
          JOIN users s ON g.owner_id = s.id
@@ -35,27 +40,33 @@ FROM inserted_item ci
 type AddCartItemParams struct {
 	CartID   int64 `json:"cart_id"`
 	GundamID int64 `json:"gundam_id"`
+	Quantity int64 `json:"quantity"`
 }
 
 type AddCartItemRow struct {
-	CartItemID      string  `json:"cart_item_id"`
-	GundamID        int64   `json:"gundam_id"`
-	GundamName      string  `json:"gundam_name"`
-	GundamPrice     *int64  `json:"gundam_price"`
-	GundamImageUrl  string  `json:"gundam_image_url"`
-	SellerID        string  `json:"seller_id"`
-	SellerName      string  `json:"seller_name"`
-	SellerAvatarUrl *string `json:"seller_avatar_url"`
+	CartItemID        string  `json:"cart_item_id"`
+	Quantity          int64   `json:"quantity"`
+	GundamID          int64   `json:"gundam_id"`
+	GundamName        string  `json:"gundam_name"`
+	GundamPrice       *int64  `json:"gundam_price"`
+	AvailableQuantity int64   `json:"available_quantity"`
+	GundamImageUrl    string  `json:"gundam_image_url"`
+	SellerID          string  `json:"seller_id"`
+	SellerName        string  `json:"seller_name"`
+	SellerAvatarUrl   *string `json:"seller_avatar_url"`
 }
 
+// Thêm Gundam vào giỏ hàng, nếu Gundam đã có trong giỏ thì cộng dồn số lượng.
 func (q *Queries) AddCartItem(ctx context.Context, arg AddCartItemParams) (AddCartItemRow, error) {
-	row := q.db.QueryRow(ctx, addCartItem, arg.CartID, arg.GundamID)
+	row := q.db.QueryRow(ctx, addCartItem, arg.CartID, arg.GundamID, arg.Quantity)
 	var i AddCartItemRow
 	err := row.Scan(
 		&i.CartItemID,
+		&i.Quantity,
 		&i.GundamID,
 		&i.GundamName,
 		&i.GundamPrice,
+		&i.AvailableQuantity,
 		&i.GundamImageUrl,
 		&i.SellerID,
 		&i.SellerName,
@@ -64,25 +75,6 @@ func (q *Queries) AddCartItem(ctx context.Context, arg AddCartItemParams) (AddCa
 	return i, err
 }
 
-const checkCartItemExists = `-- name: CheckCartItemExists :one
-SELECT EXISTS (SELECT 1
-               FROM cart_items
-               WHERE cart_id = $1
-                 AND gundam_id = $2)
-`
-
-type CheckCartItemExistsParams struct {
-	CartID   int64 `json:"cart_id"`
-	GundamID int64 `json:"gundam_id"`
-}
-
-func (q *Queries) CheckCartItemExists(ctx context.Context, arg CheckCartItemExistsParams) (bool, error) {
-	row := q.db.QueryRow(ctx, checkCartItemExists, arg.CartID, arg.GundamID)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
-}
-
 const getCartByUserID = `-- name: GetCartByUserID :one
 SELECT id
 FROM carts
@@ -96,6 +88,25 @@ func (q *Queries) GetCartByUserID(ctx context.Context, userID string) (int64, er
 	return id, err
 }
 
+const getCartItemQuantity = `-- name: GetCartItemQuantity :one
+SELECT quantity
+FROM cart_items
+WHERE cart_id = $1
+  AND gundam_id = $2
+`
+
+type GetCartItemQuantityParams struct {
+	CartID   int64 `json:"cart_id"`
+	GundamID int64 `json:"gundam_id"`
+}
+
+func (q *Queries) GetCartItemQuantity(ctx context.Context, arg GetCartItemQuantityParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getCartItemQuantity, arg.CartID, arg.GundamID)
+	var quantity int64
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
 const getOrCreateCartIfNotExists = `-- name: GetOrCreateCartIfNotExists :one
 INSERT INTO carts (user_id)
 VALUES ($1) ON CONFLICT (user_id) DO
@@ -111,15 +122,51 @@ func (q *Queries) GetOrCreateCartIfNotExists(ctx context.Context, userID string)
 	return id, err
 }
 
+const listCartItems = `-- name: ListCartItems :many
+SELECT id, cart_id, gundam_id, created_at, updated_at, quantity
+FROM cart_items
+WHERE cart_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListCartItems(ctx context.Context, cartID int64) ([]CartItem, error) {
+	rows, err := q.db.Query(ctx, listCartItems, cartID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CartItem{}
+	for rows.Next() {
+		var i CartItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.CartID,
+			&i.GundamID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCartItemsWithDetails = `-- name: ListCartItemsWithDetails :many
-SELECT ci.id        AS cart_item_id,
-       g.id         AS gundam_id,
-       g.name       AS gundam_name,
-       g.price      AS gundam_price,
-       gi.url       AS gundam_image_url,
-       s.id         AS seller_id,
-       s.full_name  AS seller_name,
-       s.avatar_url AS seller_avatar_url
+SELECT ci.id                                      AS cart_item_id,
+       ci.quantity,
+       g.id                                       AS gundam_id,
+       g.name                                     AS gundam_name,
+       g.price                                    AS gundam_price,
+       (g.quantity - g.reserved_quantity)::bigint AS available_quantity,
+       gi.url                                     AS gundam_image_url,
+       s.id                                       AS seller_id,
+       s.full_name                                AS seller_name,
+       s.avatar_url                               AS seller_avatar_url
 FROM cart_items ci
          JOIN gundams g ON ci.gundam_id = g.id
          JOIN users s ON g.owner_id = s.id
@@ -131,14 +178,16 @@ WHERE ci.cart_id = $1
 `
 
 type ListCartItemsWithDetailsRow struct {
-	CartItemID      string  `json:"cart_item_id"`
-	GundamID        int64   `json:"gundam_id"`
-	GundamName      string  `json:"gundam_name"`
-	GundamPrice     *int64  `json:"gundam_price"`
-	GundamImageUrl  string  `json:"gundam_image_url"`
-	SellerID        string  `json:"seller_id"`
-	SellerName      string  `json:"seller_name"`
-	SellerAvatarUrl *string `json:"seller_avatar_url"`
+	CartItemID        string  `json:"cart_item_id"`
+	Quantity          int64   `json:"quantity"`
+	GundamID          int64   `json:"gundam_id"`
+	GundamName        string  `json:"gundam_name"`
+	GundamPrice       *int64  `json:"gundam_price"`
+	AvailableQuantity int64   `json:"available_quantity"`
+	GundamImageUrl    string  `json:"gundam_image_url"`
+	SellerID          string  `json:"seller_id"`
+	SellerName        string  `json:"seller_name"`
+	SellerAvatarUrl   *string `json:"seller_avatar_url"`
 }
 
 func (q *Queries) ListCartItemsWithDetails(ctx context.Context, cartID int64) ([]ListCartItemsWithDetailsRow, error) {
@@ -152,9 +201,11 @@ func (q *Queries) ListCartItemsWithDetails(ctx context.Context, cartID int64) ([
 		var i ListCartItemsWithDetailsRow
 		if err := rows.Scan(
 			&i.CartItemID,
+			&i.Quantity,
 			&i.GundamID,
 			&i.GundamName,
 			&i.GundamPrice,
+			&i.AvailableQuantity,
 			&i.GundamImageUrl,
 			&i.SellerID,
 			&i.SellerName,
@@ -186,3 +237,20 @@ func (q *Queries) RemoveCartItem(ctx context.Context, arg RemoveCartItemParams)
 	_, err := q.db.Exec(ctx, removeCartItem, arg.ID, arg.CartID)
 	return err
 }
+
+const removeCartItemsByGundamIDs = `-- name: RemoveCartItemsByGundamIDs :exec
+DELETE
+FROM cart_items
+WHERE cart_id = $1
+  AND gundam_id = ANY ($2::bigint[])
+`
+
+type RemoveCartItemsByGundamIDsParams struct {
+	CartID    int64   `json:"cart_id"`
+	GundamIds []int64 `json:"gundam_ids"`
+}
+
+func (q *Queries) RemoveCartItemsByGundamIDs(ctx context.Context, arg RemoveCartItemsByGundamIDsParams) error {
+	_, err := q.db.Exec(ctx, removeCartItemsByGundamIDs, arg.CartID, arg.GundamIds)
+	return err
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	
+	"github.com/google/uuid"
+)
+
+type CheckoutTxParams struct {
+	CheckoutID uuid.UUID // Do client sinh ra, dùng làm khóa idempotency
+	BuyerID    string
+	CartID     int64
+	Orders     []CreateOrderTxParams // Mỗi người bán một đơn hàng
+}
+
+type CheckoutOrderDetails struct {
+	Order         Order         `json:"order"`
+	OrderItems    []OrderItem   `json:"order_items"`
+	OrderDelivery OrderDelivery `json:"order_delivery"`
+}
+
+type CheckoutTxResult struct {
+	Checkout Checkout               `json:"checkout"`
+	Orders   []CheckoutOrderDetails `json:"orders"`
+	// Replayed là true nếu checkout ID đã được xử lý trước đó, khi đó các đơn hàng đã tạo được trả về
+	Replayed bool `json:"-"`
+}
+
+// CheckoutTx tạo toàn bộ đơn hàng của một lần thanh toán giỏ hàng (mỗi người bán một đơn hàng) trong cùng một transaction,
+// nếu một đơn hàng thất bại thì không đơn hàng nào được tạo. Gửi lại cùng checkout ID trả về các đơn hàng đã tạo.
+func (store *SQLStore) CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error) {
+	var result CheckoutTxResult
+	
+	err := store.ExecTx(ctx, func(qTx *Queries) error {
+		var itemsSubtotal, deliveryFee, totalAmount int64
+		for _, order := range arg.Orders {
+			itemsSubtotal += order.ItemsSubtotal
+			deliveryFee += order.DeliveryFee
+			totalAmount += order.TotalAmount
+		}
+		
+		// 1. Ghi nhận checkout, request đồng thời với cùng checkout ID sẽ chờ transaction này kết thúc
+		checkout, err := qTx.CreateCheckout(ctx, CreateCheckoutParams{
+			ID:            arg.CheckoutID,
+			BuyerID:       arg.BuyerID,
+			ItemsSubtotal: itemsSubtotal,
+			DeliveryFee:   deliveryFee,
+			TotalAmount:   totalAmount,
+		})
+		if err != nil {
+			if !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("failed to create checkout: %w", err)
+			}
+			
+			// Checkout ID đã được xử lý, trả về các đơn hàng đã tạo thay vì tạo đơn hàng mới
+			existing, err := qTx.GetCheckoutByID(ctx, arg.CheckoutID)
+			if err != nil {
+				return fmt.Errorf("failed to get checkout: %w", err)
+			}
+			if existing.BuyerID != arg.BuyerID {
+				return ErrCheckoutConflict
+			}
+			
+			result, err = loadCheckoutResult(ctx, qTx, existing)
+			result.Replayed = true
+			return err
+		}
+		result.Checkout = checkout
+		
+		// 2. Khóa ví người mua rồi khóa toàn bộ Gundam của mọi đơn hàng theo thứ tự ID tăng dần trước khi giữ hàng,
+		// cùng thứ tự khóa với CreateOrderTx (ví trước, Gundam sau) để các checkout đồng thời chứa cùng Gundam của
+		// nhiều người bán không khóa dòng chéo nhau (deadlock)
+		_, err = qTx.GetWalletForUpdate(ctx, arg.BuyerID)
+		if err != nil {
+			return fmt.Errorf("failed to get buyer wallet: %w", err)
+		}
+		
+		purchasedGundamIDs := make([]int64, 0)
+		for _, orderArg := range arg.Orders {
+			for _, item := range orderArg.Items {
+				purchasedGundamIDs = append(purchasedGundamIDs, item.Gundam.ID)
+			}
+		}
+		slices.Sort(purchasedGundamIDs)
+		
+		err = qTx.LockGundamsByIDs(ctx, purchasedGundamIDs)
+		if err != nil {
+			return fmt.Errorf("failed to lock gundams: %w", err)
+		}
+		
+		// 3. Tạo đơn hàng cho từng người bán
+		for _, orderArg := range arg.Orders {
+			orderResult, err := createRegularOrder(ctx, qTx, orderArg)
+			if err != nil {
+				return fmt.Errorf("failed to create order for seller ID %s: %w", orderArg.SellerID, err)
+			}
+			
+			err = qTx.AddCheckoutOrder(ctx, AddCheckoutOrderParams{
+				CheckoutID: checkout.ID,
+				OrderID:    orderResult.Order.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to add order to checkout: %w", err)
+			}
+			
+			result.Orders = append(result.Orders, CheckoutOrderDetails{
+				Order:         orderResult.Order,
+				OrderItems:    orderResult.OrderItems,
+				OrderDelivery: orderResult.OrderDelivery,
+			})
+		}
+		
+		// 4. Xóa các sản phẩm đã mua khỏi giỏ hàng
+		err = qTx.RemoveCartItemsByGundamIDs(ctx, RemoveCartItemsByGundamIDsParams{
+			CartID:    arg.CartID,
+			GundamIds: purchasedGundamIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove purchased cart items: %w", err)
+		}
+		
+		return nil
+	})
+	
+	return result, err
+}
+
+// GetCheckoutResult trả về các đơn hàng đã được tạo bởi một checkout.
+func (store *SQLStore) GetCheckoutResult(ctx context.Context, checkout Checkout) (CheckoutTxResult, error) {
+	return loadCheckoutResult(ctx, store.Queries, checkout)
+}
+
+func loadCheckoutResult(ctx context.Context, q *Queries, checkout Checkout) (CheckoutTxResult, error) {
+	result := CheckoutTxResult{
+		Checkout: checkout,
+	}
+	
+	orders, err := q.ListCheckoutOrders(ctx, checkout.ID)
+	if err != nil {
+		return result, fmt.Errorf("failed to list checkout orders: %w", err)
+	}
+	
+	result.Orders = make([]CheckoutOrderDetails, 0, len(orders))
+	for _, order := range orders {
+		orderItems, err := q.ListOrderItems(ctx, order.ID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get order items: %w", err)
+		}
+		
+		orderDelivery, err := q.GetOrderDelivery(ctx, order.ID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get order delivery: %w", err)
+		}
+		
+		result.Orders = append(result.Orders, CheckoutOrderDetails{
+			Order:         order,
+			OrderItems:    orderItems,
+			OrderDelivery: orderDelivery,
+		})
+	}
+	
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"testing"
+	
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testCheckout tạo người mua có giỏ hàng gồm hai tin đăng của hai người bán và tham số thanh toán cả giỏ hàng.
+func testCheckout(t *testing.T) (User, []Gundam, CheckoutTxParams) {
+	t.Helper()
+	ctx := context.Background()
+	
+	buyer := createRandomUser(t, 10_000_000)
+	gundams := []Gundam{
+		createPublishedGundam(t, createRandomUser(t, 0).ID, 2, 500_000),
+		createPublishedGundam(t, createRandomUser(t, 0).ID, 1, 800_000),
+	}
+	
+	cartID, err := testStore.GetOrCreateCartIfNotExists(ctx, buyer.ID)
+	require.NoError(t, err)
+	
+	arg := CheckoutTxParams{
+		CheckoutID: uuid.New(),
+		BuyerID:    buyer.ID,
+		CartID:     cartID,
+	}
+	for _, gundam := range gundams {
+		_, err = testStore.AddCartItem(ctx, AddCartItemParams{CartID: cartID, GundamID: gundam.ID, Quantity: 1})
+		require.NoError(t, err)
+		
+		arg.Orders = append(arg.Orders, testOrderParams(t, buyer, gundam, 1))
+	}
+	
+	return buyer, gundams, arg
+}
+
+func checkoutOrderIDs(result CheckoutTxResult) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(result.Orders))
+	for _, order := range result.Orders {
+		ids = append(ids, order.Order.ID)
+	}
+	return ids
+}
+
+func requireWalletBalance(t *testing.T, userID string, balance int64) {
+	t.Helper()
+	
+	wallet, err := testStore.GetWalletByUserID(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, balance, wallet.Balance)
+}
+
+func requireCartSize(t *testing.T, cartID int64, size int) {
+	t.Helper()
+	
+	items, err := testStore.ListCartItems(context.Background(), cartID)
+	require.NoError(t, err)
+	require.Len(t, items, size)
+}
+
+func TestCheckoutTxReplay(t *testing.T) {
+	requireTestStore(t)
+	ctx := context.Background()
+	
+	buyer, gundams, arg := testCheckout(t)
+	totalAmount := arg.Orders[0].TotalAmount + arg.Orders[1].TotalAmount
+	
+	first, err := testStore.CheckoutTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, first.Replayed)
+	require.Equal(t, arg.CheckoutID, first.Checkout.ID)
+	require.Equal(t, totalAmount, first.Checkout.TotalAmount)
+	require.Len(t, first.Orders, 2)
+	
+	requireWalletBalance(t, buyer.ID, 10_000_000-totalAmount)
+	requireCartSize(t, arg.CartID, 0)
+	requireGundamStock(t, gundams[0].ID, 2, 1, GundamStatusPublished)
+	requireGundamStock(t, gundams[1].ID, 1, 1, GundamStatusProcessing)
+	
+	// Gửi lại cùng checkout ID trả về các đơn hàng đã tạo, không trừ tiền hay giữ hàng thêm lần nữa
+	replayed, err := testStore.CheckoutTx(ctx, arg)
+	require.NoError(t, err)
+	require.True(t, replayed.Replayed)
+	require.Equal(t, first.Checkout, replayed.Checkout)
+	require.ElementsMatch(t, checkoutOrderIDs(first), checkoutOrderIDs(replayed))
+	for _, order := range replayed.Orders {
+		require.Len(t, order.OrderItems, 1)
+		require.Equal(t, order.Order.ID, order.OrderDelivery.OrderID)
+	}
+	
+	requireWalletBalance(t, buyer.ID, 10_000_000-totalAmount)
+	requireGundamStock(t, gundams[0].ID, 2, 1, GundamStatusPublished)
+	requireGundamStock(t, gundams[1].ID, 1, 1, GundamStatusProcessing)
+	
+	// Checkout ID của người mua khác không được dùng để xem đơn hàng
+	other := createRandomUser(t, 10_000_000)
+	otherArg := arg
+	otherArg.BuyerID = other.ID
+	_, err = testStore.CheckoutTx(ctx, otherArg)
+	require.ErrorIs(t, err, ErrCheckoutConflict)
+	requireWalletBalance(t, other.ID, 10_000_000)
+}
+
+// Các request đồng thời với cùng checkout ID chờ request đầu tiên hoàn tất rồi nhận lại đúng các đơn hàng của nó.
+func TestCheckoutTxConcurrentReplay(t *testing.T) {
+	requireTestStore(t)
+	ctx := context.Background()
+	
+	buyer, _, arg := testCheckout(t)
+	totalAmount := arg.Orders[0].TotalAmount + arg.Orders[1].TotalAmount
+	
+	type checkoutOutcome struct {
+		result CheckoutTxResult
+		err    error
+	}
+	
+	const n = 5
+	outcomes := make(chan checkoutOutcome, n)
+	for range n {
+		go func() {
+			result, err := testStore.CheckoutTx(ctx, arg)
+			outcomes <- checkoutOutcome{result: result, err: err}
+		}()
+	}
+	
+	var created []CheckoutTxResult
+	var orderIDs [][]uuid.UUID
+	for range n {
+		outcome := <-outcomes
+		require.NoError(t, outcome.err)
+		result := outcome.result
+		if !result.Replayed {
+			created = append(created, result)
+		}
+		orderIDs = append(orderIDs, checkoutOrderIDs(result))
+	}
+	
+	require.Len(t, created, 1)
+	for _, ids := range orderIDs {
+		require.ElementsMatch(t, checkoutOrderIDs(created[0]), ids)
+	}
+	requireWalletBalance(t, buyer.ID, 10_000_000-totalAmount)
+}
+
+// Một đơn hàng thất bại thì không đơn hàng nào được tạo và checkout ID có thể được gửi lại.
+func TestCheckoutTxRollback(t *testing.T) {
+	requireTestStore(t)
+	ctx := context.Background()
+	
+	buyer, gundams, arg := testCheckout(t)
+	
+	// Người khác mua mất tin đăng thứ hai trước khi người mua thanh toán
+	createTestOrder(t, createRandomUser(t, 10_000_000), gundams[1], 1)
+	
+	_, err := testStore.CheckoutTx(ctx, arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+	
+	requireWalletBalance(t, buyer.ID, 10_000_000)
+	requireCartSize(t, arg.CartID, 2)
+	requireGundamStock(t, gundams[0].ID, 2, 0, GundamStatusPublished)
+	
+	_, err = testStore.GetCheckoutByID(ctx, arg.CheckoutID)
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	
+	// Bỏ tin đăng đã hết hàng rồi thanh toán lại với cùng checkout ID
+	arg.Orders = arg.Orders[:1]
+	result, err := testStore.CheckoutTx(ctx, arg)
+	require.NoError(t, err)
+	require.False(t, result.Replayed)
+	require.Len(t, result.Orders, 1)
+	
+	requireWalletBalance(t, buyer.ID, 10_000_000-arg.Orders[0].TotalAmount)
+	requireCartSize(t, arg.CartID, 1)
+	requireGundamStock(t, gundams[0].ID, 2, 1, GundamStatusPublished)
+}
@@ -11,12 +11,14 @@ import (
 // PostgreSQL error codes
 const (
 	UniqueViolationCode = "23505"
+	CheckViolationCode  = "23514"
 )
 
 // Constraint names
 const (
-	UniqueEmailConstraint         = "users_email_key"
-	UniqueSellerProfileConstraint = "seller_profiles_pkey"
+	UniqueEmailConstraint            = "users_email_key"
+	UniqueSellerProfileConstraint    = "seller_profiles_pkey"
+	GundamReservedQuantityConstraint = "gundams_reserved_quantity_check"
 )
 
 // Common errors
@@ -26,12 +28,17 @@ var (
 	ErrPickupAddressDeletion     = errors.New("pickup address cannot be deleted")
 	ErrSubscriptionLimitExceeded = errors.New("subscription limit exceeded")
 	ErrSubscriptionExpired       = errors.New("subscription has expired")
-	ErrCartItemExists            = errors.New("item already exists in cart")
 	ErrExchangeOfferUnique       = errors.New("user already has an offer for this exchange post")
 	ErrDuplicateParticipation    = errors.New("already participated in this auction")
 	ErrInsufficientBalance       = errors.New("insufficient balance")
 	ErrAuctionEnded              = errors.New("auction has ended")
 	ErrBidTooLow                 = errors.New("bid amount too low")
+	ErrInsufficientStock         = errors.New("insufficient stock")
+	ErrGundamPriceChanged        = errors.New("gundam price has changed")
+	ErrDeliveryFeeMismatch       = errors.New("delivery fee does not match the delivery quote")
+	ErrDeliveryTimeMismatch      = errors.New("expected delivery time does not match the delivery quote")
+	ErrDeliveryQuoteMissing      = errors.New("order has no delivery quote")
+	ErrCheckoutConflict          = errors.New("checkout ID belongs to another buyer")
 )
 
 // PgError represents a PostgreSQL error with its code, message and constraint name
@@ -65,6 +72,24 @@ func IsValidGundamStatus(status string) error {
 	return nil
 }
 
+func IsValidGundamScale(scale string) error {
+	if !GundamScale(scale).Valid() {
+		err := fmt.Errorf("invalid scale: %s, must be one of %v", scale, AllGundamScaleValues())
+		return err
+	}
+	
+	return nil
+}
+
+func IsValidGundamCondition(condition string) error {
+	if !GundamCondition(condition).Valid() {
+		err := fmt.Errorf("invalid condition: %s, must be one of %v", condition, AllGundamConditionValues())
+		return err
+	}
+	
+	return nil
+}
+
 func IsValidOrderStatus(status string) error {
 	if !OrderStatus(status).Valid() {
 		err := fmt.Errorf("invalid status: %s, must be one of %v", status, AllOrderStatusValues())
@@ -127,3 +152,12 @@ func IsValidWithdrawalRequestStatus(status string) error {
 	
 	return nil
 }
+
+func IsValidImageFlagStatus(status string) error {
+	if !ImageFlagStatus(status).Valid() {
+		err := fmt.Errorf("invalid status: %s, must be one of %v", status, AllImageFlagStatusValues())
+		return err
+	}
+	
+	return nil
+}
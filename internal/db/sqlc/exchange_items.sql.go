@@ -23,23 +23,25 @@ INSERT INTO exchange_items (id,
                             weight,
                             image_url,
                             owner_id,
-                            is_from_poster)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, exchange_id, gundam_id, name, slug, grade, scale, quantity, weight, image_url, owner_id, is_from_poster, created_at
+                            is_from_poster,
+                            condition)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, exchange_id, gundam_id, name, slug, grade, scale, quantity, weight, image_url, owner_id, is_from_poster, created_at, condition
 `
 
 type CreateExchangeItemParams struct {
-	ID           uuid.UUID `json:"id"`
-	ExchangeID   uuid.UUID `json:"exchange_id"`
-	GundamID     *int64    `json:"gundam_id"`
-	Name         string    `json:"name"`
-	Slug         string    `json:"slug"`
-	Grade        string    `json:"grade"`
-	Scale        string    `json:"scale"`
-	Quantity     int64     `json:"quantity"`
-	Weight       int64     `json:"weight"`
-	ImageURL     string    `json:"image_url"`
-	OwnerID      *string   `json:"owner_id"`
-	IsFromPoster bool      `json:"is_from_poster"`
+	ID           uuid.UUID           `json:"id"`
+	ExchangeID   uuid.UUID           `json:"exchange_id"`
+	GundamID     *int64              `json:"gundam_id"`
+	Name         string              `json:"name"`
+	Slug         string              `json:"slug"`
+	Grade        string              `json:"grade"`
+	Scale        string              `json:"scale"`
+	Quantity     int64               `json:"quantity"`
+	Weight       int64               `json:"weight"`
+	ImageURL     string              `json:"image_url"`
+	OwnerID      *string             `json:"owner_id"`
+	IsFromPoster bool                `json:"is_from_poster"`
+	Condition    NullGundamCondition `json:"condition"`
 }
 
 func (q *Queries) CreateExchangeItem(ctx context.Context, arg CreateExchangeItemParams) (ExchangeItem, error) {
@@ -56,6 +58,7 @@ func (q *Queries) CreateExchangeItem(ctx context.Context, arg CreateExchangeItem
 		arg.ImageURL,
 		arg.OwnerID,
 		arg.IsFromPoster,
+		arg.Condition,
 	)
 	var i ExchangeItem
 	err := row.Scan(
@@ -72,12 +75,13 @@ func (q *Queries) CreateExchangeItem(ctx context.Context, arg CreateExchangeItem
 		&i.OwnerID,
 		&i.IsFromPoster,
 		&i.CreatedAt,
+		&i.Condition,
 	)
 	return i, err
 }
 
 const listExchangeItems = `-- name: ListExchangeItems :many
-SELECT id, exchange_id, gundam_id, name, slug, grade, scale, quantity, weight, image_url, owner_id, is_from_poster, created_at
+SELECT id, exchange_id, gundam_id, name, slug, grade, scale, quantity, weight, image_url, owner_id, is_from_poster, created_at, condition
 FROM exchange_items
 WHERE exchange_id = $1
   AND ($2::boolean IS NULL OR is_from_poster = $2::boolean)
@@ -112,6 +116,7 @@ func (q *Queries) ListExchangeItems(ctx context.Context, arg ListExchangeItemsPa
 			&i.OwnerID,
 			&i.IsFromPoster,
 			&i.CreatedAt,
+			&i.Condition,
 		); err != nil {
 			return nil, err
 		}
@@ -82,3 +82,36 @@ func (q *Queries) ListExchangeOfferItems(ctx context.Context, arg ListExchangeOf
 	}
 	return items, nil
 }
+
+const listExchangeOfferItemsByOfferIDs = `-- name: ListExchangeOfferItemsByOfferIDs :many
+SELECT id, offer_id, gundam_id, is_from_poster, created_at
+FROM exchange_offer_items
+WHERE offer_id = ANY ($1::uuid[])
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListExchangeOfferItemsByOfferIDs(ctx context.Context, offerIDs []uuid.UUID) ([]ExchangeOfferItem, error) {
+	rows, err := q.db.Query(ctx, listExchangeOfferItemsByOfferIDs, offerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangeOfferItem{}
+	for rows.Next() {
+		var i ExchangeOfferItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OfferID,
+			&i.GundamID,
+			&i.IsFromPoster,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -76,3 +76,36 @@ func (q *Queries) ListExchangeOfferNotes(ctx context.Context, offerID uuid.UUID)
 	}
 	return items, nil
 }
+
+const listExchangeOfferNotesByOfferIDs = `-- name: ListExchangeOfferNotesByOfferIDs :many
+SELECT id, offer_id, user_id, content, created_at
+FROM exchange_offer_notes
+WHERE offer_id = ANY ($1::uuid[])
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListExchangeOfferNotesByOfferIDs(ctx context.Context, offerIDs []uuid.UUID) ([]ExchangeOfferNote, error) {
+	rows, err := q.db.Query(ctx, listExchangeOfferNotesByOfferIDs, offerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangeOfferNote{}
+	for rows.Next() {
+		var i ExchangeOfferNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.OfferID,
+			&i.UserID,
+			&i.Content,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
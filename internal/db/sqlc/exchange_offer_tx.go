@@ -438,6 +438,10 @@ func (store *SQLStore) AcceptExchangeOfferTx(ctx context.Context, arg AcceptExch
 				ImageURL:     gundam.PrimaryImageURL,
 				OwnerID:      &gundam.OwnerID,
 				IsFromPoster: true,
+				Condition: NullGundamCondition{
+					GundamCondition: GundamCondition(gundam.Condition),
+					Valid:           true,
+				},
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create exchange item: %w", err)
@@ -470,6 +474,10 @@ func (store *SQLStore) AcceptExchangeOfferTx(ctx context.Context, arg AcceptExch
 				ImageURL:     gundam.PrimaryImageURL,
 				OwnerID:      &gundam.OwnerID,
 				IsFromPoster: false,
+				Condition: NullGundamCondition{
+					GundamCondition: GundamCondition(gundam.Condition),
+					Valid:           true,
+				},
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create exchange item: %w", err)
@@ -25,6 +25,41 @@ func (q *Queries) CountExchangeOffers(ctx context.Context, postID uuid.UUID) (in
 	return count, err
 }
 
+const countExchangeOffersByPostIDs = `-- name: CountExchangeOffersByPostIDs :many
+SELECT post_id, COUNT(*) AS count
+FROM exchange_offers
+WHERE post_id = ANY ($1::uuid[])
+GROUP BY post_id
+`
+
+type CountExchangeOffersByPostIDsRow struct {
+	PostID uuid.UUID `json:"post_id"`
+	Count  int64     `json:"count"`
+}
+
+func (q *Queries) CountExchangeOffersByPostIDs(ctx context.Context, postIDs []uuid.UUID) ([]CountExchangeOffersByPostIDsRow, error) {
+	rows, err := q.db.Query(ctx, countExchangeOffersByPostIDs, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountExchangeOffersByPostIDsRow{}
+	for rows.Next() {
+		var i CountExchangeOffersByPostIDsRow
+		if err := rows.Scan(
+			&i.PostID,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createExchangeOffer = `-- name: CreateExchangeOffer :one
 INSERT INTO exchange_offers (id,
                              post_id,
@@ -14,7 +14,7 @@ type CreateExchangePostTxParams struct {
 	Content          string
 	PostImages       []*multipart.FileHeader
 	PostItemIDs      []int64
-	UploadImagesFunc func(key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
+	UploadImagesFunc func(ctx context.Context, key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
 }
 
 type CreateExchangePostTxResult struct {
@@ -28,7 +28,7 @@ func (store *SQLStore) CreateExchangePostTx(ctx context.Context, arg CreateExcha
 		exchangePostID, _ := uuid.NewV7()
 		
 		// 1. Upload images
-		imageUrls, err := arg.UploadImagesFunc("exchange_post", exchangePostID.String()[:8], util.FolderExchanges, arg.PostImages...)
+		imageUrls, err := arg.UploadImagesFunc(ctx, "exchange_post", exchangePostID.String()[:8], util.FolderExchanges, arg.PostImages...)
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -149,15 +150,106 @@ func (q *Queries) ListExchangePostItems(ctx context.Context, postID uuid.UUID) (
 	return items, nil
 }
 
+const listExchangePostItemsByPostIDs = `-- name: ListExchangePostItemsByPostIDs :many
+SELECT id, post_id, gundam_id, created_at
+FROM "exchange_post_items"
+WHERE post_id = ANY ($1::uuid[])
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListExchangePostItemsByPostIDs(ctx context.Context, postIDs []uuid.UUID) ([]ExchangePostItem, error) {
+	rows, err := q.db.Query(ctx, listExchangePostItemsByPostIDs, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangePostItem{}
+	for rows.Next() {
+		var i ExchangePostItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.GundamID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExchangePosts = `-- name: ListExchangePosts :many
 SELECT id, user_id, content, post_image_urls, status, created_at, updated_at
 FROM "exchange_posts"
 WHERE status = coalesce($1, status)
-ORDER BY created_at DESC, updated_at DESC
+  AND user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)
+  AND ($2::uuid IS NULL OR
+       CASE $3::text
+           WHEN 'oldest' THEN (created_at, id) > ($4::timestamptz, $2::uuid)
+           ELSE (created_at, id) < ($4::timestamptz, $2::uuid)
+           END)
+ORDER BY CASE WHEN $3::text = 'oldest' THEN created_at END,
+         CASE WHEN $3::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT $5::int
+`
+
+type ListExchangePostsParams struct {
+	Status      NullExchangePostStatus `json:"status"`
+	CursorID    *uuid.UUID             `json:"cursor_id"`
+	Sort        string                 `json:"sort"`
+	CursorValue *time.Time             `json:"cursor_value"`
+	Limit       *int32                 `json:"limit"`
+}
+
+// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+func (q *Queries) ListExchangePosts(ctx context.Context, arg ListExchangePostsParams) ([]ExchangePost, error) {
+	rows, err := q.db.Query(ctx, listExchangePosts,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExchangePost{}
+	for rows.Next() {
+		var i ExchangePost
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Content,
+			&i.PostImageUrls,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExchangePostsByIDs = `-- name: ListExchangePostsByIDs :many
+SELECT id, user_id, content, post_image_urls, status, created_at, updated_at
+FROM "exchange_posts"
+WHERE id = ANY ($1::uuid[])
 `
 
-func (q *Queries) ListExchangePosts(ctx context.Context, status NullExchangePostStatus) ([]ExchangePost, error) {
-	rows, err := q.db.Query(ctx, listExchangePosts, status)
+func (q *Queries) ListExchangePostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]ExchangePost, error) {
+	rows, err := q.db.Query(ctx, listExchangePostsByIDs, postIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -291,16 +291,17 @@ func (store *SQLStore) PayExchangeDeliveryFeeTx(ctx context.Context, arg PayExch
 				
 				// Tạo order item
 				_, err = qTx.CreateOrderItem(ctx, CreateOrderItemParams{
-					OrderID:  orderID,
-					GundamID: item.GundamID,
-					Name:     item.Name,
-					Slug:     item.Slug,
-					Grade:    item.Grade,
-					Scale:    item.Scale,
-					Quantity: item.Quantity,
-					Price:    0,
-					Weight:   item.Weight,
-					ImageURL: item.ImageURL,
+					OrderID:   orderID,
+					GundamID:  item.GundamID,
+					Name:      item.Name,
+					Slug:      item.Slug,
+					Grade:     item.Grade,
+					Scale:     item.Scale,
+					Quantity:  item.Quantity,
+					Price:     0,
+					Weight:    item.Weight,
+					ImageURL:  item.ImageURL,
+					Condition: item.Condition,
 				})
 				if err != nil {
 					return err
@@ -17,6 +17,7 @@ type GundamDetails struct {
 	Material             string               `json:"material"`
 	Version              string               `json:"version"`
 	Quantity             int64                `json:"quantity"`
+	AvailableQuantity    int64                `json:"available_quantity"` // Số lượng còn có thể mua (chưa được giữ cho đơn hàng nào)
 	Condition            string               `json:"condition"`
 	ConditionDescription *string              `json:"condition_description"`
 	Manufacturer         string               `json:"manufacturer"`
@@ -26,6 +27,7 @@ type GundamDetails struct {
 	Price                *int64               `json:"price"`
 	ReleaseYear          *int64               `json:"release_year"`
 	Status               string               `json:"status"`
+	ModelKitID           *int64               `json:"model_kit_id"`
 	Accessories          []GundamAccessoryDTO `json:"accessories"`
 	PrimaryImageURL      string               `json:"primary_image_url"`
 	SecondaryImageURLs   []string             `json:"secondary_image_urls"`
@@ -45,6 +47,35 @@ func ConvertGundamAccessoryToDTO(accessory GundamAccessory) GundamAccessoryDTO {
 	}
 }
 
+// GundamFacetValue là một giá trị của facet kèm số Gundam khớp bộ lọc hiện tại.
+type GundamFacetValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// GroupGundamFacets gom các dòng facet theo tên facet, giữ nguyên thứ tự trả về từ database.
+func GroupGundamFacets(rows []SearchGundamFacetsRow) map[string][]GundamFacetValue {
+	facets := map[string][]GundamFacetValue{
+		"grade":        {},
+		"scale":        {},
+		"condition":    {},
+		"manufacturer": {},
+		"price_range":  {},
+		"release_year": {},
+	}
+	
+	for _, row := range rows {
+		facets[row.Facet] = append(facets[row.Facet], GundamFacetValue{
+			Value: row.Value,
+			Label: row.Label,
+			Count: row.Count,
+		})
+	}
+	
+	return facets
+}
+
 type Sender struct {
 	User
 	ShopName *string `json:"shop_name,omitempty"` // Tên shop (nếu có)
@@ -182,6 +213,16 @@ type GundamSnapshot struct {
 	Quantity int64  `json:"quantity"`
 	Weight   int64  `json:"weight"`
 	ImageURL string `json:"image_url"`
+	// Condition là tình trạng của Gundam lúc tạo yêu cầu đấu giá, rỗng với các snapshot cũ
+	Condition string `json:"condition,omitempty"`
+}
+
+// ImageCleanupOrphan là ảnh không còn được database tham chiếu, được ghi vào báo cáo của một lần dọn ảnh.
+type ImageCleanupOrphan struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+	Deleted      bool      `json:"deleted"` // false nếu là dry run hoặc xóa thất bại
 }
 
 type AuctionDetails struct {
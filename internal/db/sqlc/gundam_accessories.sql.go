@@ -9,6 +9,25 @@ import (
 	"context"
 )
 
+const copyGundamAccessories = `-- name: CopyGundamAccessories :exec
+INSERT INTO gundam_accessories (name,
+                                gundam_id,
+                                quantity)
+SELECT name, $1::bigint, quantity
+FROM gundam_accessories
+WHERE gundam_id = $2::bigint
+`
+
+type CopyGundamAccessoriesParams struct {
+	ToGundamID   int64 `json:"to_gundam_id"`
+	FromGundamID int64 `json:"from_gundam_id"`
+}
+
+func (q *Queries) CopyGundamAccessories(ctx context.Context, arg CopyGundamAccessoriesParams) error {
+	_, err := q.db.Exec(ctx, copyGundamAccessories, arg.ToGundamID, arg.FromGundamID)
+	return err
+}
+
 const deleteAllGundamAccessories = `-- name: DeleteAllGundamAccessories :exec
 DELETE FROM gundam_accessories
 WHERE gundam_id = $1
@@ -9,6 +9,39 @@ import (
 	"context"
 )
 
+const copyGundamImages = `-- name: CopyGundamImages :exec
+INSERT INTO gundam_images (gundam_id,
+                           url,
+                           is_primary)
+SELECT $1::bigint, url, is_primary
+FROM gundam_images
+WHERE gundam_id = $2::bigint
+`
+
+type CopyGundamImagesParams struct {
+	ToGundamID   int64 `json:"to_gundam_id"`
+	FromGundamID int64 `json:"from_gundam_id"`
+}
+
+func (q *Queries) CopyGundamImages(ctx context.Context, arg CopyGundamImagesParams) error {
+	_, err := q.db.Exec(ctx, copyGundamImages, arg.ToGundamID, arg.FromGundamID)
+	return err
+}
+
+const countGundamImagesByURL = `-- name: CountGundamImagesByURL :one
+SELECT COUNT(*)
+FROM gundam_images
+WHERE url = $1
+`
+
+// Ảnh được lưu theo nội dung nên nhiều Gundam có thể dùng chung một URL, chỉ xóa file khi không còn Gundam nào dùng.
+func (q *Queries) CountGundamImagesByURL(ctx context.Context, url string) (int64, error) {
+	row := q.db.QueryRow(ctx, countGundamImagesByURL, url)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteGundamImage = `-- name: DeleteGundamImage :exec
 DELETE
 FROM gundam_images
@@ -93,6 +126,39 @@ func (q *Queries) GetImageByURL(ctx context.Context, arg GetImageByURLParams) (G
 	return i, err
 }
 
+const listGundamImagesByGundamIDs = `-- name: ListGundamImagesByGundamIDs :many
+SELECT id, gundam_id, url, is_primary, created_at
+FROM gundam_images
+WHERE gundam_id = ANY ($1::bigint[])
+ORDER BY gundam_id, created_at DESC
+`
+
+func (q *Queries) ListGundamImagesByGundamIDs(ctx context.Context, gundamIDs []int64) ([]GundamImage, error) {
+	rows, err := q.db.Query(ctx, listGundamImagesByGundamIDs, gundamIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GundamImage{}
+	for rows.Next() {
+		var i GundamImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.GundamID,
+			&i.URL,
+			&i.IsPrimary,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateGundamPrimaryImage = `-- name: UpdateGundamPrimaryImage :exec
 UPDATE gundam_images
 SET url = $2
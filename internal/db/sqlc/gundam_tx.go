@@ -26,10 +26,11 @@ type CreateGundamTxParams struct {
 	Description          string
 	Price                *int64
 	ReleaseYear          *int64
+	ModelKitID           *int64
 	Accessories          []GundamAccessoryDTO
 	PrimaryImage         *multipart.FileHeader
 	SecondaryImages      []*multipart.FileHeader
-	UploadImagesFunc     func(key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
+	UploadImagesFunc     func(ctx context.Context, key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
 }
 
 func (store *SQLStore) CreateGundamTx(ctx context.Context, arg CreateGundamTxParams) (GundamDetails, error) {
@@ -53,6 +54,7 @@ func (store *SQLStore) CreateGundamTx(ctx context.Context, arg CreateGundamTxPar
 			Description:          arg.Description,
 			Price:                arg.Price,
 			ReleaseYear:          arg.ReleaseYear,
+			ModelKitID:           arg.ModelKitID,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create gundam: %w", err)
@@ -82,11 +84,23 @@ func (store *SQLStore) CreateGundamTx(ctx context.Context, arg CreateGundamTxPar
 		result.Price = gundam.Price
 		result.ReleaseYear = gundam.ReleaseYear
 		result.Status = string(gundam.Status)
+		result.ModelKitID = gundam.ModelKitID
 		result.CreatedAt = gundam.CreatedAt
 		result.UpdatedAt = gundam.UpdatedAt
 		
+		// Ghi nhận giá ban đầu vào lịch sử giá
+		if gundam.Price != nil {
+			_, err = qTx.CreateGundamPriceHistory(ctx, CreateGundamPriceHistoryParams{
+				GundamID: gundam.ID,
+				Price:    *gundam.Price,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create gundam price history: %w", err)
+			}
+		}
+		
 		// Upload primary image and store the URL
-		primaryImageURLs, err := arg.UploadImagesFunc("gundam", gundam.Slug, util.FolderGundams, arg.PrimaryImage)
+		primaryImageURLs, err := arg.UploadImagesFunc(ctx, "gundam", gundam.Slug, util.FolderGundams, arg.PrimaryImage)
 		if err != nil {
 			return fmt.Errorf("failed to upload primary image: %w", err)
 		}
@@ -101,7 +115,7 @@ func (store *SQLStore) CreateGundamTx(ctx context.Context, arg CreateGundamTxPar
 		result.PrimaryImageURL = primaryImageURLs[0]
 		
 		// Upload secondary images and store the URLs
-		secondaryImageURLs, err := arg.UploadImagesFunc("gundam", gundam.Slug, util.FolderGundams, arg.SecondaryImages...)
+		secondaryImageURLs, err := arg.UploadImagesFunc(ctx, "gundam", gundam.Slug, util.FolderGundams, arg.SecondaryImages...)
 		if err != nil {
 			return fmt.Errorf("failed to upload secondary images: %w", err)
 		}
@@ -141,6 +155,46 @@ func (store *SQLStore) CreateGundamTx(ctx context.Context, arg CreateGundamTxPar
 	return result, err
 }
 
+type UpdateGundamTxParams struct {
+	UpdateGundamParams
+	PreviousPrice *int64 // Giá hiện tại của Gundam trước khi cập nhật
+}
+
+type UpdateGundamTxResult struct {
+	// PriceChange khác nil nếu giá của Gundam thay đổi
+	PriceChange *GundamPriceHistory
+}
+
+// UpdateGundamTx cập nhật thông tin Gundam và ghi nhận vào lịch sử giá nếu giá thay đổi.
+func (store *SQLStore) UpdateGundamTx(ctx context.Context, arg UpdateGundamTxParams) (UpdateGundamTxResult, error) {
+	var result UpdateGundamTxResult
+	err := store.ExecTx(ctx, func(qTx *Queries) error {
+		err := qTx.UpdateGundam(ctx, arg.UpdateGundamParams)
+		if err != nil {
+			return fmt.Errorf("failed to update gundam: %w", err)
+		}
+		
+		if arg.Price == nil || (arg.PreviousPrice != nil && *arg.PreviousPrice == *arg.Price) {
+			return nil
+		}
+		
+		priceChange, err := qTx.CreateGundamPriceHistory(ctx, CreateGundamPriceHistoryParams{
+			GundamID:      arg.ID,
+			PreviousPrice: arg.PreviousPrice,
+			Price:         *arg.Price,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create gundam price history: %w", err)
+		}
+		
+		result.PriceChange = &priceChange
+		
+		return nil
+	})
+	
+	return result, err
+}
+
 type UpdateGundamAccessoriesParams struct {
 	GundamID    int64
 	Accessories []GundamAccessoryDTO
@@ -175,7 +229,7 @@ func (store *SQLStore) UpdateGundamAccessoriesTx(ctx context.Context, arg Update
 type AddGundamSecondaryImagesTxParams struct {
 	Gundam           Gundam
 	Images           []*multipart.FileHeader
-	UploadImagesFunc func(key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
+	UploadImagesFunc func(ctx context.Context, key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
 }
 
 type AddGundamSecondaryImagesTxResult struct {
@@ -186,7 +240,7 @@ func (store *SQLStore) AddGundamSecondaryImagesTx(ctx context.Context, arg AddGu
 	var result AddGundamSecondaryImagesTxResult
 	err := store.ExecTx(ctx, func(qTx *Queries) error {
 		// 1. Upload secondary images and store the URLs
-		secondaryImageURLs, err := arg.UploadImagesFunc("gundam", arg.Gundam.Slug, util.FolderGundams, arg.Images...)
+		secondaryImageURLs, err := arg.UploadImagesFunc(ctx, "gundam", arg.Gundam.Slug, util.FolderGundams, arg.Images...)
 		if err != nil {
 			return fmt.Errorf("failed to upload secondary images: %w", err)
 		}
@@ -213,8 +267,8 @@ func (store *SQLStore) AddGundamSecondaryImagesTx(ctx context.Context, arg AddGu
 
 type DeleteGundamSecondaryImageTxParams struct {
 	GundamImage     GundamImage
-	PublicID        string
-	DeleteImageFunc func(publicID string, folder string) error
+	FileKey         string
+	DeleteImageFunc func(ctx context.Context, key string) error
 }
 
 func (store *SQLStore) DeleteGundamSecondaryImageTx(ctx context.Context, arg DeleteGundamSecondaryImageTxParams) error {
@@ -228,10 +282,18 @@ func (store *SQLStore) DeleteGundamSecondaryImageTx(ctx context.Context, arg Del
 			return fmt.Errorf("failed to delete secondary image from database: %w", err)
 		}
 		
-		// 2. Delete the image from Cloudinary
-		err = arg.DeleteImageFunc(arg.PublicID, "")
+		// 2. Delete the image from the file store if no other Gundam still uses it (images are content-addressed)
+		count, err := qTx.CountGundamImagesByURL(ctx, arg.GundamImage.URL)
+		if err != nil {
+			return fmt.Errorf("failed to count gundam images by URL: %w", err)
+		}
+		if count > 0 || arg.FileKey == "" {
+			return nil
+		}
+		
+		err = arg.DeleteImageFunc(ctx, arg.FileKey)
 		if err != nil {
-			return fmt.Errorf("failed to delete image from Cloudinary: %w", err)
+			return fmt.Errorf("failed to delete image from file store: %w", err)
 		}
 		
 		return nil
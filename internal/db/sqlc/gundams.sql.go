@@ -73,6 +73,92 @@ func (q *Queries) BulkUpdateGundamsInStore(ctx context.Context, arg BulkUpdateGu
 	return err
 }
 
+const cloneGundamForOwner = `-- name: CloneGundamForOwner :one
+INSERT INTO gundams (owner_id,
+                     name,
+                     slug,
+                     grade_id,
+                     series,
+                     parts_total,
+                     material,
+                     version,
+                     quantity,
+                     condition,
+                     condition_description,
+                     manufacturer,
+                     weight,
+                     scale,
+                     description,
+                     price,
+                     release_year,
+                     model_kit_id)
+SELECT $1::text,
+       name,
+       $2::text,
+       grade_id,
+       series,
+       parts_total,
+       material,
+       version,
+       $3::bigint,
+       condition,
+       condition_description,
+       manufacturer,
+       weight,
+       scale,
+       description,
+       price,
+       release_year,
+       model_kit_id
+FROM gundams
+WHERE id = $4
+RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
+`
+
+type CloneGundamForOwnerParams struct {
+	OwnerID  string `json:"owner_id"`
+	Slug     string `json:"slug"`
+	Quantity int64  `json:"quantity"`
+	ID       int64  `json:"id"`
+}
+
+// Tạo Gundam mới cho người mua với quantity đơn vị từ tin đăng của người bán, dùng khi người mua chỉ mua một phần số lượng.
+func (q *Queries) CloneGundamForOwner(ctx context.Context, arg CloneGundamForOwnerParams) (Gundam, error) {
+	row := q.db.QueryRow(ctx, cloneGundamForOwner,
+		arg.OwnerID,
+		arg.Slug,
+		arg.Quantity,
+		arg.ID,
+	)
+	var i Gundam
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.GradeID,
+		&i.Series,
+		&i.PartsTotal,
+		&i.Material,
+		&i.Version,
+		&i.Quantity,
+		&i.Condition,
+		&i.ConditionDescription,
+		&i.Manufacturer,
+		&i.Weight,
+		&i.Scale,
+		&i.Description,
+		&i.Price,
+		&i.ReleaseYear,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
 const createAccessory = `-- name: CreateAccessory :exec
 INSERT INTO gundam_accessories (gundam_id,
                                 name,
@@ -108,8 +194,9 @@ INSERT INTO gundams (owner_id,
                      scale,
                      description,
                      price,
-                     release_year)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at
+                     release_year,
+                     model_kit_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
 `
 
 type CreateGundamParams struct {
@@ -130,6 +217,7 @@ type CreateGundamParams struct {
 	Description          string          `json:"description"`
 	Price                *int64          `json:"price"`
 	ReleaseYear          *int64          `json:"release_year"`
+	ModelKitID           *int64          `json:"model_kit_id"`
 }
 
 func (q *Queries) CreateGundam(ctx context.Context, arg CreateGundamParams) (Gundam, error) {
@@ -151,6 +239,7 @@ func (q *Queries) CreateGundam(ctx context.Context, arg CreateGundamParams) (Gun
 		arg.Description,
 		arg.Price,
 		arg.ReleaseYear,
+		arg.ModelKitID,
 	)
 	var i Gundam
 	err := row.Scan(
@@ -175,6 +264,8 @@ func (q *Queries) CreateGundam(ctx context.Context, arg CreateGundamParams) (Gun
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
 	)
 	return i, err
 }
@@ -205,6 +296,61 @@ func (q *Queries) CreateGundamAccessory(ctx context.Context, arg CreateGundamAcc
 	return i, err
 }
 
+const deductGundamStock = `-- name: DeductGundamStock :one
+UPDATE gundams
+SET quantity          = quantity - $1::bigint,
+    reserved_quantity = reserved_quantity - $1::bigint,
+    status            = CASE
+                            WHEN status = 'processing' AND reserved_quantity = $1::bigint
+                                THEN 'published'::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = $2
+  AND quantity > $1::bigint
+  AND reserved_quantity >= $1::bigint
+RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
+`
+
+type DeductGundamStockParams struct {
+	Quantity int64 `json:"quantity"`
+	ID       int64 `json:"id"`
+}
+
+// Trừ quantity đơn vị đã giữ khỏi tồn kho của người bán khi đơn hàng hoàn tất,
+// chỉ áp dụng khi người bán vẫn còn hàng sau khi trừ (xem TransferGundamOwnership).
+// Gundam "processing" không còn đơn hàng nào giữ (do các đơn hàng khác đã bị hủy hoặc thất bại) được đăng bán lại.
+func (q *Queries) DeductGundamStock(ctx context.Context, arg DeductGundamStockParams) (Gundam, error) {
+	row := q.db.QueryRow(ctx, deductGundamStock, arg.Quantity, arg.ID)
+	var i Gundam
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.GradeID,
+		&i.Series,
+		&i.PartsTotal,
+		&i.Material,
+		&i.Version,
+		&i.Quantity,
+		&i.Condition,
+		&i.ConditionDescription,
+		&i.Manufacturer,
+		&i.Weight,
+		&i.Scale,
+		&i.Description,
+		&i.Price,
+		&i.ReleaseYear,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
 const deleteGundam = `-- name: DeleteGundam :exec
 DELETE
 FROM gundams
@@ -255,7 +401,7 @@ func (q *Queries) GetGundamAccessories(ctx context.Context, gundamID int64) ([]G
 }
 
 const getGundamByID = `-- name: GetGundamByID :one
-SELECT id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at
+SELECT id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
 FROM gundams
 WHERE id = $1
 `
@@ -285,6 +431,8 @@ func (q *Queries) GetGundamByID(ctx context.Context, id int64) (Gundam, error) {
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
 	)
 	return i, err
 }
@@ -310,7 +458,9 @@ SELECT g.id            AS gundam_id,
        g.release_year,
        g.status,
        g.created_at,
-       g.updated_at
+       g.updated_at,
+       g.model_kit_id,
+       g.reserved_quantity
 FROM gundams g
          JOIN gundam_grades gg ON g.grade_id = gg.id
 WHERE g.slug = $1
@@ -344,6 +494,8 @@ type GetGundamBySlugRow struct {
 	Status               GundamStatus    `json:"status"`
 	CreatedAt            time.Time       `json:"created_at"`
 	UpdatedAt            time.Time       `json:"updated_at"`
+	ModelKitID           *int64          `json:"model_kit_id"`
+	ReservedQuantity     int64           `json:"reserved_quantity"`
 }
 
 func (q *Queries) GetGundamBySlug(ctx context.Context, arg GetGundamBySlugParams) (GetGundamBySlugRow, error) {
@@ -371,10 +523,45 @@ func (q *Queries) GetGundamBySlug(ctx context.Context, arg GetGundamBySlugParams
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
 	)
 	return i, err
 }
 
+const listGundamAccessoriesByGundamIDs = `-- name: ListGundamAccessoriesByGundamIDs :many
+SELECT id, name, gundam_id, quantity, created_at
+FROM gundam_accessories
+WHERE gundam_id = ANY ($1::bigint[])
+ORDER BY gundam_id, id
+`
+
+func (q *Queries) ListGundamAccessoriesByGundamIDs(ctx context.Context, gundamIDs []int64) ([]GundamAccessory, error) {
+	rows, err := q.db.Query(ctx, listGundamAccessoriesByGundamIDs, gundamIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GundamAccessory{}
+	for rows.Next() {
+		var i GundamAccessory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.GundamID,
+			&i.Quantity,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listGundamGrades = `-- name: ListGundamGrades :many
 SELECT id, name, display_name, slug, created_at
 FROM gundam_grades
@@ -407,7 +594,7 @@ func (q *Queries) ListGundamGrades(ctx context.Context) ([]GundamGrade, error) {
 }
 
 const listGundamsByUserID = `-- name: ListGundamsByUserID :many
-SELECT g.id, g.owner_id, g.name, g.slug, g.grade_id, g.series, g.parts_total, g.material, g.version, g.quantity, g.condition, g.condition_description, g.manufacturer, g.weight, g.scale, g.description, g.price, g.release_year, g.status, g.created_at, g.updated_at,
+SELECT g.id, g.owner_id, g.name, g.slug, g.grade_id, g.series, g.parts_total, g.material, g.version, g.quantity, g.condition, g.condition_description, g.manufacturer, g.weight, g.scale, g.description, g.price, g.release_year, g.status, g.created_at, g.updated_at, g.model_kit_id, g.reserved_quantity,
        gg.display_name AS grade
 FROM gundams g
          JOIN gundam_grades gg ON g.grade_id = gg.id
@@ -443,6 +630,8 @@ type ListGundamsByUserIDRow struct {
 	Status               GundamStatus    `json:"status"`
 	CreatedAt            time.Time       `json:"created_at"`
 	UpdatedAt            time.Time       `json:"updated_at"`
+	ModelKitID           *int64          `json:"model_kit_id"`
+	ReservedQuantity     int64           `json:"reserved_quantity"`
 	Grade                string          `json:"grade"`
 }
 
@@ -477,6 +666,8 @@ func (q *Queries) ListGundamsByUserID(ctx context.Context, arg ListGundamsByUser
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ModelKitID,
+			&i.ReservedQuantity,
 			&i.Grade,
 		); err != nil {
 			return nil, err
@@ -489,7 +680,304 @@ func (q *Queries) ListGundamsByUserID(ctx context.Context, arg ListGundamsByUser
 	return items, nil
 }
 
-const listGundamsWithFilters = `-- name: ListGundamsWithFilters :many
+const listGundamsWithGradeByIDs = `-- name: ListGundamsWithGradeByIDs :many
+SELECT g.id, g.owner_id, g.name, g.slug, g.grade_id, g.series, g.parts_total, g.material, g.version, g.quantity, g.condition, g.condition_description, g.manufacturer, g.weight, g.scale, g.description, g.price, g.release_year, g.status, g.created_at, g.updated_at, g.model_kit_id, g.reserved_quantity,
+       gg.display_name AS grade
+FROM gundams g
+         JOIN gundam_grades gg ON g.grade_id = gg.id
+WHERE g.id = ANY ($1::bigint[])
+`
+
+type ListGundamsWithGradeByIDsRow struct {
+	Gundam Gundam `json:"gundam"`
+	Grade  string `json:"grade"`
+}
+
+func (q *Queries) ListGundamsWithGradeByIDs(ctx context.Context, gundamIDs []int64) ([]ListGundamsWithGradeByIDsRow, error) {
+	rows, err := q.db.Query(ctx, listGundamsWithGradeByIDs, gundamIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGundamsWithGradeByIDsRow{}
+	for rows.Next() {
+		var i ListGundamsWithGradeByIDsRow
+		if err := rows.Scan(
+			&i.Gundam.ID,
+			&i.Gundam.OwnerID,
+			&i.Gundam.Name,
+			&i.Gundam.Slug,
+			&i.Gundam.GradeID,
+			&i.Gundam.Series,
+			&i.Gundam.PartsTotal,
+			&i.Gundam.Material,
+			&i.Gundam.Version,
+			&i.Gundam.Quantity,
+			&i.Gundam.Condition,
+			&i.Gundam.ConditionDescription,
+			&i.Gundam.Manufacturer,
+			&i.Gundam.Weight,
+			&i.Gundam.Scale,
+			&i.Gundam.Description,
+			&i.Gundam.Price,
+			&i.Gundam.ReleaseYear,
+			&i.Gundam.Status,
+			&i.Gundam.CreatedAt,
+			&i.Gundam.UpdatedAt,
+			&i.Gundam.ModelKitID,
+			&i.Gundam.ReservedQuantity,
+			&i.Grade,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockGundamsByIDs = `-- name: LockGundamsByIDs :exec
+SELECT id
+FROM gundams
+WHERE id = ANY ($1::bigint[])
+ORDER BY id
+    FOR UPDATE
+`
+
+// Khóa các Gundam theo thứ tự ID tăng dần, dùng trước khi giữ hàng cho nhiều đơn hàng trong cùng một transaction
+// để các transaction đồng thời luôn khóa các dòng theo cùng một thứ tự (tránh deadlock).
+func (q *Queries) LockGundamsByIDs(ctx context.Context, gundamIds []int64) error {
+	_, err := q.db.Exec(ctx, lockGundamsByIDs, gundamIds)
+	return err
+}
+
+const releaseGundamStock = `-- name: ReleaseGundamStock :one
+UPDATE gundams
+SET reserved_quantity = reserved_quantity - $1::bigint,
+    status            = CASE
+                            WHEN status = 'processing' AND reserved_quantity = $1::bigint
+                                THEN $2::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = $3
+  AND reserved_quantity >= $1::bigint
+RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
+`
+
+type ReleaseGundamStockParams struct {
+	Quantity      int64        `json:"quantity"`
+	RestoreStatus GundamStatus `json:"restore_status"`
+	ID            int64        `json:"id"`
+}
+
+// Trả lại quantity đơn vị đã giữ khi đơn hàng bị hủy hoặc thất bại.
+// Trạng thái chỉ đổi khi không còn đơn hàng nào giữ Gundam (reserved_quantity về 0): Gundam "processing" chuyển sang restore_status.
+// Nếu vẫn còn đơn hàng khác đang giữ, trạng thái được giữ nguyên để đơn hàng bị hủy hoặc thất bại không ghi đè lên các đơn hàng đó.
+func (q *Queries) ReleaseGundamStock(ctx context.Context, arg ReleaseGundamStockParams) (Gundam, error) {
+	row := q.db.QueryRow(ctx, releaseGundamStock, arg.Quantity, arg.RestoreStatus, arg.ID)
+	var i Gundam
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.GradeID,
+		&i.Series,
+		&i.PartsTotal,
+		&i.Material,
+		&i.Version,
+		&i.Quantity,
+		&i.Condition,
+		&i.ConditionDescription,
+		&i.Manufacturer,
+		&i.Weight,
+		&i.Scale,
+		&i.Description,
+		&i.Price,
+		&i.ReleaseYear,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
+const reserveGundamStock = `-- name: ReserveGundamStock :one
+UPDATE gundams
+SET reserved_quantity = reserved_quantity + $1::bigint,
+    status            = CASE
+                            WHEN quantity - reserved_quantity = $1::bigint THEN 'processing'::gundam_status
+                            ELSE status
+        END,
+    updated_at        = now()
+WHERE id = $2
+  AND status = 'published'
+  AND quantity - reserved_quantity >= $1::bigint
+RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
+`
+
+type ReserveGundamStockParams struct {
+	Quantity int64 `json:"quantity"`
+	ID       int64 `json:"id"`
+}
+
+// Giữ quantity đơn vị của Gundam đang đăng bán cho một đơn hàng, chỉ thành công khi còn đủ hàng.
+// UPDATE khóa dòng và kiểm tra lại điều kiện sau khi chờ khóa, nên các đơn hàng đồng thời không thể giữ quá số lượng hiện có.
+// Gundam chuyển sang "processing" khi toàn bộ số lượng đã được giữ, để không thể được thêm vào giỏ hàng hay đặt mua nữa.
+func (q *Queries) ReserveGundamStock(ctx context.Context, arg ReserveGundamStockParams) (Gundam, error) {
+	row := q.db.QueryRow(ctx, reserveGundamStock, arg.Quantity, arg.ID)
+	var i Gundam
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.GradeID,
+		&i.Series,
+		&i.PartsTotal,
+		&i.Material,
+		&i.Version,
+		&i.Quantity,
+		&i.Condition,
+		&i.ConditionDescription,
+		&i.Manufacturer,
+		&i.Weight,
+		&i.Scale,
+		&i.Description,
+		&i.Price,
+		&i.ReleaseYear,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
+const searchGundamFacets = `-- name: SearchGundamFacets :many
+WITH matched AS (SELECT gg.slug         AS grade_slug,
+                        gg.display_name AS grade,
+                        g.scale,
+                        g.condition,
+                        g.manufacturer,
+                        g.price,
+                        g.release_year
+                 FROM gundams g
+                          JOIN gundam_grades gg ON g.grade_id = gg.id
+                          JOIN users u ON g.owner_id = u.id
+                 WHERE u.deleted_at IS NULL
+                   AND ($1::text IS NULL OR
+                        gundam_search_vector(g.name, g.series, g.version, g.description) @@
+                        to_tsquery('simple', immutable_unaccent($1::text)))
+                   AND ($2::text IS NULL OR gg.slug = $2::text)
+                   AND ($3::text IS NULL OR g.scale = $3::gundam_scale)
+                   AND ($4::text IS NULL OR g.condition = $4::gundam_condition)
+                   AND ($5::text IS NULL OR lower(g.manufacturer) = lower($5::text))
+                   AND ($6::text IS NULL OR g.status = $6::gundam_status)
+                   AND ($7::bigint IS NULL OR g.price >= $7::bigint)
+                   AND ($8::bigint IS NULL OR g.price < $8::bigint)
+                   AND ($9::bigint IS NULL OR g.release_year = $9::bigint)
+                   AND ($10::bigint IS NULL OR g.model_kit_id = $10::bigint))
+SELECT 'grade'::text AS facet, grade_slug AS value, grade AS label, COUNT(*) AS count
+FROM matched
+GROUP BY grade_slug, grade
+UNION ALL
+SELECT 'scale', scale::text, scale::text, COUNT(*)
+FROM matched
+GROUP BY scale
+UNION ALL
+SELECT 'condition', condition::text, condition::text, COUNT(*)
+FROM matched
+GROUP BY condition
+UNION ALL
+SELECT 'manufacturer', lower(manufacturer), min(manufacturer), COUNT(*)
+FROM matched
+GROUP BY lower(manufacturer)
+UNION ALL
+SELECT 'price_range', bucket, bucket, COUNT(*)
+FROM (SELECT CASE
+                 WHEN price < 500000 THEN '0-500000'
+                 WHEN price < 1000000 THEN '500000-1000000'
+                 WHEN price < 2000000 THEN '1000000-2000000'
+                 WHEN price < 5000000 THEN '2000000-5000000'
+                 ELSE '5000000-'
+                 END AS bucket
+      FROM matched
+      WHERE price IS NOT NULL) AS price_buckets
+GROUP BY bucket
+UNION ALL
+SELECT 'release_year', release_year::text, release_year::text, COUNT(*)
+FROM matched
+WHERE release_year IS NOT NULL
+GROUP BY release_year
+ORDER BY facet, count DESC, value
+`
+
+type SearchGundamFacetsParams struct {
+	Query        *string `json:"query"`
+	GradeSlug    *string `json:"grade_slug"`
+	Scale        *string `json:"scale"`
+	Condition    *string `json:"condition"`
+	Manufacturer *string `json:"manufacturer"`
+	Status       *string `json:"status"`
+	MinPrice     *int64  `json:"min_price"`
+	MaxPrice     *int64  `json:"max_price"`
+	ReleaseYear  *int64  `json:"release_year"`
+	ModelKitID   *int64  `json:"model_kit_id"`
+}
+
+type SearchGundamFacetsRow struct {
+	Facet string `json:"facet"`
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// Đếm số Gundam theo từng giá trị của các facet (grade, scale, condition, manufacturer, price_range, release_year)
+// trên tập kết quả sau khi áp dụng cùng bộ lọc với SearchGundams.
+func (q *Queries) SearchGundamFacets(ctx context.Context, arg SearchGundamFacetsParams) ([]SearchGundamFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchGundamFacets,
+		arg.Query,
+		arg.GradeSlug,
+		arg.Scale,
+		arg.Condition,
+		arg.Manufacturer,
+		arg.Status,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.ReleaseYear,
+		arg.ModelKitID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchGundamFacetsRow{}
+	for rows.Next() {
+		var i SearchGundamFacetsRow
+		if err := rows.Scan(
+			&i.Facet,
+			&i.Value,
+			&i.Label,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchGundams = `-- name: SearchGundams :many
 SELECT g.id            AS gundam_id,
        g.owner_id,
        g.name,
@@ -510,22 +998,65 @@ SELECT g.id            AS gundam_id,
        g.release_year,
        g.status,
        g.created_at,
-       g.updated_at
+       g.updated_at,
+       r.rank
 FROM gundams g
          JOIN gundam_grades gg ON g.grade_id = gg.id
-WHERE ($1::text IS NULL OR g.name ILIKE '%' || $1::text || '%')
-  AND gg.slug = COALESCE($2::text, gg.slug)
-  AND ($3::text IS NULL OR g.status = $3::gundam_status)
-ORDER BY g.created_at DESC
+         JOIN users u ON g.owner_id = u.id
+         CROSS JOIN LATERAL (SELECT (CASE
+                                         WHEN $1::text IS NULL THEN 0
+                                         ELSE ts_rank_cd(gundam_search_vector(g.name, g.series, g.version, g.description),
+                                                         to_tsquery('simple', immutable_unaccent($1::text)))
+             END)::real AS rank) r
+WHERE u.deleted_at IS NULL
+  AND ($1::text IS NULL OR
+       gundam_search_vector(g.name, g.series, g.version, g.description) @@
+       to_tsquery('simple', immutable_unaccent($1::text)))
+  AND ($2::text IS NULL OR gg.slug = $2::text)
+  AND ($3::text IS NULL OR g.scale = $3::gundam_scale)
+  AND ($4::text IS NULL OR g.condition = $4::gundam_condition)
+  AND ($5::text IS NULL OR lower(g.manufacturer) = lower($5::text))
+  AND ($6::text IS NULL OR g.status = $6::gundam_status)
+  AND ($7::bigint IS NULL OR g.price >= $7::bigint)
+  AND ($8::bigint IS NULL OR g.price < $8::bigint)
+  AND ($9::bigint IS NULL OR g.release_year = $9::bigint)
+  AND ($10::bigint IS NULL OR g.model_kit_id = $10::bigint)
+  AND ($11::bigint IS NULL OR
+       CASE $12::text
+           WHEN 'price_asc' THEN (COALESCE(g.price, 0), g.id) > ($13::text::bigint, $11::bigint)
+           WHEN 'price_desc' THEN (COALESCE(g.price, 0), g.id) < ($13::text::bigint, $11::bigint)
+           WHEN 'relevance' THEN (r.rank, g.id) < ($13::text::real, $11::bigint)
+           WHEN 'oldest' THEN (g.created_at, g.id) > ($13::text::timestamptz, $11::bigint)
+           ELSE (g.created_at, g.id) < ($13::text::timestamptz, $11::bigint)
+           END)
+ORDER BY CASE WHEN $12::text = 'price_asc' THEN COALESCE(g.price, 0) END,
+         CASE WHEN $12::text = 'price_desc' THEN COALESCE(g.price, 0) END DESC,
+         CASE WHEN $12::text = 'relevance' THEN r.rank END DESC,
+         CASE WHEN $12::text = 'oldest' THEN g.created_at END,
+         CASE WHEN $12::text IN ('price_asc', 'oldest') THEN g.id END,
+         CASE WHEN $12::text NOT IN ('price_asc', 'price_desc', 'relevance', 'oldest') THEN g.created_at END DESC,
+         g.id DESC
+LIMIT $14::int
 `
 
-type ListGundamsWithFiltersParams struct {
-	Name      *string `json:"name"`
-	GradeSlug *string `json:"grade_slug"`
-	Status    *string `json:"status"`
+type SearchGundamsParams struct {
+	Query        *string `json:"query"`
+	GradeSlug    *string `json:"grade_slug"`
+	Scale        *string `json:"scale"`
+	Condition    *string `json:"condition"`
+	Manufacturer *string `json:"manufacturer"`
+	Status       *string `json:"status"`
+	MinPrice     *int64  `json:"min_price"`
+	MaxPrice     *int64  `json:"max_price"`
+	ReleaseYear  *int64  `json:"release_year"`
+	ModelKitID   *int64  `json:"model_kit_id"`
+	CursorID     *int64  `json:"cursor_id"`
+	Sort         string  `json:"sort"`
+	CursorValue  *string `json:"cursor_value"`
+	Limit        *int32  `json:"limit"`
 }
 
-type ListGundamsWithFiltersRow struct {
+type SearchGundamsRow struct {
 	GundamID             int64           `json:"gundam_id"`
 	OwnerID              string          `json:"owner_id"`
 	Name                 string          `json:"name"`
@@ -547,17 +1078,36 @@ type ListGundamsWithFiltersRow struct {
 	Status               GundamStatus    `json:"status"`
 	CreatedAt            time.Time       `json:"created_at"`
 	UpdatedAt            time.Time       `json:"updated_at"`
+	Rank                 float32         `json:"rank"`
 }
 
-func (q *Queries) ListGundamsWithFilters(ctx context.Context, arg ListGundamsWithFiltersParams) ([]ListGundamsWithFiltersRow, error) {
-	rows, err := q.db.Query(ctx, listGundamsWithFilters, arg.Name, arg.GradeSlug, arg.Status)
+// Tìm kiếm toàn văn và lọc danh mục Gundam.
+// query là tsquery đã được chuẩn hóa (ví dụ: 'rx:* & 78:*'), khi có query kết quả có thể sắp xếp theo độ liên quan.
+// Phân trang keyset theo (cột sắp xếp, id), cursor_value là giá trị cột sắp xếp của dòng cuối trang trước ở dạng text.
+func (q *Queries) SearchGundams(ctx context.Context, arg SearchGundamsParams) ([]SearchGundamsRow, error) {
+	rows, err := q.db.Query(ctx, searchGundams,
+		arg.Query,
+		arg.GradeSlug,
+		arg.Scale,
+		arg.Condition,
+		arg.Manufacturer,
+		arg.Status,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.ReleaseYear,
+		arg.ModelKitID,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListGundamsWithFiltersRow{}
+	items := []SearchGundamsRow{}
 	for rows.Next() {
-		var i ListGundamsWithFiltersRow
+		var i SearchGundamsRow
 		if err := rows.Scan(
 			&i.GundamID,
 			&i.OwnerID,
@@ -580,6 +1130,7 @@ func (q *Queries) ListGundamsWithFilters(ctx context.Context, arg ListGundamsWit
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -609,6 +1160,68 @@ func (q *Queries) StoreGundamImageURL(ctx context.Context, arg StoreGundamImageU
 	return err
 }
 
+const transferGundamOwnership = `-- name: TransferGundamOwnership :one
+UPDATE gundams
+SET owner_id          = $1,
+    status            = 'in store',
+    reserved_quantity = 0,
+    updated_at        = now()
+WHERE id = $2
+  AND quantity <= $3::bigint
+RETURNING id, owner_id, name, slug, grade_id, series, parts_total, material, version, quantity, condition, condition_description, manufacturer, weight, scale, description, price, release_year, status, created_at, updated_at, model_kit_id, reserved_quantity
+`
+
+type TransferGundamOwnershipParams struct {
+	OwnerID  string `json:"owner_id"`
+	ID       int64  `json:"id"`
+	Quantity int64  `json:"quantity"`
+}
+
+// Chuyển quyền sở hữu Gundam cho người mua khi đơn hàng hoàn tất và người mua đã mua toàn bộ số lượng còn lại.
+func (q *Queries) TransferGundamOwnership(ctx context.Context, arg TransferGundamOwnershipParams) (Gundam, error) {
+	row := q.db.QueryRow(ctx, transferGundamOwnership, arg.OwnerID, arg.ID, arg.Quantity)
+	var i Gundam
+	err := row.Scan(
+		&i.ID,
+		&i.OwnerID,
+		&i.Name,
+		&i.Slug,
+		&i.GradeID,
+		&i.Series,
+		&i.PartsTotal,
+		&i.Material,
+		&i.Version,
+		&i.Quantity,
+		&i.Condition,
+		&i.ConditionDescription,
+		&i.Manufacturer,
+		&i.Weight,
+		&i.Scale,
+		&i.Description,
+		&i.Price,
+		&i.ReleaseYear,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ModelKitID,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
+const unpublishAllUserGundams = `-- name: UnpublishAllUserGundams :exec
+UPDATE gundams
+SET status     = 'in store',
+    updated_at = now()
+WHERE owner_id = $1
+  AND status = 'published'
+`
+
+func (q *Queries) UnpublishAllUserGundams(ctx context.Context, ownerID string) error {
+	_, err := q.db.Exec(ctx, unpublishAllUserGundams, ownerID)
+	return err
+}
+
 const updateGundam = `-- name: UpdateGundam :exec
 UPDATE gundams
 SET owner_id              = coalesce($1, owner_id),
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+	
+	"github.com/stretchr/testify/require"
+)
+
+// Các bước chạy nối tiếp trên cùng một tin đăng 3 đơn vị, mỗi bước kiểm tra tồn kho sau khi chạy.
+// Bước bị từ chối không được thay đổi tồn kho.
+func TestReserveReleaseDeductGundamStock(t *testing.T) {
+	requireTestStore(t)
+	ctx := context.Background()
+	
+	seller := createRandomUser(t, 0)
+	gundam := createPublishedGundam(t, seller.ID, 3, 500_000)
+	
+	reserve := func(quantity int64) func() (Gundam, error) {
+		return func() (Gundam, error) {
+			return testStore.ReserveGundamStock(ctx, ReserveGundamStockParams{Quantity: quantity, ID: gundam.ID})
+		}
+	}
+	release := func(quantity int64) func() (Gundam, error) {
+		return func() (Gundam, error) {
+			return testStore.ReleaseGundamStock(ctx, ReleaseGundamStockParams{
+				Quantity:      quantity,
+				RestoreStatus: GundamStatusPublished,
+				ID:            gundam.ID,
+			})
+		}
+	}
+	deduct := func(quantity int64) func() (Gundam, error) {
+		return func() (Gundam, error) {
+			return testStore.DeductGundamStock(ctx, DeductGundamStockParams{Quantity: quantity, ID: gundam.ID})
+		}
+	}
+	
+	steps := []struct {
+		name     string
+		run      func() (Gundam, error)
+		notFound bool
+		quantity int64
+		reserved int64
+		status   GundamStatus
+	}{
+		{name: "ReservePart", run: reserve(2), quantity: 3, reserved: 2, status: GundamStatusPublished},
+		{name: "ReserveMoreThanAvailable", run: reserve(2), notFound: true, quantity: 3, reserved: 2, status: GundamStatusPublished},
+		{name: "ReserveRest", run: reserve(1), quantity: 3, reserved: 3, status: GundamStatusProcessing},
+		{name: "ReserveProcessing", run: reserve(1), notFound: true, quantity: 3, reserved: 3, status: GundamStatusProcessing},
+		{name: "ReleaseWhileStillReserved", run: release(1), quantity: 3, reserved: 2, status: GundamStatusProcessing},
+		{name: "ReleaseMoreThanReserved", run: release(3), notFound: true, quantity: 3, reserved: 2, status: GundamStatusProcessing},
+		{name: "DeductWhileStillReserved", run: deduct(1), quantity: 2, reserved: 1, status: GundamStatusProcessing},
+		{name: "DeductLastReservation", run: deduct(1), quantity: 1, reserved: 0, status: GundamStatusPublished},
+		{name: "ReserveLastUnit", run: reserve(1), quantity: 1, reserved: 1, status: GundamStatusProcessing},
+		// Người mua mua hết số lượng còn lại thì chuyển quyền sở hữu thay vì trừ tồn kho
+		{name: "DeductAllRemaining", run: deduct(1), notFound: true, quantity: 1, reserved: 1, status: GundamStatusProcessing},
+		{name: "ReleaseLastReservation", run: release(1), quantity: 1, reserved: 0, status: GundamStatusPublished},
+	}
+	
+	for _, step := range steps {
+		updated, err := step.run()
+		if step.notFound {
+			require.ErrorIs(t, err, ErrRecordNotFound, step.name)
+		} else {
+			require.NoError(t, err, step.name)
+			require.Equal(t, step.quantity, updated.Quantity, step.name)
+			require.Equal(t, step.reserved, updated.ReservedQuantity, step.name)
+			require.Equal(t, step.status, updated.Status, step.name)
+		}
+		
+		requireGundamStock(t, gundam.ID, step.quantity, step.reserved, step.status)
+	}
+}
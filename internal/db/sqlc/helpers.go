@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"time"
 	
 	"github.com/katatrina/gundam-BE/internal/delivery"
 )
@@ -192,6 +193,7 @@ func (store *SQLStore) GetGundamDetailsByID(ctx context.Context, q *Queries, gun
 		Material:             gundam.Material,
 		Version:              gundam.Version,
 		Quantity:             gundam.Quantity,
+		AvailableQuantity:    gundam.Quantity - gundam.ReservedQuantity,
 		Condition:            string(gundam.Condition),
 		ConditionDescription: gundam.ConditionDescription,
 		Manufacturer:         gundam.Manufacturer,
@@ -201,6 +203,7 @@ func (store *SQLStore) GetGundamDetailsByID(ctx context.Context, q *Queries, gun
 		Price:                gundam.Price,
 		ReleaseYear:          gundam.ReleaseYear,
 		Status:               string(gundam.Status),
+		ModelKitID:           gundam.ModelKitID,
 		Accessories:          accessoryDTOs,
 		PrimaryImageURL:      primaryImageURL,
 		SecondaryImageURLs:   secondaryImageURLs,
@@ -238,3 +241,22 @@ func GetLowestOrderStatus(status1, status2 OrderStatus) OrderStatus {
 	}
 	return status2
 }
+
+// VerifyDeliveryQuote kiểm tra phí vận chuyển và thời gian giao hàng dự kiến do client gửi lên khớp với báo giá
+// của đơn vị vận chuyển. expectedDeliveryTime là nil khi client không gửi lên.
+func VerifyDeliveryQuote(quote delivery.Quote, deliveryFee int64, expectedDeliveryTime *time.Time) error {
+	if quote.IsZero() {
+		return ErrDeliveryQuoteMissing
+	}
+	
+	if deliveryFee != quote.DeliveryFee() {
+		return fmt.Errorf("%w: expected %d, got %d", ErrDeliveryFeeMismatch, quote.DeliveryFee(), deliveryFee)
+	}
+	
+	if expectedDeliveryTime != nil && !expectedDeliveryTime.Equal(quote.ExpectedDeliveryTime()) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrDeliveryTimeMismatch,
+			quote.ExpectedDeliveryTime().Format(time.RFC3339), expectedDeliveryTime.Format(time.RFC3339))
+	}
+	
+	return nil
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+	"time"
+	
+	"github.com/katatrina/gundam-BE/internal/delivery"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyDeliveryQuote(t *testing.T) {
+	quote := testDeliveryQuote(t)
+	expectedDeliveryTime := quote.ExpectedDeliveryTime()
+	otherDeliveryTime := expectedDeliveryTime.Add(24 * time.Hour)
+	
+	testCases := []struct {
+		name                 string
+		quote                delivery.Quote
+		deliveryFee          int64
+		expectedDeliveryTime *time.Time
+		wantErr              error
+	}{
+		{
+			name:                 "Match",
+			quote:                quote,
+			deliveryFee:          testDeliveryFee,
+			expectedDeliveryTime: &expectedDeliveryTime,
+		},
+		{
+			name:        "NoExpectedDeliveryTime",
+			quote:       quote,
+			deliveryFee: testDeliveryFee,
+		},
+		{
+			name:        "MissingQuote",
+			quote:       delivery.Quote{},
+			deliveryFee: testDeliveryFee,
+			wantErr:     ErrDeliveryQuoteMissing,
+		},
+		{
+			name:        "FeeMismatch",
+			quote:       quote,
+			deliveryFee: testDeliveryFee - 1,
+			wantErr:     ErrDeliveryFeeMismatch,
+		},
+		{
+			name:                 "DeliveryTimeMismatch",
+			quote:                quote,
+			deliveryFee:          testDeliveryFee,
+			expectedDeliveryTime: &otherDeliveryTime,
+			wantErr:              ErrDeliveryTimeMismatch,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := VerifyDeliveryQuote(tc.quote, tc.deliveryFee, tc.expectedDeliveryTime)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	
+	"github.com/google/uuid"
+)
+
+// gundamDetailsLoader gom các gundam ID cần hiển thị rồi tải GundamDetails theo lô
+// (gundam + grade, ảnh, phụ kiện) với số truy vấn cố định, thay vì gọi GetGundamDetailsByID cho từng Gundam.
+type gundamDetailsLoader struct {
+	q       *Queries
+	ids     []int64
+	seen    map[int64]struct{}
+	details map[int64]GundamDetails
+}
+
+func newGundamDetailsLoader(q *Queries) *gundamDetailsLoader {
+	return &gundamDetailsLoader{
+		q:       q,
+		seen:    make(map[int64]struct{}),
+		details: make(map[int64]GundamDetails),
+	}
+}
+
+// add đăng ký các gundam ID cần tải, ID trùng lặp chỉ được tải một lần.
+func (l *gundamDetailsLoader) add(gundamIDs ...int64) {
+	for _, id := range gundamIDs {
+		if _, ok := l.seen[id]; ok {
+			continue
+		}
+		l.seen[id] = struct{}{}
+		l.ids = append(l.ids, id)
+	}
+}
+
+// load thực hiện 3 truy vấn cho toàn bộ ID đã đăng ký.
+func (l *gundamDetailsLoader) load(ctx context.Context) error {
+	if len(l.ids) == 0 {
+		return nil
+	}
+	
+	gundams, err := l.q.ListGundamsWithGradeByIDs(ctx, l.ids)
+	if err != nil {
+		return fmt.Errorf("failed to list gundams: %w", err)
+	}
+	
+	images, err := l.q.ListGundamImagesByGundamIDs(ctx, l.ids)
+	if err != nil {
+		return fmt.Errorf("failed to list gundam images: %w", err)
+	}
+	
+	accessories, err := l.q.ListGundamAccessoriesByGundamIDs(ctx, l.ids)
+	if err != nil {
+		return fmt.Errorf("failed to list gundam accessories: %w", err)
+	}
+	
+	primaryImageURLs := make(map[int64]string, len(l.ids))
+	secondaryImageURLs := make(map[int64][]string, len(l.ids))
+	for _, image := range images {
+		if image.IsPrimary {
+			primaryImageURLs[image.GundamID] = image.URL
+			continue
+		}
+		secondaryImageURLs[image.GundamID] = append(secondaryImageURLs[image.GundamID], image.URL)
+	}
+	
+	accessoryDTOs := make(map[int64][]GundamAccessoryDTO, len(l.ids))
+	for _, accessory := range accessories {
+		accessoryDTOs[accessory.GundamID] = append(accessoryDTOs[accessory.GundamID], ConvertGundamAccessoryToDTO(accessory))
+	}
+	
+	for _, row := range gundams {
+		gundam := row.Gundam
+		
+		secondaries := secondaryImageURLs[gundam.ID]
+		if secondaries == nil {
+			secondaries = []string{}
+		}
+		gundamAccessories := accessoryDTOs[gundam.ID]
+		if gundamAccessories == nil {
+			gundamAccessories = []GundamAccessoryDTO{}
+		}
+		
+		l.details[gundam.ID] = GundamDetails{
+			ID:                   gundam.ID,
+			OwnerID:              gundam.OwnerID,
+			Name:                 gundam.Name,
+			Slug:                 gundam.Slug,
+			Grade:                row.Grade,
+			Series:               gundam.Series,
+			PartsTotal:           gundam.PartsTotal,
+			Material:             gundam.Material,
+			Version:              gundam.Version,
+			Quantity:             gundam.Quantity,
+			AvailableQuantity:    gundam.Quantity - gundam.ReservedQuantity,
+			Condition:            string(gundam.Condition),
+			ConditionDescription: gundam.ConditionDescription,
+			Manufacturer:         gundam.Manufacturer,
+			Weight:               gundam.Weight,
+			Scale:                string(gundam.Scale),
+			Description:          gundam.Description,
+			Price:                gundam.Price,
+			ReleaseYear:          gundam.ReleaseYear,
+			Status:               string(gundam.Status),
+			ModelKitID:           gundam.ModelKitID,
+			Accessories:          gundamAccessories,
+			PrimaryImageURL:      primaryImageURLs[gundam.ID],
+			SecondaryImageURLs:   secondaries,
+			CreatedAt:            gundam.CreatedAt,
+			UpdatedAt:            gundam.UpdatedAt,
+		}
+	}
+	
+	return nil
+}
+
+// list trả về GundamDetails theo đúng thứ tự ID truyền vào.
+func (l *gundamDetailsLoader) list(gundamIDs []int64) ([]GundamDetails, error) {
+	result := make([]GundamDetails, 0, len(gundamIDs))
+	for _, id := range gundamIDs {
+		detail, ok := l.details[id]
+		if !ok {
+			return nil, fmt.Errorf("gundam ID %d: %w", id, ErrRecordNotFound)
+		}
+		result = append(result, detail)
+	}
+	
+	return result, nil
+}
+
+// loadUsers tải người dùng (chưa bị xóa) theo lô và trả về map theo user ID.
+func loadUsers(ctx context.Context, q *Queries, userIDs []string) (map[string]User, error) {
+	users, err := q.ListUsersByIDs(ctx, uniqueValues(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	
+	result := make(map[string]User, len(users))
+	for _, user := range users {
+		result[user.ID] = user
+	}
+	
+	return result, nil
+}
+
+func uniqueValues[T comparable](values []T) []T {
+	seen := make(map[T]struct{}, len(values))
+	result := make([]T, 0, len(values))
+	for _, v := range values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	
+	return result
+}
+
+// ListGundamDetailsByIDs tải GundamDetails của nhiều Gundam với số truy vấn cố định, giữ nguyên thứ tự ID truyền vào.
+func (store *SQLStore) ListGundamDetailsByIDs(ctx context.Context, gundamIDs []int64) ([]GundamDetails, error) {
+	loader := newGundamDetailsLoader(store.Queries)
+	loader.add(gundamIDs...)
+	if err := loader.load(ctx); err != nil {
+		return nil, err
+	}
+	
+	return loader.list(gundamIDs)
+}
+
+// LoadOpenExchangePostInfos lắp ráp OpenExchangePostInfo cho một trang bài đăng trao đổi
+// với số truy vấn cố định (người đăng, item, Gundam, số lượng offer) bất kể số bài đăng.
+func (store *SQLStore) LoadOpenExchangePostInfos(ctx context.Context, posts []ExchangePost) ([]OpenExchangePostInfo, error) {
+	result := make([]OpenExchangePostInfo, 0, len(posts))
+	if len(posts) == 0 {
+		return result, nil
+	}
+	
+	postIDs := make([]uuid.UUID, len(posts))
+	posterIDs := make([]string, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+		posterIDs[i] = post.UserID
+	}
+	
+	posters, err := loadUsers(ctx, store.Queries, posterIDs)
+	if err != nil {
+		return nil, err
+	}
+	
+	items, err := store.ListExchangePostItemsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange post items: %w", err)
+	}
+	
+	offerCounts, err := store.CountExchangeOffersByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count exchange offers: %w", err)
+	}
+	
+	loader := newGundamDetailsLoader(store.Queries)
+	postGundamIDs := make(map[uuid.UUID][]int64, len(posts))
+	for _, item := range items {
+		postGundamIDs[item.PostID] = append(postGundamIDs[item.PostID], item.GundamID)
+		loader.add(item.GundamID)
+	}
+	if err = loader.load(ctx); err != nil {
+		return nil, err
+	}
+	
+	offerCountByPost := make(map[uuid.UUID]int64, len(offerCounts))
+	for _, row := range offerCounts {
+		offerCountByPost[row.PostID] = row.Count
+	}
+	
+	for _, post := range posts {
+		poster, ok := posters[post.UserID]
+		if !ok {
+			return nil, fmt.Errorf("poster ID %s of exchange post ID %s: %w", post.UserID, post.ID, ErrRecordNotFound)
+		}
+		
+		postItems, err := loader.list(postGundamIDs[post.ID])
+		if err != nil {
+			return nil, err
+		}
+		
+		result = append(result, OpenExchangePostInfo{
+			ExchangePost:      post,
+			ExchangePostItems: postItems,
+			Poster:            poster,
+			OfferCount:        offerCountByPost[post.ID],
+		})
+	}
+	
+	return result, nil
+}
+
+// LoadUserExchangeOfferDetails lắp ráp UserExchangeOfferDetails cho các offer của một người dùng
+// với số truy vấn cố định (bài đăng, người đăng, item của bài đăng và offer, ghi chú thương lượng, Gundam).
+func (store *SQLStore) LoadUserExchangeOfferDetails(ctx context.Context, offers []ExchangeOffer) ([]UserExchangeOfferDetails, error) {
+	result := make([]UserExchangeOfferDetails, 0, len(offers))
+	if len(offers) == 0 {
+		return result, nil
+	}
+	
+	offerIDs := make([]uuid.UUID, len(offers))
+	postIDs := make([]uuid.UUID, len(offers))
+	for i, offer := range offers {
+		offerIDs[i] = offer.ID
+		postIDs[i] = offer.PostID
+	}
+	postIDs = uniqueValues(postIDs)
+	
+	posts, err := store.ListExchangePostsByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange posts: %w", err)
+	}
+	
+	postByID := make(map[uuid.UUID]ExchangePost, len(posts))
+	posterIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postByID[post.ID] = post
+		posterIDs = append(posterIDs, post.UserID)
+	}
+	
+	posters, err := loadUsers(ctx, store.Queries, posterIDs)
+	if err != nil {
+		return nil, err
+	}
+	
+	postItems, err := store.ListExchangePostItemsByPostIDs(ctx, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange post items: %w", err)
+	}
+	
+	offerItems, err := store.ListExchangeOfferItemsByOfferIDs(ctx, offerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange offer items: %w", err)
+	}
+	
+	notes, err := store.ListExchangeOfferNotesByOfferIDs(ctx, offerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange offer notes: %w", err)
+	}
+	
+	loader := newGundamDetailsLoader(store.Queries)
+	
+	postGundamIDs := make(map[uuid.UUID][]int64, len(postIDs))
+	for _, item := range postItems {
+		postGundamIDs[item.PostID] = append(postGundamIDs[item.PostID], item.GundamID)
+		loader.add(item.GundamID)
+	}
+	
+	// Tách item của offer thành item của người đăng bài và item của người đề xuất
+	posterGundamIDs := make(map[uuid.UUID][]int64, len(offers))
+	offererGundamIDs := make(map[uuid.UUID][]int64, len(offers))
+	for _, item := range offerItems {
+		if item.IsFromPoster {
+			posterGundamIDs[item.OfferID] = append(posterGundamIDs[item.OfferID], item.GundamID)
+		} else {
+			offererGundamIDs[item.OfferID] = append(offererGundamIDs[item.OfferID], item.GundamID)
+		}
+		loader.add(item.GundamID)
+	}
+	
+	if err = loader.load(ctx); err != nil {
+		return nil, err
+	}
+	
+	notesByOffer := make(map[uuid.UUID][]ExchangeOfferNote, len(offers))
+	for _, note := range notes {
+		notesByOffer[note.OfferID] = append(notesByOffer[note.OfferID], note)
+	}
+	
+	for _, offer := range offers {
+		post, ok := postByID[offer.PostID]
+		if !ok {
+			return nil, fmt.Errorf("exchange post ID %s: %w", offer.PostID, ErrRecordNotFound)
+		}
+		
+		poster, ok := posters[post.UserID]
+		if !ok {
+			return nil, fmt.Errorf("user ID %s: %w", post.UserID, ErrRecordNotFound)
+		}
+		
+		postGundams, err := loader.list(postGundamIDs[post.ID])
+		if err != nil {
+			return nil, err
+		}
+		
+		posterGundams, err := loader.list(posterGundamIDs[offer.ID])
+		if err != nil {
+			return nil, err
+		}
+		
+		offererGundams, err := loader.list(offererGundamIDs[offer.ID])
+		if err != nil {
+			return nil, err
+		}
+		
+		offerNotes := notesByOffer[offer.ID]
+		if offerNotes == nil {
+			offerNotes = []ExchangeOfferNote{}
+		}
+		
+		result = append(result, UserExchangeOfferDetails{
+			ExchangePost:      post,
+			Poster:            poster,
+			ExchangePostItems: postGundams,
+			Offer: ExchangeOfferInfo{
+				ID:                   offer.ID,
+				PostID:               offer.PostID,
+				PayerID:              offer.PayerID,
+				CompensationAmount:   offer.CompensationAmount,
+				Note:                 offer.Note,
+				OffererExchangeItems: offererGundams,
+				PosterExchangeItems:  posterGundams,
+				NegotiationsCount:    offer.NegotiationsCount,
+				MaxNegotiations:      offer.MaxNegotiations,
+				NegotiationRequested: offer.NegotiationRequested,
+				LastNegotiationAt:    offer.LastNegotiationAt,
+				NegotiationNotes:     offerNotes,
+				CreatedAt:            offer.CreatedAt,
+				UpdatedAt:            offer.UpdatedAt,
+			},
+		})
+	}
+	
+	return result, nil
+}
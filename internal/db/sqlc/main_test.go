@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testStore kết nối tới database test đã chạy đủ migrations (make migrate-up với TEST_DATABASE_URL).
+// Khi không có TEST_DATABASE_URL, các test cần database sẽ bị bỏ qua.
+var testStore *SQLStore
+
+func TestMain(m *testing.M) {
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL != "" {
+		connPool, err := pgxpool.New(context.Background(), dbURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot connect to test database: %v\n", err)
+			os.Exit(1)
+		}
+		defer connPool.Close()
+		
+		testStore = NewStore(connPool).(*SQLStore)
+	}
+	
+	os.Exit(m.Run())
+}
+
+func requireTestStore(t *testing.T) {
+	t.Helper()
+	if testStore == nil {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+}
+
+// createRandomUser tạo một người dùng kèm ví với số dư balance.
+func createRandomUser(t *testing.T, balance int64) User {
+	t.Helper()
+	ctx := context.Background()
+	
+	user, err := testStore.CreateUserTx(ctx, CreateUserParams{
+		FullName:      "Test User",
+		Email:         fmt.Sprintf("%s@example.com", uuid.NewString()),
+		EmailVerified: true,
+		Role:          UserRoleMember,
+	})
+	require.NoError(t, err)
+	
+	if balance != 0 {
+		_, err = testStore.AddWalletBalance(ctx, AddWalletBalanceParams{
+			UserID: user.ID,
+			Amount: balance,
+		})
+		require.NoError(t, err)
+	}
+	
+	return user
+}
+
+func randomAddress(userID string) UserAddress {
+	return UserAddress{
+		UserID:        userID,
+		FullName:      "Test User",
+		PhoneNumber:   "0900000000",
+		ProvinceName:  "Hồ Chí Minh",
+		DistrictName:  "Quận 1",
+		GhnDistrictID: 1442,
+		WardName:      "Phường Bến Nghé",
+		GhnWardCode:   "20109",
+		Detail:        "1 Lê Duẩn",
+	}
+}
+
+func createTestGrade(t *testing.T) GundamGrade {
+	t.Helper()
+	
+	var grade GundamGrade
+	err := testStore.ConnPool.QueryRow(context.Background(), `
+		INSERT INTO gundam_grades (name, display_name, slug)
+		VALUES ('High Grade', 'HG', 'hg-test')
+		ON CONFLICT (slug) DO UPDATE SET name = excluded.name
+		RETURNING id, name, display_name, slug, created_at`,
+	).Scan(&grade.ID, &grade.Name, &grade.DisplayName, &grade.Slug, &grade.CreatedAt)
+	require.NoError(t, err)
+	
+	return grade
+}
+
+// createPublishedGundam tạo một tin đăng "published" gồm quantity đơn vị với đơn giá price.
+func createPublishedGundam(t *testing.T, ownerID string, quantity int64, price int64) Gundam {
+	t.Helper()
+	ctx := context.Background()
+	grade := createTestGrade(t)
+	
+	gundam, err := testStore.CreateGundam(ctx, CreateGundamParams{
+		OwnerID:      ownerID,
+		Name:         "RX-78-2 Gundam",
+		Slug:         util.GenerateRandomSlug("RX-78-2 Gundam"),
+		GradeID:      grade.ID,
+		Series:       "Mobile Suit Gundam",
+		PartsTotal:   100,
+		Material:     "Plastic",
+		Version:      "Ver. 2.0",
+		Quantity:     quantity,
+		Condition:    GundamConditionNew,
+		Manufacturer: "Bandai",
+		Weight:       300,
+		Scale:        GundamScale1144,
+		Description:  "Test listing",
+		Price:        &price,
+	})
+	require.NoError(t, err)
+	
+	err = testStore.StoreGundamImageURL(ctx, StoreGundamImageURLParams{
+		GundamID:  gundam.ID,
+		URL:       "https://example.com/rx-78-2.jpg",
+		IsPrimary: true,
+	})
+	require.NoError(t, err)
+	
+	err = testStore.UpdateGundam(ctx, UpdateGundamParams{
+		ID: gundam.ID,
+		Status: NullGundamStatus{
+			GundamStatus: GundamStatusPublished,
+			Valid:        true,
+		},
+	})
+	require.NoError(t, err)
+	
+	gundam, err = testStore.GetGundamByID(ctx, gundam.ID)
+	require.NoError(t, err)
+	
+	return gundam
+}
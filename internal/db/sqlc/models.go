@@ -540,6 +540,131 @@ func AllGundamStatusValues() []GundamStatus {
 	}
 }
 
+type ImageCleanupStatus string
+
+const (
+	ImageCleanupStatusPending   ImageCleanupStatus = "pending"
+	ImageCleanupStatusRunning   ImageCleanupStatus = "running"
+	ImageCleanupStatusCompleted ImageCleanupStatus = "completed"
+	ImageCleanupStatusFailed    ImageCleanupStatus = "failed"
+)
+
+func (e *ImageCleanupStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ImageCleanupStatus(s)
+	case string:
+		*e = ImageCleanupStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ImageCleanupStatus: %T", src)
+	}
+	return nil
+}
+
+type NullImageCleanupStatus struct {
+	ImageCleanupStatus ImageCleanupStatus `json:"image_cleanup_status"`
+	Valid              bool               `json:"valid"` // Valid is true if ImageCleanupStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullImageCleanupStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ImageCleanupStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ImageCleanupStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullImageCleanupStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ImageCleanupStatus), nil
+}
+
+func (e ImageCleanupStatus) Valid() bool {
+	switch e {
+	case ImageCleanupStatusPending,
+		ImageCleanupStatusRunning,
+		ImageCleanupStatusCompleted,
+		ImageCleanupStatusFailed:
+		return true
+	}
+	return false
+}
+
+func AllImageCleanupStatusValues() []ImageCleanupStatus {
+	return []ImageCleanupStatus{
+		ImageCleanupStatusPending,
+		ImageCleanupStatusRunning,
+		ImageCleanupStatusCompleted,
+		ImageCleanupStatusFailed,
+	}
+}
+
+type ImageFlagStatus string
+
+const (
+	ImageFlagStatusPending   ImageFlagStatus = "pending"
+	ImageFlagStatusDismissed ImageFlagStatus = "dismissed"
+	ImageFlagStatusConfirmed ImageFlagStatus = "confirmed"
+)
+
+func (e *ImageFlagStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ImageFlagStatus(s)
+	case string:
+		*e = ImageFlagStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ImageFlagStatus: %T", src)
+	}
+	return nil
+}
+
+type NullImageFlagStatus struct {
+	ImageFlagStatus ImageFlagStatus `json:"image_flag_status"`
+	Valid           bool            `json:"valid"` // Valid is true if ImageFlagStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullImageFlagStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ImageFlagStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ImageFlagStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullImageFlagStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ImageFlagStatus), nil
+}
+
+func (e ImageFlagStatus) Valid() bool {
+	switch e {
+	case ImageFlagStatusPending,
+		ImageFlagStatusDismissed,
+		ImageFlagStatusConfirmed:
+		return true
+	}
+	return false
+}
+
+func AllImageFlagStatusValues() []ImageFlagStatus {
+	return []ImageFlagStatus{
+		ImageFlagStatusPending,
+		ImageFlagStatusDismissed,
+		ImageFlagStatusConfirmed,
+	}
+}
+
 type OrderStatus string
 
 const (
@@ -1473,6 +1598,23 @@ type CartItem struct {
 	GundamID  int64     `json:"gundam_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Quantity  int64     `json:"quantity"`
+}
+
+type Checkout struct {
+	ID      uuid.UUID `json:"id"`
+	BuyerID string    `json:"buyer_id"`
+	// Tổng giá trị sản phẩm của tất cả đơn hàng
+	ItemsSubtotal int64 `json:"items_subtotal"`
+	// Tổng phí vận chuyển của tất cả đơn hàng
+	DeliveryFee int64     `json:"delivery_fee"`
+	TotalAmount int64     `json:"total_amount"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CheckoutOrder struct {
+	CheckoutID uuid.UUID `json:"checkout_id"`
+	OrderID    uuid.UUID `json:"order_id"`
 }
 
 type DeliveryInformation struct {
@@ -1518,19 +1660,20 @@ type Exchange struct {
 }
 
 type ExchangeItem struct {
-	ID           uuid.UUID `json:"id"`
-	ExchangeID   uuid.UUID `json:"exchange_id"`
-	GundamID     *int64    `json:"gundam_id"`
-	Name         string    `json:"name"`
-	Slug         string    `json:"slug"`
-	Grade        string    `json:"grade"`
-	Scale        string    `json:"scale"`
-	Quantity     int64     `json:"quantity"`
-	Weight       int64     `json:"weight"`
-	ImageURL     string    `json:"image_url"`
-	OwnerID      *string   `json:"owner_id"`
-	IsFromPoster bool      `json:"is_from_poster"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID           `json:"id"`
+	ExchangeID   uuid.UUID           `json:"exchange_id"`
+	GundamID     *int64              `json:"gundam_id"`
+	Name         string              `json:"name"`
+	Slug         string              `json:"slug"`
+	Grade        string              `json:"grade"`
+	Scale        string              `json:"scale"`
+	Quantity     int64               `json:"quantity"`
+	Weight       int64               `json:"weight"`
+	ImageURL     string              `json:"image_url"`
+	OwnerID      *string             `json:"owner_id"`
+	IsFromPoster bool                `json:"is_from_poster"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Condition    NullGundamCondition `json:"condition"`
 }
 
 type ExchangeOffer struct {
@@ -1549,11 +1692,12 @@ type ExchangeOffer struct {
 }
 
 type ExchangeOfferItem struct {
-	ID           uuid.UUID `json:"id"`
-	OfferID      uuid.UUID `json:"offer_id"`
-	GundamID     int64     `json:"gundam_id"`
-	IsFromPoster bool      `json:"is_from_poster"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID           `json:"id"`
+	OfferID      uuid.UUID           `json:"offer_id"`
+	GundamID     int64               `json:"gundam_id"`
+	IsFromPoster bool                `json:"is_from_poster"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Condition    NullGundamCondition `json:"condition"`
 }
 
 type ExchangeOfferNote struct {
@@ -1603,6 +1747,8 @@ type Gundam struct {
 	Status               GundamStatus    `json:"status"`
 	CreatedAt            time.Time       `json:"created_at"`
 	UpdatedAt            time.Time       `json:"updated_at"`
+	ModelKitID           *int64          `json:"model_kit_id"`
+	ReservedQuantity     int64           `json:"reserved_quantity"`
 }
 
 type GundamAccessory struct {
@@ -1629,6 +1775,84 @@ type GundamImage struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type GundamPriceHistory struct {
+	ID            int64     `json:"id"`
+	GundamID      int64     `json:"gundam_id"`
+	PreviousPrice *int64    `json:"previous_price"`
+	Price         int64     `json:"price"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type GundamSale struct {
+	Source     string              `json:"source"`
+	GundamID   *int64              `json:"gundam_id"`
+	ModelKitID *int64              `json:"model_kit_id"`
+	SlugFamily *string             `json:"slug_family"`
+	Condition  NullGundamCondition `json:"condition"`
+	Price      int64               `json:"price"`
+	SoldAt     *time.Time          `json:"sold_at"`
+}
+
+type ImageCleanupRun struct {
+	ID               int64                `json:"id"`
+	DryRun           bool                 `json:"dry_run"`
+	Status           ImageCleanupStatus   `json:"status"`
+	GracePeriodHours int32                `json:"grace_period_hours"`
+	ScannedCount     int32                `json:"scanned_count"`
+	RecentCount      int32                `json:"recent_count"`
+	OrphanedCount    int32                `json:"orphaned_count"`
+	OrphanedBytes    int64                `json:"orphaned_bytes"`
+	DeletedCount     int32                `json:"deleted_count"`
+	FailedCount      int32                `json:"failed_count"`
+	Orphans          []ImageCleanupOrphan `json:"orphans"`
+	Error            *string              `json:"error"`
+	RequestedBy      *string              `json:"requested_by"`
+	StartedAt        *time.Time           `json:"started_at"`
+	FinishedAt       *time.Time           `json:"finished_at"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+type ImageFingerprint struct {
+	ID             int64     `json:"id"`
+	URL            string    `json:"url"`
+	ThumbnailURL   string    `json:"thumbnail_url"`
+	Folder         string    `json:"folder"`
+	RefType        string    `json:"ref_type"`
+	RefID          string    `json:"ref_id"`
+	ContentHash    string    `json:"content_hash"`
+	PerceptualHash int64     `json:"perceptual_hash"`
+	Width          int32     `json:"width"`
+	Height         int32     `json:"height"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ImageFlag struct {
+	ID                   int64           `json:"id"`
+	FingerprintID        int64           `json:"fingerprint_id"`
+	MatchedFingerprintID int64           `json:"matched_fingerprint_id"`
+	Distance             int32           `json:"distance"`
+	Status               ImageFlagStatus `json:"status"`
+	ReviewedBy           *string         `json:"reviewed_by"`
+	ReviewedAt           *time.Time      `json:"reviewed_at"`
+	CreatedAt            time.Time       `json:"created_at"`
+}
+
+type ModelKit struct {
+	ID           int64       `json:"id"`
+	Name         string      `json:"name"`
+	Slug         string      `json:"slug"`
+	GradeID      int64       `json:"grade_id"`
+	Scale        GundamScale `json:"scale"`
+	Series       string      `json:"series"`
+	Manufacturer string      `json:"manufacturer"`
+	Version      *string     `json:"version"`
+	ReleaseYear  *int64      `json:"release_year"`
+	Msrp         *int64      `json:"msrp"`
+	ImageURL     *string     `json:"image_url"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
 type Order struct {
 	ID                 uuid.UUID     `json:"id"`
 	Code               string        `json:"code"`
@@ -1664,18 +1888,19 @@ type OrderDelivery struct {
 }
 
 type OrderItem struct {
-	ID        int64     `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	GundamID  *int64    `json:"gundam_id"`
-	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
-	Grade     string    `json:"grade"`
-	Scale     string    `json:"scale"`
-	Quantity  int64     `json:"quantity"`
-	Price     int64     `json:"price"`
-	Weight    int64     `json:"weight"`
-	ImageURL  string    `json:"image_url"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        int64               `json:"id"`
+	OrderID   uuid.UUID           `json:"order_id"`
+	GundamID  *int64              `json:"gundam_id"`
+	Name      string              `json:"name"`
+	Slug      string              `json:"slug"`
+	Grade     string              `json:"grade"`
+	Scale     string              `json:"scale"`
+	Quantity  int64               `json:"quantity"`
+	Price     int64               `json:"price"`
+	Weight    int64               `json:"weight"`
+	ImageURL  string              `json:"image_url"`
+	CreatedAt time.Time           `json:"created_at"`
+	Condition NullGundamCondition `json:"condition"`
 }
 
 type OrderTransaction struct {
@@ -1703,6 +1928,19 @@ type PaymentTransaction struct {
 	UpdatedAt             time.Time                  `json:"updated_at"`
 }
 
+type SavedSearch struct {
+	ID        int64               `json:"id"`
+	UserID    string              `json:"user_id"`
+	Name      string              `json:"name"`
+	Keyword   *string             `json:"keyword"`
+	GradeID   *int64              `json:"grade_id"`
+	Scale     NullGundamScale     `json:"scale"`
+	MaxPrice  *int64              `json:"max_price"`
+	Condition NullGundamCondition `json:"condition"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
 type SellerProfile struct {
 	SellerID  string    `json:"seller_id"`
 	ShopName  string    `json:"shop_name"`
@@ -1748,6 +1986,7 @@ type User struct {
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
 	DeletedAt           *time.Time `json:"deleted_at"`
+	TokenVersion        int64      `json:"-"`
 }
 
 type UserAddress struct {
@@ -1780,6 +2019,23 @@ type UserBankAccount struct {
 	DeletedAt     *time.Time `json:"deleted_at"`
 }
 
+type UserRecoveryCode struct {
+	ID        int64      `json:"id"`
+	UserID    string     `json:"user_id"`
+	CodeHash  string     `json:"code_hash"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type UserTotpCredential struct {
+	UserID       string     `json:"user_id"`
+	Secret       string     `json:"secret"`
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep int64      `json:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
 type Wallet struct {
 	UserID                string    `json:"user_id"`
 	Balance               int64     `json:"balance"`
@@ -1803,6 +2059,24 @@ type WalletEntry struct {
 	CompletedAt   *time.Time          `json:"completed_at"`
 }
 
+type WatchMatch struct {
+	ID            int64      `json:"id"`
+	UserID        string     `json:"user_id"`
+	GundamID      int64      `json:"gundam_id"`
+	AuctionID     *uuid.UUID `json:"auction_id"`
+	SavedSearchID *int64     `json:"saved_search_id"`
+	Price         int64      `json:"price"`
+	CreatedAt     time.Time  `json:"created_at"`
+	NotifiedAt    *time.Time `json:"notified_at"`
+	PreviousPrice *int64     `json:"previous_price"`
+}
+
+type WishlistItem struct {
+	UserID    string    `json:"user_id"`
+	GundamID  int64     `json:"gundam_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type WithdrawalRequest struct {
 	ID                   uuid.UUID               `json:"id"`
 	UserID               string                  `json:"user_id"`
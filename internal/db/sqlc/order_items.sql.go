@@ -21,21 +21,23 @@ INSERT INTO order_items (order_id,
                          price,
                          quantity,
                          weight,
-                         image_url)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, order_id, gundam_id, name, slug, grade, scale, quantity, price, weight, image_url, created_at
+                         image_url,
+                         condition)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, order_id, gundam_id, name, slug, grade, scale, quantity, price, weight, image_url, created_at, condition
 `
 
 type CreateOrderItemParams struct {
-	OrderID  uuid.UUID `json:"order_id"`
-	GundamID *int64    `json:"gundam_id"`
-	Name     string    `json:"name"`
-	Slug     string    `json:"slug"`
-	Grade    string    `json:"grade"`
-	Scale    string    `json:"scale"`
-	Price    int64     `json:"price"`
-	Quantity int64     `json:"quantity"`
-	Weight   int64     `json:"weight"`
-	ImageURL string    `json:"image_url"`
+	OrderID   uuid.UUID           `json:"order_id"`
+	GundamID  *int64              `json:"gundam_id"`
+	Name      string              `json:"name"`
+	Slug      string              `json:"slug"`
+	Grade     string              `json:"grade"`
+	Scale     string              `json:"scale"`
+	Price     int64               `json:"price"`
+	Quantity  int64               `json:"quantity"`
+	Weight    int64               `json:"weight"`
+	ImageURL  string              `json:"image_url"`
+	Condition NullGundamCondition `json:"condition"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error) {
@@ -50,6 +52,7 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Quantity,
 		arg.Weight,
 		arg.ImageURL,
+		arg.Condition,
 	)
 	var i OrderItem
 	err := row.Scan(
@@ -65,12 +68,13 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.Weight,
 		&i.ImageURL,
 		&i.CreatedAt,
+		&i.Condition,
 	)
 	return i, err
 }
 
 const listOrderItems = `-- name: ListOrderItems :many
-SELECT id, order_id, gundam_id, name, slug, grade, scale, quantity, price, weight, image_url, created_at
+SELECT id, order_id, gundam_id, name, slug, grade, scale, quantity, price, weight, image_url, created_at, condition
 FROM order_items
 WHERE order_id = $1
 `
@@ -97,6 +101,7 @@ func (q *Queries) ListOrderItems(ctx context.Context, orderID uuid.UUID) ([]Orde
 			&i.Weight,
 			&i.ImageURL,
 			&i.CreatedAt,
+			&i.Condition,
 		); err != nil {
 			return nil, err
 		}
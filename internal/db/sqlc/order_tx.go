@@ -1,10 +1,12 @@
 package db
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"slices"
 	"time"
 	
 	"github.com/google/uuid"
@@ -22,9 +24,16 @@ type CreateOrderTxParams struct {
 	TotalAmount          int64
 	DeliveryFee          int64
 	ExpectedDeliveryTime time.Time
+	DeliveryQuote        delivery.Quote // Báo giá của đơn vị vận chuyển, DeliveryFee và ExpectedDeliveryTime phải khớp với báo giá
 	PaymentMethod        PaymentMethod
 	Note                 *string
-	Gundams              []Gundam
+	Items                []CreateOrderTxItem
+}
+
+// CreateOrderTxItem là một Gundam trong đơn hàng cùng số lượng người mua đặt.
+type CreateOrderTxItem struct {
+	Gundam   Gundam
+	Quantity int64
 }
 
 type CreateOrderTxResult struct {
@@ -40,157 +49,191 @@ func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParam
 	
 	err := store.ExecTx(ctx, func(qTx *Queries) error {
 		var err error
-		var buyerWallet Wallet
-		var buyerEntry WalletEntry
-		
-		// Kể từ đây, chúng ta sẽ giả sử đơn hàng được thanh toán bằng ví.
-		
-		// 1. Kiểm tra và cập nhật số dư ví của người mua
-		buyerWallet, err = qTx.GetWalletForUpdate(ctx, arg.BuyerID)
-		if err != nil {
-			return fmt.Errorf("failed to get buyer wallet: %w", err)
-		}
-		
-		if buyerWallet.Balance < arg.TotalAmount {
-			return fmt.Errorf("insufficient balance: available %d, needed %d",
-				buyerWallet.Balance, arg.TotalAmount)
-		}
-		
-		orderID, _ := uuid.NewV7() // Xác suất xảy ra err gần như bằng 0
-		
-		// 2. Tạo order
-		orderCode := util.GenerateOrderCode() // Bỏ qua kiểm tra unique cho đơn giản
-		order, err := qTx.CreateOrder(ctx, CreateOrderParams{
-			ID:            orderID, // Đã ràng buộc unique trong db
-			Code:          orderCode,
-			BuyerID:       arg.BuyerID,
-			SellerID:      arg.SellerID,
-			ItemsSubtotal: arg.ItemsSubtotal,
-			DeliveryFee:   arg.DeliveryFee, // Phí vận chuyển có thể được cập nhật trong tương lai
-			TotalAmount:   arg.TotalAmount,
-			Status:        OrderStatusPending,
-			PaymentMethod: arg.PaymentMethod,
-			Type:          OrderTypeRegular,
-			Note:          arg.Note,
+		result, err = createRegularOrder(ctx, qTx, arg)
+		return err
+	})
+	
+	return result, err
+}
+
+// createRegularOrder tạo một đơn hàng thông thường trong transaction qTx: trừ tiền ví người mua, giữ hàng,
+// tạo order items, thông tin vận chuyển và order transaction. Được dùng chung bởi CreateOrderTx và CheckoutTx.
+func createRegularOrder(ctx context.Context, qTx *Queries, arg CreateOrderTxParams) (CreateOrderTxResult, error) {
+	var result CreateOrderTxResult
+	var err error
+	var buyerWallet Wallet
+	var buyerEntry WalletEntry
+	
+	// Phí vận chuyển và thời gian giao hàng dự kiến phải là giá do đơn vị vận chuyển báo
+	err = VerifyDeliveryQuote(arg.DeliveryQuote, arg.DeliveryFee, &arg.ExpectedDeliveryTime)
+	if err != nil {
+		return result, err
+	}
+	
+	// Kể từ đây, chúng ta sẽ giả sử đơn hàng được thanh toán bằng ví.
+	
+	// 1. Kiểm tra và cập nhật số dư ví của người mua
+	buyerWallet, err = qTx.GetWalletForUpdate(ctx, arg.BuyerID)
+	if err != nil {
+		return result, fmt.Errorf("failed to get buyer wallet: %w", err)
+	}
+	
+	if buyerWallet.Balance < arg.TotalAmount {
+		return result, fmt.Errorf("%w: available %d, needed %d",
+			ErrInsufficientBalance, buyerWallet.Balance, arg.TotalAmount)
+	}
+	
+	orderID, _ := uuid.NewV7() // Xác suất xảy ra err gần như bằng 0
+	
+	// 2. Tạo order
+	orderCode := util.GenerateOrderCode() // Bỏ qua kiểm tra unique cho đơn giản
+	order, err := qTx.CreateOrder(ctx, CreateOrderParams{
+		ID:            orderID, // Đã ràng buộc unique trong db
+		Code:          orderCode,
+		BuyerID:       arg.BuyerID,
+		SellerID:      arg.SellerID,
+		ItemsSubtotal: arg.ItemsSubtotal,
+		DeliveryFee:   arg.DeliveryFee, // Phí vận chuyển có thể được cập nhật trong tương lai
+		TotalAmount:   arg.TotalAmount,
+		Status:        OrderStatusPending,
+		PaymentMethod: arg.PaymentMethod,
+		Type:          OrderTypeRegular,
+		Note:          arg.Note,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Order = order
+	
+	// Trừ tiền từ ví người mua
+	_, err = qTx.AddWalletBalance(ctx, AddWalletBalanceParams{
+		UserID: buyerWallet.UserID,
+		Amount: -arg.TotalAmount, // Truyền số âm để trừ
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to deduct balance: %w", err)
+	}
+	
+	// Tạo wallet entry cho người mua ✅
+	buyerEntry, err = qTx.CreateWalletEntry(ctx, CreateWalletEntryParams{
+		WalletID:      buyerWallet.UserID,
+		ReferenceID:   &order.Code,
+		ReferenceType: WalletReferenceTypeOrder,
+		EntryType:     WalletEntryTypePayment,
+		AffectedField: WalletAffectedFieldBalance,
+		Amount:        -arg.TotalAmount, // Trừ tiền từ ví người mua
+		Status:        WalletEntryStatusCompleted,
+		CompletedAt:   util.TimePointer(time.Now()),
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to create buyer wallet entry: %w", err)
+	}
+	result.BuyerEntry = buyerEntry
+	
+	// 3. Tạo các order items
+	// Giữ hàng theo thứ tự ID tăng dần để hai đơn hàng đồng thời chứa cùng các Gundam không khóa dòng chéo nhau (deadlock)
+	items := slices.Clone(arg.Items)
+	slices.SortFunc(items, func(a, b CreateOrderTxItem) int {
+		return cmp.Compare(a.Gundam.ID, b.Gundam.ID)
+	})
+	
+	for _, item := range items {
+		var orderItem OrderItem
+		gundam := item.Gundam
+		
+		// 4. Giữ số lượng đã đặt, Gundam chuyển sang "processing" khi đã được giữ hết
+		// để tránh người khác mua khi giao dịch chưa hoàn tất
+		reservedGundam, err := qTx.ReserveGundamStock(ctx, ReserveGundamStockParams{
+			Quantity: item.Quantity,
+			ID:       gundam.ID,
 		})
 		if err != nil {
-			return err
+			if errors.Is(err, ErrRecordNotFound) {
+				return result, fmt.Errorf("%w: gundam ID %d does not have %d units available", ErrInsufficientStock, gundam.ID, item.Quantity)
+			}
+			return result, fmt.Errorf("failed to reserve gundam stock: %w", err)
 		}
-		result.Order = order
 		
-		// Trừ tiền từ ví người mua
-		_, err = qTx.AddWalletBalance(ctx, AddWalletBalanceParams{
-			UserID: buyerWallet.UserID,
-			Amount: -arg.TotalAmount, // Truyền số âm để trừ
-		})
-		if err != nil {
-			return fmt.Errorf("failed to deduct balance: %w", err)
+		// Dòng Gundam đã bị khóa, giá đọc được là giá hiện tại. Nếu người bán vừa đổi giá thì
+		// tổng tiền đã tính theo giá cũ không còn đúng.
+		if reservedGundam.Price == nil || *reservedGundam.Price != *gundam.Price {
+			return result, fmt.Errorf("%w: gundam ID %d", ErrGundamPriceChanged, gundam.ID)
 		}
 		
-		// Tạo wallet entry cho người mua ✅
-		buyerEntry, err = qTx.CreateWalletEntry(ctx, CreateWalletEntryParams{
-			WalletID:      buyerWallet.UserID,
-			ReferenceID:   &order.Code,
-			ReferenceType: WalletReferenceTypeOrder,
-			EntryType:     WalletEntryTypePayment,
-			AffectedField: WalletAffectedFieldBalance,
-			Amount:        -arg.TotalAmount, // Trừ tiền từ ví người mua
-			Status:        WalletEntryStatusCompleted,
-			CompletedAt:   util.TimePointer(time.Now()),
-		})
+		grade, err := qTx.GetGradeByID(ctx, gundam.GradeID)
 		if err != nil {
-			return fmt.Errorf("failed to create buyer wallet entry: %w", err)
+			return result, fmt.Errorf("failed to get grade by ID: %w", err)
 		}
-		result.BuyerEntry = buyerEntry
 		
-		// 3. Tạo các order items
-		for _, gundam := range arg.Gundams {
-			var orderItem OrderItem
-			
-			grade, err := qTx.GetGradeByID(ctx, gundam.GradeID)
-			if err != nil {
-				return fmt.Errorf("failed to get grade by ID: %w", err)
-			}
-			
-			primaryImageURL, err := qTx.GetGundamPrimaryImageURL(ctx, gundam.ID)
-			if err != nil {
-				return fmt.Errorf("failed to get primary image: %w", err)
-			}
-			
-			orderItem, err = qTx.CreateOrderItem(ctx, CreateOrderItemParams{
-				OrderID:  order.ID,
-				GundamID: &gundam.ID,
-				Name:     gundam.Name,
-				Slug:     gundam.Slug,
-				Grade:    grade.DisplayName,
-				Scale:    string(gundam.Scale),
-				Price:    *gundam.Price,
-				Quantity: gundam.Quantity,
-				Weight:   gundam.Weight,
-				ImageURL: primaryImageURL,
-			})
-			if err != nil {
-				return err
-			}
-			
-			// 4. Cập nhật trạng thái Gundam thành "processing"
-			// để tránh người khác mua sản phẩm nếu giao dịch chưa hoàn tất
-			if err = qTx.UpdateGundam(ctx, UpdateGundamParams{
-				ID: gundam.ID,
-				Status: NullGundamStatus{
-					GundamStatus: GundamStatusProcessing,
-					Valid:        true,
-				},
-			}); err != nil {
-				return err
-			}
-			
-			result.OrderItems = append(result.OrderItems, orderItem)
-		}
-		
-		// 5. Tạo thông tin vận chuyển
-		buyerDelivery, sellerDelivery, err := createDeliveryInfo(qTx, ctx, arg)
-		if err != nil {
-			return err
-		}
-		
-		// 6. Tạo order delivery
-		// Các cột status, overall_status, delivery_tracking_code sẽ được cập nhật sau
-		// khi người bán xác nhận và đóng gói đơn hàng.
-		orderDelivery, err := qTx.CreateOrderDelivery(ctx, CreateOrderDeliveryParams{
-			OrderID:              order.ID,
-			ExpectedDeliveryTime: arg.ExpectedDeliveryTime,
-			FromDeliveryID:       sellerDelivery.ID,
-			ToDeliveryID:         buyerDelivery.ID,
-		})
+		primaryImageURL, err := qTx.GetGundamPrimaryImageURL(ctx, gundam.ID)
 		if err != nil {
-			return err
+			return result, fmt.Errorf("failed to get primary image: %w", err)
 		}
-		result.OrderDelivery = orderDelivery
 		
-		// 7. Tạo order transaction
-		orderTrans, err := qTx.CreateOrderTransaction(ctx, CreateOrderTransactionParams{
-			OrderID:      order.ID,
-			Amount:       arg.TotalAmount,
-			Status:       OrderTransactionStatusPending,
-			BuyerEntryID: buyerEntry.ID,
-			// seller_entry_id sẽ được cập nhật sau khi người bán xác nhận đơn hàng
+		orderItem, err = qTx.CreateOrderItem(ctx, CreateOrderItemParams{
+			OrderID:  order.ID,
+			GundamID: &gundam.ID,
+			Name:     gundam.Name,
+			Slug:     gundam.Slug,
+			Grade:    grade.DisplayName,
+			Scale:    string(gundam.Scale),
+			Price:    *gundam.Price, // Đơn giá
+			Quantity: item.Quantity,
+			Weight:   gundam.Weight,
+			ImageURL: primaryImageURL,
+			// Chụp lại tình trạng của Gundam lúc bán để thống kê giá thị trường không phụ thuộc vào tin đăng sau này
+			Condition: NullGundamCondition{
+				GundamCondition: gundam.Condition,
+				Valid:           true,
+			},
 		})
 		if err != nil {
-			return fmt.Errorf("failed to create order transaction: %w", err)
+			return result, err
 		}
-		result.OrderTransaction = orderTrans
 		
-		return nil
+		result.OrderItems = append(result.OrderItems, orderItem)
+	}
+	
+	// 5. Tạo thông tin vận chuyển
+	buyerDelivery, sellerDelivery, err := createDeliveryInfo(qTx, ctx, arg)
+	if err != nil {
+		return result, err
+	}
+	
+	// 6. Tạo order delivery
+	// Các cột status, overall_status, delivery_tracking_code sẽ được cập nhật sau
+	// khi người bán xác nhận và đóng gói đơn hàng.
+	orderDelivery, err := qTx.CreateOrderDelivery(ctx, CreateOrderDeliveryParams{
+		OrderID:              order.ID,
+		ExpectedDeliveryTime: arg.ExpectedDeliveryTime,
+		FromDeliveryID:       sellerDelivery.ID,
+		ToDeliveryID:         buyerDelivery.ID,
 	})
+	if err != nil {
+		return result, err
+	}
+	result.OrderDelivery = orderDelivery
 	
-	return result, err
+	// 7. Tạo order transaction
+	orderTrans, err := qTx.CreateOrderTransaction(ctx, CreateOrderTransactionParams{
+		OrderID:      order.ID,
+		Amount:       arg.TotalAmount,
+		Status:       OrderTransactionStatusPending,
+		BuyerEntryID: buyerEntry.ID,
+		// seller_entry_id sẽ được cập nhật sau khi người bán xác nhận đơn hàng
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to create order transaction: %w", err)
+	}
+	result.OrderTransaction = orderTrans
+	
+	return result, nil
 }
 
 type PackageOrderTxParams struct {
 	Order               *Order
 	PackageImages       []*multipart.FileHeader
-	UploadImagesFunc    func(key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
+	UploadImagesFunc    func(ctx context.Context, key string, value string, folder string, files ...*multipart.FileHeader) ([]string, error)
 	CreateDeliveryOrder func(ctx context.Context, request delivery.CreateOrderRequest) (*delivery.CreateOrderResponse, error)
 }
 
@@ -204,7 +247,7 @@ func (store *SQLStore) PackageOrderTx(ctx context.Context, arg PackageOrderTxPar
 	
 	err := store.ExecTx(ctx, func(qTx *Queries) error {
 		// 1. Upload packaging images and store the URLs
-		packagingImageURLs, err := arg.UploadImagesFunc("packaging_image", arg.Order.Code, util.FolderOrders, arg.PackageImages...)
+		packagingImageURLs, err := arg.UploadImagesFunc(ctx, "packaging_image", arg.Order.Code, util.FolderOrders, arg.PackageImages...)
 		if err != nil {
 			return err
 		}
@@ -424,20 +467,11 @@ func (store *SQLStore) CompleteRegularOrderTx(ctx context.Context, arg CompleteR
 		}
 		result.OrderTransaction = updatedOrderTransaction
 		
-		// 4. Chuyển quyền sở hữu các mặt hàng trong đơn hàng cho người nhận hàng,
-		// cũng như cập nhật trạng thái của chúng thành "in store"
+		// 4. Chuyển các mặt hàng trong đơn hàng cho người nhận hàng với trạng thái "in store"
 		for _, item := range arg.OrderItems {
 			if item.GundamID != nil {
-				err = qTx.UpdateGundam(ctx, UpdateGundamParams{
-					ID:      *item.GundamID,
-					OwnerID: &arg.Order.BuyerID,
-					Status: NullGundamStatus{
-						GundamStatus: GundamStatusInstore,
-						Valid:        true,
-					},
-				})
-				if err != nil {
-					return fmt.Errorf("failed to update gundam owner: %w", err)
+				if err = transferOrderItem(ctx, qTx, item, arg.Order.BuyerID); err != nil {
+					return err
 				}
 			} else {
 				log.Warn().Msgf("gundam ID %d not found in order item %d", item.GundamID, item.ID)
@@ -540,7 +574,7 @@ func (store *SQLStore) CancelOrderByBuyerTx(ctx context.Context, arg CancelOrder
 		}
 		result.BuyerWallet = updatedBuyerWallet
 		
-		// 6. Khôi phục trạng thái gundam về "published"
+		// 6. Trả lại số lượng đã giữ, Gundam không còn ai giữ được đăng bán lại với trạng thái "published"
 		orderItems, err := qTx.ListOrderItems(ctx, updatedOrder.ID)
 		if err != nil {
 			return fmt.Errorf("failed to get order items: %w", err)
@@ -548,14 +582,8 @@ func (store *SQLStore) CancelOrderByBuyerTx(ctx context.Context, arg CancelOrder
 		
 		for _, item := range orderItems {
 			if item.GundamID != nil {
-				if err = qTx.UpdateGundam(ctx, UpdateGundamParams{
-					ID: *item.GundamID,
-					Status: NullGundamStatus{
-						GundamStatus: GundamStatusPublished,
-						Valid:        true,
-					},
-				}); err != nil {
-					return fmt.Errorf("failed to restore gundam status: %w", err)
+				if err = releaseOrderItem(ctx, qTx, item, GundamStatusPublished); err != nil {
+					return err
 				}
 			} else {
 				log.Warn().Msgf("Gundam ID %d not found in order item %d", item.GundamID, item.ID)
@@ -668,18 +696,10 @@ func (store *SQLStore) FailRegularOrderTx(ctx context.Context, arg FailRegularOr
 		}
 		result.OrderTransaction = updatedTransaction
 		
-		// 6. Cập nhật trạng thái các sản phẩm trong đơn hàng về "in store" cho người bán
+		// 6. Trả lại số lượng đã giữ cho người bán, Gundam không còn ai giữ được chuyển về "in store"
 		for _, item := range arg.OrderItems {
 			if item.GundamID != nil {
-				// Đảm bảo Gundam vẫn thuộc về người bán
-				err = qTx.UpdateGundam(ctx, UpdateGundamParams{
-					ID: *item.GundamID,
-					Status: NullGundamStatus{
-						GundamStatus: GundamStatusInstore,
-						Valid:        true,
-					},
-				})
-				if err != nil {
+				if err = releaseOrderItem(ctx, qTx, item, GundamStatusInstore); err != nil {
 					return err
 				}
 			}
@@ -836,3 +856,75 @@ func (store *SQLStore) FailAuctionOrderTx(ctx context.Context, arg FailAuctionOr
 	
 	return result, err
 }
+
+// releaseOrderItem trả lại số lượng mà đơn hàng thông thường đã giữ của Gundam khi đơn hàng bị hủy hoặc thất bại.
+// Trạng thái của Gundam "processing" chỉ chuyển thành restoreStatus khi không còn đơn hàng nào khác đang giữ nó.
+func releaseOrderItem(ctx context.Context, qTx *Queries, item OrderItem, restoreStatus GundamStatus) error {
+	_, err := qTx.ReleaseGundamStock(ctx, ReleaseGundamStockParams{
+		Quantity:      item.Quantity,
+		RestoreStatus: restoreStatus,
+		ID:            *item.GundamID,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("gundam ID %d does not have %d reserved units for order item %d", *item.GundamID, item.Quantity, item.ID)
+		}
+		return fmt.Errorf("failed to release gundam stock: %w", err)
+	}
+	
+	return nil
+}
+
+// transferOrderItem chuyển các đơn vị đã mua của một mặt hàng cho người mua khi đơn hàng hoàn tất.
+// Nếu người mua mua hết số lượng còn lại, chính Gundam đó được chuyển quyền sở hữu (giống đơn hàng một sản phẩm trước đây),
+// ngược lại số lượng đã mua được trừ khỏi tin đăng của người bán và người mua nhận một Gundam mới với cùng thông tin, ảnh và phụ kiện.
+func transferOrderItem(ctx context.Context, qTx *Queries, item OrderItem, buyerID string) error {
+	gundam, err := qTx.DeductGundamStock(ctx, DeductGundamStockParams{
+		Quantity: item.Quantity,
+		ID:       *item.GundamID,
+	})
+	if errors.Is(err, ErrRecordNotFound) {
+		_, err = qTx.TransferGundamOwnership(ctx, TransferGundamOwnershipParams{
+			OwnerID:  buyerID,
+			ID:       *item.GundamID,
+			Quantity: item.Quantity,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("gundam ID %d does not have %d reserved units for order item %d", *item.GundamID, item.Quantity, item.ID)
+			}
+			return fmt.Errorf("failed to update gundam owner: %w", err)
+		}
+		
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deduct gundam stock: %w", err)
+	}
+	
+	buyerGundam, err := qTx.CloneGundamForOwner(ctx, CloneGundamForOwnerParams{
+		OwnerID:  buyerID,
+		Slug:     util.GenerateRandomSlug(gundam.Name),
+		Quantity: item.Quantity,
+		ID:       gundam.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create gundam for buyer: %w", err)
+	}
+	
+	if err = qTx.CopyGundamImages(ctx, CopyGundamImagesParams{
+		ToGundamID:   buyerGundam.ID,
+		FromGundamID: gundam.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy gundam images: %w", err)
+	}
+	
+	if err = qTx.CopyGundamAccessories(ctx, CopyGundamAccessoriesParams{
+		ToGundamID:   buyerGundam.ID,
+		FromGundamID: gundam.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy gundam accessories: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+	
+	"github.com/katatrina/gundam-BE/internal/delivery"
+	"github.com/stretchr/testify/require"
+)
+
+const testDeliveryFee int64 = 30000
+
+// fakeDeliveryProvider báo giá cố định thay cho GHN, các phương thức tạo và tra cứu đơn vận chuyển không được dùng.
+type fakeDeliveryProvider struct {
+	delivery.IDeliveryProvider
+	fee      int64
+	leadTime time.Time
+}
+
+func (p fakeDeliveryProvider) ListServices(_ context.Context, _, _ int64) (*delivery.ListServicesResponse, error) {
+	return &delivery.ListServicesResponse{
+		Data: []delivery.ServiceInfo{{ServiceID: 53320, ServiceTypeID: delivery.DefaultServiceTypeID}},
+	}, nil
+}
+
+func (p fakeDeliveryProvider) CalculateFee(_ context.Context, _ delivery.FeeRequest) (*delivery.CalculateFeeResponse, error) {
+	var response delivery.CalculateFeeResponse
+	response.Data.Total = p.fee
+	return &response, nil
+}
+
+func (p fakeDeliveryProvider) GetLeadTime(_ context.Context, _ delivery.LeadTimeRequest) (*delivery.GetLeadTimeResponse, error) {
+	var response delivery.GetLeadTimeResponse
+	response.Data.LeadTime = p.leadTime.Unix()
+	return &response, nil
+}
+
+func testDeliveryQuote(t *testing.T) delivery.Quote {
+	t.Helper()
+	
+	provider := fakeDeliveryProvider{
+		fee:      testDeliveryFee,
+		leadTime: time.Now().Add(72 * time.Hour),
+	}
+	quote, err := delivery.QuoteDelivery(context.Background(), provider, delivery.Location{}, delivery.Location{}, 300)
+	require.NoError(t, err)
+	
+	return quote
+}
+
+// testOrderParams trả về tham số đặt quantity đơn vị của gundam cho buyer, thanh toán bằng ví.
+func testOrderParams(t *testing.T, buyer User, gundam Gundam, quantity int64) CreateOrderTxParams {
+	t.Helper()
+	
+	itemsSubtotal := *gundam.Price * quantity
+	quote := testDeliveryQuote(t)
+	return CreateOrderTxParams{
+		BuyerID:              buyer.ID,
+		BuyerAddress:         randomAddress(buyer.ID),
+		SellerID:             gundam.OwnerID,
+		SellerAddress:        randomAddress(gundam.OwnerID),
+		ItemsSubtotal:        itemsSubtotal,
+		TotalAmount:          itemsSubtotal + testDeliveryFee,
+		DeliveryFee:          testDeliveryFee,
+		ExpectedDeliveryTime: quote.ExpectedDeliveryTime(),
+		DeliveryQuote:        quote,
+		PaymentMethod:        PaymentMethodWallet,
+		Items: []CreateOrderTxItem{
+			{Gundam: gundam, Quantity: quantity},
+		},
+	}
+}
+
+// createTestOrder đặt quantity đơn vị của gundam cho buyer qua CreateOrderTx.
+func createTestOrder(t *testing.T, buyer User, gundam Gundam, quantity int64) CreateOrderTxResult {
+	t.Helper()
+	
+	result, err := testStore.CreateOrderTx(context.Background(), testOrderParams(t, buyer, gundam, quantity))
+	require.NoError(t, err)
+	
+	return result
+}
+
+// failTestOrder cho người bán xác nhận đơn hàng rồi xử lý giao hàng thất bại giống OrderTracker.
+func failTestOrder(t *testing.T, created CreateOrderTxResult) {
+	t.Helper()
+	ctx := context.Background()
+	
+	confirmed, err := testStore.ConfirmOrderBySellerTx(ctx, ConfirmOrderTxParams{
+		Order:    &created.Order,
+		SellerID: created.Order.SellerID,
+	})
+	require.NoError(t, err)
+	
+	_, err = testStore.FailRegularOrderTx(ctx, FailRegularOrderTxParams{
+		FailedOrder:  &confirmed.Order,
+		BuyerEntry:   &created.BuyerEntry,
+		SellerEntry:  &confirmed.SellerEntry,
+		Transaction:  &confirmed.OrderTransaction,
+		OrderItems:   confirmed.OrderItems,
+		RefundAmount: created.BuyerEntry.Amount,
+	})
+	require.NoError(t, err)
+}
+
+func cancelTestOrder(t *testing.T, created CreateOrderTxResult) {
+	t.Helper()
+	
+	_, err := testStore.CancelOrderByBuyerTx(context.Background(), CancelOrderByBuyerTxParams{
+		Order: &created.Order,
+	})
+	require.NoError(t, err)
+}
+
+func requireGundamStock(t *testing.T, gundamID int64, quantity, reserved int64, status GundamStatus) {
+	t.Helper()
+	
+	gundam, err := testStore.GetGundamByID(context.Background(), gundamID)
+	require.NoError(t, err)
+	require.Equal(t, quantity, gundam.Quantity)
+	require.Equal(t, reserved, gundam.ReservedQuantity)
+	require.Equal(t, status, gundam.Status)
+}
+
+// Hai đơn hàng cùng giữ một tin đăng nhiều đơn vị: đơn hàng thất bại hoặc bị hủy trước
+// không được đổi trạng thái của tin đăng khi đơn hàng còn lại vẫn đang giữ hàng.
+func TestReleaseStockWithTwoOrdersOnOneListing(t *testing.T) {
+	requireTestStore(t)
+	
+	testCases := []struct {
+		name        string
+		first       func(t *testing.T, created CreateOrderTxResult)
+		second      func(t *testing.T, created CreateOrderTxResult)
+		finalStatus GundamStatus
+	}{
+		{
+			name:        "FailedDeliveryThenCancel",
+			first:       failTestOrder,
+			second:      cancelTestOrder,
+			finalStatus: GundamStatusPublished,
+		},
+		{
+			name:        "CancelThenFailedDelivery",
+			first:       cancelTestOrder,
+			second:      failTestOrder,
+			finalStatus: GundamStatusInstore,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seller := createRandomUser(t, 0)
+			buyerA := createRandomUser(t, 10_000_000)
+			buyerB := createRandomUser(t, 10_000_000)
+			gundam := createPublishedGundam(t, seller.ID, 2, 500_000)
+			
+			orderA := createTestOrder(t, buyerA, gundam, 1)
+			requireGundamStock(t, gundam.ID, 2, 1, GundamStatusPublished)
+			
+			orderB := createTestOrder(t, buyerB, gundam, 1)
+			requireGundamStock(t, gundam.ID, 2, 2, GundamStatusProcessing)
+			
+			// Đơn hàng B vẫn đang giữ một đơn vị nên tin đăng vẫn là "processing"
+			tc.first(t, orderA)
+			requireGundamStock(t, gundam.ID, 2, 1, GundamStatusProcessing)
+			
+			// Không còn đơn hàng nào giữ hàng, trạng thái theo đơn hàng cuối cùng được trả lại
+			tc.second(t, orderB)
+			requireGundamStock(t, gundam.ID, 2, 0, tc.finalStatus)
+		})
+	}
+}
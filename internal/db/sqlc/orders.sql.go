@@ -270,16 +270,40 @@ SELECT id, code, buyer_id, seller_id, items_subtotal, delivery_fee, total_amount
 FROM orders
 WHERE (buyer_id = $1 OR (type = 'exchange' AND seller_id = $1))
   AND status = COALESCE($2::order_status, status)
-ORDER BY updated_at DESC, created_at DESC
+  AND ($3::uuid IS NULL OR
+       CASE $4::text
+           WHEN 'newest' THEN (created_at, id) < ($5::timestamptz, $3::uuid)
+           WHEN 'oldest' THEN (created_at, id) > ($5::timestamptz, $3::uuid)
+           ELSE (updated_at, id) < ($5::timestamptz, $3::uuid)
+           END)
+ORDER BY CASE WHEN $4::text = 'newest' THEN created_at END DESC,
+         CASE WHEN $4::text = 'oldest' THEN created_at END,
+         CASE WHEN $4::text = 'oldest' THEN id END,
+         CASE WHEN $4::text NOT IN ('newest', 'oldest') THEN updated_at END DESC,
+         id DESC
+LIMIT $6::int
 `
 
 type ListMemberOrdersParams struct {
-	BuyerID string          `json:"buyer_id"`
-	Status  NullOrderStatus `json:"status"`
+	BuyerID     string          `json:"buyer_id"`
+	Status      NullOrderStatus `json:"status"`
+	CursorID    *uuid.UUID      `json:"cursor_id"`
+	Sort        string          `json:"sort"`
+	CursorValue *time.Time      `json:"cursor_value"`
+	Limit       *int32          `json:"limit"`
 }
 
+// Phân trang keyset: sort là recently_updated (mặc định), newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+// Cursor của recently_updated dựa trên updated_at nên đơn hàng được cập nhật trong lúc client đang phân trang có thể bị bỏ qua hoặc trả về hai lần; dùng newest/oldest nếu cần duyệt ổn định.
 func (q *Queries) ListMemberOrders(ctx context.Context, arg ListMemberOrdersParams) ([]Order, error) {
-	rows, err := q.db.Query(ctx, listMemberOrders, arg.BuyerID, arg.Status)
+	rows, err := q.db.Query(ctx, listMemberOrders,
+		arg.BuyerID,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -12,23 +12,40 @@ import (
 )
 
 type Querier interface {
+	// Thêm Gundam vào giỏ hàng, nếu Gundam đã có trong giỏ thì cộng dồn số lượng.
 	AddCartItem(ctx context.Context, arg AddCartItemParams) (AddCartItemRow, error)
+	AddCheckoutOrder(ctx context.Context, arg AddCheckoutOrderParams) error
 	AddWalletBalance(ctx context.Context, arg AddWalletBalanceParams) (Wallet, error)
 	AddWalletNonWithdrawableAmount(ctx context.Context, arg AddWalletNonWithdrawableAmountParams) error
+	AddWishlistItem(ctx context.Context, arg AddWishlistItemParams) error
+	// Xóa thông tin cá nhân và đánh dấu tài khoản đã bị xóa.
+	// Email được thay bằng địa chỉ không tồn tại để giải phóng ràng buộc UNIQUE cho lần đăng ký sau.
+	AnonymizeUser(ctx context.Context, id string) (User, error)
 	BulkUpdateGundamsExchanging(ctx context.Context, arg BulkUpdateGundamsExchangingParams) error
 	BulkUpdateGundamsForExchange(ctx context.Context, arg BulkUpdateGundamsForExchangeParams) error
 	BulkUpdateGundamsInStore(ctx context.Context, arg BulkUpdateGundamsInStoreParams) error
-	CheckCartItemExists(ctx context.Context, arg CheckCartItemExistsParams) (bool, error)
 	CheckUserParticipation(ctx context.Context, arg CheckUserParticipationParams) (bool, error)
+	// Tạo Gundam mới cho người mua với quantity đơn vị từ tin đăng của người bán, dùng khi người mua chỉ mua một phần số lượng.
+	CloneGundamForOwner(ctx context.Context, arg CloneGundamForOwnerParams) (Gundam, error)
+	CompleteImageCleanupRun(ctx context.Context, arg CompleteImageCleanupRunParams) (ImageCleanupRun, error)
 	ConfirmOrderByID(ctx context.Context, arg ConfirmOrderByIDParams) (Order, error)
+	CopyGundamAccessories(ctx context.Context, arg CopyGundamAccessoriesParams) error
+	CopyGundamImages(ctx context.Context, arg CopyGundamImagesParams) error
 	CountExchangeOffers(ctx context.Context, postID uuid.UUID) (int64, error)
+	CountExchangeOffersByPostIDs(ctx context.Context, postIDs []uuid.UUID) ([]CountExchangeOffersByPostIDsRow, error)
 	CountExistingPendingAuctionRequest(ctx context.Context, gundamID *int64) (int64, error)
+	// Ảnh được lưu theo nội dung nên nhiều Gundam có thể dùng chung một URL, chỉ xóa file khi không còn Gundam nào dùng.
+	CountGundamImagesByURL(ctx context.Context, url string) (int64, error)
+	CountSavedSearchesByUserID(ctx context.Context, userID string) (int64, error)
 	CountSellerActiveAuctions(ctx context.Context, sellerID string) (int64, error)
+	CountUnusedUserRecoveryCodes(ctx context.Context, userID string) (int64, error)
 	CreateAccessory(ctx context.Context, arg CreateAccessoryParams) error
 	CreateAuction(ctx context.Context, arg CreateAuctionParams) (Auction, error)
 	CreateAuctionBid(ctx context.Context, arg CreateAuctionBidParams) (AuctionBid, error)
 	CreateAuctionParticipant(ctx context.Context, arg CreateAuctionParticipantParams) (AuctionParticipant, error)
 	CreateAuctionRequest(ctx context.Context, arg CreateAuctionRequestParams) (AuctionRequest, error)
+	// Trả về ErrRecordNotFound nếu checkout ID đã tồn tại (checkout đã được xử lý hoặc đang được xử lý bởi request khác).
+	CreateCheckout(ctx context.Context, arg CreateCheckoutParams) (Checkout, error)
 	CreateDeliveryInformation(ctx context.Context, arg CreateDeliveryInformationParams) (DeliveryInformation, error)
 	CreateExchange(ctx context.Context, arg CreateExchangeParams) (Exchange, error)
 	CreateExchangeItem(ctx context.Context, arg CreateExchangeItemParams) (ExchangeItem, error)
@@ -39,28 +56,53 @@ type Querier interface {
 	CreateExchangePostItems(ctx context.Context, arg CreateExchangePostItemsParams) ([]ExchangePostItem, error)
 	CreateGundam(ctx context.Context, arg CreateGundamParams) (Gundam, error)
 	CreateGundamAccessory(ctx context.Context, arg CreateGundamAccessoryParams) (GundamAccessory, error)
+	CreateGundamPriceHistory(ctx context.Context, arg CreateGundamPriceHistoryParams) (GundamPriceHistory, error)
+	CreateImageCleanupRun(ctx context.Context, arg CreateImageCleanupRunParams) (ImageCleanupRun, error)
+	CreateImageFingerprint(ctx context.Context, arg CreateImageFingerprintParams) (ImageFingerprint, error)
+	CreateModelKit(ctx context.Context, arg CreateModelKitParams) (ModelKit, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderDelivery(ctx context.Context, arg CreateOrderDeliveryParams) (OrderDelivery, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
 	CreateOrderTransaction(ctx context.Context, arg CreateOrderTransactionParams) (OrderTransaction, error)
 	CreatePaymentTransaction(ctx context.Context, arg CreatePaymentTransactionParams) (PaymentTransaction, error)
+	CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error)
 	CreateSellerProfile(ctx context.Context, arg CreateSellerProfileParams) (SellerProfile, error)
 	CreateSellerSubscription(ctx context.Context, arg CreateSellerSubscriptionParams) (SellerSubscription, error)
 	CreateTrialSubscriptionForSeller(ctx context.Context, sellerID string) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUserAddress(ctx context.Context, arg CreateUserAddressParams) (UserAddress, error)
 	CreateUserBankAccount(ctx context.Context, arg CreateUserBankAccountParams) (UserBankAccount, error)
+	CreateUserRecoveryCodes(ctx context.Context, arg CreateUserRecoveryCodesParams) error
 	CreateUserWithGoogleAccount(ctx context.Context, arg CreateUserWithGoogleAccountParams) (User, error)
 	CreateWallet(ctx context.Context, userID string) error
 	CreateWalletEntry(ctx context.Context, arg CreateWalletEntryParams) (WalletEntry, error)
+	// Ghi nhận một Gundam vừa được đăng bán, giảm giá (previous_price khác NULL) hoặc đưa lên đấu giá cho những người dùng
+	// có Gundam này trong danh sách yêu thích hoặc có tìm kiếm đã lưu khớp với nó. Chủ sở hữu Gundam và tài khoản đã bị xóa không được ghi nhận.
+	// Nếu người dùng đã có dòng chờ thông báo cho Gundam thì chỉ cập nhật giá, giữ nguyên giá trước khi giảm của dòng đó.
+	// Trả về ID của những người dùng có dòng chờ thông báo mới hoặc vừa được cập nhật.
+	CreateWatchMatches(ctx context.Context, arg CreateWatchMatchesParams) ([]string, error)
 	CreateWithdrawalRequest(ctx context.Context, arg CreateWithdrawalRequestParams) (WithdrawalRequest, error)
+	// Trừ quantity đơn vị đã giữ khỏi tồn kho của người bán khi đơn hàng hoàn tất,
+	// chỉ áp dụng khi người bán vẫn còn hàng sau khi trừ (xem TransferGundamOwnership).
+	// Gundam "processing" không còn đơn hàng nào giữ (do các đơn hàng khác đã bị hủy hoặc thất bại) được đăng bán lại.
+	DeductGundamStock(ctx context.Context, arg DeductGundamStockParams) (Gundam, error)
 	DeleteAllGundamAccessories(ctx context.Context, gundamID int64) error
+	DeleteAllUserAddresses(ctx context.Context, userID string) error
 	DeleteAuctionRequest(ctx context.Context, id uuid.UUID) error
 	DeleteExchangeOffer(ctx context.Context, id uuid.UUID) (ExchangeOffer, error)
 	DeleteExchangePost(ctx context.Context, id uuid.UUID) (ExchangePost, error)
 	DeleteGundam(ctx context.Context, arg DeleteGundamParams) error
 	DeleteGundamImage(ctx context.Context, arg DeleteGundamImageParams) error
+	DeleteModelKit(ctx context.Context, id int64) error
+	DeleteSavedSearch(ctx context.Context, arg DeleteSavedSearchParams) (int64, error)
 	DeleteUserAddress(ctx context.Context, arg DeleteUserAddressParams) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID string) error
+	DeleteUserTOTPCredential(ctx context.Context, userID string) error
+	DeleteWishlistItem(ctx context.Context, arg DeleteWishlistItemParams) (int64, error)
+	EnableUserTOTP(ctx context.Context, userID string) (UserTotpCredential, error)
+	FailImageCleanupRun(ctx context.Context, arg FailImageCleanupRunParams) error
+	// Đánh dấu các ảnh cùng thư mục của đối tượng khác có khoảng cách Hamming giữa hai perceptual hash không vượt quá max_distance.
+	FlagDuplicateImages(ctx context.Context, arg FlagDuplicateImagesParams) (int64, error)
 	GetActiveOrderDeliveries(ctx context.Context) ([]GetActiveOrderDeliveriesRow, error)
 	// Metric 7: Đấu giá hoàn thành thành công tuần này (bảng auctions)
 	GetAdminCompletedAuctionsThisWeek(ctx context.Context) (int64, error)
@@ -89,6 +131,8 @@ type Querier interface {
 	GetAuctionParticipantByUserID(ctx context.Context, arg GetAuctionParticipantByUserIDParams) (AuctionParticipant, error)
 	GetAuctionRequestByID(ctx context.Context, id uuid.UUID) (AuctionRequest, error)
 	GetCartByUserID(ctx context.Context, userID string) (int64, error)
+	GetCartItemQuantity(ctx context.Context, arg GetCartItemQuantityParams) (int64, error)
+	GetCheckoutByID(ctx context.Context, id uuid.UUID) (Checkout, error)
 	GetCurrentActiveSubscriptionDetailsForSeller(ctx context.Context, sellerID string) (GetCurrentActiveSubscriptionDetailsForSellerRow, error)
 	GetDeliveredOrdersToAutoComplete(ctx context.Context, updatedAt time.Time) ([]Order, error)
 	GetDeliveryInformation(ctx context.Context, id int64) (DeliveryInformation, error)
@@ -104,6 +148,13 @@ type Querier interface {
 	GetGundamPrimaryImageURL(ctx context.Context, gundamID int64) (string, error)
 	GetGundamSecondaryImageURLs(ctx context.Context, gundamID int64) ([]string, error)
 	GetImageByURL(ctx context.Context, arg GetImageByURLParams) (GundamImage, error)
+	GetImageCleanupRun(ctx context.Context, id int64) (ImageCleanupRun, error)
+	// Thời điểm gửi thông báo tổng hợp gần nhất cho người dùng.
+	GetLastWatchDigestTime(ctx context.Context, userID string) (*time.Time, error)
+	// Thống kê giá bán (min, p25, median, p75, max) của một model kit hoặc các Gundam cùng họ slug (xem slug_family) kể từ thời điểm since.
+	// Mỗi tình trạng (condition) một dòng, cộng thêm một dòng tổng hợp cho mọi tình trạng với is_overall = true.
+	// Chỉ tính các lần bán có nguồn (source của gundam_sales) nằm trong sources.
+	GetMarketPriceStats(ctx context.Context, arg GetMarketPriceStatsParams) ([]GetMarketPriceStatsRow, error)
 	// Metric 1: Yêu cầu đấu giá chờ moderator duyệt (bảng auction_requests)
 	GetModPendingAuctionRequestsCount(ctx context.Context) (int64, error)
 	// Metric 2: Yêu cầu rút tiền chờ moderator xử lý (bảng withdrawal_requests)
@@ -112,6 +163,9 @@ type Querier interface {
 	GetModTotalExchangesThisWeek(ctx context.Context) (int64, error)
 	// Metric 4: Tất cả đơn hàng được tạo tuần này - VOLUME INDICATOR (bảng orders)
 	GetModTotalOrdersThisWeek(ctx context.Context) (int64, error)
+	GetModelKitByID(ctx context.Context, id int64) (ModelKit, error)
+	// Thông tin một model kit kèm số tin đăng đang bán và giá thấp nhất trong các tin đăng đó.
+	GetModelKitDetails(ctx context.Context, id int64) (GetModelKitDetailsRow, error)
 	GetOrCreateCartIfNotExists(ctx context.Context, userID string) (int64, error)
 	GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error)
 	GetOrderDelivery(ctx context.Context, orderID uuid.UUID) (OrderDelivery, error)
@@ -143,36 +197,74 @@ type Querier interface {
 	GetUserBankAccount(ctx context.Context, arg GetUserBankAccountParams) (UserBankAccount, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id string) (User, error)
+	// Dùng để hiển thị thông tin đối tác trong các giao dịch đã kết thúc, kể cả khi tài khoản đã bị xóa
+	GetUserByIDIncludingDeleted(ctx context.Context, id string) (User, error)
 	GetUserByPhoneNumber(ctx context.Context, phoneNumber *string) (User, error)
+	// Đếm các giao dịch chưa kết thúc khiến tài khoản chưa thể bị xóa
+	GetUserDeletionBlockers(ctx context.Context, userID string) (GetUserDeletionBlockersRow, error)
 	GetUserExchangeOfferForPost(ctx context.Context, arg GetUserExchangeOfferForPostParams) (ExchangeOffer, error)
 	GetUserExchangePost(ctx context.Context, arg GetUserExchangePostParams) (ExchangePost, error)
 	GetUserPickupAddress(ctx context.Context, userID string) (UserAddress, error)
+	GetUserTOTPCredential(ctx context.Context, userID string) (UserTotpCredential, error)
 	GetWalletByUserID(ctx context.Context, userID string) (Wallet, error)
 	GetWalletEntryByID(ctx context.Context, id int64) (WalletEntry, error)
 	GetWalletForUpdate(ctx context.Context, userID string) (Wallet, error)
 	GetWithdrawalRequest(ctx context.Context, id uuid.UUID) (GetWithdrawalRequestRow, error)
 	IncrementAuctionParticipants(ctx context.Context, id uuid.UUID) (Auction, error)
 	IncrementAuctionTotalBids(ctx context.Context, id uuid.UUID) (Auction, error)
+	IncrementUserTokenVersion(ctx context.Context, id string) (int64, error)
 	ListAuctionBids(ctx context.Context, auctionID *uuid.UUID) ([]AuctionBid, error)
 	ListAuctionParticipants(ctx context.Context, auctionID uuid.UUID) ([]AuctionParticipant, error)
 	ListAuctionParticipantsExcept(ctx context.Context, arg ListAuctionParticipantsExceptParams) ([]AuctionParticipant, error)
-	ListAuctionRequests(ctx context.Context, status NullAuctionRequestStatus) ([]AuctionRequest, error)
-	ListAuctions(ctx context.Context, status NullAuctionStatus) ([]Auction, error)
+	// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
+	ListAuctionRequests(ctx context.Context, arg ListAuctionRequestsParams) ([]AuctionRequest, error)
+	// Phân trang keyset theo (cột sắp xếp, id):
+	// ending_soon theo end_time tăng dần, starting_soon theo start_time tăng dần, oldest/newest theo created_at.
+	ListAuctions(ctx context.Context, arg ListAuctionsParams) ([]Auction, error)
+	ListCartItems(ctx context.Context, cartID int64) ([]CartItem, error)
 	ListCartItemsWithDetails(ctx context.Context, cartID int64) ([]ListCartItemsWithDetailsRow, error)
+	ListCheckoutOrders(ctx context.Context, checkoutID uuid.UUID) ([]Order, error)
 	ListExchangeItems(ctx context.Context, arg ListExchangeItemsParams) ([]ExchangeItem, error)
 	ListExchangeOfferItems(ctx context.Context, arg ListExchangeOfferItemsParams) ([]ExchangeOfferItem, error)
+	ListExchangeOfferItemsByOfferIDs(ctx context.Context, offerIDs []uuid.UUID) ([]ExchangeOfferItem, error)
 	ListExchangeOfferNotes(ctx context.Context, offerID uuid.UUID) ([]ExchangeOfferNote, error)
+	ListExchangeOfferNotesByOfferIDs(ctx context.Context, offerIDs []uuid.UUID) ([]ExchangeOfferNote, error)
 	ListExchangeOffers(ctx context.Context, postID uuid.UUID) ([]ExchangeOffer, error)
 	ListExchangeOffersByOfferer(ctx context.Context, offererID string) ([]ExchangeOffer, error)
 	ListExchangeOffersByPostExcluding(ctx context.Context, arg ListExchangeOffersByPostExcludingParams) ([]ExchangeOffer, error)
 	ListExchangePostItems(ctx context.Context, postID uuid.UUID) ([]ExchangePostItem, error)
-	ListExchangePosts(ctx context.Context, status NullExchangePostStatus) ([]ExchangePost, error)
+	// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+	ListExchangePostItemsByPostIDs(ctx context.Context, postIDs []uuid.UUID) ([]ExchangePostItem, error)
+	ListExchangePosts(ctx context.Context, arg ListExchangePostsParams) ([]ExchangePost, error)
+	ListExchangePostsByIDs(ctx context.Context, postIDs []uuid.UUID) ([]ExchangePost, error)
+	ListGundamAccessoriesByGundamIDs(ctx context.Context, gundamIDs []int64) ([]GundamAccessory, error)
 	ListGundamGrades(ctx context.Context) ([]GundamGrade, error)
+	ListGundamImagesByGundamIDs(ctx context.Context, gundamIDs []int64) ([]GundamImage, error)
+	ListGundamPriceHistory(ctx context.Context, gundamID int64) ([]GundamPriceHistory, error)
 	ListGundamsByUserID(ctx context.Context, arg ListGundamsByUserIDParams) ([]ListGundamsByUserIDRow, error)
-	ListGundamsWithFilters(ctx context.Context, arg ListGundamsWithFiltersParams) ([]ListGundamsWithFiltersRow, error)
+	// Phân trang keyset: sort là recently_updated (mặc định), newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+	// Cursor của recently_updated dựa trên updated_at nên đơn hàng được cập nhật trong lúc client đang phân trang có thể bị bỏ qua hoặc trả về hai lần; dùng newest/oldest nếu cần duyệt ổn định.
+	ListGundamsWithGradeByIDs(ctx context.Context, gundamIDs []int64) ([]ListGundamsWithGradeByIDsRow, error)
+	// Danh sách các lần dọn ảnh cho admin, mới nhất trước, không kèm danh sách ảnh mồ côi. Phân trang keyset theo id.
+	ListImageCleanupRuns(ctx context.Context, arg ListImageCleanupRunsParams) ([]ListImageCleanupRunsRow, error)
+	// Danh sách các cặp ảnh bị đánh dấu trùng cho moderator, mới nhất trước. Phân trang keyset theo id.
+	ListImageFlags(ctx context.Context, arg ListImageFlagsParams) ([]ListImageFlagsRow, error)
+	// Giá bán trung vị theo từng tháng của một model kit hoặc các Gundam cùng họ slug (xem slug_family), có thể lọc theo tình trạng.
+	// Chỉ tính các lần bán có nguồn (source của gundam_sales) nằm trong sources.
+	ListMarketPriceTrend(ctx context.Context, arg ListMarketPriceTrendParams) ([]ListMarketPriceTrendRow, error)
 	ListMemberOrders(ctx context.Context, arg ListMemberOrdersParams) ([]Order, error)
+	// Danh sách model kit cho trang danh mục, kèm số tin đăng đang bán và giá thấp nhất của mỗi kit.
+	// Phân trang keyset theo (cột sắp xếp, id), cursor_value là giá trị cột sắp xếp của dòng cuối trang trước ở dạng text.
+	ListModelKits(ctx context.Context, arg ListModelKitsParams) ([]ListModelKitsRow, error)
 	ListOrderItems(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error)
+	// Các dòng chờ thông báo của người dùng, is_available cho biết Gundam còn đang được bán hoặc phiên đấu giá chưa kết thúc.
+	ListPendingWatchMatches(ctx context.Context, userID string) ([]ListPendingWatchMatchesRow, error)
+	// Tất cả URL ảnh đang được database tham chiếu, kể cả ảnh trong snapshot của đơn hàng, giao dịch trao đổi và đấu giá.
+	ListReferencedImageURLs(ctx context.Context) ([]string, error)
+	// Phân trang keyset: sort là recently_updated (mặc định), newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+	// Cursor của recently_updated dựa trên updated_at nên đơn hàng được cập nhật trong lúc client đang phân trang có thể bị bỏ qua hoặc trả về hai lần; dùng newest/oldest nếu cần duyệt ổn định.
 	ListSalesOrders(ctx context.Context, arg ListSalesOrdersParams) ([]Order, error)
+	ListSavedSearchesByUserID(ctx context.Context, userID string) ([]SavedSearch, error)
 	ListSellerAuctionRequests(ctx context.Context, arg ListSellerAuctionRequestsParams) ([]AuctionRequest, error)
 	ListSellerAuctions(ctx context.Context, arg ListSellerAuctionsParams) ([]Auction, error)
 	ListSubscriptionHistory(ctx context.Context, sellerID string) ([]ListSubscriptionHistoryRow, error)
@@ -183,12 +275,45 @@ type Querier interface {
 	ListUserExchangePosts(ctx context.Context, arg ListUserExchangePostsParams) ([]ExchangePost, error)
 	ListUserExchanges(ctx context.Context, arg ListUserExchangesParams) ([]Exchange, error)
 	ListUserParticipatedAuctions(ctx context.Context, userID string) ([]ListUserParticipatedAuctionsRow, error)
+	// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
 	ListUserWalletEntries(ctx context.Context, arg ListUserWalletEntriesParams) ([]WalletEntry, error)
 	ListUserWithdrawalRequests(ctx context.Context, arg ListUserWithdrawalRequestsParams) ([]ListUserWithdrawalRequestsRow, error)
-	ListWithdrawalRequests(ctx context.Context, status NullWithdrawalRequestStatus) ([]ListWithdrawalRequestsRow, error)
+	// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
+	ListUsersByIDs(ctx context.Context, userIDs []string) ([]User, error)
+	// Danh sách Gundam trong danh sách yêu thích của người dùng, mới thêm trước.
+	// Phân trang keyset theo (created_at, gundam_id) của danh sách yêu thích.
+	ListWishlistItems(ctx context.Context, arg ListWishlistItemsParams) ([]ListWishlistItemsRow, error)
+	ListWithdrawalRequests(ctx context.Context, arg ListWithdrawalRequestsParams) ([]ListWithdrawalRequestsRow, error)
+	// Khóa các Gundam theo thứ tự ID tăng dần, dùng trước khi giữ hàng cho nhiều đơn hàng trong cùng một transaction
+	// để các transaction đồng thời luôn khóa các dòng theo cùng một thứ tự (tránh deadlock).
+	LockGundamsByIDs(ctx context.Context, gundamIds []int64) error
+	MarkWatchMatchesNotified(ctx context.Context, ids []int64) error
+	// Trả lại quantity đơn vị đã giữ khi đơn hàng bị hủy hoặc thất bại.
+	// Trạng thái chỉ đổi khi không còn đơn hàng nào giữ Gundam (reserved_quantity về 0): Gundam "processing" chuyển sang restore_status.
+	// Nếu vẫn còn đơn hàng khác đang giữ, trạng thái được giữ nguyên để đơn hàng bị hủy hoặc thất bại không ghi đè lên các đơn hàng đó.
+	ReleaseGundamStock(ctx context.Context, arg ReleaseGundamStockParams) (Gundam, error)
 	RemoveCartItem(ctx context.Context, arg RemoveCartItemParams) error
+	RemoveCartItemsByGundamIDs(ctx context.Context, arg RemoveCartItemsByGundamIDsParams) error
+	// Giữ quantity đơn vị của Gundam đang đăng bán cho một đơn hàng, chỉ thành công khi còn đủ hàng.
+	// UPDATE khóa dòng và kiểm tra lại điều kiện sau khi chờ khóa, nên các đơn hàng đồng thời không thể giữ quá số lượng hiện có.
+	// Gundam chuyển sang "processing" khi toàn bộ số lượng đã được giữ, để không thể được thêm vào giỏ hàng hay đặt mua nữa.
+	ReserveGundamStock(ctx context.Context, arg ReserveGundamStockParams) (Gundam, error)
+	ReviewImageFlag(ctx context.Context, arg ReviewImageFlagParams) (ImageFlag, error)
+	// Đếm số Gundam theo từng giá trị của các facet (grade, scale, condition, manufacturer, price_range, release_year)
+	// trên tập kết quả sau khi áp dụng cùng bộ lọc với SearchGundams.
+	SearchGundamFacets(ctx context.Context, arg SearchGundamFacetsParams) ([]SearchGundamFacetsRow, error)
+	// Tìm kiếm toàn văn và lọc danh mục Gundam.
+	// query là tsquery đã được chuẩn hóa (ví dụ: 'rx:* & 78:*'), khi có query kết quả có thể sắp xếp theo độ liên quan.
+	// Phân trang keyset theo (cột sắp xếp, id), cursor_value là giá trị cột sắp xếp của dòng cuối trang trước ở dạng text.
+	SearchGundams(ctx context.Context, arg SearchGundamsParams) ([]SearchGundamsRow, error)
+	SoftDeleteAllUserBankAccounts(ctx context.Context, userID string) error
+	// Chuyển lần chạy sang running, task được thử lại sau khi worker dừng giữa chừng sẽ chạy lại từ đầu.
+	StartImageCleanupRun(ctx context.Context, id int64) (ImageCleanupRun, error)
 	StoreGundamImageURL(ctx context.Context, arg StoreGundamImageURLParams) error
+	// Chuyển quyền sở hữu Gundam cho người mua khi đơn hàng hoàn tất và người mua đã mua toàn bộ số lượng còn lại.
+	TransferGundamOwnership(ctx context.Context, arg TransferGundamOwnershipParams) (Gundam, error)
 	TransferNonWithdrawableToBalance(ctx context.Context, arg TransferNonWithdrawableToBalanceParams) (Wallet, error)
+	UnpublishAllUserGundams(ctx context.Context, ownerID string) error
 	UnsetPickupAddress(ctx context.Context, userID string) error
 	UnsetPrimaryAddress(ctx context.Context, userID string) error
 	UpdateAuction(ctx context.Context, arg UpdateAuctionParams) (Auction, error)
@@ -200,16 +325,25 @@ type Querier interface {
 	UpdateExchangePost(ctx context.Context, arg UpdateExchangePostParams) (ExchangePost, error)
 	UpdateGundam(ctx context.Context, arg UpdateGundamParams) error
 	UpdateGundamPrimaryImage(ctx context.Context, arg UpdateGundamPrimaryImageParams) error
+	UpdateModelKit(ctx context.Context, arg UpdateModelKitParams) (ModelKit, error)
 	UpdateOrder(ctx context.Context, arg UpdateOrderParams) (Order, error)
 	UpdateOrderDelivery(ctx context.Context, arg UpdateOrderDeliveryParams) (OrderDelivery, error)
 	UpdateOrderTransaction(ctx context.Context, arg UpdateOrderTransactionParams) (OrderTransaction, error)
 	UpdatePaymentTransactionStatus(ctx context.Context, arg UpdatePaymentTransactionStatusParams) error
+	// Thay toàn bộ bộ lọc của một tìm kiếm đã lưu, bộ lọc để NULL sẽ khớp với mọi giá trị.
+	UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error)
 	UpdateSellerProfileByID(ctx context.Context, arg UpdateSellerProfileByIDParams) (SellerProfile, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserAddress(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error)
 	UpdateUserBankAccount(ctx context.Context, arg UpdateUserBankAccountParams) (UserBankAccount, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	// Chỉ cập nhật khi bước thời gian mới lớn hơn bước đã dùng, để mỗi mã TOTP chỉ được dùng một lần
+	UpdateUserTOTPLastUsedStep(ctx context.Context, arg UpdateUserTOTPLastUsedStepParams) (int64, error)
 	UpdateWalletEntryByID(ctx context.Context, arg UpdateWalletEntryByIDParams) (WalletEntry, error)
 	UpdateWithdrawalRequest(ctx context.Context, arg UpdateWithdrawalRequestParams) (WithdrawalRequest, error)
+	// Tạo secret mới cho lần đăng ký, không ghi đè nếu TOTP đã được bật
+	UpsertUserTOTPSecret(ctx context.Context, arg UpsertUserTOTPSecretParams) (UserTotpCredential, error)
+	UseUserRecoveryCode(ctx context.Context, arg UseUserRecoveryCodeParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -264,7 +264,7 @@ func (store *SQLStore) CancelOrderBySellerTx(ctx context.Context, arg CancelOrde
 		}
 		result.BuyerWallet = updatedBuyerWallet
 		
-		// 6. Khôi phục trạng thái gundam về "published"
+		// 6. Trả lại số lượng đã giữ, Gundam đã hết hàng được đăng bán lại với trạng thái "published"
 		orderItems, err := qTx.ListOrderItems(ctx, updatedOrder.ID)
 		if err != nil {
 			return fmt.Errorf("failed to get order items: %w", err)
@@ -272,14 +272,8 @@ func (store *SQLStore) CancelOrderBySellerTx(ctx context.Context, arg CancelOrde
 		
 		for _, item := range orderItems {
 			if item.GundamID != nil {
-				if err = qTx.UpdateGundam(ctx, UpdateGundamParams{
-					ID: *item.GundamID,
-					Status: NullGundamStatus{
-						GundamStatus: GundamStatusPublished,
-						Valid:        true,
-					},
-				}); err != nil {
-					return fmt.Errorf("failed to restore gundam status: %w", err)
+				if err = releaseOrderItem(ctx, qTx, item, GundamStatusPublished); err != nil {
+					return err
 				}
 			} else {
 				log.Warn().Msgf("Gundam ID not found in order item %d", item.ID)
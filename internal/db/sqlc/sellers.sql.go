@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -87,10 +88,11 @@ func (q *Queries) GetSellerActiveAuctionsCount(ctx context.Context, sellerID str
 }
 
 const getSellerByID = `-- name: GetSellerByID :one
-SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 FROM users
 WHERE id = $1
   AND role = 'seller'
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetSellerByID(ctx context.Context, id string) (User, error) {
@@ -110,6 +112,7 @@ func (q *Queries) GetSellerByID(ctx context.Context, id string) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
@@ -129,11 +132,12 @@ func (q *Queries) GetSellerCompletedOrdersCount(ctx context.Context, sellerID st
 }
 
 const getSellerDetailByID = `-- name: GetSellerDetailByID :one
-SELECT u.id, u.google_account_id, u.full_name, u.hashed_password, u.email, u.email_verified, u.phone_number, u.phone_number_verified, u.role, u.avatar_url, u.created_at, u.updated_at, u.deleted_at,
+SELECT u.id, u.google_account_id, u.full_name, u.hashed_password, u.email, u.email_verified, u.phone_number, u.phone_number_verified, u.role, u.avatar_url, u.created_at, u.updated_at, u.deleted_at, u.token_version,
        sp.seller_id, sp.shop_name, sp.created_at, sp.updated_at
 FROM users u
          JOIN seller_profiles sp ON u.id = sp.seller_id
 WHERE u.id = $1
+  AND u.deleted_at IS NULL
 `
 
 type GetSellerDetailByIDRow struct {
@@ -158,6 +162,7 @@ func (q *Queries) GetSellerDetailByID(ctx context.Context, id string) (GetSeller
 		&i.User.CreatedAt,
 		&i.User.UpdatedAt,
 		&i.User.DeletedAt,
+		&i.User.TokenVersion,
 		&i.SellerProfile.SellerID,
 		&i.SellerProfile.ShopName,
 		&i.SellerProfile.CreatedAt,
@@ -265,16 +270,40 @@ FROM orders
 WHERE seller_id = $1
   AND type != 'exchange'
   AND status = COALESCE($2::order_status, status)
-ORDER BY updated_at DESC, created_at DESC
+  AND ($3::uuid IS NULL OR
+       CASE $4::text
+           WHEN 'newest' THEN (created_at, id) < ($5::timestamptz, $3::uuid)
+           WHEN 'oldest' THEN (created_at, id) > ($5::timestamptz, $3::uuid)
+           ELSE (updated_at, id) < ($5::timestamptz, $3::uuid)
+           END)
+ORDER BY CASE WHEN $4::text = 'newest' THEN created_at END DESC,
+         CASE WHEN $4::text = 'oldest' THEN created_at END,
+         CASE WHEN $4::text = 'oldest' THEN id END,
+         CASE WHEN $4::text NOT IN ('newest', 'oldest') THEN updated_at END DESC,
+         id DESC
+LIMIT $6::int
 `
 
 type ListSalesOrdersParams struct {
-	SellerID string          `json:"seller_id"`
-	Status   NullOrderStatus `json:"status"`
+	SellerID    string          `json:"seller_id"`
+	Status      NullOrderStatus `json:"status"`
+	CursorID    *uuid.UUID      `json:"cursor_id"`
+	Sort        string          `json:"sort"`
+	CursorValue *time.Time      `json:"cursor_value"`
+	Limit       *int32          `json:"limit"`
 }
 
+// Phân trang keyset: sort là recently_updated (mặc định), newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
+// Cursor của recently_updated dựa trên updated_at nên đơn hàng được cập nhật trong lúc client đang phân trang có thể bị bỏ qua hoặc trả về hai lần; dùng newest/oldest nếu cần duyệt ổn định.
 func (q *Queries) ListSalesOrders(ctx context.Context, arg ListSalesOrdersParams) ([]Order, error) {
-	rows, err := q.db.Query(ctx, listSalesOrders, arg.SellerID, arg.Status)
+	rows, err := q.db.Query(ctx, listSalesOrders,
+		arg.SellerID,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -18,12 +18,18 @@ type Store interface {
 	ExecTx(ctx context.Context, fn func(*Queries) error) error
 	
 	CreateUserTx(ctx context.Context, arg CreateUserParams) (User, error)
+	ResetUserPasswordTx(ctx context.Context, arg ResetUserPasswordTxParams) (int64, error)
+	DeleteUserTx(ctx context.Context, userID string) (User, error)
+	EnableUserTOTPTx(ctx context.Context, arg EnableUserTOTPTxParams) (UserTotpCredential, error)
+	ReplaceUserRecoveryCodesTx(ctx context.Context, arg ReplaceUserRecoveryCodesTxParams) error
+	DisableUserTOTPTx(ctx context.Context, userID string) error
 	
 	CreateUserAddressTx(ctx context.Context, arg CreateUserAddressTxParams) (UserAddress, error)
 	UpdateUserAddressTx(ctx context.Context, arg UpdateUserAddressParams) (UserAddress, error)
 	DeleteUserAddressTx(ctx context.Context, arg DeleteUserAddressParams) error
 	
 	CreateGundamTx(ctx context.Context, arg CreateGundamTxParams) (GundamDetails, error)
+	UpdateGundamTx(ctx context.Context, arg UpdateGundamTxParams) (UpdateGundamTxResult, error)
 	PublishGundamTx(ctx context.Context, arg PublishGundamTxParams) error
 	UnpublishGundamTx(ctx context.Context, arg UnpublishGundamTxParams) error
 	UpdateGundamAccessoriesTx(ctx context.Context, arg UpdateGundamAccessoriesParams) error
@@ -35,6 +41,8 @@ type Store interface {
 	HandleZalopayCallbackTx(ctx context.Context, arg HandleZalopayCallbackTxParams) error
 	
 	CreateOrderTx(ctx context.Context, arg CreateOrderTxParams) (CreateOrderTxResult, error)
+	CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error)
+	GetCheckoutResult(ctx context.Context, checkout Checkout) (CheckoutTxResult, error)
 	CancelOrderBySellerTx(ctx context.Context, arg CancelOrderBySellerTxParams) (CancelOrderBySellerTxResult, error)
 	CancelOrderByBuyerTx(ctx context.Context, arg CancelOrderByBuyerTxParams) (CancelOrderByBuyerTxResult, error)
 	ConfirmOrderBySellerTx(ctx context.Context, arg ConfirmOrderTxParams) (ConfirmOrderTxResult, error)
@@ -52,6 +60,9 @@ type Store interface {
 	UpdateExchangeOfferTx(ctx context.Context, arg UpdateExchangeOfferTxParams) (UpdateExchangeOfferTxResult, error)
 	AcceptExchangeOfferTx(ctx context.Context, arg AcceptExchangeOfferTxParams) (AcceptExchangeOfferTxResult, error)
 	GetGundamDetailsByID(ctx context.Context, q *Queries, gundamID int64) (GundamDetails, error)
+	ListGundamDetailsByIDs(ctx context.Context, gundamIDs []int64) ([]GundamDetails, error)
+	LoadOpenExchangePostInfos(ctx context.Context, posts []ExchangePost) ([]OpenExchangePostInfo, error)
+	LoadUserExchangeOfferDetails(ctx context.Context, offers []ExchangeOffer) ([]UserExchangeOfferDetails, error)
 	ProvideDeliveryAddressesForExchangeTx(ctx context.Context, arg ProvideDeliveryAddressesForExchangeTxParams) (ProvideDeliveryAddressesForExchangeTxResult, error)
 	PayExchangeDeliveryFeeTx(ctx context.Context, arg PayExchangeDeliveryFeeTxParams) (PayExchangeDeliveryFeeTxResult, error)
 	CancelExchangeTx(ctx context.Context, arg CancelExchangeTxParams) (CancelExchangeTxResult, error)
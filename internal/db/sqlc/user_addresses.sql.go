@@ -64,6 +64,17 @@ func (q *Queries) CreateUserAddress(ctx context.Context, arg CreateUserAddressPa
 	return i, err
 }
 
+const deleteAllUserAddresses = `-- name: DeleteAllUserAddresses :exec
+DELETE
+FROM user_addresses
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteAllUserAddresses(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteAllUserAddresses, userID)
+	return err
+}
+
 const deleteUserAddress = `-- name: DeleteUserAddress :exec
 DELETE
 FROM user_addresses
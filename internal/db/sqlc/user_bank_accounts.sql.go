@@ -129,6 +129,19 @@ func (q *Queries) ListUserBankAccounts(ctx context.Context, userID string) ([]Us
 	return items, nil
 }
 
+const softDeleteAllUserBankAccounts = `-- name: SoftDeleteAllUserBankAccounts :exec
+UPDATE user_bank_accounts
+SET deleted_at = now(),
+    updated_at = now()
+WHERE user_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteAllUserBankAccounts(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, softDeleteAllUserBankAccounts, userID)
+	return err
+}
+
 const updateUserBankAccount = `-- name: UpdateUserBankAccount :one
 UPDATE user_bank_accounts
 SET deleted_at = COALESCE($3, deleted_at),
@@ -2,6 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 )
 
 func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -165,6 +168,12 @@ func (store *SQLStore) BecomeSellerTx(ctx context.Context, userID string) (User,
 		}
 		seller = userUpdated
 		
+		// Tăng token version để các access token cũ (mang role member) bị từ chối
+		seller.TokenVersion, err = qTx.IncrementUserTokenVersion(ctx, userUpdated.ID)
+		if err != nil {
+			return err
+		}
+		
 		err = qTx.CreateTrialSubscriptionForSeller(ctx, userUpdated.ID)
 		if err != nil {
 			return err
@@ -175,3 +184,185 @@ func (store *SQLStore) BecomeSellerTx(ctx context.Context, userID string) (User,
 	
 	return seller, err
 }
+
+type ResetUserPasswordTxParams struct {
+	UserID         string
+	HashedPassword string
+}
+
+// ResetUserPasswordTx đặt lại mật khẩu và tăng token version để vô hiệu hóa
+// tất cả access token đã cấp trước đó. Trả về token version mới.
+func (store *SQLStore) ResetUserPasswordTx(ctx context.Context, arg ResetUserPasswordTxParams) (int64, error) {
+	var tokenVersion int64
+	
+	err := store.ExecTx(ctx, func(qTx *Queries) error {
+		err := qTx.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+			HashedPassword: &arg.HashedPassword,
+			UserID:         arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		
+		tokenVersion, err = qTx.IncrementUserTokenVersion(ctx, arg.UserID)
+		return err
+	})
+	
+	return tokenVersion, err
+}
+
+// UserDeletionBlockedError được trả về khi tài khoản còn giao dịch chưa kết thúc hoặc ví còn số dư
+type UserDeletionBlockedError struct {
+	Blockers GetUserDeletionBlockersRow
+}
+
+func (e *UserDeletionBlockedError) Error() string {
+	return fmt.Sprintf("account cannot be deleted: %s", strings.Join(e.Blockers.Reasons(), ", "))
+}
+
+// Reasons liệt kê các lý do khiến tài khoản chưa thể bị xóa, rỗng nếu có thể xóa
+func (b GetUserDeletionBlockersRow) Reasons() []string {
+	var reasons []string
+	if b.OpenOrders > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d open order(s)", b.OpenOrders))
+	}
+	if b.OpenExchanges > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d open exchange(s), exchange post(s) or offer(s)", b.OpenExchanges))
+	}
+	if b.OpenAuctions > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d ongoing auction(s) or auction request(s)", b.OpenAuctions))
+	}
+	if b.PendingWithdrawals > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d pending withdrawal request(s)", b.PendingWithdrawals))
+	}
+	if b.WalletBalance != 0 {
+		reasons = append(reasons, fmt.Sprintf("wallet balance of %d must be withdrawn first", b.WalletBalance))
+	}
+	
+	return reasons
+}
+
+// DeleteUserTx xóa tài khoản người dùng: ẩn danh hóa thông tin cá nhân, xóa địa chỉ và tài khoản ngân hàng,
+// gỡ các gundam đang bán và tăng token version để vô hiệu hóa mọi access token.
+// Dữ liệu giao dịch (đơn hàng, bút toán ví) được giữ lại để đối soát.
+// Trả về *UserDeletionBlockedError nếu tài khoản còn giao dịch chưa kết thúc hoặc ví còn số dư.
+func (store *SQLStore) DeleteUserTx(ctx context.Context, userID string) (User, error) {
+	var result User
+	
+	err := store.ExecTx(ctx, func(qTx *Queries) error {
+		// Khóa ví để không có giao dịch mới làm thay đổi số dư trong lúc xóa tài khoản
+		_, err := qTx.GetWalletForUpdate(ctx, userID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return err
+		}
+		
+		blockers, err := qTx.GetUserDeletionBlockers(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		if len(blockers.Reasons()) > 0 {
+			return &UserDeletionBlockedError{Blockers: blockers}
+		}
+		
+		err = qTx.UnpublishAllUserGundams(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		err = qTx.DeleteAllUserAddresses(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		err = qTx.SoftDeleteAllUserBankAccounts(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		err = qTx.DeleteUserTOTPCredential(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		err = qTx.DeleteUserRecoveryCodes(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		result, err = qTx.AnonymizeUser(ctx, userID)
+		return err
+	})
+	
+	return result, err
+}
+
+type EnableUserTOTPTxParams struct {
+	UserID             string
+	Step               int64 // Bước thời gian của mã TOTP người dùng vừa nhập để xác nhận
+	RecoveryCodeHashes []string
+}
+
+// EnableUserTOTPTx bật xác thực hai lớp sau khi người dùng nhập đúng mã đầu tiên
+// và thay toàn bộ mã khôi phục cũ bằng bộ mã mới.
+func (store *SQLStore) EnableUserTOTPTx(ctx context.Context, arg EnableUserTOTPTxParams) (UserTotpCredential, error) {
+	var result UserTotpCredential
+	
+	err := store.ExecTx(ctx, func(qTx *Queries) error {
+		credential, err := qTx.EnableUserTOTP(ctx, arg.UserID)
+		if err != nil {
+			return err
+		}
+		result = credential
+		
+		// Đánh dấu mã vừa dùng để không thể dùng lại cho lần đăng nhập tiếp theo
+		_, err = qTx.UpdateUserTOTPLastUsedStep(ctx, UpdateUserTOTPLastUsedStepParams{
+			Step:   arg.Step,
+			UserID: arg.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		result.LastUsedStep = arg.Step
+		
+		return replaceUserRecoveryCodes(ctx, qTx, arg.UserID, arg.RecoveryCodeHashes)
+	})
+	
+	return result, err
+}
+
+type ReplaceUserRecoveryCodesTxParams struct {
+	UserID             string
+	RecoveryCodeHashes []string
+}
+
+// ReplaceUserRecoveryCodesTx vô hiệu hóa toàn bộ mã khôi phục cũ và lưu bộ mã mới
+func (store *SQLStore) ReplaceUserRecoveryCodesTx(ctx context.Context, arg ReplaceUserRecoveryCodesTxParams) error {
+	return store.ExecTx(ctx, func(qTx *Queries) error {
+		return replaceUserRecoveryCodes(ctx, qTx, arg.UserID, arg.RecoveryCodeHashes)
+	})
+}
+
+// DisableUserTOTPTx tắt xác thực hai lớp và xóa các mã khôi phục
+func (store *SQLStore) DisableUserTOTPTx(ctx context.Context, userID string) error {
+	return store.ExecTx(ctx, func(qTx *Queries) error {
+		err := qTx.DeleteUserTOTPCredential(ctx, userID)
+		if err != nil {
+			return err
+		}
+		
+		return qTx.DeleteUserRecoveryCodes(ctx, userID)
+	})
+}
+
+func replaceUserRecoveryCodes(ctx context.Context, qTx *Queries, userID string, codeHashes []string) error {
+	err := qTx.DeleteUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		return err
+	}
+	
+	return qTx.CreateUserRecoveryCodes(ctx, CreateUserRecoveryCodesParams{
+		UserID:     userID,
+		CodeHashes: codeHashes,
+	})
+}
@@ -9,10 +9,51 @@ import (
 	"context"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :one
+UPDATE users
+SET google_account_id     = NULL,
+    full_name             = 'Deleted user',
+    hashed_password       = NULL,
+    email                 = concat('deleted+', id, '@deleted.invalid'),
+    email_verified        = false,
+    phone_number          = NULL,
+    phone_number_verified = false,
+    avatar_url            = NULL,
+    token_version         = token_version + 1,
+    deleted_at            = now(),
+    updated_at            = now()
+WHERE id = $1
+  AND deleted_at IS NULL RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
+`
+
+// Xóa thông tin cá nhân và đánh dấu tài khoản đã bị xóa.
+// Email được thay bằng địa chỉ không tồn tại để giải phóng ràng buộc UNIQUE cho lần đăng ký sau.
+func (q *Queries) AnonymizeUser(ctx context.Context, id string) (User, error) {
+	row := q.db.QueryRow(ctx, anonymizeUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GoogleAccountID,
+		&i.FullName,
+		&i.HashedPassword,
+		&i.Email,
+		&i.EmailVerified,
+		&i.PhoneNumber,
+		&i.PhoneNumberVerified,
+		&i.Role,
+		&i.AvatarURL,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.TokenVersion,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (hashed_password, full_name, email, email_verified, phone_number, phone_number_verified, role,
                    avatar_url)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 `
 
 type CreateUserParams struct {
@@ -52,13 +93,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
 
 const createUserWithGoogleAccount = `-- name: CreateUserWithGoogleAccount :one
 INSERT INTO users (google_account_id, full_name, email, email_verified, avatar_url)
-VALUES ($1, $2, $3, $4, $5) RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+VALUES ($1, $2, $3, $4, $5) RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 `
 
 type CreateUserWithGoogleAccountParams struct {
@@ -92,14 +134,16 @@ func (q *Queries) CreateUserWithGoogleAccount(ctx context.Context, arg CreateUse
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 FROM users
 WHERE email = $1
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -119,14 +163,16 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 FROM users
 WHERE id = $1
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
@@ -146,14 +192,45 @@ func (q *Queries) GetUserByID(ctx context.Context, id string) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
+	)
+	return i, err
+}
+
+const getUserByIDIncludingDeleted = `-- name: GetUserByIDIncludingDeleted :one
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
+FROM users
+WHERE id = $1
+`
+
+// Dùng để hiển thị thông tin đối tác trong các giao dịch đã kết thúc, kể cả khi tài khoản đã bị xóa
+func (q *Queries) GetUserByIDIncludingDeleted(ctx context.Context, id string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDIncludingDeleted, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GoogleAccountID,
+		&i.FullName,
+		&i.HashedPassword,
+		&i.Email,
+		&i.EmailVerified,
+		&i.PhoneNumber,
+		&i.PhoneNumberVerified,
+		&i.Role,
+		&i.AvatarURL,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
 
 const getUserByPhoneNumber = `-- name: GetUserByPhoneNumber :one
-SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 FROM users
 WHERE phone_number = $1
+  AND deleted_at IS NULL
 `
 
 func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber *string) (User, error) {
@@ -173,10 +250,127 @@ func (q *Queries) GetUserByPhoneNumber(ctx context.Context, phoneNumber *string)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
+	)
+	return i, err
+}
+
+const getUserDeletionBlockers = `-- name: GetUserDeletionBlockers :one
+SELECT (SELECT COUNT(*)
+        FROM orders o
+        WHERE (o.buyer_id = $1 OR o.seller_id = $1)
+          AND o.status NOT IN ('completed', 'failed', 'canceled'))::bigint AS open_orders,
+       ((SELECT COUNT(*)
+         FROM exchanges e
+         WHERE (e.poster_id = $1 OR e.offerer_id = $1)
+           AND e.status NOT IN ('completed', 'canceled', 'failed')) +
+        (SELECT COUNT(*)
+         FROM exchange_posts ep
+         WHERE ep.user_id = $1
+           AND ep.status = 'open') +
+        (SELECT COUNT(*)
+         FROM exchange_offers eo
+         WHERE eo.offerer_id = $1))::bigint AS open_exchanges,
+       ((SELECT COUNT(*)
+         FROM auctions a
+         WHERE a.seller_id = $1
+           AND a.status IN ('scheduled', 'active', 'ended')) +
+        (SELECT COUNT(*)
+         FROM auction_participants ap
+                  JOIN auctions a ON ap.auction_id = a.id
+         WHERE ap.user_id = $1
+           AND a.status IN ('scheduled', 'active', 'ended')) +
+        (SELECT COUNT(*)
+         FROM auction_requests ar
+         WHERE ar.seller_id = $1
+           AND ar.status = 'pending'))::bigint AS open_auctions,
+       (SELECT COUNT(*)
+        FROM withdrawal_requests wr
+        WHERE wr.user_id = $1
+          AND wr.status IN ('pending', 'approved'))::bigint AS pending_withdrawals,
+       COALESCE((SELECT w.balance + w.non_withdrawable_amount
+                 FROM wallets w
+                 WHERE w.user_id = $1), 0)::bigint AS wallet_balance
+`
+
+type GetUserDeletionBlockersRow struct {
+	OpenOrders         int64 `json:"open_orders"`
+	OpenExchanges      int64 `json:"open_exchanges"`
+	OpenAuctions       int64 `json:"open_auctions"`
+	PendingWithdrawals int64 `json:"pending_withdrawals"`
+	WalletBalance      int64 `json:"wallet_balance"`
+}
+
+// Đếm các giao dịch chưa kết thúc khiến tài khoản chưa thể bị xóa
+func (q *Queries) GetUserDeletionBlockers(ctx context.Context, userID string) (GetUserDeletionBlockersRow, error) {
+	row := q.db.QueryRow(ctx, getUserDeletionBlockers, userID)
+	var i GetUserDeletionBlockersRow
+	err := row.Scan(
+		&i.OpenOrders,
+		&i.OpenExchanges,
+		&i.OpenAuctions,
+		&i.PendingWithdrawals,
+		&i.WalletBalance,
 	)
 	return i, err
 }
 
+const incrementUserTokenVersion = `-- name: IncrementUserTokenVersion :one
+UPDATE users
+SET token_version = token_version + 1,
+    updated_at    = now()
+WHERE id = $1 RETURNING token_version
+`
+
+func (q *Queries) IncrementUserTokenVersion(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRow(ctx, incrementUserTokenVersion, id)
+	var token_version int64
+	err := row.Scan(&token_version)
+	return token_version, err
+}
+
+const listUsersByIDs = `-- name: ListUsersByIDs :many
+SELECT id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
+FROM users
+WHERE id = ANY ($1::text[])
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) ListUsersByIDs(ctx context.Context, userIDs []string) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByIDs, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.GoogleAccountID,
+			&i.FullName,
+			&i.HashedPassword,
+			&i.Email,
+			&i.EmailVerified,
+			&i.PhoneNumber,
+			&i.PhoneNumberVerified,
+			&i.Role,
+			&i.AvatarURL,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.TokenVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET full_name             = COALESCE($1, full_name),
@@ -185,7 +379,7 @@ SET full_name             = COALESCE($1, full_name),
     phone_number_verified = COALESCE($4, phone_number_verified),
     role                  = COALESCE($5, role),
     updated_at            = now()
-WHERE id = $6 RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at
+WHERE id = $6 RETURNING id, google_account_id, full_name, hashed_password, email, email_verified, phone_number, phone_number_verified, role, avatar_url, created_at, updated_at, deleted_at, token_version
 `
 
 type UpdateUserParams struct {
@@ -221,6 +415,24 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.TokenVersion,
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET hashed_password = $1,
+    updated_at      = now()
+WHERE id = $2
+`
+
+type UpdateUserPasswordParams struct {
+	HashedPassword *string `json:"-"`
+	UserID         string  `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.HashedPassword, arg.UserID)
+	return err
+}
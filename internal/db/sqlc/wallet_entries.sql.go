@@ -125,16 +125,37 @@ SELECT id, wallet_id, reference_id, reference_type, entry_type, affected_field,
 FROM wallet_entries
 WHERE wallet_id = $1
   AND status = COALESCE($2, status)
-ORDER BY created_at DESC
+  AND ($3::bigint IS NULL OR
+       CASE $4::text
+           WHEN 'oldest' THEN (created_at, id) > ($5::timestamptz, $3::bigint)
+           ELSE (created_at, id) < ($5::timestamptz, $3::bigint)
+           END)
+ORDER BY CASE WHEN $4::text = 'oldest' THEN created_at END,
+         CASE WHEN $4::text = 'oldest' THEN id END,
+         created_at DESC,
+         id DESC
+LIMIT $6::int
 `
 
 type ListUserWalletEntriesParams struct {
-	WalletID string                `json:"wallet_id"`
-	Status   NullWalletEntryStatus `json:"status"`
+	WalletID    string                `json:"wallet_id"`
+	Status      NullWalletEntryStatus `json:"status"`
+	CursorID    *int64                `json:"cursor_id"`
+	Sort        string                `json:"sort"`
+	CursorValue *time.Time            `json:"cursor_value"`
+	Limit       *int32                `json:"limit"`
 }
 
+// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest. Khi limit là NULL thì trả về toàn bộ kết quả.
 func (q *Queries) ListUserWalletEntries(ctx context.Context, arg ListUserWalletEntriesParams) ([]WalletEntry, error) {
-	rows, err := q.db.Query(ctx, listUserWalletEntries, arg.WalletID, arg.Status)
+	rows, err := q.db.Query(ctx, listUserWalletEntries,
+		arg.WalletID,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -165,12 +165,21 @@ func (q *Queries) ListUserWithdrawalRequests(ctx context.Context, arg ListUserWi
 }
 
 const listWithdrawalRequests = `-- name: ListWithdrawalRequests :many
-SELECT wr.id, wr.user_id, wr.bank_account_id, wr.amount, wr.status, wr.processed_by, wr.processed_at, wr.rejected_reason, wr.transaction_reference, wr.wallet_entry_id, wr.created_at, wr.updated_at, wr.completed_at,
-       uba.id, uba.user_id, uba.account_name, uba.account_number, uba.bank_code, uba.bank_name, uba.bank_short_name, uba.created_at, uba.updated_at, uba.deleted_at
+SELECT sqlc.embed(wr),
+       sqlc.embed(uba)
 FROM withdrawal_requests wr
          LEFT JOIN user_bank_accounts uba ON wr.bank_account_id = uba.id
 WHERE wr.status = COALESCE($1, wr.status)
-ORDER BY wr.created_at DESC
+  AND ($2::uuid IS NULL OR
+       CASE $3::text
+           WHEN 'oldest' THEN (wr.created_at, wr.id) > ($4::timestamptz, $2::uuid)
+           ELSE (wr.created_at, wr.id) < ($4::timestamptz, $2::uuid)
+           END)
+ORDER BY CASE WHEN $3::text = 'oldest' THEN wr.created_at END,
+         CASE WHEN $3::text = 'oldest' THEN wr.id END,
+         wr.created_at DESC,
+         wr.id DESC
+LIMIT $5::int
 `
 
 type ListWithdrawalRequestsRow struct {
@@ -178,8 +187,23 @@ type ListWithdrawalRequestsRow struct {
 	UserBankAccount   UserBankAccount   `json:"user_bank_account"`
 }
 
-func (q *Queries) ListWithdrawalRequests(ctx context.Context, status NullWithdrawalRequestStatus) ([]ListWithdrawalRequestsRow, error) {
-	rows, err := q.db.Query(ctx, listWithdrawalRequests, status)
+type ListWithdrawalRequestsParams struct {
+	Status      NullWithdrawalRequestStatus `json:"status"`
+	CursorID    *uuid.UUID                  `json:"cursor_id"`
+	Sort        string                      `json:"sort"`
+	CursorValue *time.Time                  `json:"cursor_value"`
+	Limit       *int32                      `json:"limit"`
+}
+
+// Phân trang keyset theo (created_at, id), sort là newest hoặc oldest.
+func (q *Queries) ListWithdrawalRequests(ctx context.Context, arg ListWithdrawalRequestsParams) ([]ListWithdrawalRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listWithdrawalRequests,
+		arg.Status,
+		arg.CursorID,
+		arg.Sort,
+		arg.CursorValue,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
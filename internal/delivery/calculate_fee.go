@@ -0,0 +1,39 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CalculateFee tính phí vận chuyển của một kiện hàng theo quận/huyện và phường/xã GHN của người gửi và người nhận.
+func (s *GHNService) CalculateFee(ctx context.Context, arg FeeRequest) (*CalculateFeeResponse, error) {
+	feeData := map[string]interface{}{
+		"from_district_id": arg.FromDistrictID,
+		"from_ward_code":   arg.FromWardCode,
+		"to_district_id":   arg.ToDistrictID,
+		"to_ward_code":     arg.ToWardCode,
+		"weight":           arg.Weight,
+		"length":           DefaultPackageLength,
+		"width":            DefaultPackageWidth,
+		"height":           DefaultPackageHeight,
+		"insurance_value":  arg.InsuranceValue,
+	}
+	if arg.ServiceID != 0 {
+		feeData["service_id"] = arg.ServiceID
+	} else {
+		feeData["service_type_id"] = DefaultServiceTypeID
+	}
+	
+	var response CalculateFeeResponse
+	if err := s.post(ctx, "/shipping-order/fee", feeData, &response); err != nil {
+		return nil, err
+	}
+	
+	if response.Code != int64(http.StatusOK) {
+		return nil, fmt.Errorf("GHN API returned business error: code=%d, message=%s",
+			response.Code, response.Message)
+	}
+	
+	return &response, nil
+}
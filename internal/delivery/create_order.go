@@ -42,11 +42,11 @@ func (s *GHNService) CreateOrder(ctx context.Context, arg CreateOrderRequest) (*
 		"content":              "Mô hình Gundam",
 		"weight":               totalWeight,
 		// Sử dụng giá trị mặc định cho toàn bộ đơn hàng
-		"length":          int64(40), // cm
-		"width":           int64(30),
-		"height":          int64(20),
-		"service_type_id": int64(2), // Chọn loại dịch vụ "Hàng nhẹ" cho đơn giản
-		"payment_type_id": int64(2), // Người mua thanh toán phí dịch vụ
+		"length":          DefaultPackageLength,
+		"width":           DefaultPackageWidth,
+		"height":          DefaultPackageHeight,
+		"service_type_id": DefaultServiceTypeID, // Chọn loại dịch vụ "Hàng nhẹ" cho đơn giản
+		"payment_type_id": int64(2),             // Người mua thanh toán phí dịch vụ
 		"required_note":   "CHOXEMHANGKHONGTHU",
 		"insurance_value": int64(0), // Không thêm phí bảo hiểm cho môi trường test
 		// TODO: Thêm các thông tin khác nếu cần thiết
@@ -1,7 +1,12 @@
 package delivery
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 )
 
 const (
@@ -9,9 +14,20 @@ const (
 	GHNBaseURL = "https://dev-online-gateway.ghn.vn/shiip/public-api/v2"
 )
 
+// Kích thước và loại dịch vụ mặc định cho toàn bộ kiện hàng, dùng chung khi tính phí và khi tạo đơn vận chuyển
+const (
+	DefaultPackageLength = int64(40) // cm
+	DefaultPackageWidth  = int64(30)
+	DefaultPackageHeight = int64(20)
+	DefaultServiceTypeID = int64(2) // Loại dịch vụ "Hàng nhẹ"
+)
+
 type IDeliveryProvider interface {
 	CreateOrder(ctx context.Context, request CreateOrderRequest) (*CreateOrderResponse, error)
 	GetOrderDetails(ctx context.Context, orderCode string) (*GetOrderDetailsResponse, error)
+	CalculateFee(ctx context.Context, request FeeRequest) (*CalculateFeeResponse, error)
+	GetLeadTime(ctx context.Context, request LeadTimeRequest) (*GetLeadTimeResponse, error)
+	ListServices(ctx context.Context, fromDistrictID, toDistrictID int64) (*ListServicesResponse, error)
 }
 
 type GHNService struct {
@@ -25,3 +41,42 @@ func NewGHNService(token, shopID string) IDeliveryProvider {
 		ShopID: shopID,
 	}
 }
+
+// post gửi request JSON tới GHN API và parse response vào out.
+func (s *GHNService) post(ctx context.Context, path string, payload any, out any) error {
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GHN request body: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, GHNBaseURL+path, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create GHN request: %w", err)
+	}
+	
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", s.Token)
+	req.Header.Set("ShopId", s.ShopID)
+	
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GHN API returned non-OK status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse GHN response: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,30 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetLeadTime tính thời gian giao hàng dự kiến của một dịch vụ GHN giữa hai địa chỉ.
+func (s *GHNService) GetLeadTime(ctx context.Context, arg LeadTimeRequest) (*GetLeadTimeResponse, error) {
+	leadTimeData := map[string]interface{}{
+		"from_district_id": arg.FromDistrictID,
+		"from_ward_code":   arg.FromWardCode,
+		"to_district_id":   arg.ToDistrictID,
+		"to_ward_code":     arg.ToWardCode,
+		"service_id":       arg.ServiceID,
+	}
+	
+	var response GetLeadTimeResponse
+	if err := s.post(ctx, "/shipping-order/leadtime", leadTimeData, &response); err != nil {
+		return nil, err
+	}
+	
+	if response.Code != int64(http.StatusOK) {
+		return nil, fmt.Errorf("GHN API returned business error: code=%d, message=%s",
+			response.Code, response.Message)
+	}
+	
+	return &response, nil
+}
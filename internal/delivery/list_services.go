@@ -0,0 +1,34 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ListServices liệt kê các dịch vụ GHN khả dụng giữa quận/huyện của người gửi và người nhận.
+func (s *GHNService) ListServices(ctx context.Context, fromDistrictID, toDistrictID int64) (*ListServicesResponse, error) {
+	shopID, err := strconv.ParseInt(s.ShopID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GHN shop ID %s: %w", s.ShopID, err)
+	}
+	
+	servicesData := map[string]interface{}{
+		"shop_id":       shopID,
+		"from_district": fromDistrictID,
+		"to_district":   toDistrictID,
+	}
+	
+	var response ListServicesResponse
+	if err = s.post(ctx, "/shipping-order/available-services", servicesData, &response); err != nil {
+		return nil, err
+	}
+	
+	if response.Code != int64(http.StatusOK) {
+		return nil, fmt.Errorf("GHN API returned business error: code=%d, message=%s",
+			response.Code, response.Message)
+	}
+	
+	return &response, nil
+}
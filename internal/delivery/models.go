@@ -76,3 +76,62 @@ type GetOrderDetailsResponse struct {
 		LeadTime time.Time `json:"lead_time"` // Thời gian dự kiến giao hàng sớm nhất
 	} `json:"data"`
 }
+
+// FeeRequest là thông tin của một kiện hàng dùng để tính phí vận chuyển giữa hai địa chỉ GHN.
+type FeeRequest struct {
+	FromDistrictID int64
+	FromWardCode   string
+	ToDistrictID   int64
+	ToWardCode     string
+	ServiceID      int64 // 0: dùng loại dịch vụ mặc định (DefaultServiceTypeID)
+	Weight         int64 // Tổng khối lượng (gram)
+	InsuranceValue int64 // Giá trị khai báo để tính phí bảo hiểm (VND)
+}
+
+type CalculateFeeResponse struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Total          int64 `json:"total"` // Tổng phí vận chuyển
+		ServiceFee     int64 `json:"service_fee"`
+		InsuranceFee   int64 `json:"insurance_fee"`
+		PickStationFee int64 `json:"pick_station_fee"`
+		CouponValue    int64 `json:"coupon_value"`
+		R2SFee         int64 `json:"r2s_fee"`
+	} `json:"data"`
+}
+
+// LeadTimeRequest là thông tin dùng để tính thời gian giao hàng dự kiến giữa hai địa chỉ GHN.
+type LeadTimeRequest struct {
+	FromDistrictID int64
+	FromWardCode   string
+	ToDistrictID   int64
+	ToWardCode     string
+	ServiceID      int64
+}
+
+type GetLeadTimeResponse struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		LeadTime  int64 `json:"leadtime"`   // Thời gian giao hàng dự kiến (unix timestamp)
+		OrderDate int64 `json:"order_date"` // Thời điểm tạo đơn dùng để tính (unix timestamp)
+	} `json:"data"`
+}
+
+// ExpectedDeliveryTime trả về thời gian giao hàng dự kiến.
+func (r *GetLeadTimeResponse) ExpectedDeliveryTime() time.Time {
+	return time.Unix(r.Data.LeadTime, 0)
+}
+
+type ServiceInfo struct {
+	ServiceID     int64  `json:"service_id"`
+	ShortName     string `json:"short_name"`
+	ServiceTypeID int64  `json:"service_type_id"`
+}
+
+type ListServicesResponse struct {
+	Code    int64         `json:"code"`
+	Message string        `json:"message"`
+	Data    []ServiceInfo `json:"data"`
+}
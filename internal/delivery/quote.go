@@ -0,0 +1,93 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Location là quận/huyện và phường/xã GHN của một địa chỉ, đủ để đơn vị vận chuyển báo giá.
+type Location struct {
+	DistrictID int64
+	WardCode   string
+}
+
+// Quote là phí vận chuyển và thời gian giao hàng dự kiến do đơn vị vận chuyển báo cho một kiện hàng.
+// Các trường không được export, Quote chỉ được tạo bởi QuoteDelivery nên luôn là giá do đơn vị vận chuyển báo,
+// không phải giá trị do client gửi lên.
+type Quote struct {
+	serviceID            int64
+	deliveryFee          int64
+	expectedDeliveryTime time.Time
+}
+
+// ServiceID là ID của dịch vụ GHN dùng để báo giá.
+func (q Quote) ServiceID() int64 {
+	return q.serviceID
+}
+
+// DeliveryFee là phí vận chuyển (VND).
+func (q Quote) DeliveryFee() int64 {
+	return q.deliveryFee
+}
+
+// ExpectedDeliveryTime là thời gian giao hàng dự kiến.
+func (q Quote) ExpectedDeliveryTime() time.Time {
+	return q.expectedDeliveryTime
+}
+
+// IsZero cho biết Quote chưa được báo giá bởi QuoteDelivery.
+func (q Quote) IsZero() bool {
+	return q == Quote{}
+}
+
+// QuoteDelivery lấy phí vận chuyển và thời gian giao hàng dự kiến từ đơn vị vận chuyển cho một kiện hàng
+// nặng weight gram được gửi từ địa chỉ from tới địa chỉ to, dùng dịch vụ "Hàng nhẹ" giống như khi tạo đơn vận chuyển.
+func QuoteDelivery(ctx context.Context, provider IDeliveryProvider, from Location, to Location, weight int64) (Quote, error) {
+	var quote Quote
+	
+	services, err := provider.ListServices(ctx, from.DistrictID, to.DistrictID)
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to list delivery services: %w", err)
+	}
+	if len(services.Data) == 0 {
+		return Quote{}, errors.New("no delivery service is available between the two addresses")
+	}
+	
+	quote.serviceID = services.Data[0].ServiceID
+	for _, service := range services.Data {
+		if service.ServiceTypeID == DefaultServiceTypeID {
+			quote.serviceID = service.ServiceID
+			break
+		}
+	}
+	
+	fee, err := provider.CalculateFee(ctx, FeeRequest{
+		FromDistrictID: from.DistrictID,
+		FromWardCode:   from.WardCode,
+		ToDistrictID:   to.DistrictID,
+		ToWardCode:     to.WardCode,
+		ServiceID:      quote.serviceID,
+		Weight:         weight,
+		InsuranceValue: 0, // Đơn vận chuyển được tạo không kèm phí bảo hiểm
+	})
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to calculate delivery fee: %w", err)
+	}
+	quote.deliveryFee = fee.Data.Total
+	
+	leadTime, err := provider.GetLeadTime(ctx, LeadTimeRequest{
+		FromDistrictID: from.DistrictID,
+		FromWardCode:   from.WardCode,
+		ToDistrictID:   to.DistrictID,
+		ToWardCode:     to.WardCode,
+		ServiceID:      quote.serviceID,
+	})
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to get delivery lead time: %w", err)
+	}
+	quote.expectedDeliveryTime = leadTime.ExpectedDeliveryTime()
+	
+	return quote, nil
+}
@@ -0,0 +1,88 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	httpSenderTimeout = 10 * time.Second
+)
+
+// HTTPSender gửi email qua một dịch vụ có HTTP API (SendGrid, Mailgun, Resend qua proxy...).
+// Request được gửi dạng JSON {"from", "to", "subject", "text", "html"} kèm header Authorization: Bearer <api key>,
+// dịch vụ trả về status 2xx khi đã nhận email.
+type HTTPSender struct {
+	url    string
+	apiKey string
+	from   Address
+	client *http.Client
+}
+
+// NewHTTPSender tạo một instance mới của HTTPSender
+func NewHTTPSender(url, apiKey string, from Address) *HTTPSender {
+	return &HTTPSender{
+		url:    url,
+		apiKey: apiKey,
+		from:   from,
+		client: &http.Client{Timeout: httpSenderTimeout},
+	}
+}
+
+func (s *HTTPSender) Name() string {
+	return ProviderHTTP
+}
+
+type httpSendRequest struct {
+	From    Address   `json:"from"`
+	To      []Address `json:"to"`
+	Subject string    `json:"subject"`
+	Text    string    `json:"text"`
+	HTML    string    `json:"html,omitempty"`
+}
+
+func (s *HTTPSender) Send(ctx context.Context, msg Message) error {
+	to := make([]Address, 0, len(msg.To))
+	for _, email := range msg.To {
+		to = append(to, Address{Email: email})
+	}
+	
+	body, err := json.Marshal(httpSendRequest{
+		From:    s.from,
+		To:      to,
+		Subject: msg.Subject,
+		Text:    msg.Text,
+		HTML:    msg.HTML,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal email request: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create email request: %w", err)
+	}
+	
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+	
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email request: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("email API returned status %d: %s", resp.StatusCode, respBody)
+	}
+	
+	return nil
+}
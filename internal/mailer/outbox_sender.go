@@ -0,0 +1,81 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// Số email tối đa được giữ trong bộ nhớ, email cũ nhất bị bỏ khi vượt quá
+	outboxCapacity = 100
+)
+
+// OutboxSender không gửi email thật mà giữ email trong bộ nhớ và ghi ra file hoặc stdout.
+// Dùng khi chạy ở local (không cần tài khoản SMTP) và trong test để kiểm tra email đã gửi.
+type OutboxSender struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	messages []Message
+}
+
+// NewOutboxSender tạo một instance mới của OutboxSender.
+// Nếu path rỗng hoặc là "-", email được ghi ra stdout.
+func NewOutboxSender(path string) (*OutboxSender, error) {
+	if path == "" || path == "-" {
+		return &OutboxSender{writer: os.Stdout}, nil
+	}
+	
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open email outbox file %s: %w", path, err)
+	}
+	
+	return &OutboxSender{writer: file}, nil
+}
+
+// NewMemoryOutbox tạo OutboxSender chỉ giữ email trong bộ nhớ, không ghi ra đâu cả
+func NewMemoryOutbox() *OutboxSender {
+	return &OutboxSender{writer: io.Discard}
+}
+
+func (s *OutboxSender) Name() string {
+	return ProviderOutbox
+}
+
+func (s *OutboxSender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.messages = append(s.messages, msg)
+	if len(s.messages) > outboxCapacity {
+		s.messages = s.messages[len(s.messages)-outboxCapacity:]
+	}
+	
+	_, err := fmt.Fprintf(s.writer, "[%s] Email to %v: %s\n%s\n\n", time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Text)
+	if err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	
+	return nil
+}
+
+// Messages trả về các email đã gửi, email cũ nhất đứng đầu
+func (s *OutboxSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	return slices.Clone(s.messages)
+}
+
+// Reset xóa các email đang được giữ trong bộ nhớ
+func (s *OutboxSender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.messages = nil
+}
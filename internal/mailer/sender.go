@@ -1,11 +1,65 @@
 package mailer
 
+import (
+	"context"
+	"errors"
+	"strings"
+	
+	"github.com/katatrina/gundam-BE/internal/util"
+)
+
+// Các provider gửi email được hỗ trợ, cấu hình bằng EMAIL_PROVIDER
 const (
-	senderEmailName    = "Mecha World"
-	senderEmailAddress = "mechaworldcapstone@gmail.com"
+	ProviderSMTP   = "smtp"   // SMTP server bất kỳ (Gmail, SES, Mailgun, MailHog...)
+	ProviderHTTP   = "http"   // Dịch vụ gửi email có HTTP API
+	ProviderOutbox = "outbox" // Giữ email trong bộ nhớ và ghi ra file/stdout, dùng cho test và khi chạy ở local
 )
 
-type EmailHeader struct {
-	Subject string
-	To      []string
+var (
+	ErrUnknownProvider = errors.New("unknown email provider")
+)
+
+// Address là địa chỉ email kèm tên hiển thị
+type Address struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+// Message là một email đã được render, cần gửi đến các địa chỉ To
+type Message struct {
+	To []string
+	Email
+}
+
+// EmailSender gửi email qua một provider cụ thể.
+// Các phần còn lại của ứng dụng chỉ phụ thuộc vào interface này, không phụ thuộc vào provider.
+type EmailSender interface {
+	// Name trả về tên của provider, dùng cho log
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewSenderFromConfig tạo EmailSender theo provider được cấu hình.
+// Không provider nào kết nối đến server khi khởi tạo, nên ứng dụng vẫn khởi động được khi provider tạm thời không truy cập được.
+func NewSenderFromConfig(config *util.Config) (EmailSender, error) {
+	from := Address{
+		Name:  config.EmailFromName,
+		Email: config.EmailFromAddress,
+	}
+	
+	switch strings.ToLower(config.EmailProvider) {
+	case ProviderSMTP:
+		return NewSMTPSender(SMTPConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+		}, from)
+	case ProviderHTTP:
+		return NewHTTPSender(config.EmailAPIURL, config.EmailAPIKey, from), nil
+	case ProviderOutbox, "":
+		return NewOutboxSender(config.EmailOutboxPath)
+	default:
+		return nil, ErrUnknownProvider
+	}
 }
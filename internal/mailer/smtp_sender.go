@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	
+	"github.com/wneessen/go-mail"
+)
+
+// SMTPConfig là thông tin kết nối đến SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Bỏ trống nếu SMTP server không yêu cầu xác thực, ví dụ: MailHog
+	Password string
+}
+
+// SMTPSender gửi email qua một SMTP server bất kỳ.
+// Kết nối chỉ được mở khi gửi email, nên SMTP server không cần truy cập được lúc ứng dụng khởi động.
+type SMTPSender struct {
+	client *mail.Client
+	from   Address
+}
+
+// NewSMTPSender tạo một instance mới của SMTPSender
+func NewSMTPSender(config SMTPConfig, from Address) (*SMTPSender, error) {
+	opts := []mail.Option{
+		mail.WithPort(config.Port),
+		mail.WithTLSPolicy(mail.TLSOpportunistic),
+	}
+	if config.Username != "" {
+		opts = append(opts,
+			mail.WithSMTPAuth(mail.SMTPAuthPlain),
+			mail.WithUsername(config.Username),
+			mail.WithPassword(config.Password),
+		)
+	}
+	
+	client, err := mail.NewClient(config.Host, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+	
+	return &SMTPSender{
+		client: client,
+		from:   from,
+	}, nil
+}
+
+func (s *SMTPSender) Name() string {
+	return ProviderSMTP
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	m := mail.NewMsg()
+	
+	if err := m.FromFormat(s.from.Name, s.from.Email); err != nil {
+		return fmt.Errorf("failed to set From address: %w", err)
+	}
+	
+	if err := m.To(msg.To...); err != nil {
+		return fmt.Errorf("failed to set To address: %w", err)
+	}
+	
+	m.Subject(msg.Subject)
+	m.SetBodyString(mail.TypeTextPlain, msg.Text)
+	if msg.HTML != "" {
+		m.AddAlternativeString(mail.TypeTextHTML, msg.HTML)
+	}
+	
+	if err := s.client.DialAndSendWithContext(ctx, m); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,197 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	
+	"github.com/katatrina/gundam-BE/internal/util"
+)
+
+// Locale là ngôn ngữ của email
+type Locale string
+
+const (
+	LocaleVietnamese Locale = "vi"
+	LocaleEnglish    Locale = "en"
+	
+	DefaultLocale = LocaleVietnamese
+)
+
+var supportedLocales = []Locale{LocaleVietnamese, LocaleEnglish}
+
+// TemplateName là tên của một email mẫu.
+// Mỗi email mẫu gồm templates/<locale>/<name>.txt (định nghĩa "subject" và "content" dạng văn bản thuần)
+// và templates/<locale>/<name>.html (định nghĩa "content" dạng HTML), được lồng vào layout chung.
+type TemplateName string
+
+const (
+	TemplateOrderConfirmation   TemplateName = "order_confirmation"
+	TemplateOrderShipped        TemplateName = "order_shipped"
+	TemplateAuctionWon          TemplateName = "auction_won"
+	TemplatePaymentReminder     TemplateName = "payment_reminder"
+	TemplateWithdrawalCompleted TemplateName = "withdrawal_completed"
+	TemplateExchangeAccepted    TemplateName = "exchange_accepted"
+	TemplateEmailVerification   TemplateName = "email_verification"
+	TemplatePasswordReset       TemplateName = "password_reset"
+)
+
+var templateNames = []TemplateName{
+	TemplateOrderConfirmation,
+	TemplateOrderShipped,
+	TemplateAuctionWon,
+	TemplatePaymentReminder,
+	TemplateWithdrawalCompleted,
+	TemplateExchangeAccepted,
+	TemplateEmailVerification,
+	TemplatePasswordReset,
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+// Email là email đã được render, gồm tiêu đề, nội dung HTML và nội dung văn bản thuần thay thế
+type Email struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// templateContext là dữ liệu truyền vào layout và email mẫu
+type templateContext struct {
+	Locale        Locale
+	SenderName    string
+	RecipientName string
+	Data          any // Dữ liệu riêng của từng email mẫu, ví dụ: OrderConfirmationData
+}
+
+type templateKey struct {
+	name   TemplateName
+	locale Locale
+}
+
+// TemplateEngine render các email mẫu theo ngôn ngữ, dựa trên html/template và text/template
+type TemplateEngine struct {
+	senderName string // Tên hiển thị của người gửi, dùng trong layout
+	html       map[templateKey]*htmltemplate.Template
+	text       map[templateKey]*texttemplate.Template
+}
+
+// Thời gian trong email luôn hiển thị theo giờ Việt Nam, không phụ thuộc múi giờ của server
+var vietnamLocation = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		return time.FixedZone("Asia/Ho_Chi_Minh", 7*60*60)
+	}
+	return loc
+}()
+
+var templateFuncs = map[string]any{
+	"vnd": util.FormatVND,
+	"datetime": func(t time.Time) string {
+		return t.In(vietnamLocation).Format("15:04 02/01/2006")
+	},
+}
+
+// NewTemplateEngine parse toàn bộ email mẫu được nhúng trong binary.
+// Trả về lỗi nếu thiếu email mẫu cho một ngôn ngữ được hỗ trợ.
+func NewTemplateEngine(senderName string) (*TemplateEngine, error) {
+	htmlLayout, err := htmltemplate.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, "templates/layout.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML layout: %w", err)
+	}
+	
+	textLayout, err := texttemplate.New("layout.txt").Funcs(templateFuncs).ParseFS(templateFS, "templates/layout.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text layout: %w", err)
+	}
+	
+	engine := &TemplateEngine{
+		senderName: senderName,
+		html:       make(map[templateKey]*htmltemplate.Template),
+		text:       make(map[templateKey]*texttemplate.Template),
+	}
+	
+	for _, locale := range supportedLocales {
+		for _, name := range templateNames {
+			key := templateKey{name: name, locale: locale}
+			path := fmt.Sprintf("templates/%s/%s", locale, name)
+			
+			htmlTemplate, err := htmltemplate.Must(htmlLayout.Clone()).ParseFS(templateFS, path+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s.html: %w", path, err)
+			}
+			
+			textTemplate, err := texttemplate.Must(textLayout.Clone()).ParseFS(templateFS, path+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse email template %s.txt: %w", path, err)
+			}
+			
+			engine.html[key] = htmlTemplate
+			engine.text[key] = textTemplate
+		}
+	}
+	
+	return engine, nil
+}
+
+// Render render email mẫu name theo ngôn ngữ locale.
+// Nếu locale không được hỗ trợ, email được render bằng DefaultLocale.
+func (engine *TemplateEngine) Render(name TemplateName, locale Locale, recipientName string, data any) (Email, error) {
+	key := templateKey{name: name, locale: locale}
+	if _, ok := engine.html[key]; !ok {
+		key.locale = DefaultLocale
+	}
+	
+	htmlTemplate, ok := engine.html[key]
+	if !ok {
+		return Email{}, fmt.Errorf("email template %q not found", name)
+	}
+	textTemplate := engine.text[key]
+	
+	ctx := templateContext{
+		Locale:        key.locale,
+		SenderName:    engine.senderName,
+		RecipientName: recipientName,
+		Data:          data,
+	}
+	
+	var subject, text, html bytes.Buffer
+	if err := textTemplate.ExecuteTemplate(&subject, "subject", ctx); err != nil {
+		return Email{}, fmt.Errorf("failed to render subject of email template %q: %w", name, err)
+	}
+	
+	if err := textTemplate.ExecuteTemplate(&text, "layout.txt", ctx); err != nil {
+		return Email{}, fmt.Errorf("failed to render text of email template %q: %w", name, err)
+	}
+	
+	if err := htmlTemplate.ExecuteTemplate(&html, "layout.html", ctx); err != nil {
+		return Email{}, fmt.Errorf("failed to render HTML of email template %q: %w", name, err)
+	}
+	
+	return Email{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// LocaleFromAcceptLanguage chọn ngôn ngữ email từ header Accept-Language của request
+func LocaleFromAcceptLanguage(header string) Locale {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		
+		for _, locale := range supportedLocales {
+			if Locale(language) == locale {
+				return locale
+			}
+		}
+	}
+	
+	return DefaultLocale
+}
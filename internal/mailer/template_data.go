@@ -0,0 +1,106 @@
+package mailer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderConfirmationData là dữ liệu của email xác nhận đơn hàng gửi cho người mua
+type OrderConfirmationData struct {
+	OrderCode            string          `json:"order_code"`
+	Items                []OrderItemData `json:"items"`
+	ItemsSubtotal        int64           `json:"items_subtotal"`
+	DeliveryFee          int64           `json:"delivery_fee"`
+	TotalAmount          int64           `json:"total_amount"`
+	ExpectedDeliveryTime time.Time       `json:"expected_delivery_time"`
+}
+
+type OrderItemData struct {
+	Name     string `json:"name"`
+	Quantity int64  `json:"quantity"`
+	Price    int64  `json:"price"`
+}
+
+// OrderShippedData là dữ liệu của email thông báo đơn hàng đã được bàn giao cho đơn vị vận chuyển
+type OrderShippedData struct {
+	OrderCode            string    `json:"order_code"`
+	TrackingCode         string    `json:"tracking_code"`
+	ExpectedDeliveryTime time.Time `json:"expected_delivery_time"`
+}
+
+// AuctionWonData là dữ liệu của email chúc mừng người thắng phiên đấu giá
+type AuctionWonData struct {
+	GundamName      string    `json:"gundam_name"`
+	FinalPrice      int64     `json:"final_price"`
+	PaymentDeadline time.Time `json:"payment_deadline"`
+}
+
+// PaymentReminderData là dữ liệu của email nhắc người thắng đấu giá thanh toán
+type PaymentReminderData struct {
+	GundamName       string    `json:"gundam_name"`
+	RemainingHours   int       `json:"remaining_hours"`
+	ReminderSequence int       `json:"reminder_sequence"`
+	PaymentDeadline  time.Time `json:"payment_deadline"`
+}
+
+// WithdrawalCompletedData là dữ liệu của email thông báo yêu cầu rút tiền đã hoàn tất
+type WithdrawalCompletedData struct {
+	Amount               int64     `json:"amount"`
+	BankName             string    `json:"bank_name"`
+	AccountNumber        string    `json:"account_number"` // Đã được ẩn bớt, chỉ giữ 4 số cuối
+	TransactionReference string    `json:"transaction_reference"`
+	CompletedAt          time.Time `json:"completed_at"`
+}
+
+// MaskAccountNumber ẩn số tài khoản ngân hàng trước khi đưa vào email, chỉ giữ lại 4 số cuối
+func MaskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	
+	return strings.Repeat("*", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
+
+// ExchangeAcceptedData là dữ liệu của email thông báo đề xuất trao đổi đã được chấp nhận
+type ExchangeAcceptedData struct {
+	PostExcerpt        string `json:"post_excerpt"`
+	CompensationAmount int64  `json:"compensation_amount"` // 0 nếu không có tiền bù
+	PaysCompensation   bool   `json:"pays_compensation"`   // Người nhận email là người trả tiền bù
+}
+
+// OTPData là dữ liệu của email gửi mã xác thực email hoặc mã đặt lại mật khẩu
+type OTPData struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DecodeTemplateData giải mã dữ liệu JSON (ví dụ: từ payload của task) thành kiểu dữ liệu tương ứng với email mẫu
+func DecodeTemplateData(name TemplateName, raw json.RawMessage) (any, error) {
+	var data any
+	switch name {
+	case TemplateOrderConfirmation:
+		data = new(OrderConfirmationData)
+	case TemplateOrderShipped:
+		data = new(OrderShippedData)
+	case TemplateAuctionWon:
+		data = new(AuctionWonData)
+	case TemplatePaymentReminder:
+		data = new(PaymentReminderData)
+	case TemplateWithdrawalCompleted:
+		data = new(WithdrawalCompletedData)
+	case TemplateExchangeAccepted:
+		data = new(ExchangeAcceptedData)
+	case TemplateEmailVerification, TemplatePasswordReset:
+		data = new(OTPData)
+	default:
+		return nil, fmt.Errorf("email template %q not found", name)
+	}
+	
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, fmt.Errorf("failed to decode data of email template %q: %w", name, err)
+	}
+	
+	return data, nil
+}
@@ -150,8 +150,9 @@ func (t *OrderTracker) autoCompleteRegularOrder(ctx context.Context, order db.Or
 				return err
 			}
 			
-			if gundam.Status != db.GundamStatusProcessing {
-				return fmt.Errorf("gundam ID %d is not in processing status", *item.GundamID)
+			// Gundam phải đang được giữ cho đơn hàng: đã hết hàng ("processing") hoặc còn số lượng đang được giữ
+			if gundam.Status != db.GundamStatusProcessing && gundam.ReservedQuantity < item.Quantity {
+				return fmt.Errorf("gundam ID %d is not reserved for order item %d", *item.GundamID, item.ID)
 			}
 		}
 	}
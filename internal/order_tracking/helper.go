@@ -8,6 +8,7 @@ import (
 	
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/rs/zerolog/log"
@@ -297,6 +298,20 @@ func (t *OrderTracker) handlePickingToDelivering(ctx context.Context, currentOrd
 		log.Err(err).Msg("failed to send notification to buyer")
 	}
 	
+	// Gửi email thông báo đơn hàng đang được giao cho người nhận
+	var trackingCode string
+	if currentOrder.DeliveryTrackingCode != nil {
+		trackingCode = *currentOrder.DeliveryTrackingCode
+	}
+	err = worker.DistributeEmail(ctx, t.taskDistributor, currentOrder.BuyerID, mailer.TemplateOrderShipped, mailer.DefaultLocale, mailer.OrderShippedData{
+		OrderCode:            currentOrder.OrderCode,
+		TrackingCode:         trackingCode,
+		ExpectedDeliveryTime: currentOrder.ExpectedDeliveryTime,
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("order_code", currentOrder.OrderCode).Msg("failed to send order shipped email to buyer")
+	}
+	
 	// Gửi thông báo cho người gửi
 	err = t.taskDistributor.DistributeTaskSendNotification(ctx, &worker.PayloadSendNotification{
 		RecipientID: currentOrder.SellerID,
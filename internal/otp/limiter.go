@@ -0,0 +1,152 @@
+package otp
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/katatrina/gundam-BE/internal/util"
+)
+
+// Mã lỗi trả về cho client để phân biệt các trường hợp OTP bị từ chối
+const (
+	ErrCodeInvalidFormat   = "OTP_INVALID_FORMAT"
+	ErrCodeInvalid         = "OTP_INVALID"
+	ErrCodeExpired         = "OTP_EXPIRED"
+	ErrCodeResendCooldown  = "OTP_RESEND_COOLDOWN"
+	ErrCodeTooManyAttempts = "OTP_TOO_MANY_ATTEMPTS"
+	ErrCodeLocked          = "OTP_LOCKED"
+	ErrCodeIPLocked        = "OTP_IP_LOCKED"
+	ErrCodeTooManyResends  = "OTP_TOO_MANY_RESENDS"
+)
+
+// Error là lỗi nghiệp vụ của OTP, kèm mã lỗi và thời gian client cần chờ (nếu có)
+type Error struct {
+	Code              string
+	Message           string
+	RetryAfter        time.Duration // > 0 nếu client cần chờ trước khi thử lại
+	RemainingAttempts int           // Số lần nhập sai còn lại (chỉ có với OTP_INVALID)
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is cho phép so sánh lỗi theo mã lỗi bằng errors.Is
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	
+	return e.Code == t.Code
+}
+
+var (
+	ErrInvalidFormat = &Error{Code: ErrCodeInvalidFormat, Message: "invalid OTP format"}
+	ErrExpired       = &Error{Code: ErrCodeExpired, Message: "no OTP found or expired"}
+)
+
+func newInvalidOTPError(remainingAttempts int) *Error {
+	return &Error{
+		Code:              ErrCodeInvalid,
+		Message:           fmt.Sprintf("invalid OTP, %d attempt(s) remaining", remainingAttempts),
+		RemainingAttempts: remainingAttempts,
+	}
+}
+
+func newResendCooldownError(retryAfter time.Duration) *Error {
+	return &Error{
+		Code:       ErrCodeResendCooldown,
+		Message:    fmt.Sprintf("please wait %d second(s) before requesting a new OTP", retryAfterSeconds(retryAfter)),
+		RetryAfter: retryAfter,
+	}
+}
+
+func newLockedError(code string, retryAfter time.Duration) *Error {
+	var message string
+	switch code {
+	case ErrCodeIPLocked:
+		message = "too many failed OTP attempts from this IP address, please try again later"
+	case ErrCodeTooManyResends:
+		message = "too many OTP requests, please try again later"
+	case ErrCodeTooManyAttempts:
+		message = "too many failed OTP attempts, please request a new OTP later"
+	default:
+		message = "OTP is temporarily locked, please try again later"
+	}
+	
+	return &Error{
+		Code:       code,
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+func retryAfterSeconds(d time.Duration) int64 {
+	seconds := int64(d / time.Second)
+	if d%time.Second != 0 {
+		seconds++
+	}
+	
+	return seconds
+}
+
+// Limits cấu hình chống brute-force cho OTP
+type Limits struct {
+	MaxVerifyAttempts      int           // Số lần nhập sai tối đa cho một identifier trước khi bị khóa
+	MaxVerifyAttemptsPerIP int           // Số lần nhập sai tối đa từ một IP (trên mọi identifier) trước khi IP bị khóa
+	MaxResends             int           // Số lần gửi OTP tối đa cho một identifier trong thời gian khóa
+	ResendCooldown         time.Duration // Thời gian chờ cơ bản giữa 2 lần gửi, nhân đôi sau mỗi lần gửi
+	MaxResendCooldown      time.Duration // Thời gian chờ tối đa giữa 2 lần gửi
+	LockoutDuration        time.Duration // Thời gian khóa khi vượt quá giới hạn
+}
+
+// DefaultLimits được dùng khi không cấu hình giới hạn
+var DefaultLimits = Limits{
+	MaxVerifyAttempts:      5,
+	MaxVerifyAttemptsPerIP: 20,
+	MaxResends:             5,
+	ResendCooldown:         30 * time.Second,
+	MaxResendCooldown:      10 * time.Minute,
+	LockoutDuration:        30 * time.Minute,
+}
+
+// LimitsFromConfig đọc giới hạn OTP từ cấu hình, giá trị nào không được cấu hình sẽ dùng DefaultLimits
+func LimitsFromConfig(config *util.Config) Limits {
+	limits := DefaultLimits
+	
+	if config.OTPMaxVerifyAttempts > 0 {
+		limits.MaxVerifyAttempts = config.OTPMaxVerifyAttempts
+	}
+	if config.OTPMaxVerifyAttemptsPerIP > 0 {
+		limits.MaxVerifyAttemptsPerIP = config.OTPMaxVerifyAttemptsPerIP
+	}
+	if config.OTPMaxResends > 0 {
+		limits.MaxResends = config.OTPMaxResends
+	}
+	if config.OTPResendCooldown > 0 {
+		limits.ResendCooldown = config.OTPResendCooldown
+	}
+	if config.OTPMaxResendCooldown > 0 {
+		limits.MaxResendCooldown = config.OTPMaxResendCooldown
+	}
+	if config.OTPLockoutDuration > 0 {
+		limits.LockoutDuration = config.OTPLockoutDuration
+	}
+	
+	return limits
+}
+
+// resendCooldown tính thời gian chờ sau lần gửi thứ n (bắt đầu từ 1) theo exponential backoff
+func (l Limits) resendCooldown(sendCount int64) time.Duration {
+	cooldown := l.ResendCooldown
+	for i := int64(1); i < sendCount; i++ {
+		cooldown *= 2
+		if cooldown >= l.MaxResendCooldown {
+			return l.MaxResendCooldown
+		}
+	}
+	
+	return min(cooldown, l.MaxResendCooldown)
+}
@@ -3,7 +3,6 @@ package otp
 import (
 	"context"
 	"crypto/rand"
-	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -12,11 +11,27 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// consumeOTPScript so sánh OTP được cung cấp với OTP đang lưu và xóa nó (cùng các bộ đếm trong KEYS) trong cùng một lệnh,
+// để hai request đồng thời với cùng một mã đúng không thể cùng xác thực thành công.
+// Trả về -1 nếu OTP không tồn tại hoặc đã hết hạn, 1 nếu OTP đúng và đã bị xóa, 0 nếu OTP sai.
+var consumeOTPScript = redis.NewScript(`
+local stored = redis.call('GET', KEYS[1])
+if not stored then
+	return -1
+end
+if stored == ARGV[1] then
+	redis.call('DEL', unpack(KEYS))
+	return 1
+end
+return 0
+`)
+
 // OTPService xử lý việc tạo và xác thực mã OTP
 type OTPService struct {
 	redis      *redis.Client // Kết nối đến Redis để lưu trữ OTP
 	otpPrefix  string        // Prefix để phân biệt các loại OTP (email, phone, etc.)
 	expiration time.Duration // Thời gian sống của OTP (10 phút)
+	limits     Limits        // Giới hạn chống brute-force
 }
 
 // OTPServiceOption là function type để cấu hình OTPService
@@ -27,6 +42,7 @@ func NewOTPService(redis *redis.Client, opts ...OTPServiceOption) *OTPService {
 	s := &OTPService{
 		redis:      redis,
 		expiration: 10 * time.Minute, // Mặc định OTP có hiệu lực 10 phút
+		limits:     DefaultLimits,
 	}
 	
 	// Áp dụng các tùy chọn cấu hình
@@ -43,8 +59,57 @@ func WithPrefix(prefix string) OTPServiceOption {
 	}
 }
 
-// GenerateOTP tạo mã OTP mới và lưu vào Redis
-func (s *OTPService) GenerateOTP(ctx context.Context, identifier string) (code string, createdAt time.Time, expiresAt time.Time, err error) {
+// WithLimits cấu hình giới hạn số lần nhập sai, thời gian chờ gửi lại và thời gian khóa
+func WithLimits(limits Limits) OTPServiceOption {
+	return func(s *OTPService) {
+		s.limits = limits
+	}
+}
+
+// GenerateOTP tạo mã OTP mới và lưu vào Redis.
+// Mỗi lần gửi lại phải chờ một khoảng thời gian tăng dần (exponential backoff),
+// gửi quá số lần cho phép sẽ bị khóa tạm thời.
+func (s *OTPService) GenerateOTP(ctx context.Context, identifier string, clientIP string) (code string, createdAt time.Time, expiresAt time.Time, err error) {
+	if err = s.checkLocks(ctx, identifier, clientIP); err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	
+	// Kiểm tra thời gian chờ giữa 2 lần gửi
+	cooldownKey := s.key("cooldown", identifier)
+	remaining, err := s.redis.PTTL(ctx, cooldownKey).Result()
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("failed to get OTP resend cooldown: %w", err)
+	}
+	if remaining > 0 {
+		return "", time.Time{}, time.Time{}, newResendCooldownError(remaining)
+	}
+	
+	// Đếm số lần gửi trong thời gian khóa để tính thời gian chờ cho lần gửi tiếp theo
+	sendsKey := s.key("sends", identifier)
+	sendCount, err := s.incrWithExpiry(ctx, sendsKey, s.limits.LockoutDuration)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	
+	if sendCount > int64(s.limits.MaxResends) {
+		err = s.lock(ctx, s.key("lock", identifier), sendsKey)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, err
+		}
+		
+		return "", time.Time{}, time.Time{}, newLockedError(ErrCodeTooManyResends, s.limits.LockoutDuration)
+	}
+	
+	// SETNX để tránh 2 request đồng thời cùng vượt qua bước kiểm tra thời gian chờ
+	ok, err := s.redis.SetNX(ctx, cooldownKey, sendCount, s.limits.resendCooldown(sendCount)).Result()
+	if err != nil {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("failed to set OTP resend cooldown: %w", err)
+	}
+	if !ok {
+		remaining, _ = s.redis.PTTL(ctx, cooldownKey).Result()
+		return "", time.Time{}, time.Time{}, newResendCooldownError(remaining)
+	}
+	
 	// Tạo mã OTP ngẫu nhiên 6 chữ số
 	otp, err := generateSixDigitOTP()
 	if err != nil {
@@ -75,33 +140,131 @@ func (s *OTPService) GenerateOTP(ctx context.Context, identifier string) (code s
 	return otp, now, expiresAt, nil
 }
 
-// VerifyOTP xác thực mã OTP được cung cấp
-func (s *OTPService) VerifyOTP(ctx context.Context, identifier, providedOTP string) (bool, error) {
+// VerifyOTP xác thực mã OTP được cung cấp.
+// Mỗi lần nhập sai được đếm theo identifier và theo IP, vượt quá giới hạn sẽ bị khóa tạm thời.
+func (s *OTPService) VerifyOTP(ctx context.Context, identifier, providedOTP, clientIP string) (bool, error) {
 	// Kiểm tra độ dài OTP phải là 6 chữ số
 	if len(providedOTP) != 6 {
-		return false, fmt.Errorf("invalid OTP format")
+		return false, ErrInvalidFormat
+	}
+	
+	if err := s.checkLocks(ctx, identifier, clientIP); err != nil {
+		return false, err
 	}
 	
 	// Tạo key để lấy OTP từ Redis
 	otpKey := fmt.Sprintf("%s:%s", s.otpPrefix, identifier)
+	attemptsKey := s.key("attempts", identifier)
 	
-	// Lấy OTP từ Redis
-	storedOTP, err := s.redis.Get(ctx, otpKey).Result()
+	// So sánh và xóa OTP trong cùng một lệnh: nếu OTP đúng, nó và các bộ đếm bị xóa khỏi Redis để không thể sử dụng lại
+	keys := []string{otpKey, attemptsKey, s.key("sends", identifier), s.key("cooldown", identifier)}
+	consumed, err := consumeOTPScript.Run(ctx, s.redis, keys, providedOTP).Int()
 	if err != nil {
-		if errors.Is(err, redis.Nil) {
-			return false, fmt.Errorf("no OTP found or expired")
-		}
-		return false, fmt.Errorf("failed to retrieve OTP: %w", err)
+		return false, fmt.Errorf("failed to verify OTP: %w", err)
 	}
 	
-	// So sánh OTP được cung cấp với OTP trong Redis
-	if storedOTP == providedOTP {
-		// Nếu OTP đúng, xóa nó khỏi Redis để không thể sử dụng lại
-		s.redis.Del(ctx, otpKey)
+	switch consumed {
+	case -1:
+		return false, ErrExpired
+	case 1:
 		return true, nil
 	}
 	
-	return false, fmt.Errorf("invalid OTP")
+	// Đếm số lần nhập sai theo IP (trên mọi identifier) để chặn việc dò nhiều tài khoản từ một IP
+	if clientIP != "" {
+		ipAttempts, err := s.incrWithExpiry(ctx, ipKey("attempts", clientIP), s.limits.LockoutDuration)
+		if err != nil {
+			return false, err
+		}
+		
+		if ipAttempts >= int64(s.limits.MaxVerifyAttemptsPerIP) {
+			if err = s.lock(ctx, ipKey("lock", clientIP), ipKey("attempts", clientIP)); err != nil {
+				return false, err
+			}
+			
+			return false, newLockedError(ErrCodeIPLocked, s.limits.LockoutDuration)
+		}
+	}
+	
+	attempts, err := s.incrWithExpiry(ctx, attemptsKey, s.expiration)
+	if err != nil {
+		return false, err
+	}
+	
+	if attempts >= int64(s.limits.MaxVerifyAttempts) {
+		// Hủy OTP hiện tại, người dùng phải yêu cầu OTP mới sau khi hết thời gian khóa
+		if err = s.lock(ctx, s.key("lock", identifier), attemptsKey, otpKey); err != nil {
+			return false, err
+		}
+		
+		return false, newLockedError(ErrCodeTooManyAttempts, s.limits.LockoutDuration)
+	}
+	
+	return false, newInvalidOTPError(s.limits.MaxVerifyAttempts - int(attempts))
+}
+
+// checkLocks trả về lỗi nếu identifier hoặc IP đang bị khóa
+func (s *OTPService) checkLocks(ctx context.Context, identifier string, clientIP string) error {
+	remaining, err := s.redis.PTTL(ctx, s.key("lock", identifier)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get OTP lock: %w", err)
+	}
+	if remaining > 0 {
+		return newLockedError(ErrCodeLocked, remaining)
+	}
+	
+	if clientIP == "" {
+		return nil
+	}
+	
+	remaining, err = s.redis.PTTL(ctx, ipKey("lock", clientIP)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get OTP IP lock: %w", err)
+	}
+	if remaining > 0 {
+		return newLockedError(ErrCodeIPLocked, remaining)
+	}
+	
+	return nil
+}
+
+// lock khóa key trong LockoutDuration và xóa các key liên quan (bộ đếm, OTP hiện tại)
+func (s *OTPService) lock(ctx context.Context, lockKey string, keysToDelete ...string) error {
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, lockKey, 1, s.limits.LockoutDuration)
+		pipe.Del(ctx, keysToDelete...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock OTP: %w", err)
+	}
+	
+	return nil
+}
+
+// incrWithExpiry tăng bộ đếm và đặt thời gian sống cho bộ đếm ở lần tăng đầu tiên
+func (s *OTPService) incrWithExpiry(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increase OTP counter: %w", err)
+	}
+	
+	return incr.Val(), nil
+}
+
+// key tạo key phụ trợ cho identifier, ví dụ: otp:email:attempts:user@example.com
+func (s *OTPService) key(kind string, identifier string) string {
+	return fmt.Sprintf("%s:%s:%s", s.otpPrefix, kind, identifier)
+}
+
+// ipKey tạo key theo IP, dùng chung cho mọi loại OTP
+func ipKey(kind string, clientIP string) string {
+	return fmt.Sprintf("otp:ip:%s:%s", kind, clientIP)
 }
 
 // generateSixDigitOTP tạo mã OTP ngẫu nhiên 6 chữ số
@@ -0,0 +1,212 @@
+package otp
+
+import (
+	"context"
+	"testing"
+	"time"
+	
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+var testLimits = Limits{
+	MaxVerifyAttempts:      3,
+	MaxVerifyAttemptsPerIP: 5,
+	MaxResends:             3,
+	ResendCooldown:         30 * time.Second,
+	MaxResendCooldown:      90 * time.Second,
+	LockoutDuration:        30 * time.Minute,
+}
+
+const testIP = "203.0.113.7"
+
+// newTestOTPService tạo OTPService dùng miniredis, thời gian sống của các key được tua nhanh bằng server.FastForward.
+func newTestOTPService(t *testing.T) (*OTPService, *miniredis.Miniredis) {
+	t.Helper()
+	
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	
+	return NewOTPService(client, WithPrefix("otp:email"), WithLimits(testLimits)), server
+}
+
+func requireOTPError(t *testing.T, err error, code string) *Error {
+	t.Helper()
+	
+	require.ErrorIs(t, err, &Error{Code: code})
+	otpErr := err.(*Error)
+	return otpErr
+}
+
+// wrongOTP trả về một mã 6 chữ số khác code.
+func wrongOTP(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func TestResendCooldown(t *testing.T) {
+	limits := Limits{ResendCooldown: 30 * time.Second, MaxResendCooldown: 10 * time.Minute}
+	
+	testCases := []struct {
+		sendCount int64
+		want      time.Duration
+	}{
+		{sendCount: 1, want: 30 * time.Second},
+		{sendCount: 2, want: time.Minute},
+		{sendCount: 3, want: 2 * time.Minute},
+		{sendCount: 4, want: 4 * time.Minute},
+		{sendCount: 5, want: 8 * time.Minute},
+		{sendCount: 6, want: 10 * time.Minute},
+		{sendCount: 100, want: 10 * time.Minute},
+	}
+	
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, limits.resendCooldown(tc.sendCount), "send %d", tc.sendCount)
+	}
+}
+
+func TestGenerateOTPResendCooldownAndLockout(t *testing.T) {
+	service, server := newTestOTPService(t)
+	ctx := context.Background()
+	const email = "user@example.com"
+	
+	code, createdAt, expiresAt, err := service.GenerateOTP(ctx, email, testIP)
+	require.NoError(t, err)
+	require.Len(t, code, 6)
+	require.Equal(t, 10*time.Minute, expiresAt.Sub(createdAt))
+	
+	// Thời gian chờ tăng gấp đôi sau mỗi lần gửi: 30s, 60s rồi dừng ở MaxResendCooldown
+	for _, cooldown := range []time.Duration{30 * time.Second, 60 * time.Second, 90 * time.Second} {
+		_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+		otpErr := requireOTPError(t, err, ErrCodeResendCooldown)
+		require.Equal(t, cooldown, otpErr.RetryAfter)
+		
+		server.FastForward(cooldown - time.Second)
+		_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+		requireOTPError(t, err, ErrCodeResendCooldown)
+		
+		server.FastForward(time.Second)
+		if cooldown == 90*time.Second {
+			break
+		}
+		_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+		require.NoError(t, err)
+	}
+	
+	// Lần gửi thứ MaxResends+1 trong thời gian khóa bị từ chối và khóa identifier
+	_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+	otpErr := requireOTPError(t, err, ErrCodeTooManyResends)
+	require.Equal(t, testLimits.LockoutDuration, otpErr.RetryAfter)
+	
+	_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+	requireOTPError(t, err, ErrCodeLocked)
+	
+	// Identifier khác không bị ảnh hưởng
+	_, _, _, err = service.GenerateOTP(ctx, "other@example.com", testIP)
+	require.NoError(t, err)
+	
+	server.FastForward(testLimits.LockoutDuration)
+	_, _, _, err = service.GenerateOTP(ctx, email, testIP)
+	require.NoError(t, err)
+}
+
+func TestVerifyOTP(t *testing.T) {
+	service, server := newTestOTPService(t)
+	ctx := context.Background()
+	const email = "user@example.com"
+	
+	_, err := service.VerifyOTP(ctx, email, "12345", testIP)
+	requireOTPError(t, err, ErrCodeInvalidFormat)
+	
+	_, err = service.VerifyOTP(ctx, email, "123456", testIP)
+	requireOTPError(t, err, ErrCodeExpired)
+	
+	code, _, _, err := service.GenerateOTP(ctx, email, testIP)
+	require.NoError(t, err)
+	
+	_, err = service.VerifyOTP(ctx, email, wrongOTP(code), testIP)
+	otpErr := requireOTPError(t, err, ErrCodeInvalid)
+	require.Equal(t, testLimits.MaxVerifyAttempts-1, otpErr.RemainingAttempts)
+	
+	ok, err := service.VerifyOTP(ctx, email, code, testIP)
+	require.NoError(t, err)
+	require.True(t, ok)
+	
+	// OTP chỉ dùng được một lần
+	_, err = service.VerifyOTP(ctx, email, code, testIP)
+	requireOTPError(t, err, ErrCodeExpired)
+	
+	// Xác thực thành công xóa thời gian chờ gửi lại
+	code, _, _, err = service.GenerateOTP(ctx, email, testIP)
+	require.NoError(t, err)
+	
+	// OTP hết hạn sau 10 phút
+	server.FastForward(10 * time.Minute)
+	_, err = service.VerifyOTP(ctx, email, code, testIP)
+	requireOTPError(t, err, ErrCodeExpired)
+}
+
+func TestVerifyOTPTooManyAttempts(t *testing.T) {
+	service, server := newTestOTPService(t)
+	ctx := context.Background()
+	const email = "user@example.com"
+	
+	code, _, _, err := service.GenerateOTP(ctx, email, testIP)
+	require.NoError(t, err)
+	
+	for remaining := testLimits.MaxVerifyAttempts - 1; remaining > 0; remaining-- {
+		_, err = service.VerifyOTP(ctx, email, wrongOTP(code), testIP)
+		otpErr := requireOTPError(t, err, ErrCodeInvalid)
+		require.Equal(t, remaining, otpErr.RemainingAttempts)
+	}
+	
+	_, err = service.VerifyOTP(ctx, email, wrongOTP(code), testIP)
+	otpErr := requireOTPError(t, err, ErrCodeTooManyAttempts)
+	require.Equal(t, testLimits.LockoutDuration, otpErr.RetryAfter)
+	
+	// Mã đúng cũng bị từ chối khi đang bị khóa, và OTP cũ đã bị hủy
+	_, err = service.VerifyOTP(ctx, email, code, testIP)
+	requireOTPError(t, err, ErrCodeLocked)
+	
+	server.FastForward(testLimits.LockoutDuration)
+	_, err = service.VerifyOTP(ctx, email, code, testIP)
+	requireOTPError(t, err, ErrCodeExpired)
+}
+
+func TestVerifyOTPLocksIPAcrossIdentifiers(t *testing.T) {
+	service, server := newTestOTPService(t)
+	ctx := context.Background()
+	
+	// Mỗi identifier chỉ nhập sai một lần nhưng tổng số lần sai từ một IP vượt quá giới hạn
+	codes := make([]string, testLimits.MaxVerifyAttemptsPerIP)
+	for i := range codes {
+		identifier := string(rune('a'+i)) + "@example.com"
+		code, _, _, err := service.GenerateOTP(ctx, identifier, testIP)
+		require.NoError(t, err)
+		codes[i] = code
+		
+		_, err = service.VerifyOTP(ctx, identifier, wrongOTP(code), testIP)
+		if i < len(codes)-1 {
+			requireOTPError(t, err, ErrCodeInvalid)
+			continue
+		}
+		otpErr := requireOTPError(t, err, ErrCodeIPLocked)
+		require.Equal(t, testLimits.LockoutDuration, otpErr.RetryAfter)
+	}
+	
+	// IP bị khóa với mọi identifier, IP khác vẫn xác thực được
+	_, err := service.VerifyOTP(ctx, "a@example.com", codes[0], testIP)
+	requireOTPError(t, err, ErrCodeIPLocked)
+	
+	ok, err := service.VerifyOTP(ctx, "a@example.com", codes[0], "198.51.100.1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	
+	server.FastForward(testLimits.LockoutDuration)
+	_, err = service.VerifyOTP(ctx, "b@example.com", "123456", testIP)
+	requireOTPError(t, err, ErrCodeExpired)
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"time"
 	
-	"github.com/bwmarrin/discordgo"
 	"github.com/katatrina/gundam-BE/internal/otp"
+	"github.com/katatrina/gundam-BE/internal/sms"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/redis/go-redis/v9"
 )
@@ -14,61 +14,88 @@ import (
 // PhoneNumberService xử lý các thao tác liên quan đến số điện thoại
 // Bao gồm việc gửi và xác thực OTP qua số điện thoại
 type PhoneNumberService struct {
-	otpService *otp.OTPService    // Service để tạo và xác thực OTP
-	discord    *discordgo.Session // Kết nối Discord để gửi OTP (tạm thời)
-	config     *util.Config       // Cấu hình của ứng dụng
+	otpService              *otp.OTPService // Service để tạo và xác thực OTP
+	passwordResetOTPService *otp.OTPService // Service để tạo và xác thực OTP đặt lại mật khẩu
+	smsSender               sms.SMSSender   // Provider gửi SMS được chọn theo cấu hình
+	config                  *util.Config    // Cấu hình của ứng dụng
 }
 
 // NewPhoneService tạo một instance mới của PhoneNumberService
-// Khởi tạo kết nối Discord và cấu hình OTP service
+// Khởi tạo SMS sender theo cấu hình (SMS_PROVIDER) và cấu hình OTP service
 func NewPhoneService(config *util.Config, redis *redis.Client) (*PhoneNumberService, error) {
-	// Khởi tạo kết nối Discord với token bot
-	discord, err := discordgo.New("Bot " + config.DiscordBotToken)
+	smsSender, err := sms.NewSenderFromConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Discord session: %w", err)
+		return nil, fmt.Errorf("failed to create SMS sender: %w", err)
 	}
 	
 	return &PhoneNumberService{
-		discord: discord,
+		smsSender: smsSender,
 		// Khởi tạo OTP service với prefix cho số điện thoại
 		otpService: otp.NewOTPService(redis,
 			otp.WithPrefix("otp:phone_number"),
+			otp.WithLimits(otp.LimitsFromConfig(config)),
+		),
+		passwordResetOTPService: otp.NewOTPService(redis,
+			otp.WithPrefix("otp:password_reset:phone_number"),
+			otp.WithLimits(otp.LimitsFromConfig(config)),
 		),
 		config: config,
 	}, nil
 }
 
-// SendOTP tạo và gửi mã OTP đến số điện thoại
-// Hiện tại đang gửi qua Discord (tạm thời)
-func (s *PhoneNumberService) SendOTP(ctx context.Context, phoneNumber string) (code string, expiresAt time.Time, createdAt time.Time, err error) {
+// SendOTP tạo và gửi mã OTP đến số điện thoại qua SMS
+func (s *PhoneNumberService) SendOTP(ctx context.Context, phoneNumber string, clientIP string) (code string, expiresAt time.Time, createdAt time.Time, err error) {
 	// Tạo mã OTP mới
-	code, createdAt, expiresAt, err = s.otpService.GenerateOTP(ctx, phoneNumber)
+	code, createdAt, expiresAt, err = s.otpService.GenerateOTP(ctx, phoneNumber, clientIP)
 	if err != nil {
 		return "", time.Time{}, time.Time{}, err
 	}
 	
-	// Định dạng nội dung tin nhắn
-	message := fmt.Sprintf("Số điện thoại: %s | OTP: %s | Hiệu lực từ %s đến %s",
-		phoneNumber,
+	// Nội dung SMS viết không dấu: tin nhắn có dấu phải dùng bảng mã Unicode, chỉ chứa được 70 ký tự mỗi tin
+	message := fmt.Sprintf("Ma xac thuc Gundam Platform cua ban la %s. Ma co hieu luc den %s. Khong chia se ma nay voi bat ky ai.",
 		code,
-		createdAt.Format("15:04:05 02/01/2006"),
-		expiresAt.Format("15:04:05 02/01/2006"),
+		expiresAt.Format("15:04 02/01/2006"),
 	)
 	
-	// Gửi OTP qua Discord
-	// TODO: Thay thế bằng SMS service thực tế
-	_, err = s.discord.ChannelMessageSend(s.config.DiscordChannelID, message)
+	err = s.smsSender.Send(ctx, sms.Message{
+		To:   phoneNumber,
+		Body: message,
+	})
 	return code, expiresAt, createdAt, err
 }
 
 // VerifyOTP xác thực mã OTP được cung cấp
 // Trả về true nếu OTP hợp lệ, false nếu không
-func (s *PhoneNumberService) VerifyOTP(ctx context.Context, phone string, code string) (bool, error) {
+func (s *PhoneNumberService) VerifyOTP(ctx context.Context, phone string, code string, clientIP string) (bool, error) {
 	// Xác thực OTP
-	ok, err := s.otpService.VerifyOTP(ctx, phone, code)
+	ok, err := s.otpService.VerifyOTP(ctx, phone, code, clientIP)
 	if err != nil {
 		return false, err
 	}
 	
 	return ok, nil
 }
+
+// SendPasswordResetOTP tạo và gửi mã đặt lại mật khẩu đến số điện thoại
+func (s *PhoneNumberService) SendPasswordResetOTP(ctx context.Context, phoneNumber string, clientIP string) (expiresAt time.Time, err error) {
+	code, _, expiresAt, err := s.passwordResetOTPService.GenerateOTP(ctx, phoneNumber, clientIP)
+	if err != nil {
+		return time.Time{}, err
+	}
+	
+	message := fmt.Sprintf("Ma dat lai mat khau Gundam Platform cua ban la %s. Ma co hieu luc den %s. Neu ban khong yeu cau, hay bo qua tin nhan nay.",
+		code,
+		expiresAt.Format("15:04 02/01/2006"),
+	)
+	
+	err = s.smsSender.Send(ctx, sms.Message{
+		To:   phoneNumber,
+		Body: message,
+	})
+	return expiresAt, err
+}
+
+// VerifyPasswordResetOTP xác thực mã đặt lại mật khẩu đã gửi đến số điện thoại
+func (s *PhoneNumberService) VerifyPasswordResetOTP(ctx context.Context, phoneNumber string, code string, clientIP string) (bool, error) {
+	return s.passwordResetOTPService.VerifyOTP(ctx, phoneNumber, code, clientIP)
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "rate_limit" // rate_limit:<policy>:<subject> -> sorted set các request trong cửa sổ
+)
+
+// slidingWindowScript đếm số request trong cửa sổ trượt bằng sorted set (score là thời điểm request, đơn vị ms).
+// Request chỉ được ghi lại khi còn lượt, nên client bị chặn liên tục không tự kéo dài thời gian chờ của mình.
+// Trả về {allowed, remaining, reset_ms}, trong đó reset_ms là thời gian đến khi request cũ nhất rời khỏi cửa sổ.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+
+local reset = 0
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] ~= nil then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+// Policy là giới hạn áp dụng cho một nhóm route: tối đa Limit request trong mỗi Window
+type Policy struct {
+	Name   string // Dùng làm namespace của key, các route dùng chung Name sẽ dùng chung hạn mức
+	Limit  int
+	Window time.Duration
+}
+
+// Result là kết quả kiểm tra giới hạn của một request
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // Thời gian đến khi hạn mức được phục hồi thêm ít nhất 1 lượt
+	RetryAfter time.Duration // > 0 nếu request bị từ chối
+}
+
+// Limiter giới hạn số request theo thuật toán sliding window log, lưu trạng thái trong Redis
+// để giới hạn được chia sẻ giữa các instance của server.
+type Limiter struct {
+	redis *redis.Client
+}
+
+// NewLimiter tạo một instance mới của Limiter
+func NewLimiter(redis *redis.Client) *Limiter {
+	return &Limiter{
+		redis: redis,
+	}
+}
+
+// Allow ghi nhận một request của subject (ví dụ: "user:<id>" hoặc "ip:<ip>") theo policy
+// và cho biết request có được phép hay không.
+func (l *Limiter) Allow(ctx context.Context, policy Policy, subject string) (Result, error) {
+	now := time.Now().UnixMilli()
+	key := fmt.Sprintf("%s:%s:%s", keyPrefix, policy.Name, subject)
+	
+	values, err := slidingWindowScript.Run(ctx, l.redis, []string{key},
+		now,
+		policy.Window.Milliseconds(),
+		policy.Limit,
+		fmt.Sprintf("%d-%s", now, uuid.NewString()),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	
+	result := Result{
+		Allowed:    values[0] == 1,
+		Limit:      policy.Limit,
+		Remaining:  int(max(values[1], 0)),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}
+	if !result.Allowed {
+		result.RetryAfter = result.ResetAfter
+	}
+	
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+	
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(t *testing.T) *Limiter {
+	t.Helper()
+	
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	
+	return NewLimiter(client)
+}
+
+func TestAllowWithinLimit(t *testing.T) {
+	limiter := newTestLimiter(t)
+	ctx := context.Background()
+	policy := Policy{Name: "test", Limit: 3, Window: time.Minute}
+	
+	for remaining := 2; remaining >= 0; remaining-- {
+		result, err := limiter.Allow(ctx, policy, "ip:127.0.0.1")
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 3, result.Limit)
+		require.Equal(t, remaining, result.Remaining)
+		require.Zero(t, result.RetryAfter)
+		require.LessOrEqual(t, result.ResetAfter, time.Minute)
+	}
+	
+	result, err := limiter.Allow(ctx, policy, "ip:127.0.0.1")
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Zero(t, result.Remaining)
+	require.Greater(t, result.RetryAfter, 59*time.Second)
+	require.LessOrEqual(t, result.RetryAfter, time.Minute)
+	require.Equal(t, result.ResetAfter, result.RetryAfter)
+}
+
+func TestAllowIsolatesPoliciesAndSubjects(t *testing.T) {
+	limiter := newTestLimiter(t)
+	ctx := context.Background()
+	login := Policy{Name: "login", Limit: 1, Window: time.Minute}
+	otp := Policy{Name: "otp", Limit: 1, Window: time.Minute}
+	
+	testCases := []struct {
+		name    string
+		policy  Policy
+		subject string
+		allowed bool
+	}{
+		{name: "First", policy: login, subject: "ip:1.1.1.1", allowed: true},
+		{name: "SamePolicyAndSubject", policy: login, subject: "ip:1.1.1.1", allowed: false},
+		{name: "OtherSubject", policy: login, subject: "ip:2.2.2.2", allowed: true},
+		{name: "OtherPolicy", policy: otp, subject: "ip:1.1.1.1", allowed: true},
+	}
+	
+	for _, tc := range testCases {
+		result, err := limiter.Allow(ctx, tc.policy, tc.subject)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.allowed, result.Allowed, tc.name)
+	}
+}
+
+// Cửa sổ trượt: mỗi request rời khỏi cửa sổ đúng Window sau khi được ghi nhận,
+// request bị từ chối không được ghi nhận nên không kéo dài thời gian chờ.
+func TestAllowSlidingWindow(t *testing.T) {
+	limiter := newTestLimiter(t)
+	ctx := context.Background()
+	const window = 600 * time.Millisecond
+	policy := Policy{Name: "sliding", Limit: 2, Window: window}
+	
+	allow := func() Result {
+		result, err := limiter.Allow(ctx, policy, "user:1")
+		require.NoError(t, err)
+		return result
+	}
+	
+	start := time.Now()
+	require.True(t, allow().Allowed)
+	
+	time.Sleep(window / 2)
+	require.True(t, allow().Allowed)
+	
+	rejected := allow()
+	require.False(t, rejected.Allowed)
+	// Phải chờ request đầu tiên rời khỏi cửa sổ, không phải cả cửa sổ
+	require.LessOrEqual(t, rejected.RetryAfter, window-time.Since(start)+10*time.Millisecond)
+	
+	// Request đầu tiên đã rời khỏi cửa sổ, request thứ hai thì chưa
+	time.Sleep(time.Until(start.Add(window + 50*time.Millisecond)))
+	require.True(t, allow().Allowed)
+	require.False(t, allow().Allowed)
+}
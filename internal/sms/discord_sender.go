@@ -0,0 +1,48 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordSender gửi tin nhắn vào một kênh Discord thay vì gửi SMS thật.
+// Chỉ dùng để debug, không dùng trong production vì mọi người trong kênh đều đọc được mã OTP.
+type DiscordSender struct {
+	session   *discordgo.Session
+	channelID string
+}
+
+// NewDiscordSender tạo một instance mới của DiscordSender
+func NewDiscordSender(botToken, channelID string) (*DiscordSender, error) {
+	if botToken == "" || channelID == "" {
+		return nil, errors.New("DISCORD_BOT_TOKEN and DISCORD_CHANNEL_ID are required")
+	}
+	
+	session, err := discordgo.New("Bot " + botToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Discord session: %w", err)
+	}
+	
+	return &DiscordSender{
+		session:   session,
+		channelID: channelID,
+	}, nil
+}
+
+func (s *DiscordSender) Name() string {
+	return ProviderDiscord
+}
+
+func (s *DiscordSender) Send(ctx context.Context, msg Message) error {
+	content := fmt.Sprintf("Số điện thoại: %s | %s", msg.To, msg.Body)
+	
+	_, err := s.session.ChannelMessageSend(s.channelID, content, discordgo.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to send Discord message: %w", err)
+	}
+	
+	return nil
+}
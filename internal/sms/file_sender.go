@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSender ghi tin nhắn ra file hoặc stdout thay vì gửi SMS thật, dùng khi phát triển ở local
+type FileSender struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewFileSender tạo một instance mới của FileSender.
+// Nếu path rỗng hoặc là "-", tin nhắn được ghi ra stdout.
+func NewFileSender(path string) (*FileSender, error) {
+	if path == "" || path == "-" {
+		return &FileSender{writer: os.Stdout}, nil
+	}
+	
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SMS file %s: %w", path, err)
+	}
+	
+	return &FileSender{writer: file}, nil
+}
+
+func (s *FileSender) Name() string {
+	return ProviderFile
+}
+
+func (s *FileSender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	_, err := fmt.Fprintf(s.writer, "[%s] SMS %s to %s: %s\n", time.Now().Format(time.RFC3339), msg.ID, msg.To, msg.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write SMS: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,74 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	httpGatewayTimeout = 10 * time.Second
+)
+
+// HTTPGatewaySender gửi SMS qua một SMS gateway có HTTP API (eSMS, SpeedSMS, Twilio proxy...).
+// Request được gửi dạng JSON {"to", "message", "sender"} kèm header Authorization: Bearer <api key>,
+// gateway trả về status 2xx khi đã nhận tin nhắn.
+type HTTPGatewaySender struct {
+	url        string
+	apiKey     string
+	senderName string
+	client     *http.Client
+}
+
+// NewHTTPGatewaySender tạo một instance mới của HTTPGatewaySender
+func NewHTTPGatewaySender(url, apiKey, senderName string) *HTTPGatewaySender {
+	return &HTTPGatewaySender{
+		url:        url,
+		apiKey:     apiKey,
+		senderName: senderName,
+		client:     &http.Client{Timeout: httpGatewayTimeout},
+	}
+}
+
+func (s *HTTPGatewaySender) Name() string {
+	return ProviderHTTP
+}
+
+func (s *HTTPGatewaySender) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"id":      msg.ID,
+		"to":      msg.To,
+		"message": msg.Body,
+		"sender":  s.senderName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SMS request: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create SMS request: %w", err)
+	}
+	
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+	
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS request: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("SMS gateway returned status %d: %s", resp.StatusCode, respBody)
+	}
+	
+	return nil
+}
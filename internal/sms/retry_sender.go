@@ -0,0 +1,85 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// RetrySender gửi tin nhắn qua lần lượt từng provider, mỗi provider được thử lại tối đa maxAttempts lần.
+// Trạng thái của từng lần gửi được ghi vào log theo message ID.
+type RetrySender struct {
+	senders     []SMSSender
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewRetrySender tạo một instance mới của RetrySender.
+// senders[0] là provider chính, các provider sau là provider dự phòng.
+func NewRetrySender(maxAttempts int, retryDelay time.Duration, senders ...SMSSender) *RetrySender {
+	return &RetrySender{
+		senders:     senders,
+		maxAttempts: max(maxAttempts, 1),
+		retryDelay:  retryDelay,
+	}
+}
+
+func (s *RetrySender) Name() string {
+	return s.senders[0].Name()
+}
+
+func (s *RetrySender) Send(ctx context.Context, msg Message) error {
+	if msg.ID == "" {
+		msg.ID = uuid.NewString()
+	}
+	
+	var errs []error
+	for _, sender := range s.senders {
+		for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+			start := time.Now()
+			err := sender.Send(ctx, msg)
+			
+			logEvent := log.Info()
+			status := "sent"
+			if err != nil {
+				logEvent = log.Warn().Err(err)
+				status = "failed"
+			}
+			logEvent.
+				Str("message_id", msg.ID).
+				Str("provider", sender.Name()).
+				Str("to", maskPhoneNumber(msg.To)).
+				Int("attempt", attempt).
+				Str("status", status).
+				Dur("duration", time.Since(start)).
+				Msg("SMS delivery attempt")
+			
+			if err == nil {
+				return nil
+			}
+			
+			errs = append(errs, fmt.Errorf("%s attempt %d: %w", sender.Name(), attempt, err))
+			
+			if attempt < s.maxAttempts {
+				// Chờ lâu hơn sau mỗi lần thất bại (exponential backoff)
+				select {
+				case <-ctx.Done():
+					return errors.Join(append(errs, ctx.Err())...)
+				case <-time.After(s.retryDelay * time.Duration(1<<(attempt-1))):
+				}
+			}
+		}
+	}
+	
+	log.Error().
+		Str("message_id", msg.ID).
+		Str("to", maskPhoneNumber(msg.To)).
+		Str("status", "undelivered").
+		Msg("failed to deliver SMS with every provider")
+	
+	return fmt.Errorf("failed to send SMS: %w", errors.Join(errs...))
+}
@@ -0,0 +1,89 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	
+	"github.com/katatrina/gundam-BE/internal/util"
+)
+
+// Các provider gửi SMS được hỗ trợ, cấu hình bằng SMS_PROVIDER và SMS_FALLBACK_PROVIDER
+const (
+	ProviderHTTP    = "http"    // SMS gateway qua HTTP API
+	ProviderFile    = "file"    // Ghi tin nhắn ra file hoặc stdout, dùng khi phát triển ở local
+	ProviderDiscord = "discord" // Gửi tin nhắn vào kênh Discord, chỉ dùng để debug
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultRetryDelay  = 500 * time.Millisecond
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown SMS provider")
+)
+
+// Message là một tin nhắn SMS cần gửi
+type Message struct {
+	ID   string // ID để theo dõi trạng thái gửi trong log
+	To   string // Số điện thoại người nhận
+	Body string
+}
+
+// SMSSender gửi tin nhắn SMS qua một provider cụ thể
+type SMSSender interface {
+	// Name trả về tên của provider, dùng cho log
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewSenderFromConfig tạo SMSSender theo provider được cấu hình.
+// Nếu có SMS_FALLBACK_PROVIDER, tin nhắn sẽ được gửi qua provider dự phòng khi provider chính thất bại sau các lần thử lại.
+func NewSenderFromConfig(config *util.Config) (SMSSender, error) {
+	primary, err := newProvider(config, config.SMSProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMS provider %q: %w", config.SMSProvider, err)
+	}
+	
+	senders := []SMSSender{primary}
+	if config.SMSFallbackProvider != "" && config.SMSFallbackProvider != config.SMSProvider {
+		fallback, err := newProvider(config, config.SMSFallbackProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create SMS fallback provider %q: %w", config.SMSFallbackProvider, err)
+		}
+		
+		senders = append(senders, fallback)
+	}
+	
+	maxAttempts := config.SMSMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	
+	return NewRetrySender(maxAttempts, defaultRetryDelay, senders...), nil
+}
+
+func newProvider(config *util.Config, provider string) (SMSSender, error) {
+	switch strings.ToLower(provider) {
+	case ProviderHTTP:
+		return NewHTTPGatewaySender(config.SMSGatewayURL, config.SMSGatewayAPIKey, config.SMSSenderName), nil
+	case ProviderFile, "":
+		return NewFileSender(config.SMSFilePath)
+	case ProviderDiscord:
+		return NewDiscordSender(config.DiscordBotToken, config.DiscordChannelID)
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+// maskPhoneNumber ẩn các chữ số ở giữa số điện thoại khi ghi log, ví dụ: 090****567
+func maskPhoneNumber(phoneNumber string) string {
+	if len(phoneNumber) <= 6 {
+		return strings.Repeat("*", len(phoneNumber))
+	}
+	
+	return phoneNumber[:3] + strings.Repeat("*", len(phoneNumber)-6) + phoneNumber[len(phoneNumber)-3:]
+}
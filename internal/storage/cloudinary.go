@@ -4,32 +4,38 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
 	
-	"github.com/rs/zerolog/log"
-	
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/katatrina/gundam-BE/internal/util"
 )
 
+// Số ảnh tối đa Admin API trả về trong một trang
+const cloudinaryMaxListResults = 500
+
 type CloudinaryStore struct {
 	*cloudinary.Cloudinary
 }
 
-func (cld *CloudinaryStore) UploadFile(file []byte, filename string, folder string) (string, error) {
+func (cld *CloudinaryStore) UploadFile(ctx context.Context, file []byte, filename string, folder string) (StoredObject, error) {
 	// Kiểm tra extension của file
 	fileExt := filepath.Ext(filename)
 	fileBase := strings.TrimSuffix(filename, filepath.Ext(filename))
 	
-	// Tạo upload parameters mặc định
+	// Tạo upload parameters mặc định.
+	// Ảnh người dùng được đặt tên theo nội dung (xem ProcessImage) nên không ghi đè file đã có:
+	// nếu trùng tên thì đó chính là cùng một ảnh và Cloudinary trả về ảnh đã lưu.
 	uploadParams := uploader.UploadParams{
 		Folder:         folder,
 		PublicID:       fileBase,
 		UniqueFilename: api.Bool(false),
-		Overwrite:      api.Bool(true),
+		Overwrite:      api.Bool(false),
 	}
 	
 	// Xử lý đặc biệt cho file SVG
@@ -41,10 +47,13 @@ func (cld *CloudinaryStore) UploadFile(file []byte, filename string, folder stri
 	
 	// Tiến hành upload
 	reader := bytes.NewReader(file)
-	result, err := cld.Upload.Upload(context.Background(), reader, uploadParams)
+	result, err := cld.Upload.Upload(ctx, reader, uploadParams)
 	if err != nil {
 		err = fmt.Errorf("failed to upload file to cloudinary: %w", err)
-		return "", err
+		return StoredObject{}, err
+	}
+	if result.Error.Message != "" {
+		return StoredObject{}, fmt.Errorf("failed to upload file to cloudinary: %s", result.Error.Message)
 	}
 	
 	// Đảm bảo URL cho SVG có extension đúng
@@ -53,39 +62,127 @@ func (cld *CloudinaryStore) UploadFile(file []byte, filename string, folder stri
 		secureURL = secureURL + ".svg"
 	}
 	
-	return secureURL, nil
+	return StoredObject{
+		Key: result.PublicID,
+		URL: secureURL,
+	}, nil
 }
 
-func (cld *CloudinaryStore) DeleteFile(publicID string, folder string) error {
-	if publicID == "" {
-		return fmt.Errorf("publicID cannot be empty")
-	}
-	
-	fullPublicID := publicID
-	if folder != "" {
-		fullPublicID = fmt.Sprintf("%s/%s", folder, publicID)
+// DeleteFile xóa file theo public ID (gồm cả folder) trên Cloudinary.
+func (cld *CloudinaryStore) DeleteFile(ctx context.Context, key string) error {
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
 	}
 	
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 	
-	_, err := cld.Upload.Destroy(ctx, uploader.DestroyParams{
-		PublicID: fullPublicID,
+	result, err := cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID: key,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to delete file from cloudinary (ID: %s): %w", fullPublicID, err)
+		return fmt.Errorf("failed to delete file from cloudinary (ID: %s): %w", key, err)
+	}
+	if result.Error.Message != "" {
+		return fmt.Errorf("failed to delete file from cloudinary (ID: %s): %s", key, result.Error.Message)
 	}
 	
 	return nil
 }
 
-func NewCloudinaryStore(url string) FileStore {
+// KeyFromURL trả về public ID của file từ URL của Cloudinary.
+func (cld *CloudinaryStore) KeyFromURL(fileURL string) (string, error) {
+	publicID, err := util.ExtractPublicIDFromURL(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidFileURL, err)
+	}
+	
+	return publicID, nil
+}
+
+// ListFiles liệt kê các ảnh có public ID bắt đầu bằng folder qua Admin API, LastModified là thời điểm tải lên.
+func (cld *CloudinaryStore) ListFiles(ctx context.Context, folder string) ([]StoredFile, error) {
+	var files []StoredFile
+	nextCursor := ""
+	
+	for {
+		result, err := cld.Admin.Assets(ctx, admin.AssetsParams{
+			AssetType:    api.Image,
+			DeliveryType: "upload",
+			Prefix:       folder + "/",
+			MaxResults:   cloudinaryMaxListResults,
+			NextCursor:   nextCursor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in cloudinary folder %s: %w", folder, err)
+		}
+		if result.Error.Message != "" {
+			return nil, fmt.Errorf("failed to list files in cloudinary folder %s: %s", folder, result.Error.Message)
+		}
+		
+		for _, asset := range result.Assets {
+			files = append(files, StoredFile{
+				Key:          asset.PublicID,
+				Size:         int64(asset.Bytes),
+				LastModified: asset.CreatedAt,
+			})
+		}
+		
+		if result.NextCursor == "" {
+			return files, nil
+		}
+		nextCursor = result.NextCursor
+	}
+}
+
+// PresignUpload không được hỗ trợ: chữ ký upload của Cloudinary không ràng buộc được kích thước và nội dung của file.
+func (cld *CloudinaryStore) PresignUpload(_ context.Context, _ string, _ UploadConstraints, _ time.Duration) (PresignedUpload, error) {
+	return PresignedUpload{}, ErrPresignNotSupported
+}
+
+// DownloadFile tải file theo public ID qua delivery URL của Cloudinary.
+func (cld *CloudinaryStore) DownloadFile(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	asset, err := cld.Image(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cloudinary URL (ID: %s): %w", key, err)
+	}
+	
+	assetURL, err := asset.String()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cloudinary URL (ID: %s): %w", key, err)
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloudinary request: %w", err)
+	}
+	
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file from cloudinary (ID: %s): %w", key, err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to download file from cloudinary (ID: %s): status %d", key, resp.StatusCode)
+	}
+	
+	return readLimited(resp.Body, maxBytes)
+}
+
+func NewCloudinaryStore(url string) (FileStore, error) {
 	cld, err := cloudinary.NewFromURL(url)
 	if err != nil {
-		log.Fatal().Err(err).Msg("failed to create cloudinary store 😣")
+		return nil, fmt.Errorf("failed to create cloudinary store: %w", err)
 	}
 	
 	cld.Config.URL.Secure = true
 	
-	return &CloudinaryStore{cld}
+	return &CloudinaryStore{cld}, nil
 }
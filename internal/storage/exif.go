@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// jpegOrientation đọc tag Orientation (0x0112) trong segment EXIF (APP1) của ảnh JPEG.
+// Trả về 1 (không xoay) nếu ảnh không có EXIF hoặc EXIF không hợp lệ.
+func jpegOrientation(data []byte) int {
+	const orientationTag = 0x0112
+	
+	// Bỏ qua SOI (FFD8), duyệt các segment cho đến khi gặp APP1 hoặc bắt đầu dữ liệu ảnh (SOS)
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+		segment := data[pos+4 : pos+2+length]
+		pos += 2 + length
+		
+		if marker != 0xE1 || !bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			continue
+		}
+		
+		// Header TIFF: thứ tự byte ("II" hoặc "MM"), số 42 và vị trí của IFD0
+		tiff := segment[6:]
+		if len(tiff) < 8 {
+			return 1
+		}
+		var order binary.ByteOrder
+		switch string(tiff[:2]) {
+		case "II":
+			order = binary.LittleEndian
+		case "MM":
+			order = binary.BigEndian
+		default:
+			return 1
+		}
+		
+		ifd := int(order.Uint32(tiff[4:]))
+		if ifd+2 > len(tiff) {
+			return 1
+		}
+		entries := int(order.Uint16(tiff[ifd:]))
+		for i := 0; i < entries; i++ {
+			entry := ifd + 2 + i*12
+			if entry+12 > len(tiff) {
+				return 1
+			}
+			if order.Uint16(tiff[entry:]) != orientationTag {
+				continue
+			}
+			
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+		
+		return 1
+	}
+	
+	return 1
+}
+
+// applyOrientation xoay/lật ảnh theo giá trị EXIF orientation để ảnh hiển thị đúng chiều khi không còn EXIF.
+//
+//	1: giữ nguyên, 2: lật ngang, 3: xoay 180°, 4: lật dọc,
+//	5: chuyển vị, 6: xoay 90° theo chiều kim đồng hồ, 7: chuyển vị ngược, 8: xoay 90° ngược chiều kim đồng hồ
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+	
+	bounds := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	
+	width, height := bounds.Dx(), bounds.Dy()
+	dstW, dstH := width, height
+	if orientation >= 5 {
+		dstW, dstH = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+	
+	return dst
+}
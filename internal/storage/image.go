@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+)
+
+// ErrInvalidImage được trả về khi ảnh người dùng tải lên không hợp lệ (sai định dạng, quá lớn, hỏng, ...).
+// Các lỗi cụ thể đều bọc lỗi này để handler có thể trả về 400 thay vì 500.
+var ErrInvalidImage = errors.New("invalid image")
+
+var (
+	ErrUnsupportedImageType = fmt.Errorf("%w: unsupported image type, only JPEG, PNG and GIF are allowed", ErrInvalidImage)
+	ErrImageTooLarge        = fmt.Errorf("%w: image file is too large", ErrInvalidImage)
+	ErrImageTooManyPixels   = fmt.Errorf("%w: image dimensions are too large", ErrInvalidImage)
+)
+
+// Định dạng ảnh nhận diện được từ magic bytes.
+const (
+	imageFormatJPEG = "jpeg"
+	imageFormatPNG  = "png"
+	imageFormatGIF  = "gif"
+)
+
+// thumbnailSuffix được thêm vào tên file của thumbnail
+const thumbnailSuffix = "_thumb"
+
+// ImageOptions là các giới hạn và kích thước dùng khi xử lý ảnh tải lên.
+type ImageOptions struct {
+	MaxBytes      int // Kích thước file tối đa (byte)
+	MaxPixels     int // Số điểm ảnh tối đa (rộng x cao), chặn "decompression bomb"
+	ThumbnailSize int // Cạnh dài nhất của thumbnail (px)
+	JPEGQuality   int
+}
+
+var DefaultImageOptions = ImageOptions{
+	MaxBytes:      10 << 20,
+	MaxPixels:     40_000_000,
+	ThumbnailSize: 320,
+	JPEGQuality:   90,
+}
+
+// ProcessedImage là ảnh đã được kiểm tra, xoay đúng chiều và mã hóa lại (không còn EXIF/GPS).
+type ProcessedImage struct {
+	Data      []byte
+	Ext       string // Phần mở rộng tương ứng với Data: ".jpg" hoặc ".png"
+	Width     int
+	Height    int
+	Thumbnail []byte // Thumbnail dạng JPEG
+	
+	// ContentHash là SHA-256 (hex) của Data, dùng làm ID của file trên file store
+	ContentHash string
+	// PerceptualHash là dHash 64 bit của ảnh, hai ảnh gần giống nhau có khoảng cách Hamming nhỏ
+	PerceptualHash uint64
+}
+
+// Filename trả về tên file theo nội dung của ảnh, hai ảnh giống hệt nhau sẽ có cùng tên.
+func (p *ProcessedImage) Filename() string {
+	return p.ContentHash + p.Ext
+}
+
+// ThumbnailFilename trả về tên file của thumbnail, được lưu cùng thư mục với ảnh gốc.
+func (p *ProcessedImage) ThumbnailFilename() string {
+	return p.ContentHash + thumbnailSuffix + ".jpg"
+}
+
+// ImageStem trả về key của ảnh sau khi bỏ phần mở rộng và hậu tố thumbnail,
+// ảnh gốc và thumbnail của nó có cùng stem (ví dụ: gundams/<sha256>).
+func ImageStem(key string) string {
+	stem := strings.TrimSuffix(key, path.Ext(key))
+	return strings.TrimSuffix(stem, thumbnailSuffix)
+}
+
+// ProcessImage kiểm tra magic bytes và giới hạn kích thước của ảnh, xoay ảnh theo EXIF orientation,
+// mã hóa lại để loại bỏ toàn bộ metadata (EXIF, GPS, ...), tạo thumbnail và tính các hash của ảnh.
+// Ảnh GIF chỉ giữ lại frame đầu tiên và được lưu dưới dạng PNG.
+func ProcessImage(raw []byte, opts ImageOptions) (*ProcessedImage, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidImage)
+	}
+	if len(raw) > opts.MaxBytes {
+		return nil, ErrImageTooLarge
+	}
+	
+	format := detectImageFormat(raw)
+	if format == "" {
+		return nil, ErrUnsupportedImageType
+	}
+	
+	// Kiểm tra kích thước trước khi giải mã để không phải cấp phát bộ nhớ cho ảnh quá lớn
+	config, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > opts.MaxPixels {
+		return nil, ErrImageTooManyPixels
+	}
+	
+	var img image.Image
+	switch format {
+	case imageFormatJPEG:
+		img, err = jpeg.Decode(bytes.NewReader(raw))
+	case imageFormatPNG:
+		img, err = png.Decode(bytes.NewReader(raw))
+	case imageFormatGIF:
+		img, err = gif.Decode(bytes.NewReader(raw))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidImage, err)
+	}
+	
+	// Chỉ JPEG mới có EXIF orientation, ảnh sau khi xoay được lưu mà không kèm EXIF
+	if format == imageFormatJPEG {
+		img = applyOrientation(img, jpegOrientation(raw))
+	}
+	
+	flat := flatten(img)
+	result := &ProcessedImage{
+		Width:          flat.Bounds().Dx(),
+		Height:         flat.Bounds().Dy(),
+		PerceptualHash: differenceHash(flat),
+	}
+	
+	var buf bytes.Buffer
+	if format == imageFormatJPEG {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: opts.JPEGQuality})
+		result.Ext = ".jpg"
+	} else {
+		err = png.Encode(&buf, img)
+		result.Ext = ".png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	result.Data = buf.Bytes()
+	
+	sum := sha256.Sum256(result.Data)
+	result.ContentHash = hex.EncodeToString(sum[:])
+	
+	var thumbBuf bytes.Buffer
+	thumbnail := resizeToFit(flat, opts.ThumbnailSize)
+	if err = jpeg.Encode(&thumbBuf, thumbnail, &jpeg.Options{Quality: opts.JPEGQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	result.Thumbnail = thumbBuf.Bytes()
+	
+	return result, nil
+}
+
+// detectImageFormat nhận diện định dạng ảnh từ magic bytes, không tin vào tên file hay Content-Type của client.
+func detectImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return imageFormatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return imageFormatPNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return imageFormatGIF
+	}
+	
+	return ""
+}
+
+// flatten chuyển ảnh sang RGBA để đọc trực tiếp điểm ảnh qua Pix thay vì image.Image.At (chậm).
+// Nền trong suốt được thay bằng màu trắng vì thumbnail là JPEG.
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+	
+	return flat
+}
+
+// resizeToFit thu nhỏ ảnh sao cho cạnh dài nhất không vượt quá maxSize, giữ nguyên tỉ lệ.
+func resizeToFit(src *image.RGBA, maxSize int) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+	
+	return boxResize(src, width, height)
+}
+
+// boxResize thu nhỏ ảnh về width x height, mỗi điểm ảnh đích là trung bình của vùng điểm ảnh nguồn tương ứng.
+func boxResize(src *image.RGBA, width int, height int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max(y0+1, (y+1)*srcH/height)
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max(x0+1, (x+1)*srcW/width)
+			
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[offset])
+					g += int(src.Pix[offset+1])
+					b += int(src.Pix[offset+2])
+					a += int(src.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	
+	return dst
+}
+
+// differenceHash tính dHash 64 bit: thu nhỏ ảnh xám về 9x8 và so sánh độ sáng của từng cặp điểm ảnh liền kề.
+// Hash ít thay đổi khi ảnh bị nén lại, đổi kích thước hay chỉnh màu nhẹ.
+func differenceHash(img *image.RGBA) uint64 {
+	small := boxResize(img, 9, 8)
+	
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if luminance(small, x, y) > luminance(small, x+1, y) {
+				hash |= 1 << uint(y*8+x)
+			}
+		}
+	}
+	
+	return hash
+}
+
+func luminance(img *image.RGBA, x int, y int) int {
+	offset := img.PixOffset(x, y)
+	return 299*int(img.Pix[offset]) + 587*int(img.Pix[offset+1]) + 114*int(img.Pix[offset+2])
+}
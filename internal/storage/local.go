@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// LocalFilesRoute là route mà server dùng để phục vụ file của LocalStore, LocalStorageBaseURL phải trỏ tới route này
+	LocalFilesRoute = "/files"
+	
+	// LocalContentSHA256Header là header chứa SHA-256 (hex) của file khi PUT qua presigned URL
+	LocalContentSHA256Header = "X-Content-Sha256"
+)
+
+// LocalStore lưu file trong một thư mục trên đĩa và phục vụ chúng qua route tĩnh có chữ ký (xem ServeHTTP).
+// URL của file kèm chữ ký HMAC của key nên không thể đoán URL của file khác, URL không hết hạn vì được lưu vào database.
+// Dùng khi chạy ở local (không cần tài khoản Cloudinary) và trong test.
+type LocalStore struct {
+	dir        string
+	baseURL    *url.URL
+	signingKey []byte
+}
+
+// NewLocalStore tạo LocalStore lưu file trong dir, baseURL là URL công khai của LocalFilesRoute (ví dụ: http://localhost:8080/files).
+func NewLocalStore(dir string, baseURL string, signingKey string) (FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("local storage directory is required")
+	}
+	if signingKey == "" {
+		return nil, fmt.Errorf("local storage signing key is required")
+	}
+	
+	parsedURL, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("invalid local storage base URL %q", baseURL)
+	}
+	
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory %s: %w", dir, err)
+	}
+	
+	return &LocalStore{
+		dir:        dir,
+		baseURL:    parsedURL,
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+func (s *LocalStore) UploadFile(_ context.Context, file []byte, filename string, folder string) (StoredObject, error) {
+	key := objectKey(folder, filename)
+	if err := s.writeFile(key, file); err != nil {
+		return StoredObject{}, err
+	}
+	
+	return StoredObject{
+		Key: key,
+		URL: s.fileURL(key),
+	}, nil
+}
+
+func (s *LocalStore) DeleteFile(_ context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	
+	err = os.Remove(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file %s: %w", key, err)
+	}
+	
+	return nil
+}
+
+func (s *LocalStore) KeyFromURL(fileURL string) (string, error) {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil || parsedURL.Host != s.baseURL.Host {
+		return "", ErrInvalidFileURL
+	}
+	
+	key, found := keyFromPath(parsedURL.Path, s.baseURL.Path)
+	if !found {
+		return "", ErrInvalidFileURL
+	}
+	
+	return key, nil
+}
+
+func (s *LocalStore) ListFiles(_ context.Context, folder string) ([]StoredFile, error) {
+	root, err := s.filePath(folder)
+	if err != nil {
+		return nil, err
+	}
+	
+	var files []StoredFile
+	err = filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		// Bỏ qua thư mục và file tạm đang được ghi (xem writeFile)
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		
+		relPath, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		
+		files = append(files, StoredFile{
+			Key:          filepath.ToSlash(relPath),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", folder, err)
+	}
+	
+	return files, nil
+}
+
+// PresignUpload trả về URL để client PUT file lên LocalFilesRoute.
+// Chữ ký gồm key, thời điểm hết hạn, Content-Type, kích thước và SHA-256 của file nên client không thể tải lên file khác.
+func (s *LocalStore) PresignUpload(_ context.Context, key string, constraints UploadConstraints, expiry time.Duration) (PresignedUpload, error) {
+	if _, err := s.filePath(key); err != nil {
+		return PresignedUpload{}, err
+	}
+	
+	expiresAt := time.Now().Add(expiry)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(http.MethodPut, key, expires, constraints.ContentType,
+		strconv.FormatInt(constraints.Size, 10), constraints.SHA256))
+	
+	return PresignedUpload{
+		Key:    key,
+		Method: http.MethodPut,
+		URL:    s.baseURL.JoinPath(key).String() + "?" + query.Encode(),
+		Headers: map[string]string{
+			"Content-Type":           constraints.ContentType,
+			LocalContentSHA256Header: constraints.SHA256,
+		},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *LocalStore) DownloadFile(_ context.Context, key string, maxBytes int64) ([]byte, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrFileNotFound
+		}
+		return nil, fmt.Errorf("failed to open file %s: %w", key, err)
+	}
+	defer file.Close()
+	
+	return readLimited(file, maxBytes)
+}
+
+// ServeHTTP phục vụ LocalFilesRoute: GET trả về file nếu chữ ký hợp lệ, PUT nhận file tải lên qua presigned URL (xem PresignUpload).
+// Request path là key của file (route cần được gắn với http.StripPrefix(LocalFilesRoute+"/", store)).
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Path
+	query := r.URL.Query()
+	
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if !s.verify(query.Get("signature"), http.MethodGet, key, "") {
+			http.Error(w, "invalid signature", http.StatusForbidden)
+			return
+		}
+		
+		filePath, err := s.filePath(key)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		
+		// Không cho trình duyệt thực thi nội dung của file (ví dụ: script trong ảnh SVG)
+		w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		http.ServeFile(w, r, filePath)
+	
+	case http.MethodPut:
+		expires := query.Get("expires")
+		expiresAt, err := strconv.ParseInt(expires, 10, 64)
+		contentSHA256 := r.Header.Get(LocalContentSHA256Header)
+		if err != nil || time.Now().Unix() > expiresAt || r.ContentLength < 0 ||
+			!s.verify(query.Get("signature"), http.MethodPut, key, expires, r.Header.Get("Content-Type"),
+				strconv.FormatInt(r.ContentLength, 10), contentSHA256) {
+			http.Error(w, "invalid or expired signature", http.StatusForbidden)
+			return
+		}
+		
+		data, err := readLimited(r.Body, r.ContentLength)
+		if err != nil {
+			http.Error(w, "request body does not match Content-Length", http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256(data)
+		if int64(len(data)) != r.ContentLength || hex.EncodeToString(sum[:]) != contentSHA256 {
+			http.Error(w, "request body does not match the signed size and SHA-256", http.StatusBadRequest)
+			return
+		}
+		
+		if err = s.writeFile(key, data); err != nil {
+			http.Error(w, "failed to store file", http.StatusInternalServerError)
+			return
+		}
+		
+		w.WriteHeader(http.StatusCreated)
+	
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// filePath trả về đường dẫn của file trên đĩa, từ chối các key thoát ra ngoài thư mục lưu trữ.
+func (s *LocalStore) filePath(key string) (string, error) {
+	if key == "" || !fs.ValidPath(key) {
+		return "", fmt.Errorf("invalid file key %q", key)
+	}
+	
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStore) writeFile(key string, data []byte) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return err
+	}
+	
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+	
+	// Ghi ra file tạm rồi đổi tên để không ai đọc được file đang ghi dở
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+path.Base(key)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file %s: %w", key, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", key, err)
+	}
+	
+	if err = os.Rename(tmp.Name(), filePath); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", key, err)
+	}
+	
+	return nil
+}
+
+func (s *LocalStore) fileURL(key string) string {
+	query := url.Values{}
+	query.Set("signature", s.sign(http.MethodGet, key, ""))
+	
+	return s.baseURL.JoinPath(key).String() + "?" + query.Encode()
+}
+
+// sign tạo chữ ký HMAC-SHA256 cho method, key, thời điểm hết hạn (rỗng nếu không hết hạn) và các ràng buộc khác của request.
+func (s *LocalStore) sign(method string, key string, expires string, constraints ...string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(strings.Join(append([]string{method, key, expires}, constraints...), "\n")))
+	
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) verify(signature string, method string, key string, expires string, constraints ...string) bool {
+	return hmac.Equal([]byte(signature), []byte(s.sign(method, key, expires, constraints...)))
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:8080"+LocalFilesRoute, "test-signing-key")
+	require.NoError(t, err)
+	
+	return store.(*LocalStore)
+}
+
+// putPresigned gửi body tới presigned URL của upload qua ServeHTTP, headers ghi đè các header của upload.
+func putPresigned(store *LocalStore, upload PresignedUpload, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+	target := strings.TrimPrefix(upload.URL, "http://localhost:8080"+LocalFilesRoute)
+	req := httptest.NewRequest(upload.Method, target, bytes.NewReader(body))
+	for name, value := range upload.Headers {
+		req.Header.Set(name, value)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	
+	recorder := httptest.NewRecorder()
+	http.StripPrefix("/", store).ServeHTTP(recorder, req)
+	
+	return recorder
+}
+
+func TestLocalPresignUpload(t *testing.T) {
+	body := []byte("not really an image")
+	otherBody := []byte("not really an image!")
+	
+	testCases := []struct {
+		name    string
+		body    []byte
+		headers map[string]string
+		expiry  time.Duration
+		status  int
+	}{
+		{
+			name:   "OK",
+			body:   body,
+			expiry: time.Minute,
+			status: http.StatusCreated,
+		},
+		{
+			name:   "Expired",
+			body:   body,
+			expiry: -time.Minute,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "DifferentSize",
+			body:   otherBody,
+			expiry: time.Minute,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "DifferentContent",
+			body:   []byte("not really an imagE"),
+			expiry: time.Minute,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:    "DifferentContentType",
+			body:    body,
+			headers: map[string]string{"Content-Type": "image/svg+xml"},
+			expiry:  time.Minute,
+			status:  http.StatusForbidden,
+		},
+		{
+			name:    "DeclaredHashOfOtherFile",
+			body:    otherBody,
+			headers: map[string]string{LocalContentSHA256Header: sha256Hex(otherBody)},
+			expiry:  time.Minute,
+			status:  http.StatusForbidden,
+		},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := newTestLocalStore(t)
+			key := NewStagingKey("user-1")
+			
+			upload, err := store.PresignUpload(t.Context(), key, UploadConstraints{
+				ContentType: "image/png",
+				Size:        int64(len(body)),
+				SHA256:      sha256Hex(body),
+			}, tc.expiry)
+			require.NoError(t, err)
+			require.Equal(t, key, upload.Key)
+			
+			recorder := putPresigned(store, upload, tc.body, tc.headers)
+			require.Equal(t, tc.status, recorder.Code)
+			
+			data, err := store.DownloadFile(t.Context(), key, 1<<20)
+			if tc.status != http.StatusCreated {
+				require.ErrorIs(t, err, ErrFileNotFound)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, body, data)
+		})
+	}
+}
+
+func TestLocalDownloadFileTooLarge(t *testing.T) {
+	store := newTestLocalStore(t)
+	
+	uploaded, err := store.UploadFile(t.Context(), []byte("0123456789"), "file.txt", "gundams")
+	require.NoError(t, err)
+	
+	_, err = store.DownloadFile(t.Context(), uploaded.Key, 9)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	
+	data, err := store.DownloadFile(t.Context(), uploaded.Key, 10)
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789"), data)
+}
+
+func TestLocalServeSignedFile(t *testing.T) {
+	store := newTestLocalStore(t)
+	
+	uploaded, err := store.UploadFile(t.Context(), []byte("content"), "file.txt", "gundams")
+	require.NoError(t, err)
+	
+	get := func(target string) int {
+		req := httptest.NewRequest(http.MethodGet, strings.TrimPrefix(target, "http://localhost:8080"+LocalFilesRoute), nil)
+		recorder := httptest.NewRecorder()
+		http.StripPrefix("/", store).ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	
+	require.Equal(t, http.StatusOK, get(uploaded.URL))
+	require.Equal(t, http.StatusForbidden, get(strings.Replace(uploaded.URL, "file.txt", "other.txt", 1)))
+}
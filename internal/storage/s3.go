@@ -0,0 +1,403 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service    = "s3"
+	s3Algorithm  = "AWS4-HMAC-SHA256"
+	s3TimeFormat = "20060102T150405Z"
+	s3DateFormat = "20060102"
+	// Thời hạn tối đa của presigned URL theo giới hạn của S3
+	s3MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// S3Config là cấu hình kết nối tới dịch vụ tương thích S3.
+type S3Config struct {
+	Endpoint        string // Ví dụ: https://s3.ap-southeast-1.amazonaws.com hoặc http://localhost:9000 (MinIO)
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL là URL công khai của bucket (ví dụ: CDN), bỏ trống để dùng <Endpoint>/<Bucket>
+	PublicURL string
+}
+
+// S3Store lưu file trong một bucket của dịch vụ tương thích S3 (AWS S3, MinIO, ...).
+// Request được ký bằng AWS Signature Version 4 và dùng path-style URL (<endpoint>/<bucket>/<key>) để chạy được với MinIO.
+// Bucket cần cho phép đọc công khai (hoặc đặt sau CDN) vì URL trả về được lưu vào database và hiển thị trực tiếp.
+type S3Store struct {
+	config     S3Config
+	endpoint   *url.URL
+	publicURL  *url.URL
+	httpClient *http.Client
+}
+
+func NewS3Store(config S3Config) (FileStore, error) {
+	if config.Endpoint == "" || config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 endpoint, bucket, access key ID and secret access key are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", config.Endpoint)
+	}
+	
+	publicURL := endpoint.JoinPath(config.Bucket)
+	if config.PublicURL != "" {
+		publicURL, err = url.Parse(strings.TrimSuffix(config.PublicURL, "/"))
+		if err != nil || publicURL.Scheme == "" || publicURL.Host == "" {
+			return nil, fmt.Errorf("invalid s3 public URL %q", config.PublicURL)
+		}
+	}
+	
+	return &S3Store{
+		config:    config,
+		endpoint:  endpoint,
+		publicURL: publicURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+func (s *S3Store) UploadFile(ctx context.Context, file []byte, filename string, folder string) (StoredObject, error) {
+	key := objectKey(folder, filename)
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(file))
+	if err != nil {
+		return StoredObject{}, fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentTypeOf(filename))
+	req.Header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	
+	if _, err = s.do(req, file); err != nil {
+		return StoredObject{}, fmt.Errorf("failed to upload file %s to s3: %w", key, err)
+	}
+	
+	return StoredObject{
+		Key: key,
+		URL: s.publicURL.JoinPath(key).String(),
+	}, nil
+}
+
+func (s *S3Store) DeleteFile(ctx context.Context, key string) error {
+	if key == "" {
+		return fmt.Errorf("key cannot be empty")
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	
+	// S3 trả về 204 kể cả khi object không tồn tại
+	if _, err = s.do(req, nil); err != nil {
+		return fmt.Errorf("failed to delete file %s from s3: %w", key, err)
+	}
+	
+	return nil
+}
+
+func (s *S3Store) KeyFromURL(fileURL string) (string, error) {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil || parsedURL.Host != s.publicURL.Host {
+		return "", ErrInvalidFileURL
+	}
+	
+	key, found := keyFromPath(parsedURL.Path, s.publicURL.Path)
+	if !found {
+		return "", ErrInvalidFileURL
+	}
+	
+	return key, nil
+}
+
+// listObjectsResult là response của ListObjectsV2.
+type listObjectsResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// ListFiles liệt kê các object có key bắt đầu bằng folder bằng ListObjectsV2, mỗi trang tối đa 1000 object.
+func (s *S3Store) ListFiles(ctx context.Context, folder string) ([]StoredFile, error) {
+	var files []StoredFile
+	continuationToken := ""
+	
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", folder+"/")
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		
+		listURL := s.endpoint.JoinPath(s.config.Bucket)
+		listURL.RawQuery = canonicalQuery(query)
+		
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, listURL.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create s3 request: %w", err)
+		}
+		
+		body, err := s.do(req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in s3 folder %s: %w", folder, err)
+		}
+		
+		var result listObjectsResult
+		if err = xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse s3 list response: %w", err)
+		}
+		
+		for _, object := range result.Contents {
+			files = append(files, StoredFile{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+			})
+		}
+		
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// PresignUpload tạo presigned URL (query string SigV4) để client PUT file trực tiếp lên bucket.
+// Content-Length, Content-Type và X-Amz-Content-Sha256 được ký nên S3 từ chối file có kích thước hoặc nội dung khác.
+func (s *S3Store) PresignUpload(_ context.Context, key string, constraints UploadConstraints, expiry time.Duration) (PresignedUpload, error) {
+	now := time.Now().UTC()
+	expiry = min(expiry, s3MaxPresignExpiry)
+	
+	objectURL, err := url.Parse(s.objectURL(key))
+	if err != nil {
+		return PresignedUpload{}, fmt.Errorf("failed to build s3 object URL: %w", err)
+	}
+	
+	headers := map[string]string{
+		"Content-Length":       strconv.FormatInt(constraints.Size, 10),
+		"Content-Type":         constraints.ContentType,
+		"X-Amz-Content-Sha256": constraints.SHA256,
+	}
+	objectURL.RawQuery = s.presignQuery(http.MethodPut, objectURL, headers, constraints.SHA256, now, expiry)
+	
+	// Client (trình duyệt) tự đặt Content-Length theo body, không được đặt header này
+	delete(headers, "Content-Length")
+	
+	return PresignedUpload{
+		Key:       key,
+		Method:    http.MethodPut,
+		URL:       objectURL.String(),
+		Headers:   headers,
+		ExpiresAt: now.Add(expiry),
+	}, nil
+}
+
+func (s *S3Store) DownloadFile(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	s.signRequest(req, sha256Hex(nil), time.Now().UTC())
+	
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file %s from s3: %w", key, err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFileNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("failed to download file %s from s3: %w", key, responseError(resp))
+	}
+	
+	return readLimited(resp.Body, maxBytes)
+}
+
+// objectURL trả về URL path-style của object trên endpoint.
+func (s *S3Store) objectURL(key string) string {
+	return s.endpoint.JoinPath(s.config.Bucket, key).String()
+}
+
+// do ký request bằng SigV4 (header Authorization), gửi đi và trả về body của response, trả về lỗi nếu status code không phải 2xx.
+func (s *S3Store) do(req *http.Request, body []byte) ([]byte, error) {
+	s.signRequest(req, sha256Hex(body), time.Now().UTC())
+	
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode/100 != 2 {
+		return nil, responseError(resp)
+	}
+	
+	return io.ReadAll(resp.Body)
+}
+
+// signRequest ký request bằng SigV4 tại thời điểm now và đặt header Authorization.
+// Tất cả header đã đặt trên request đều được ký, payloadHash là SHA-256 (hex) của body.
+func (s *S3Store) signRequest(req *http.Request, payloadHash string, now time.Time) {
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	
+	headers := make(map[string]string, len(req.Header))
+	for name := range req.Header {
+		headers[name] = req.Header.Get(name)
+	}
+	canonicalHeaders, signedHeaders := canonicalHeaders(req.URL.Host, headers)
+	
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKeyID, s.credentialScope(now), signedHeaders, s.signature(now, canonicalRequest)))
+	req.Host = req.URL.Host
+}
+
+// presignQuery trả về query string đã ký (SigV4 query string) cho request method tới objectURL, có hiệu lực trong expiry.
+// Request phải được gửi kèm đúng các header trong headers, payloadHash là SHA-256 (hex) của body.
+func (s *S3Store) presignQuery(method string, objectURL *url.URL, headers map[string]string, payloadHash string, now time.Time, expiry time.Duration) string {
+	canonicalHeaders, signedHeaders := canonicalHeaders(objectURL.Host, headers)
+	
+	query := objectURL.Query()
+	query.Set("X-Amz-Algorithm", s3Algorithm)
+	query.Set("X-Amz-Credential", s.config.AccessKeyID+"/"+s.credentialScope(now))
+	query.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI(objectURL.Path),
+		canonicalQuery(query),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	
+	query.Set("X-Amz-Signature", s.signature(now, canonicalRequest))
+	return canonicalQuery(query)
+}
+
+// canonicalHeaders trả về canonical headers và signed headers của SigV4 cho host và headers:
+// tên header viết thường, sắp xếp theo thứ tự, giá trị bỏ khoảng trắng ở hai đầu.
+func canonicalHeaders(host string, headers map[string]string) (string, string) {
+	values := map[string]string{"host": host}
+	for name, value := range headers {
+		if lowerName := strings.ToLower(name); lowerName != "host" {
+			values[lowerName] = strings.TrimSpace(value)
+		}
+	}
+	
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + values[name] + "\n")
+	}
+	
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// responseError trả về lỗi kèm status code và một phần body của response lỗi từ S3.
+func responseError(resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+func (s *S3Store) credentialScope(t time.Time) string {
+	return strings.Join([]string{t.Format(s3DateFormat), s.config.Region, s3Service, "aws4_request"}, "/")
+}
+
+// signature ký canonical request theo SigV4 với signing key được dẫn xuất từ secret access key, ngày, region và service.
+func (s *S3Store) signature(t time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		t.Format(s3TimeFormat),
+		s.credentialScope(t),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+	
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), t.Format(s3DateFormat))
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalURI mã hóa path theo yêu cầu của SigV4: mọi byte ngoài các ký tự không cần mã hóa của RFC 3986
+// (A-Z, a-z, 0-9, '-', '.', '_', '~') và '/' đều được mã hóa thành %XX.
+// EscapedPath của net/url giữ nguyên các ký tự như '$' hay '@' nên không dùng được.
+func canonicalURI(urlPath string) string {
+	if urlPath == "" {
+		return "/"
+	}
+	
+	var uri strings.Builder
+	for _, c := range []byte(urlPath) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~', c == '/':
+			uri.WriteByte(c)
+		default:
+			fmt.Fprintf(&uri, "%%%02X", c)
+		}
+	}
+	
+	return uri.String()
+}
+
+// canonicalQuery mã hóa query string theo yêu cầu của SigV4: sắp xếp theo key và mã hóa khoảng trắng thành %20.
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
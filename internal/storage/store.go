@@ -1,6 +1,170 @@
 package storage
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"path"
+	"strings"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/katatrina/gundam-BE/internal/util"
+)
+
+// Các provider lưu trữ file được hỗ trợ, cấu hình bằng FILE_STORE_PROVIDER
+const (
+	ProviderCloudinary = "cloudinary" // Cloudinary
+	ProviderLocal      = "local"      // Thư mục trên đĩa, phục vụ qua route tĩnh có chữ ký, dùng khi chạy ở local và trong test
+	ProviderS3         = "s3"         // Dịch vụ tương thích S3 (AWS S3, MinIO, Cloudflare R2...)
+)
+
+// StagingFolder chứa file do client tải trực tiếp lên qua PresignUpload, mỗi người dùng một thư mục con.
+// File trong thư mục này chưa được kiểm tra nên không bao giờ được hiển thị: server tải file về, xử lý bằng ProcessImage,
+// lưu ảnh đã xử lý dưới tên theo nội dung vào thư mục ảnh thật rồi xóa file tạm.
+const StagingFolder = "uploads/staging"
+
+var (
+	ErrUnknownProvider     = errors.New("unknown file store provider")
+	ErrInvalidFileURL      = errors.New("file URL does not belong to this file store")
+	ErrFileNotFound        = errors.New("file not found")
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrPresignNotSupported = errors.New("file store does not support presigned uploads")
+)
+
+// StoredObject là file đã được lưu trên file store.
+type StoredObject struct {
+	// Key là khóa ổn định của file trên file store (ví dụ: gundams/<sha256>), dùng để xóa và đối soát file
+	Key string `json:"key"`
+	// URL là URL công khai để hiển thị file, được lưu vào database
+	URL string `json:"url"`
+}
+
+// PresignedUpload là thông tin để client PUT file trực tiếp lên file store mà không đi qua server.
+// Client gửi request với Method tới URL, kèm đúng các Headers, body phải có đúng số byte và SHA-256 đã khai báo khi tạo.
+type PresignedUpload struct {
+	Key       string            `json:"key"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// UploadConstraints là các ràng buộc được ký vào presigned upload, file store từ chối file không khớp.
+type UploadConstraints struct {
+	ContentType string
+	Size        int64  // Số byte của file
+	SHA256      string // SHA-256 (hex, chữ thường) của file
+}
+
+// StoredFile là thông tin của một file trên file store, dùng để đối soát file.
+type StoredFile struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// FileStore lưu trữ file (ảnh) của người dùng.
+// Các phần còn lại của ứng dụng chỉ phụ thuộc vào interface này, không phụ thuộc vào provider.
 type FileStore interface {
-	UploadFile(file []byte, filename string, folder string) (string, error)
-	DeleteFile(publicID string, folder string) error
+	// UploadFile lưu file vào folder với tên filename (gồm phần mở rộng).
+	// Nếu đã có file cùng tên thì file cũ được giữ nguyên, vì tên file được đặt theo nội dung (xem ProcessImage).
+	UploadFile(ctx context.Context, file []byte, filename string, folder string) (StoredObject, error)
+	// DeleteFile xóa file theo key, không trả về lỗi nếu file không tồn tại
+	DeleteFile(ctx context.Context, key string) error
+	// KeyFromURL trả về key của file từ URL do UploadFile trả về
+	KeyFromURL(url string) (string, error)
+	// ListFiles liệt kê tất cả file trong folder (kể cả thư mục con), trả về danh sách rỗng nếu folder không tồn tại
+	ListFiles(ctx context.Context, folder string) ([]StoredFile, error)
+	// PresignUpload tạo thông tin để client tải file thỏa constraints trực tiếp lên key, có hiệu lực trong expiry.
+	// Trả về ErrPresignNotSupported nếu provider không thể ràng buộc kích thước và nội dung của file.
+	PresignUpload(ctx context.Context, key string, constraints UploadConstraints, expiry time.Duration) (PresignedUpload, error)
+	// DownloadFile trả về nội dung của file theo key, trả về ErrFileNotFound nếu file không tồn tại
+	// và ErrFileTooLarge nếu file lớn hơn maxBytes
+	DownloadFile(ctx context.Context, key string, maxBytes int64) ([]byte, error)
+}
+
+// NewFileStoreFromConfig tạo FileStore theo provider được cấu hình.
+// Không provider nào kết nối đến server khi khởi tạo, nên ứng dụng vẫn khởi động được khi provider tạm thời không truy cập được.
+func NewFileStoreFromConfig(config *util.Config) (FileStore, error) {
+	switch strings.ToLower(config.FileStoreProvider) {
+	case ProviderCloudinary, "":
+		return NewCloudinaryStore(config.CloudinaryURL)
+	case ProviderLocal:
+		return NewLocalStore(config.LocalStorageDir, config.LocalStorageBaseURL, config.LocalStorageSigningKey)
+	case ProviderS3:
+		return NewS3Store(S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			PublicURL:       config.S3PublicURL,
+		})
+	default:
+		return nil, ErrUnknownProvider
+	}
+}
+
+// objectKey ghép folder và filename thành key của file.
+func objectKey(folder string, filename string) string {
+	if folder == "" {
+		return filename
+	}
+	
+	return path.Join(folder, filename)
+}
+
+// keyFromPath trả về key của file từ path của URL, basePath là path của URL gốc (có thể rỗng).
+func keyFromPath(urlPath string, basePath string) (string, bool) {
+	key := strings.TrimPrefix(urlPath, "/")
+	if prefix := strings.Trim(basePath, "/"); prefix != "" {
+		var found bool
+		if key, found = strings.CutPrefix(key, prefix+"/"); !found {
+			return "", false
+		}
+	}
+	
+	return key, key != ""
+}
+
+// NewStagingKey tạo key mới trong thư mục tạm của người dùng ownerID cho một presigned upload.
+func NewStagingKey(ownerID string) string {
+	return path.Join(StagingFolder, ownerID, uuid.NewString())
+}
+
+// IsStagingKeyOf cho biết key có phải là file tạm của người dùng ownerID (xem NewStagingKey).
+func IsStagingKeyOf(key string, ownerID string) bool {
+	if ownerID == "" || !fs.ValidPath(key) {
+		return false
+	}
+	
+	name, found := strings.CutPrefix(key, path.Join(StagingFolder, ownerID)+"/")
+	return found && name != "" && !strings.Contains(name, "/")
+}
+
+// readLimited đọc toàn bộ r, trả về ErrFileTooLarge nếu r có nhiều hơn maxBytes byte.
+func readLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrFileTooLarge
+	}
+	
+	return data, nil
+}
+
+// contentTypeOf trả về Content-Type theo phần mở rộng của filename.
+func contentTypeOf(filename string) string {
+	contentType := mime.TypeByExtension(path.Ext(filename))
+	if contentType == "" {
+		return "application/octet-stream"
+	}
+	
+	return contentType
 }
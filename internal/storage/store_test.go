@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"testing"
+	
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStagingKeyOf(t *testing.T) {
+	testCases := []struct {
+		name    string
+		key     string
+		ownerID string
+		want    bool
+	}{
+		{name: "NewStagingKey", key: NewStagingKey("user-1"), ownerID: "user-1", want: true},
+		{name: "OtherUser", key: NewStagingKey("user-2"), ownerID: "user-1", want: false},
+		{name: "OwnerIDPrefix", key: NewStagingKey("user-10"), ownerID: "user-1", want: false},
+		{name: "UserFolder", key: StagingFolder + "/user-1", ownerID: "user-1", want: false},
+		{name: "UserFolderWithSlash", key: StagingFolder + "/user-1/", ownerID: "user-1", want: false},
+		{name: "NestedKey", key: StagingFolder + "/user-1/a/b", ownerID: "user-1", want: false},
+		{name: "PathTraversal", key: StagingFolder + "/user-1/../../../gundams/abc.jpg", ownerID: "user-1", want: false},
+		{name: "ImageFolder", key: "gundams/abc.jpg", ownerID: "user-1", want: false},
+		{name: "EmptyOwner", key: StagingFolder + "//abc", ownerID: "", want: false},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, IsStagingKeyOf(tc.key, tc.ownerID))
+		})
+	}
+}
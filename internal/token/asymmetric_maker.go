@@ -0,0 +1,220 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	SigningMethodHS256 = "HS256"
+	SigningMethodEdDSA = "EdDSA"
+	SigningMethodRS256 = "RS256"
+	
+	minRSAKeyBits = 2048
+)
+
+var (
+	ErrUnknownKeyID = errors.New("unknown signing key id")
+)
+
+// SigningKey là một private key dùng để ký token, được định danh bởi kid
+type SigningKey struct {
+	ID         string
+	PrivateKey crypto.Signer
+}
+
+// AsymmetricMaker ký token bằng private key (Ed25519 hoặc RSA) để các service khác
+// có thể tự xác thực token bằng public key được công bố qua JWKS, không cần chia sẻ secret.
+//
+// Maker giữ nhiều key cùng lúc: token mới luôn được ký bằng active key,
+// còn các key khác chỉ dùng để xác thực token đã cấp trước đó. Để rotate key:
+//  1. Thêm key mới và chuyển active key sang key mới.
+//  2. Giữ key cũ cho đến khi mọi token ký bởi nó hết hạn (tối đa REFRESH_TOKEN_DURATION) rồi mới xóa.
+type AsymmetricMaker struct {
+	method      jwt.SigningMethod
+	activeKeyID string
+	keys        map[string]SigningKey
+}
+
+// NewAsymmetricMaker tạo một AsymmetricMaker với thuật toán ký (EdDSA hoặc RS256),
+// danh sách key và kid của key dùng để ký token mới.
+func NewAsymmetricMaker(signingMethod string, keys []SigningKey, activeKeyID string) (Maker, error) {
+	var method jwt.SigningMethod
+	switch signingMethod {
+	case SigningMethodEdDSA:
+		method = jwt.SigningMethodEdDSA
+	case SigningMethodRS256:
+		method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported asymmetric signing method: %s", signingMethod)
+	}
+	
+	maker := &AsymmetricMaker{
+		method:      method,
+		activeKeyID: activeKeyID,
+		keys:        make(map[string]SigningKey, len(keys)),
+	}
+	
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("signing key id is required")
+		}
+		
+		if _, exists := maker.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate signing key id: %s", key.ID)
+		}
+		
+		if err := validateSigningKey(method, key); err != nil {
+			return nil, err
+		}
+		
+		maker.keys[key.ID] = key
+	}
+	
+	if _, ok := maker.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active signing key %q not found", activeKeyID)
+	}
+	
+	return maker, nil
+}
+
+func (maker *AsymmetricMaker) CreateToken(claims UserClaims, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(claims, tokenType, duration)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create payload: %w", err)
+	}
+	
+	unsignedToken := jwt.NewWithClaims(maker.method, payload)
+	unsignedToken.Header["kid"] = maker.activeKeyID
+	
+	signedToken, err := unsignedToken.SignedString(maker.keys[maker.activeKeyID].PrivateKey)
+	return signedToken, &payload, err
+}
+
+func (maker *AsymmetricMaker) VerifyToken(tokenString string, tokenType TokenType) (*Payload, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Payload{}, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		
+		key, ok := maker.keys[keyID]
+		if !ok {
+			return nil, ErrUnknownKeyID
+		}
+		
+		return key.PrivateKey.Public(), nil
+	}, jwt.WithValidMethods([]string{maker.method.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	
+	return verifyPayload(token, tokenType)
+}
+
+// JWKS trả về public key của tất cả các key đang được giữ (kể cả key đã rotate nhưng chưa xóa)
+func (maker *AsymmetricMaker) JWKS() JSONWebKeySet {
+	keyIDs := make([]string, 0, len(maker.keys))
+	for keyID := range maker.keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	
+	set := JSONWebKeySet{
+		Keys: make([]JSONWebKey, 0, len(keyIDs)),
+	}
+	for _, keyID := range keyIDs {
+		set.Keys = append(set.Keys, newJSONWebKey(keyID, maker.method.Alg(), maker.keys[keyID].PrivateKey.Public()))
+	}
+	
+	return set
+}
+
+// LoadSigningKeys đọc tất cả private key định dạng PEM (*.pem) trong thư mục.
+// Tên file (bỏ phần mở rộng) được dùng làm kid, ví dụ: 2025-06-01.pem -> kid "2025-06-01".
+func LoadSigningKeys(dir string) ([]SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s", dir)
+	}
+	
+	keys := make([]SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key %s: %w", path, err)
+		}
+		
+		privateKey, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+		}
+		
+		keys = append(keys, SigningKey{
+			ID:         strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+			PrivateKey: privateKey,
+		})
+	}
+	
+	return keys, nil
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM data")
+	}
+	
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		
+		return signer, nil
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type: %s", block.Type)
+	}
+}
+
+func validateSigningKey(method jwt.SigningMethod, key SigningKey) error {
+	switch privateKey := key.PrivateKey.(type) {
+	case ed25519.PrivateKey:
+		if method != jwt.SigningMethodEdDSA {
+			return fmt.Errorf("signing key %s is an Ed25519 key but signing method is %s", key.ID, method.Alg())
+		}
+	case *rsa.PrivateKey:
+		if method != jwt.SigningMethodRS256 {
+			return fmt.Errorf("signing key %s is an RSA key but signing method is %s", key.ID, method.Alg())
+		}
+		
+		if privateKey.N.BitLen() < minRSAKeyBits {
+			return fmt.Errorf("signing key %s is too short: must be at least %d bits", key.ID, minRSAKeyBits)
+		}
+	default:
+		return fmt.Errorf("signing key %s has unsupported type %T", key.ID, key.PrivateKey)
+	}
+	
+	return nil
+}
@@ -0,0 +1,57 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWKSProvider được implement bởi các Maker có public key để công bố (ký bất đối xứng).
+// JWTMaker (HMAC) không implement interface này vì secret key không được phép công khai.
+type JWKSProvider interface {
+	JWKS() JSONWebKeySet
+}
+
+// JSONWebKeySet theo RFC 7517, được trả về tại /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey là public key dạng JWK (RFC 7517, RFC 8037 cho Ed25519)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	
+	// RSA
+	Modulus  string `json:"n,omitempty"`
+	Exponent string `json:"e,omitempty"`
+}
+
+func newJSONWebKey(keyID string, algorithm string, publicKey crypto.PublicKey) JSONWebKey {
+	jwk := JSONWebKey{
+		KeyID:     keyID,
+		Use:       "sig",
+		Algorithm: algorithm,
+	}
+	
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.Modulus = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	}
+	
+	return jwk
+}
@@ -24,8 +24,8 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 	return &JWTMaker{secretKey}, nil
 }
 
-func (maker *JWTMaker) CreateToken(userID string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(userID, duration)
+func (maker *JWTMaker) CreateToken(claims UserClaims, tokenType TokenType, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(claims, tokenType, duration)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create payload: %w", err)
 	}
@@ -36,7 +36,7 @@ func (maker *JWTMaker) CreateToken(userID string, duration time.Duration) (strin
 	return signedToken, &payload, err
 }
 
-func (maker *JWTMaker) VerifyToken(tokenString string) (*Payload, error) {
+func (maker *JWTMaker) VerifyToken(tokenString string, tokenType TokenType) (*Payload, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Payload{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -48,10 +48,5 @@ func (maker *JWTMaker) VerifyToken(tokenString string) (*Payload, error) {
 		return nil, err
 	}
 	
-	payload, ok := token.Claims.(*Payload)
-	if !ok {
-		return nil, fmt.Errorf("unknown payload type, cannot proceed")
-	}
-	
-	return payload, nil
+	return verifyPayload(token, tokenType)
 }
@@ -5,6 +5,6 @@ import (
 )
 
 type Maker interface {
-	CreateToken(userID string, duration time.Duration) (token string, payload *Payload, err error)
-	VerifyToken(tokenString string) (payload *Payload, err error)
+	CreateToken(claims UserClaims, tokenType TokenType, duration time.Duration) (token string, payload *Payload, err error)
+	VerifyToken(tokenString string, tokenType TokenType) (payload *Payload, err error)
 }
@@ -1,28 +1,70 @@
 package token
 
 import (
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 	
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// TokenType phân biệt access token và refresh token để không thể dùng lẫn cho nhau
+type TokenType string
+
+const (
+	TokenTypeAccessToken  TokenType = "access"
+	TokenTypeRefreshToken TokenType = "refresh"
+	
+	// TokenTypeMFAChallenge là token ngắn hạn được cấp sau khi người dùng nhập đúng mật khẩu
+	// nhưng chưa xác thực bước 2 (TOTP), chỉ dùng để đổi lấy access token tại /auth/login/2fa
+	TokenTypeMFAChallenge TokenType = "mfa_challenge"
+)
+
+var (
+	ErrInvalidTokenType = errors.New("invalid token type")
+)
+
+// UserClaims là thông tin của người dùng được nhúng vào token
+type UserClaims struct {
+	UserID       string
+	Role         string
+	TokenVersion int64 // users.token_version tại thời điểm cấp token
+	MFA          bool  // Người dùng đã xác thực hai lớp (TOTP) khi đăng nhập
+}
+
 type Payload struct {
+	Type TokenType `json:"token_type"`
+	
+	// Các claim phân quyền chỉ có trong access token, giúp middleware phân quyền mà không cần truy vấn database.
+	// Refresh token không mang các claim này, khi refresh sẽ đọc lại role mới nhất từ database.
+	Role               string       `json:"role,omitempty"`
+	Permissions        []Permission `json:"permissions,omitempty"`
+	PermissionsVersion int          `json:"permissions_version,omitempty"`
+	TokenVersion       int64        `json:"token_version"`
+	
+	// MFA cho biết phiên đăng nhập đã qua xác thực hai lớp, có trong cả access token và refresh token
+	// để giữ nguyên qua các lần refresh.
+	MFA bool `json:"mfa,omitempty"`
+	
 	jwt.RegisteredClaims
 }
 
-func NewPayload(userID string, duration time.Duration) (payload Payload, err error) {
+func NewPayload(claims UserClaims, tokenType TokenType, duration time.Duration) (payload Payload, err error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return payload, fmt.Errorf("failed to generate tokenID: %w", err)
 	}
 	
 	payload = Payload{
+		Type:         tokenType,
+		TokenVersion: claims.TokenVersion,
+		MFA:          claims.MFA,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        tokenID.String(),
 			Issuer:    "cvp",
-			Subject:   userID,
+			Subject:   claims.UserID,
 			Audience:  jwt.ClaimStrings{"client"},
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -30,5 +72,40 @@ func NewPayload(userID string, duration time.Duration) (payload Payload, err err
 		},
 	}
 	
+	if tokenType == TokenTypeAccessToken {
+		payload.Role = claims.Role
+		payload.Permissions = PermissionsForRole(claims.Role)
+		payload.PermissionsVersion = PermissionSetVersion
+	}
+	
 	return payload, nil
 }
+
+// verifyPayload kiểm tra các claim của token đã được xác thực chữ ký
+func verifyPayload(token *jwt.Token, tokenType TokenType) (*Payload, error) {
+	payload, ok := token.Claims.(*Payload)
+	if !ok {
+		return nil, fmt.Errorf("unknown payload type, cannot proceed")
+	}
+	
+	if payload.Type != tokenType {
+		return nil, ErrInvalidTokenType
+	}
+	
+	// Access token được cấp với bộ quyền cũ phải được refresh lại
+	if tokenType == TokenTypeAccessToken && payload.PermissionsVersion != PermissionSetVersion {
+		return nil, ErrStalePermissions
+	}
+	
+	return payload, nil
+}
+
+// HasRole kiểm tra role của người dùng trong access token
+func (payload *Payload) HasRole(role string) bool {
+	return payload.Role == role
+}
+
+// HasPermission kiểm tra access token có chứa quyền được yêu cầu hay không
+func (payload *Payload) HasPermission(permission Permission) bool {
+	return slices.Contains(payload.Permissions, permission)
+}
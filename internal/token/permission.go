@@ -0,0 +1,68 @@
+package token
+
+import (
+	"errors"
+	"slices"
+)
+
+// PermissionSetVersion là phiên bản của bộ quyền bên dưới.
+// Mỗi khi thay đổi quyền của một role (thêm, bớt, đổi tên), cần tăng version này
+// để các access token được cấp với bộ quyền cũ bị từ chối và client phải refresh lại token.
+const PermissionSetVersion = 2
+
+var (
+	ErrStalePermissions = errors.New("token permissions are outdated, please refresh the token")
+)
+
+// Permission là một quyền cụ thể được nhúng vào access token
+type Permission string
+
+const (
+	PermissionManageProfile       Permission = "profile:manage"
+	PermissionPlaceOrders         Permission = "orders:place"
+	PermissionTradeExchanges      Permission = "exchanges:trade"
+	PermissionBidAuctions         Permission = "auctions:bid"
+	PermissionManageWallet        Permission = "wallet:manage"
+	PermissionSellGundams         Permission = "gundams:sell"
+	PermissionManageSalesOrders   Permission = "sales_orders:manage"
+	PermissionRequestAuctions     Permission = "auction_requests:create"
+	PermissionModerateAuctions    Permission = "auctions:moderate"
+	PermissionProcessWithdrawals  Permission = "withdrawals:process"
+	PermissionModerateCatalog     Permission = "catalog:moderate" // Danh mục model kit và ảnh đăng bán bị gắn cờ
+	PermissionViewPlatformMetrics Permission = "platform_metrics:view"
+	PermissionManageFileStorage   Permission = "file_storage:manage" // Đối soát và dọn dẹp file trên file store
+)
+
+var memberPermissions = []Permission{
+	PermissionManageProfile,
+	PermissionPlaceOrders,
+	PermissionTradeExchanges,
+	PermissionBidAuctions,
+	PermissionManageWallet,
+}
+
+// rolePermissions ánh xạ role của người dùng (users.role) sang bộ quyền tương ứng
+var rolePermissions = map[string][]Permission{
+	"member": memberPermissions,
+	"seller": append(slices.Clone(memberPermissions),
+		PermissionSellGundams,
+		PermissionManageSalesOrders,
+		PermissionRequestAuctions,
+	),
+	"moderator": {
+		PermissionManageProfile,
+		PermissionModerateAuctions,
+		PermissionProcessWithdrawals,
+		PermissionModerateCatalog,
+	},
+	"admin": {
+		PermissionManageProfile,
+		PermissionViewPlatformMetrics,
+		PermissionManageFileStorage,
+	},
+}
+
+// PermissionsForRole trả về bộ quyền của một role. Role không xác định sẽ không có quyền nào.
+func PermissionsForRole(role string) []Permission {
+	return slices.Clone(rolePermissions[role])
+}
@@ -0,0 +1,173 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	refreshTokenKeyPrefix       = "refresh_token"        // refresh_token:<jti> -> thông tin của refresh token
+	refreshTokenFamilyKeyPrefix = "refresh_token_family" // refresh_token_family:<family_id> -> user ID
+	userRefreshFamiliesPrefix   = "user_refresh_token_families"
+)
+
+var (
+	ErrRefreshTokenRevoked = errors.New("refresh token has been revoked")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// RefreshTokenStore lưu trạng thái của refresh token trong Redis.
+// Mỗi lần đăng nhập tạo ra một "family" (chuỗi refresh token), mỗi lần refresh sẽ
+// xoay vòng (rotate) sang một refresh token mới trong cùng family. Nếu một refresh token
+// đã dùng bị gửi lại, toàn bộ family sẽ bị thu hồi vì có khả năng token đã bị đánh cắp.
+type RefreshTokenStore struct {
+	redis *redis.Client
+}
+
+// NewRefreshTokenStore tạo một instance mới của RefreshTokenStore
+func NewRefreshTokenStore(redis *redis.Client) *RefreshTokenStore {
+	return &RefreshTokenStore{
+		redis: redis,
+	}
+}
+
+// NewFamilyID tạo ID cho một family refresh token mới (mỗi lần đăng nhập)
+func NewFamilyID() string {
+	return uuid.NewString()
+}
+
+// Save lưu refresh token vừa được tạo vào family tương ứng.
+func (s *RefreshTokenStore) Save(ctx context.Context, payload *Payload, familyID string) error {
+	ttl := time.Until(payload.ExpiresAt.Time)
+	if ttl <= 0 {
+		return fmt.Errorf("refresh token %s already expired", payload.ID)
+	}
+	
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		tokenKey := refreshTokenKey(payload.ID)
+		pipe.HSet(ctx, tokenKey, map[string]interface{}{
+			"user_id":   payload.Subject,
+			"family_id": familyID,
+			"used":      0,
+		})
+		pipe.Expire(ctx, tokenKey, ttl)
+		
+		// Family sống ít nhất bằng refresh token mới nhất của nó
+		pipe.Set(ctx, refreshTokenFamilyKey(familyID), payload.Subject, ttl)
+		
+		familiesKey := userRefreshFamiliesKey(payload.Subject)
+		pipe.SAdd(ctx, familiesKey, familyID)
+		pipe.ExpireGT(ctx, familiesKey, ttl)
+		pipe.ExpireNX(ctx, familiesKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save refresh token: %w", err)
+	}
+	
+	return nil
+}
+
+// Rotate đánh dấu refresh token đã được sử dụng và trả về family ID của nó
+// để cấp refresh token mới trong cùng family.
+// Nếu token đã được dùng trước đó, toàn bộ family sẽ bị thu hồi và trả về ErrRefreshTokenReused.
+func (s *RefreshTokenStore) Rotate(ctx context.Context, payload *Payload) (familyID string, err error) {
+	familyID, err = s.FamilyID(ctx, payload)
+	if err != nil {
+		return "", err
+	}
+	
+	// HINCRBY là atomic nên chỉ có đúng một request được phép dùng token này
+	used, err := s.redis.HIncrBy(ctx, refreshTokenKey(payload.ID), "used", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	
+	if used > 1 {
+		if revokeErr := s.RevokeFamily(ctx, payload.Subject, familyID); revokeErr != nil {
+			return "", fmt.Errorf("failed to revoke reused refresh token family: %w", revokeErr)
+		}
+		
+		return "", ErrRefreshTokenReused
+	}
+	
+	return familyID, nil
+}
+
+// FamilyID trả về family ID của refresh token nếu token và family của nó vẫn còn hiệu lực.
+func (s *RefreshTokenStore) FamilyID(ctx context.Context, payload *Payload) (string, error) {
+	values, err := s.redis.HMGet(ctx, refreshTokenKey(payload.ID), "user_id", "family_id").Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	
+	userID, _ := values[0].(string)
+	familyID, _ := values[1].(string)
+	if userID == "" || familyID == "" || userID != payload.Subject {
+		return "", ErrRefreshTokenRevoked
+	}
+	
+	exists, err := s.redis.Exists(ctx, refreshTokenFamilyKey(familyID)).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to get refresh token family: %w", err)
+	}
+	
+	if exists == 0 {
+		return "", ErrRefreshTokenRevoked
+	}
+	
+	return familyID, nil
+}
+
+// RevokeFamily thu hồi toàn bộ refresh token thuộc một family (đăng xuất khỏi một thiết bị).
+func (s *RefreshTokenStore) RevokeFamily(ctx context.Context, userID, familyID string) error {
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, refreshTokenFamilyKey(familyID))
+		pipe.SRem(ctx, userRefreshFamiliesKey(userID), familyID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	
+	return nil
+}
+
+// RevokeAllForUser thu hồi tất cả refresh token của người dùng (đăng xuất khỏi tất cả thiết bị).
+func (s *RefreshTokenStore) RevokeAllForUser(ctx context.Context, userID string) error {
+	familiesKey := userRefreshFamiliesKey(userID)
+	
+	familyIDs, err := s.redis.SMembers(ctx, familiesKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list refresh token families: %w", err)
+	}
+	
+	keys := make([]string, 0, len(familyIDs)+1)
+	for _, familyID := range familyIDs {
+		keys = append(keys, refreshTokenFamilyKey(familyID))
+	}
+	keys = append(keys, familiesKey)
+	
+	if err = s.redis.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to revoke refresh token families: %w", err)
+	}
+	
+	return nil
+}
+
+func refreshTokenKey(tokenID string) string {
+	return fmt.Sprintf("%s:%s", refreshTokenKeyPrefix, tokenID)
+}
+
+func refreshTokenFamilyKey(familyID string) string {
+	return fmt.Sprintf("%s:%s", refreshTokenFamilyKeyPrefix, familyID)
+}
+
+func userRefreshFamiliesKey(userID string) string {
+	return fmt.Sprintf("%s:%s", userRefreshFamiliesPrefix, userID)
+}
@@ -0,0 +1,294 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sessionKeyPrefix       = "session"        // session:<session_id> -> thông tin phiên đăng nhập
+	sessionTokensKeyPrefix = "session_tokens" // session_tokens:<session_id> -> set các jti đã cấp trong phiên
+	sessionTokenKeyPrefix  = "session_token"  // session_token:<jti> -> session ID
+	userSessionsKeyPrefix  = "user_sessions"  // user_sessions:<user_id> -> set các session ID của người dùng
+	
+	// Chỉ ghi lại last_seen_at tối đa mỗi phút một lần để giảm số lần ghi vào Redis
+	lastSeenUpdateInterval = time.Minute
+)
+
+var (
+	ErrSessionRevoked  = errors.New("session has been revoked, please log in again")
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// touchSessionScript tìm phiên đăng nhập của token theo jti và cập nhật thời điểm hoạt động gần nhất.
+// Trả về session ID, hoặc chuỗi rỗng nếu token không thuộc phiên nào còn hiệu lực.
+var touchSessionScript = redis.NewScript(`
+local session_id = redis.call('GET', KEYS[1])
+if not session_id then
+	return ''
+end
+
+local session_key = ARGV[1] .. ':' .. session_id
+local last_seen = redis.call('HGET', session_key, 'last_seen_at')
+if not last_seen then
+	return ''
+end
+
+if tonumber(ARGV[2]) - tonumber(last_seen) >= tonumber(ARGV[3]) then
+	redis.call('HSET', session_key, 'last_seen_at', ARGV[2], 'ip_address', ARGV[4])
+end
+
+return session_id
+`)
+
+// Session là một phiên đăng nhập của người dùng trên một thiết bị.
+// Mỗi lần đăng nhập tạo ra một phiên mới, ID của phiên trùng với family ID của refresh token.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"-"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// SessionClient là thông tin của thiết bị đang sử dụng phiên đăng nhập
+type SessionClient struct {
+	Device    string
+	UserAgent string
+	IPAddress string
+}
+
+// SessionStore lưu các phiên đăng nhập và mọi token (theo jti) được cấp trong mỗi phiên.
+// Middleware dùng jti của access token để kiểm tra phiên còn hiệu lực, nên thu hồi một phiên
+// sẽ vô hiệu hóa ngay lập tức cả access token lẫn refresh token của phiên đó.
+type SessionStore struct {
+	redis *redis.Client
+}
+
+// NewSessionStore tạo một instance mới của SessionStore
+func NewSessionStore(redis *redis.Client) *SessionStore {
+	return &SessionStore{
+		redis: redis,
+	}
+}
+
+// Track ghi nhận các token vừa được cấp vào phiên sessionID, tạo phiên mới nếu chưa tồn tại.
+// Phiên sống ít nhất bằng token có thời hạn dài nhất của nó.
+func (s *SessionStore) Track(ctx context.Context, sessionID string, client SessionClient, payloads ...*Payload) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+	
+	userID := payloads[0].Subject
+	now := time.Now()
+	
+	var expiresAt time.Time
+	for _, payload := range payloads {
+		if payload.ExpiresAt.After(expiresAt) {
+			expiresAt = payload.ExpiresAt.Time
+		}
+	}
+	
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("session %s already expired", sessionID)
+	}
+	
+	_, err := s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		key := sessionKey(sessionID)
+		pipe.HSetNX(ctx, key, "created_at", now.Unix())
+		pipe.HSet(ctx, key, map[string]interface{}{
+			"user_id":      userID,
+			"device":       client.Device,
+			"user_agent":   client.UserAgent,
+			"ip_address":   client.IPAddress,
+			"last_seen_at": now.Unix(),
+			"expires_at":   expiresAt.Unix(),
+		})
+		pipe.Expire(ctx, key, ttl)
+		
+		tokensKey := sessionTokensKey(sessionID)
+		for _, payload := range payloads {
+			pipe.Set(ctx, sessionTokenKey(payload.ID), sessionID, time.Until(payload.ExpiresAt.Time))
+			pipe.SAdd(ctx, tokensKey, payload.ID)
+		}
+		pipe.Expire(ctx, tokensKey, ttl)
+		
+		sessionsKey := userSessionsKey(userID)
+		pipe.SAdd(ctx, sessionsKey, sessionID)
+		pipe.ExpireGT(ctx, sessionsKey, ttl)
+		pipe.ExpireNX(ctx, sessionsKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to track session: %w", err)
+	}
+	
+	return nil
+}
+
+// Touch kiểm tra token (theo jti) thuộc một phiên còn hiệu lực và cập nhật thời điểm hoạt động gần nhất của phiên.
+// Trả về ErrSessionRevoked nếu phiên đã bị thu hồi hoặc hết hạn.
+func (s *SessionStore) Touch(ctx context.Context, payload *Payload, ipAddress string) (sessionID string, err error) {
+	sessionID, err = touchSessionScript.Run(ctx, s.redis, []string{sessionTokenKey(payload.ID)},
+		sessionKeyPrefix,
+		time.Now().Unix(),
+		int64(lastSeenUpdateInterval.Seconds()),
+		ipAddress,
+	).Text()
+	if err != nil {
+		return "", fmt.Errorf("failed to check session: %w", err)
+	}
+	
+	if sessionID == "" {
+		return "", ErrSessionRevoked
+	}
+	
+	return sessionID, nil
+}
+
+// List trả về các phiên đăng nhập còn hiệu lực của người dùng, phiên hoạt động gần nhất đứng đầu.
+func (s *SessionStore) List(ctx context.Context, userID string) ([]Session, error) {
+	sessionsKey := userSessionsKey(userID)
+	
+	sessionIDs, err := s.redis.SMembers(ctx, sessionsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	
+	cmds := make([]*redis.MapStringStringCmd, len(sessionIDs))
+	_, err = s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, sessionID := range sessionIDs {
+			cmds[i] = pipe.HGetAll(ctx, sessionKey(sessionID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	
+	sessions := make([]Session, 0, len(sessionIDs))
+	var expired []interface{}
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		if len(values) == 0 || values["user_id"] != userID {
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+		
+		sessions = append(sessions, parseSession(sessionIDs[i], values))
+	}
+	
+	// Dọn các phiên đã hết hạn khỏi danh sách của người dùng
+	if len(expired) > 0 {
+		if err = s.redis.SRem(ctx, sessionsKey, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to remove expired sessions: %w", err)
+		}
+	}
+	
+	slices.SortFunc(sessions, func(a, b Session) int {
+		return b.LastSeenAt.Compare(a.LastSeenAt)
+	})
+	
+	return sessions, nil
+}
+
+// Revoke thu hồi một phiên đăng nhập của người dùng cùng mọi token đã được cấp trong phiên.
+// Trả về ErrSessionNotFound nếu phiên không tồn tại hoặc không thuộc về người dùng.
+func (s *SessionStore) Revoke(ctx context.Context, userID, sessionID string) error {
+	owner, err := s.redis.HGet(ctx, sessionKey(sessionID), "user_id").Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	
+	if owner != userID {
+		return ErrSessionNotFound
+	}
+	
+	return s.revoke(ctx, userID, sessionID)
+}
+
+// RevokeAllForUser thu hồi tất cả phiên đăng nhập của người dùng.
+func (s *SessionStore) RevokeAllForUser(ctx context.Context, userID string) error {
+	sessionIDs, err := s.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	
+	for _, sessionID := range sessionIDs {
+		if err = s.revoke(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+func (s *SessionStore) revoke(ctx context.Context, userID, sessionID string) error {
+	tokensKey := sessionTokensKey(sessionID)
+	
+	tokenIDs, err := s.redis.SMembers(ctx, tokensKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list session tokens: %w", err)
+	}
+	
+	keys := make([]string, 0, len(tokenIDs)+2)
+	for _, tokenID := range tokenIDs {
+		keys = append(keys, sessionTokenKey(tokenID))
+	}
+	keys = append(keys, tokensKey, sessionKey(sessionID))
+	
+	_, err = s.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	
+	return nil
+}
+
+func parseSession(sessionID string, values map[string]string) Session {
+	return Session{
+		ID:         sessionID,
+		UserID:     values["user_id"],
+		Device:     values["device"],
+		UserAgent:  values["user_agent"],
+		IPAddress:  values["ip_address"],
+		CreatedAt:  parseUnix(values["created_at"]),
+		LastSeenAt: parseUnix(values["last_seen_at"]),
+		ExpiresAt:  parseUnix(values["expires_at"]),
+	}
+}
+
+func parseUnix(value string) time.Time {
+	seconds, _ := strconv.ParseInt(value, 10, 64)
+	return time.Unix(seconds, 0)
+}
+
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("%s:%s", sessionKeyPrefix, sessionID)
+}
+
+func sessionTokensKey(sessionID string) string {
+	return fmt.Sprintf("%s:%s", sessionTokensKeyPrefix, sessionID)
+}
+
+func sessionTokenKey(tokenID string) string {
+	return fmt.Sprintf("%s:%s", sessionTokenKeyPrefix, tokenID)
+}
+
+func userSessionsKey(userID string) string {
+	return fmt.Sprintf("%s:%s", userSessionsKeyPrefix, userID)
+}
@@ -0,0 +1,80 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	tokenVersionKeyPrefix = "user_token_version" // user_token_version:<user_id> -> users.token_version
+)
+
+var (
+	ErrTokenVersionRevoked = errors.New("token has been revoked because user permissions changed, please refresh the token")
+)
+
+// setTokenVersionScript chỉ ghi đè version khi version mới lớn hơn version hiện tại,
+// tránh trường hợp một request đăng nhập đọc version cũ từ database rồi ghi đè lên version vừa được tăng.
+var setTokenVersionScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]))
+if current == nil or current < tonumber(ARGV[1]) then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+else
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// TokenVersionStore lưu bản sao của users.token_version trong Redis để middleware
+// có thể từ chối access token cũ mà không cần truy vấn database ở mỗi request.
+type TokenVersionStore struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+// NewTokenVersionStore tạo một instance mới của TokenVersionStore.
+// ttl nên bằng thời hạn của refresh token: mỗi lần cấp token version sẽ được ghi lại,
+// nên mọi access token còn hiệu lực đều được cấp khi key vẫn còn tồn tại.
+func NewTokenVersionStore(redis *redis.Client, ttl time.Duration) *TokenVersionStore {
+	return &TokenVersionStore{
+		redis: redis,
+		ttl:   ttl,
+	}
+}
+
+// Set ghi lại token version hiện tại của người dùng
+func (s *TokenVersionStore) Set(ctx context.Context, userID string, version int64) error {
+	err := setTokenVersionScript.Run(ctx, s.redis, []string{tokenVersionKey(userID)}, version, s.ttl.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set token version: %w", err)
+	}
+	
+	return nil
+}
+
+// Verify trả về ErrTokenVersionRevoked nếu token được cấp trước lần thay đổi quyền gần nhất của người dùng.
+// Nếu không tìm thấy version trong Redis, token được coi là hợp lệ.
+func (s *TokenVersionStore) Verify(ctx context.Context, payload *Payload) error {
+	current, err := s.redis.Get(ctx, tokenVersionKey(payload.Subject)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		
+		return fmt.Errorf("failed to get token version: %w", err)
+	}
+	
+	if payload.TokenVersion < current {
+		return ErrTokenVersionRevoked
+	}
+	
+	return nil
+}
+
+func tokenVersionKey(userID string) string {
+	return fmt.Sprintf("%s:%s", tokenVersionKeyPrefix, userID)
+}
@@ -0,0 +1,142 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Tham số theo RFC 6238, trùng với mặc định của Google Authenticator, Authy, 1Password...
+const (
+	Digits     = 6
+	Period     = 30 * time.Second
+	secretSize = 20 // 160 bit, khuyến nghị của RFC 4226 cho HMAC-SHA1
+	
+	// Chấp nhận mã của bước thời gian liền trước và liền sau để bù sai lệch đồng hồ của thiết bị
+	allowedSkew = 1
+	
+	RecoveryCodeCount = 10
+	recoveryCodeSize  = 5 // 5 byte -> 8 ký tự base32, hiển thị dạng xxxx-xxxx
+)
+
+var (
+	ErrInvalidCode   = errors.New("invalid two-factor authentication code")
+	ErrInvalidSecret = errors.New("invalid TOTP secret")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret tạo secret ngẫu nhiên dạng base32 (không padding) để nhập vào ứng dụng xác thực
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	
+	return encoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI trả về URI otpauth:// để client hiển thị dưới dạng QR code.
+// Định dạng: https://github.com/google/google-authenticator/wiki/Key-Uri-Format
+func ProvisioningURI(issuer, accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", Digits))
+	query.Set("period", fmt.Sprintf("%d", int(Period.Seconds())))
+	
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	
+	return uri.String()
+}
+
+// Step trả về bước thời gian (time step) của thời điểm t
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// GenerateCode tạo mã TOTP của secret tại bước thời gian step
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+	
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	
+	// Dynamic truncation (RFC 4226, mục 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	
+	return fmt.Sprintf("%0*d", Digits, value%1_000_000), nil
+}
+
+// Validate kiểm tra mã TOTP tại thời điểm t, cho phép lệch allowedSkew bước thời gian.
+// Trả về bước thời gian khớp với mã để người gọi chặn việc dùng lại cùng một mã (replay).
+func Validate(secret, code string, t time.Time) (int64, error) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, ErrInvalidCode
+	}
+	
+	current := Step(t)
+	for skew := -allowedSkew; skew <= allowedSkew; skew++ {
+		step := current + int64(skew)
+		
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, err
+		}
+		
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+	
+	return 0, ErrInvalidCode
+}
+
+// GenerateRecoveryCodes tạo các mã khôi phục dùng một lần, dùng khi người dùng mất thiết bị xác thực
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	for range RecoveryCodeCount {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		
+		code := strings.ToLower(encoding.EncodeToString(raw))
+		codes = append(codes, code[:4]+"-"+code[4:])
+	}
+	
+	return codes, nil
+}
+
+// HashRecoveryCode băm mã khôi phục trước khi lưu vào database.
+// Mã có đủ độ ngẫu nhiên (40 bit) và chỉ dùng một lần nên SHA-256 là đủ, không cần bcrypt.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
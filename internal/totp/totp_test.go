@@ -0,0 +1,136 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	
+	"github.com/stretchr/testify/require"
+)
+
+// Secret ASCII "12345678901234567890" của các test vector trong RFC 6238, phụ lục B
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	// Mã 8 chữ số của RFC được cắt còn 6 chữ số cuối
+	testCases := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	
+	for _, tc := range testCases {
+		code, err := GenerateCode(rfcSecret, Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		require.Equal(t, tc.want, code, "T=%d", tc.unix)
+	}
+	
+	// Secret nhập tay có thể là chữ thường
+	code, err := GenerateCode(strings.ToLower(rfcSecret), Step(time.Unix(59, 0)))
+	require.NoError(t, err)
+	require.Equal(t, "287082", code)
+	
+	_, err = GenerateCode("not base32!", 1)
+	require.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	codeAt := func(step int64) string {
+		code, err := GenerateCode(rfcSecret, step)
+		require.NoError(t, err)
+		return code
+	}
+	
+	testCases := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantErr  error
+	}{
+		{name: "CurrentStep", code: codeAt(step), wantStep: step},
+		{name: "PreviousStep", code: codeAt(step - 1), wantStep: step - 1},
+		{name: "NextStep", code: codeAt(step + 1), wantStep: step + 1},
+		{name: "Whitespace", code: " " + codeAt(step) + "\n", wantStep: step},
+		{name: "TooOld", code: codeAt(step - 2), wantErr: ErrInvalidCode},
+		{name: "TooNew", code: codeAt(step + 2), wantErr: ErrInvalidCode},
+		{name: "TooShort", code: codeAt(step)[:Digits-1], wantErr: ErrInvalidCode},
+		{name: "TooLong", code: codeAt(step) + "0", wantErr: ErrInvalidCode},
+		{name: "Empty", code: "", wantErr: ErrInvalidCode},
+	}
+	
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := Validate(rfcSecret, tc.code, now)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			
+			require.NoError(t, err)
+			require.Equal(t, tc.wantStep, matched)
+		})
+	}
+	
+	_, err := Validate("not base32!", "123456", now)
+	require.ErrorIs(t, err, ErrInvalidSecret)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+	
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+	
+	now := time.Now()
+	code, err := GenerateCode(secret, Step(now))
+	require.NoError(t, err)
+	_, err = Validate(secret, code, now)
+	require.NoError(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("Gundam Platform", "mod@example.com", rfcSecret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Gundam Platform:mod@example.com", uri.Path)
+	
+	query := uri.Query()
+	require.Equal(t, rfcSecret, query.Get("secret"))
+	require.Equal(t, "Gundam Platform", query.Get("issuer"))
+	require.Equal(t, "SHA1", query.Get("algorithm"))
+	require.Equal(t, "6", query.Get("digits"))
+	require.Equal(t, "30", query.Get("period"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		require.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		require.False(t, seen[code], "duplicate recovery code %s", code)
+		seen[code] = true
+	}
+	
+	// Mã người dùng nhập có thể viết hoa, thiếu dấu gạch hoặc thừa khoảng trắng
+	hash := HashRecoveryCode(codes[0])
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashRecoveryCode(" "+strings.ToUpper(codes[0])+" "))
+	require.Equal(t, hash, HashRecoveryCode(strings.ReplaceAll(codes[0], "-", "")))
+	require.NotEqual(t, hash, HashRecoveryCode(codes[1]))
+}
@@ -19,24 +19,69 @@ const (
 // Config stores all configuration of the application.
 // The values are read by viper from a config file or environment variables.
 type Config struct {
-	AllowedOrigins      []string      `mapstructure:"ALLOWED_ORIGINS"`
-	DatabaseURL         string        `mapstructure:"DATABASE_URL"`
-	HTTPServerAddress   string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	TokenSecretKey      string        `mapstructure:"TOKEN_SECRET_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	GoogleClientID      string        `mapstructure:"GOOGLE_CLIENT_ID"`
-	CloudinaryURL       string        `mapstructure:"CLOUDINARY_URL"`
-	RedisServerAddress  string        `mapstructure:"REDIS_SERVER_ADDRESS"`
-	RedisServerPassword string        `mapstructure:"REDIS_SERVER_PASSWORD"`
-	DiscordBotToken     string        `mapstructure:"DISCORD_BOT_TOKEN"`
-	DiscordChannelID    string        `mapstructure:"DISCORD_CHANNEL_ID"`
-	GmailSMTPUsername   string        `mapstructure:"GMAIL_SMTP_USERNAME"`
-	GmailSMTPPassword   string        `mapstructure:"GMAIL_SMTP_PASSWORD"`
-	ZalopayCallbackURL  string        `mapstructure:"ZALOPAY_CALLBACK_URL"`
-	Environment         string        `mapstructure:"ENVIRONMENT"`
-	NgrokAuthToken      string        `mapstructure:"NGROK_AUTH_TOKEN"`
-	GHNShopID           string        `mapstructure:"GHN_SHOP_ID"`
-	GHNToken            string        `mapstructure:"GHN_TOKEN"`
+	AllowedOrigins       []string      `mapstructure:"ALLOWED_ORIGINS"`
+	DatabaseURL          string        `mapstructure:"DATABASE_URL"`
+	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	TokenSigningMethod   string        `mapstructure:"TOKEN_SIGNING_METHOD"`   // HS256 (mặc định), EdDSA hoặc RS256
+	TokenSecretKey       string        `mapstructure:"TOKEN_SECRET_KEY"`       // Chỉ dùng với HS256
+	TokenSigningKeysDir  string        `mapstructure:"TOKEN_SIGNING_KEYS_DIR"` // Thư mục chứa private key (<kid>.pem), dùng với EdDSA/RS256
+	TokenActiveKeyID     string        `mapstructure:"TOKEN_ACTIVE_KEY_ID"`    // kid của key dùng để ký token mới
+	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	GoogleClientID       string        `mapstructure:"GOOGLE_CLIENT_ID"`
+	CloudinaryURL        string        `mapstructure:"CLOUDINARY_URL"`
+	RedisServerAddress   string        `mapstructure:"REDIS_SERVER_ADDRESS"`
+	RedisServerPassword  string        `mapstructure:"REDIS_SERVER_PASSWORD"`
+	DiscordBotToken      string        `mapstructure:"DISCORD_BOT_TOKEN"`
+	DiscordChannelID     string        `mapstructure:"DISCORD_CHANNEL_ID"`
+	GmailSMTPUsername    string        `mapstructure:"GMAIL_SMTP_USERNAME"` // Deprecated: dùng SMTP_USERNAME
+	GmailSMTPPassword    string        `mapstructure:"GMAIL_SMTP_PASSWORD"` // Deprecated: dùng SMTP_PASSWORD
+	ZalopayCallbackURL   string        `mapstructure:"ZALOPAY_CALLBACK_URL"`
+	Environment          string        `mapstructure:"ENVIRONMENT"`
+	NgrokAuthToken       string        `mapstructure:"NGROK_AUTH_TOKEN"`
+	GHNShopID            string        `mapstructure:"GHN_SHOP_ID"`
+	GHNToken             string        `mapstructure:"GHN_TOKEN"`
+	
+	// Giới hạn chống brute-force OTP, bỏ trống để dùng giá trị mặc định của package otp
+	OTPMaxVerifyAttempts      int           `mapstructure:"OTP_MAX_VERIFY_ATTEMPTS"`
+	OTPMaxVerifyAttemptsPerIP int           `mapstructure:"OTP_MAX_VERIFY_ATTEMPTS_PER_IP"`
+	OTPMaxResends             int           `mapstructure:"OTP_MAX_RESENDS"`
+	OTPResendCooldown         time.Duration `mapstructure:"OTP_RESEND_COOLDOWN"`
+	OTPMaxResendCooldown      time.Duration `mapstructure:"OTP_MAX_RESEND_COOLDOWN"`
+	OTPLockoutDuration        time.Duration `mapstructure:"OTP_LOCKOUT_DURATION"`
+	
+	// Gửi SMS: http (SMS gateway), file (ghi ra file/stdout) hoặc discord (debug)
+	SMSProvider         string `mapstructure:"SMS_PROVIDER"`
+	SMSFallbackProvider string `mapstructure:"SMS_FALLBACK_PROVIDER"` // Provider dự phòng khi provider chính thất bại, có thể bỏ trống
+	SMSMaxAttempts      int    `mapstructure:"SMS_MAX_ATTEMPTS"`      // Số lần thử lại với mỗi provider
+	SMSGatewayURL       string `mapstructure:"SMS_GATEWAY_URL"`
+	SMSGatewayAPIKey    string `mapstructure:"SMS_GATEWAY_API_KEY"`
+	SMSSenderName       string `mapstructure:"SMS_SENDER_NAME"` // Brandname hiển thị với người nhận
+	SMSFilePath         string `mapstructure:"SMS_FILE_PATH"`   // Bỏ trống để ghi ra stdout
+	
+	// Gửi email: smtp (SMTP server bất kỳ), http (dịch vụ có HTTP API) hoặc outbox (ghi ra file/stdout)
+	EmailProvider    string `mapstructure:"EMAIL_PROVIDER"`
+	EmailFromName    string `mapstructure:"EMAIL_FROM_NAME"`
+	EmailFromAddress string `mapstructure:"EMAIL_FROM_ADDRESS"`
+	SMTPHost         string `mapstructure:"SMTP_HOST"`
+	SMTPPort         int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername     string `mapstructure:"SMTP_USERNAME"` // Bỏ trống nếu SMTP server không yêu cầu xác thực
+	SMTPPassword     string `mapstructure:"SMTP_PASSWORD"`
+	EmailAPIURL      string `mapstructure:"EMAIL_API_URL"`
+	EmailAPIKey      string `mapstructure:"EMAIL_API_KEY"`
+	EmailOutboxPath  string `mapstructure:"EMAIL_OUTBOX_PATH"` // Bỏ trống để ghi ra stdout
+	
+	// Lưu trữ file: cloudinary, local (thư mục trên đĩa) hoặc s3 (dịch vụ tương thích S3 như AWS S3, MinIO)
+	FileStoreProvider      string `mapstructure:"FILE_STORE_PROVIDER"`
+	LocalStorageDir        string `mapstructure:"LOCAL_STORAGE_DIR"`
+	LocalStorageBaseURL    string `mapstructure:"LOCAL_STORAGE_BASE_URL"`    // URL công khai của route /files, ví dụ: http://localhost:8080/files
+	LocalStorageSigningKey string `mapstructure:"LOCAL_STORAGE_SIGNING_KEY"` // Bỏ trống để dùng TOKEN_SECRET_KEY
+	S3Endpoint             string `mapstructure:"S3_ENDPOINT"`
+	S3Region               string `mapstructure:"S3_REGION"`
+	S3Bucket               string `mapstructure:"S3_BUCKET"`
+	S3AccessKeyID          string `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey      string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+	S3PublicURL            string `mapstructure:"S3_PUBLIC_URL"` // Bỏ trống để dùng <S3_ENDPOINT>/<S3_BUCKET>
 }
 
 // LoadConfig reads configuration from file (dev) or environment variables (prod)
@@ -54,7 +99,14 @@ func LoadConfig(path string) (config Config, err error) {
 		// Set defaults for development
 		viper.SetDefault("ALLOWED_ORIGINS", []string{"http://localhost:5173"})
 		viper.SetDefault("HTTP_SERVER_ADDRESS", "0.0.0.0:8080")
-		viper.SetDefault("ACCESS_TOKEN_DURATION", "24h")
+		viper.SetDefault("TOKEN_SIGNING_METHOD", "HS256")
+		viper.SetDefault("ACCESS_TOKEN_DURATION", "15m")
+		viper.SetDefault("REFRESH_TOKEN_DURATION", "168h")
+		viper.SetDefault("SMS_PROVIDER", "file")
+		viper.SetDefault("EMAIL_PROVIDER", "outbox")
+		viper.SetDefault("FILE_STORE_PROVIDER", "local")
+		viper.SetDefault("LOCAL_STORAGE_DIR", "./uploads")
+		viper.SetDefault("LOCAL_STORAGE_BASE_URL", "http://localhost:8080/files")
 		viper.SetDefault("ENVIRONMENT", environment)
 		
 		// Load config file (required in development)
@@ -79,6 +131,10 @@ func LoadConfig(path string) (config Config, err error) {
 		log.Info().Msg("Production mode: Using environment variables only")
 		
 		viper.SetDefault("ENVIRONMENT", environment)
+		viper.SetDefault("TOKEN_SIGNING_METHOD", "HS256")
+		viper.SetDefault("SMS_PROVIDER", "http")
+		viper.SetDefault("EMAIL_PROVIDER", "smtp")
+		viper.SetDefault("FILE_STORE_PROVIDER", "cloudinary")
 		
 		// Configure viper for environment variables
 		viper.AutomaticEnv()
@@ -87,7 +143,10 @@ func LoadConfig(path string) (config Config, err error) {
 		// Bind all environment variables
 		envVars := []string{
 			"DATABASE_URL",
+			"TOKEN_SIGNING_METHOD",
 			"TOKEN_SECRET_KEY",
+			"TOKEN_SIGNING_KEYS_DIR",
+			"TOKEN_ACTIVE_KEY_ID",
 			"GOOGLE_CLIENT_ID",
 			"CLOUDINARY_URL",
 			"REDIS_SERVER_ADDRESS",
@@ -102,7 +161,41 @@ func LoadConfig(path string) (config Config, err error) {
 			"ALLOWED_ORIGINS",
 			"HTTP_SERVER_ADDRESS",
 			"ACCESS_TOKEN_DURATION",
+			"REFRESH_TOKEN_DURATION",
 			"ZALOPAY_CALLBACK_URL",
+			"OTP_MAX_VERIFY_ATTEMPTS",
+			"OTP_MAX_VERIFY_ATTEMPTS_PER_IP",
+			"OTP_MAX_RESENDS",
+			"OTP_RESEND_COOLDOWN",
+			"OTP_MAX_RESEND_COOLDOWN",
+			"OTP_LOCKOUT_DURATION",
+			"SMS_PROVIDER",
+			"SMS_FALLBACK_PROVIDER",
+			"SMS_MAX_ATTEMPTS",
+			"SMS_GATEWAY_URL",
+			"SMS_GATEWAY_API_KEY",
+			"SMS_SENDER_NAME",
+			"SMS_FILE_PATH",
+			"EMAIL_PROVIDER",
+			"EMAIL_FROM_NAME",
+			"EMAIL_FROM_ADDRESS",
+			"SMTP_HOST",
+			"SMTP_PORT",
+			"SMTP_USERNAME",
+			"SMTP_PASSWORD",
+			"EMAIL_API_URL",
+			"EMAIL_API_KEY",
+			"EMAIL_OUTBOX_PATH",
+			"FILE_STORE_PROVIDER",
+			"LOCAL_STORAGE_DIR",
+			"LOCAL_STORAGE_BASE_URL",
+			"LOCAL_STORAGE_SIGNING_KEY",
+			"S3_ENDPOINT",
+			"S3_REGION",
+			"S3_BUCKET",
+			"S3_ACCESS_KEY_ID",
+			"S3_SECRET_ACCESS_KEY",
+			"S3_PUBLIC_URL",
 		}
 		
 		for _, env := range envVars {
@@ -113,6 +206,12 @@ func LoadConfig(path string) (config Config, err error) {
 		}
 	}
 	
+	// Mặc định gửi email từ tài khoản Gmail của nền tảng
+	viper.SetDefault("EMAIL_FROM_NAME", "Mecha World")
+	viper.SetDefault("EMAIL_FROM_ADDRESS", "mechaworldcapstone@gmail.com")
+	viper.SetDefault("SMTP_HOST", "smtp.gmail.com")
+	viper.SetDefault("SMTP_PORT", 587)
+	
 	// Unmarshal config into struct
 	err = viper.UnmarshalExact(&config)
 	if err != nil {
@@ -158,6 +257,16 @@ func postProcessConfig(config Config) Config {
 		config.AllowedOrigins = origins
 	}
 	
+	// Tương thích với cấu hình cũ chỉ hỗ trợ Gmail
+	if config.SMTPUsername == "" && config.SMTPPassword == "" {
+		config.SMTPUsername = config.GmailSMTPUsername
+		config.SMTPPassword = config.GmailSMTPPassword
+	}
+	
+	if config.LocalStorageSigningKey == "" {
+		config.LocalStorageSigningKey = config.TokenSecretKey
+	}
+	
 	return config
 }
 
@@ -166,14 +275,42 @@ func validateConfig(config Config) error {
 	if config.DatabaseURL == "" {
 		return fmt.Errorf("DATABASE_URL is required")
 	}
-	if config.TokenSecretKey == "" {
-		return fmt.Errorf("TOKEN_SECRET_KEY is required")
+	switch config.TokenSigningMethod {
+	case "HS256":
+		if config.TokenSecretKey == "" {
+			return fmt.Errorf("TOKEN_SECRET_KEY is required")
+		}
+	case "EdDSA", "RS256":
+		if config.TokenSigningKeysDir == "" {
+			return fmt.Errorf("TOKEN_SIGNING_KEYS_DIR is required when TOKEN_SIGNING_METHOD is %s", config.TokenSigningMethod)
+		}
+		if config.TokenActiveKeyID == "" {
+			return fmt.Errorf("TOKEN_ACTIVE_KEY_ID is required when TOKEN_SIGNING_METHOD is %s", config.TokenSigningMethod)
+		}
+	default:
+		return fmt.Errorf("TOKEN_SIGNING_METHOD must be one of HS256, EdDSA or RS256")
 	}
 	if config.GoogleClientID == "" {
 		return fmt.Errorf("GOOGLE_CLIENT_ID is required")
 	}
-	if config.CloudinaryURL == "" {
-		return fmt.Errorf("CLOUDINARY_URL is required")
+	switch config.FileStoreProvider {
+	case "", "cloudinary":
+		if config.CloudinaryURL == "" {
+			return fmt.Errorf("CLOUDINARY_URL is required")
+		}
+	case "local":
+		if config.LocalStorageDir == "" || config.LocalStorageBaseURL == "" {
+			return fmt.Errorf("LOCAL_STORAGE_DIR and LOCAL_STORAGE_BASE_URL are required when the local file store is used")
+		}
+		if config.LocalStorageSigningKey == "" {
+			return fmt.Errorf("LOCAL_STORAGE_SIGNING_KEY is required when the local file store is used")
+		}
+	case "s3":
+		if config.S3Endpoint == "" || config.S3Bucket == "" || config.S3AccessKeyID == "" || config.S3SecretAccessKey == "" {
+			return fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when the s3 file store is used")
+		}
+	default:
+		return fmt.Errorf("FILE_STORE_PROVIDER must be one of cloudinary, local or s3")
 	}
 	if config.RedisServerAddress == "" {
 		return fmt.Errorf("REDIS_SERVER_ADDRESS is required")
@@ -181,17 +318,37 @@ func validateConfig(config Config) error {
 	if config.RedisServerPassword == "" && config.Environment == EnvironmentProduction {
 		return fmt.Errorf("REDIS_SERVER_PASSWORD is required")
 	}
-	if config.DiscordBotToken == "" {
-		return fmt.Errorf("DISCORD_BOT_TOKEN is required")
-	}
-	if config.DiscordChannelID == "" {
-		return fmt.Errorf("DISCORD_CHANNEL_ID is required")
+	for _, provider := range []string{config.SMSProvider, config.SMSFallbackProvider} {
+		switch provider {
+		case "":
+		case "http":
+			if config.SMSGatewayURL == "" {
+				return fmt.Errorf("SMS_GATEWAY_URL is required when the http SMS provider is used")
+			}
+		case "file":
+		case "discord":
+			if config.DiscordBotToken == "" || config.DiscordChannelID == "" {
+				return fmt.Errorf("DISCORD_BOT_TOKEN and DISCORD_CHANNEL_ID are required when the discord SMS provider is used")
+			}
+		default:
+			return fmt.Errorf("SMS_PROVIDER and SMS_FALLBACK_PROVIDER must be one of http, file or discord")
+		}
 	}
-	if config.GmailSMTPUsername == "" {
-		return fmt.Errorf("GMAIL_SMTP_USERNAME is required")
+	switch config.EmailProvider {
+	case "smtp":
+		if config.SMTPHost == "" || config.SMTPPort == 0 {
+			return fmt.Errorf("SMTP_HOST and SMTP_PORT are required when the smtp email provider is used")
+		}
+	case "http":
+		if config.EmailAPIURL == "" {
+			return fmt.Errorf("EMAIL_API_URL is required when the http email provider is used")
+		}
+	case "", "outbox":
+	default:
+		return fmt.Errorf("EMAIL_PROVIDER must be one of smtp, http or outbox")
 	}
-	if config.GmailSMTPPassword == "" {
-		return fmt.Errorf("GMAIL_SMTP_PASSWORD is required")
+	if config.EmailFromAddress == "" {
+		return fmt.Errorf("EMAIL_FROM_ADDRESS is required")
 	}
 	if config.NgrokAuthToken == "" {
 		return fmt.Errorf("NGROK_AUTH_TOKEN is required")
@@ -5,4 +5,5 @@ const (
 	FolderGundams   = "gundams"
 	FolderOrders    = "orders"
 	FolderExchanges = "exchanges"
+	FolderModelKits = "model-kits"
 )
@@ -12,6 +12,10 @@ const (
 	TaskEndAuction          = "auction:end"
 	TaskCheckAuctionPayment = "auction:check_payment"
 	TaskPaymentReminder     = "auction:payment_reminder"
+	TaskSendEmail           = "email:send"
+	TaskMatchWatchers       = "watch:match"
+	TaskSendWatchDigest     = "watch:digest"
+	TaskCleanupImages       = "storage:cleanup_images"
 )
 
 /*
@@ -24,6 +28,10 @@ type TaskDistributor interface {
 	DistributeTaskEndAuction(ctx context.Context, payload *PayloadEndAuction, opts ...asynq.Option) error
 	DistributeTaskCheckAuctionPayment(ctx context.Context, payload *PayloadCheckAuctionPayment, opts ...asynq.Option) error
 	DistributeTaskPaymentReminder(ctx context.Context, payload *PayloadPaymentReminder, opts ...asynq.Option) error
+	DistributeTaskSendEmail(ctx context.Context, payload *PayloadSendEmail, opts ...asynq.Option) error
+	DistributeTaskMatchWatchers(ctx context.Context, payload *PayloadMatchWatchers, opts ...asynq.Option) error
+	DistributeTaskSendWatchDigest(ctx context.Context, payload *PayloadSendWatchDigest, opts ...asynq.Option) error
+	DistributeTaskCleanupImages(ctx context.Context, payload *PayloadCleanupImages, opts ...asynq.Option) error
 }
 
 type RedisTaskDistributor struct {
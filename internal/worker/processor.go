@@ -8,6 +8,8 @@ import (
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
 	"github.com/katatrina/gundam-BE/internal/event"
+	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/rs/zerolog/log"
 )
 
@@ -21,14 +23,17 @@ const (
 )
 
 type RedisTaskProcessor struct {
-	server          *asynq.Server     // server will process tasks from the Redis queue.
-	store           db.Store          // Tương tác với db
-	firestoreClient *firestore.Client // Dùng để gửi thông báo đến cho người dùng thông qua Firestore
-	distributor     TaskDistributor   // Dùng để phân phối task đến Redis queue
-	eventSender     event.EventSender // Dùng để gửi sự kiện đến client
+	server          *asynq.Server          // server will process tasks from the Redis queue.
+	store           db.Store               // Tương tác với db
+	firestoreClient *firestore.Client      // Dùng để gửi thông báo đến cho người dùng thông qua Firestore
+	distributor     TaskDistributor        // Dùng để phân phối task đến Redis queue
+	eventSender     event.EventSender      // Dùng để gửi sự kiện đến client
+	emailSender     mailer.EmailSender     // Dùng để gửi email giao dịch cho người dùng
+	emailTemplates  *mailer.TemplateEngine // Render email mẫu trước khi gửi
+	fileStore       storage.FileStore      // Dùng để dọn ảnh không còn được dùng
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, firebaseApp *firebase.App, distributor TaskDistributor, eventSender event.EventSender) *RedisTaskProcessor {
+func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, firebaseApp *firebase.App, distributor TaskDistributor, eventSender event.EventSender, emailSender mailer.EmailSender, emailTemplates *mailer.TemplateEngine, fileStore storage.FileStore) *RedisTaskProcessor {
 	// Initialize Firestore client
 	firestoreClient, err := firebaseApp.Firestore(context.Background())
 	if err != nil {
@@ -57,6 +62,9 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, fireba
 		firestoreClient: firestoreClient,
 		distributor:     distributor,
 		eventSender:     eventSender,
+		emailSender:     emailSender,
+		emailTemplates:  emailTemplates,
+		fileStore:       fileStore,
 	}
 }
 
@@ -69,6 +77,14 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux.HandleFunc(TaskEndAuction, processor.ProcessTaskEndAuction)
 	mux.HandleFunc(TaskCheckAuctionPayment, processor.ProcessTaskCheckAuctionPayment)
 	mux.HandleFunc(TaskPaymentReminder, processor.ProcessTaskPaymentReminder)
+	mux.HandleFunc(TaskSendEmail, processor.ProcessTaskSendEmail)
+	mux.HandleFunc(TaskMatchWatchers, processor.ProcessTaskMatchWatchers)
+	mux.HandleFunc(TaskSendWatchDigest, processor.ProcessTaskSendWatchDigest)
+	mux.HandleFunc(TaskCleanupImages, processor.ProcessTaskCleanupImages)
+	
+	if err := processor.scheduleImageCleanup(context.Background()); err != nil {
+		log.Error().Err(err).Msg("failed to schedule image cleanup")
+	}
 	
 	return processor.server.Start(mux)
 }
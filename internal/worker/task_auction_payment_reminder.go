@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/rs/zerolog/log"
 )
 
@@ -101,7 +102,7 @@ func (processor *RedisTaskProcessor) ProcessTaskPaymentReminder(
 	}
 	
 	// Kiểm tra xem còn deadline thanh toán không
-	if auction.WinnerPaymentDeadline != nil {
+	if auction.WinnerPaymentDeadline == nil {
 		log.Warn().
 			Str("auction_id", payload.AuctionID.String()).
 			Msg("auction has no payment deadline, skipping reminder")
@@ -151,6 +152,21 @@ func (processor *RedisTaskProcessor) ProcessTaskPaymentReminder(
 		// Không return error vì đây chỉ là nhắc nhở
 	}
 	
+	err = DistributeEmail(ctx, processor.distributor, payload.WinnerID, mailer.TemplatePaymentReminder, mailer.DefaultLocale, mailer.PaymentReminderData{
+		GundamName:       gundamName,
+		RemainingHours:   payload.RemainingHours,
+		ReminderSequence: payload.ReminderSequence,
+		PaymentDeadline:  *auction.WinnerPaymentDeadline,
+	})
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("winner_id", payload.WinnerID).
+			Str("auction_id", payload.AuctionID.String()).
+			Int("reminder_sequence", payload.ReminderSequence).
+			Msg("failed to send payment reminder email")
+	}
+	
 	log.Info().
 		Str("auction_id", payload.AuctionID.String()).
 		Str("winner_id", payload.WinnerID).
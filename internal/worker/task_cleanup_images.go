@@ -0,0 +1,240 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/hibiken/asynq"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/storage"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ImageCleanupGracePeriod là thời gian tối thiểu kể từ khi file được tải lên trước khi bị xem là mồ côi.
+	// Ảnh được tải lên trước khi transaction ghi URL vào database, nên file mới chưa được tham chiếu chưa chắc đã bị bỏ.
+	ImageCleanupGracePeriod = 72 * time.Hour
+	
+	// Giờ chạy dọn ảnh tự động hàng ngày (theo giờ của server), lúc ít người dùng
+	imageCleanupHour = 3
+	
+	// Số ảnh mồ côi tối đa được ghi vào báo cáo, các số liệu tổng vẫn tính tất cả ảnh
+	maxReportedOrphans = 1000
+)
+
+// imageCleanupFolders là các thư mục chứa ảnh do người dùng tải lên
+var imageCleanupFolders = []string{
+	util.FolderGundams,
+	util.FolderExchanges,
+	util.FolderOrders,
+	util.FolderAvatars,
+	util.FolderModelKits,
+	// File tạm của presigned upload không bao giờ được tham chiếu, file chưa được finalize sẽ bị xóa sau grace period
+	storage.StagingFolder,
+}
+
+type PayloadCleanupImages struct {
+	RunID int64 `json:"run_id"` // 0 nếu là lần chạy tự động hàng ngày, khi đó task tự tạo lần chạy mới
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskCleanupImages(
+	ctx context.Context,
+	payload *PayloadCleanupImages,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	
+	task := asynq.NewTask(TaskCleanupImages, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Int64("run_id", payload.RunID).
+		Str("task_id", info.ID).
+		Time("process_at", info.NextProcessAt).
+		Msg("task enqueued")
+	
+	return nil
+}
+
+// ProcessTaskCleanupImages đối soát file trên file store với các URL ảnh trong database
+// và xóa các ảnh mồ côi đã quá grace period (hoặc chỉ ghi báo cáo nếu là dry run).
+func (processor *RedisTaskProcessor) ProcessTaskCleanupImages(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadCleanupImages
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	
+	if payload.RunID == 0 {
+		// Lên lịch cho ngày hôm sau dù lần chạy này thành công hay thất bại
+		defer func() {
+			if err := processor.scheduleImageCleanup(context.Background()); err != nil {
+				log.Error().Err(err).Msg("failed to schedule next image cleanup")
+			}
+		}()
+		
+		run, err := processor.store.CreateImageCleanupRun(ctx, db.CreateImageCleanupRunParams{
+			DryRun:           false,
+			GracePeriodHours: int32(ImageCleanupGracePeriod.Hours()),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create image cleanup run: %w", err)
+		}
+		payload.RunID = run.ID
+	}
+	
+	run, err := processor.store.StartImageCleanupRun(ctx, payload.RunID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			log.Warn().Int64("run_id", payload.RunID).Msg("image cleanup run not found or already finished")
+			return nil
+		}
+		
+		return fmt.Errorf("failed to start image cleanup run: %w", err)
+	}
+	
+	result, err := processor.cleanupImages(ctx, run)
+	if err != nil {
+		failErr := processor.store.FailImageCleanupRun(context.Background(), db.FailImageCleanupRunParams{
+			Error: err.Error(),
+			ID:    run.ID,
+		})
+		if failErr != nil {
+			log.Error().Err(failErr).Int64("run_id", run.ID).Msg("failed to mark image cleanup run as failed")
+		}
+		
+		// Không thử lại vì lần chạy đã được đánh dấu thất bại, admin có thể yêu cầu chạy lại
+		return fmt.Errorf("failed to clean up images: %v: %w", err, asynq.SkipRetry)
+	}
+	
+	run, err = processor.store.CompleteImageCleanupRun(ctx, result)
+	if err != nil {
+		return fmt.Errorf("failed to complete image cleanup run: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Int64("run_id", run.ID).
+		Bool("dry_run", run.DryRun).
+		Int32("scanned", run.ScannedCount).
+		Int32("orphaned", run.OrphanedCount).
+		Int32("deleted", run.DeletedCount).
+		Int32("failed", run.FailedCount).
+		Msg("task processed")
+	
+	return nil
+}
+
+// cleanupImages liệt kê file trong các thư mục ảnh, so với các URL được database tham chiếu và xóa file mồ côi.
+// Ảnh gốc và thumbnail của nó được xem là một (xem storage.ImageStem).
+func (processor *RedisTaskProcessor) cleanupImages(ctx context.Context, run db.ImageCleanupRun) (db.CompleteImageCleanupRunParams, error) {
+	result := db.CompleteImageCleanupRunParams{
+		ID:      run.ID,
+		Orphans: []db.ImageCleanupOrphan{},
+	}
+	
+	var files []storage.StoredFile
+	for _, folder := range imageCleanupFolders {
+		folderFiles, err := processor.fileStore.ListFiles(ctx, folder)
+		if err != nil {
+			return result, err
+		}
+		files = append(files, folderFiles...)
+	}
+	
+	// Lấy URL sau khi liệt kê file, để ảnh được ghi vào database trong lúc liệt kê không bị xem là mồ côi
+	urls, err := processor.store.ListReferencedImageURLs(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to list referenced image URLs: %w", err)
+	}
+	
+	referenced := make(map[string]struct{}, len(urls))
+	for _, url := range urls {
+		// URL của file store khác (ví dụ: ảnh được tải lên trước khi đổi provider) không thể trỏ tới file của file store hiện tại
+		key, err := processor.fileStore.KeyFromURL(url)
+		if err != nil {
+			continue
+		}
+		referenced[storage.ImageStem(key)] = struct{}{}
+	}
+	
+	// Cấu hình file store sai (ví dụ: đổi base URL) làm mọi ảnh trông như mồ côi, không xóa gì trong trường hợp này
+	if len(urls) > 0 && len(referenced) == 0 {
+		return result, fmt.Errorf("none of the %d referenced image URLs belongs to the current file store", len(urls))
+	}
+	
+	cutoff := time.Now().Add(-time.Duration(run.GracePeriodHours) * time.Hour)
+	for _, file := range files {
+		result.ScannedCount++
+		
+		if _, ok := referenced[storage.ImageStem(file.Key)]; ok {
+			continue
+		}
+		
+		if file.LastModified.After(cutoff) {
+			result.RecentCount++
+			continue
+		}
+		
+		result.OrphanedCount++
+		result.OrphanedBytes += file.Size
+		
+		orphan := db.ImageCleanupOrphan{
+			Key:          file.Key,
+			Size:         file.Size,
+			LastModified: file.LastModified,
+		}
+		
+		if !run.DryRun {
+			if err = processor.fileStore.DeleteFile(ctx, file.Key); err != nil {
+				log.Warn().Err(err).Str("key", file.Key).Msg("failed to delete orphaned image")
+				result.FailedCount++
+			} else {
+				result.DeletedCount++
+				orphan.Deleted = true
+			}
+		}
+		
+		if len(result.Orphans) < maxReportedOrphans {
+			result.Orphans = append(result.Orphans, orphan)
+		}
+	}
+	
+	return result, nil
+}
+
+// scheduleImageCleanup lên lịch lần dọn ảnh tự động tiếp theo vào imageCleanupHour giờ.
+// Task ID theo ngày nên gọi nhiều lần (ví dụ: mỗi khi worker khởi động) cũng chỉ có một lần chạy mỗi ngày.
+func (processor *RedisTaskProcessor) scheduleImageCleanup(ctx context.Context) error {
+	now := time.Now()
+	processAt := time.Date(now.Year(), now.Month(), now.Day(), imageCleanupHour, 0, 0, 0, now.Location())
+	if !processAt.After(now) {
+		processAt = processAt.AddDate(0, 0, 1)
+	}
+	
+	taskID := fmt.Sprintf("%s:%s", TaskCleanupImages, processAt.Format(time.DateOnly))
+	err := processor.distributor.DistributeTaskCleanupImages(ctx, &PayloadCleanupImages{},
+		asynq.ProcessAt(processAt),
+		asynq.TaskID(taskID),
+		asynq.MaxRetry(1),
+		asynq.Queue(QueueDefault),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+	
+	return nil
+}
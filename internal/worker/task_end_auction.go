@@ -11,6 +11,7 @@ import (
 	"github.com/hibiken/asynq"
 	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
 	"github.com/katatrina/gundam-BE/internal/event"
+	"github.com/katatrina/gundam-BE/internal/mailer"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/rs/zerolog/log"
 )
@@ -216,6 +217,19 @@ func (processor *RedisTaskProcessor) ProcessTaskEndAuction(
 				Msg("failed to send win notification")
 		}
 		
+		err = DistributeEmail(ctx, processor.distributor, *result.WinnerID, mailer.TemplateAuctionWon, mailer.DefaultLocale, mailer.AuctionWonData{
+			GundamName:      auction.GundamSnapshot.Name,
+			FinalPrice:      result.FinalPrice,
+			PaymentDeadline: *paymentDeadline,
+		})
+		if err != nil {
+			log.Warn().
+				Err(err).
+				Str("winner_id", *result.WinnerID).
+				Str("auction_id", payload.AuctionID.String()).
+				Msg("failed to send auction won email")
+		}
+		
 		// Gửi thông báo cho người bán
 		err = processor.distributor.DistributeTaskSendNotification(ctx, &PayloadSendNotification{
 			RecipientID: auction.SellerID,
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// watchDigestInterval là khoảng cách tối thiểu giữa hai thông báo tổng hợp gửi cho cùng một người dùng
+const watchDigestInterval = time.Hour
+
+// PayloadMatchWatchers chứa Gundam vừa được đăng bán, giảm giá hoặc đưa lên đấu giá,
+// cần được so khớp với danh sách yêu thích và các tìm kiếm đã lưu của người dùng.
+type PayloadMatchWatchers struct {
+	GundamID      int64      `json:"gundam_id"`
+	AuctionID     *uuid.UUID `json:"auction_id"`     // Khác nil nếu Gundam được đưa lên đấu giá
+	Price         int64      `json:"price"`          // Giá bán, hoặc giá khởi điểm của phiên đấu giá
+	PreviousPrice *int64     `json:"previous_price"` // Khác nil nếu Gundam đang bán vừa được giảm giá
+}
+
+// DistributeMatchWatchers đưa task so khớp Gundam với những người đang theo dõi vào hàng đợi.
+// Thông báo này không gấp nên được xử lý ở hàng đợi mặc định.
+func DistributeMatchWatchers(ctx context.Context, distributor TaskDistributor, payload *PayloadMatchWatchers) error {
+	return distributor.DistributeTaskMatchWatchers(ctx, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueDefault),
+	)
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskMatchWatchers(
+	ctx context.Context,
+	payload *PayloadMatchWatchers,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	
+	task := asynq.NewTask(TaskMatchWatchers, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Int64("gundam_id", payload.GundamID).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued")
+	
+	return nil
+}
+
+// ProcessTaskMatchWatchers ghi nhận Gundam cho những người dùng đang theo dõi nó,
+// sau đó lên lịch thông báo tổng hợp cho từng người thay vì gửi thông báo ngay.
+func (processor *RedisTaskProcessor) ProcessTaskMatchWatchers(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadMatchWatchers
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	
+	userIDs, err := processor.store.CreateWatchMatches(ctx, db.CreateWatchMatchesParams{
+		GundamID:      payload.GundamID,
+		Price:         payload.Price,
+		AuctionID:     payload.AuctionID,
+		PreviousPrice: payload.PreviousPrice,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create watch matches: %w", err)
+	}
+	
+	for _, userID := range userIDs {
+		// Các dòng đã được ghi nhận, nếu lên lịch thất bại thì chúng sẽ được gửi cùng lần khớp tiếp theo của người dùng
+		if err = processor.scheduleWatchDigest(ctx, userID); err != nil {
+			log.Error().Err(err).Str("user_id", userID).Msg("failed to schedule watch digest")
+		}
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Int64("gundam_id", payload.GundamID).
+		Int("matched_users", len(userIDs)).
+		Msg("task processed")
+	
+	return nil
+}
+
+// scheduleWatchDigest lên lịch thông báo tổng hợp cho người dùng, sớm nhất là watchDigestInterval sau lần gửi gần nhất.
+// Task ID gắn với thời điểm xử lý nên các lần khớp cùng chờ một lần gửi chỉ tạo ra một task.
+func (processor *RedisTaskProcessor) scheduleWatchDigest(ctx context.Context, userID string) error {
+	processAt := time.Now()
+	
+	lastDigestAt, err := processor.store.GetLastWatchDigestTime(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get last watch digest time: %w", err)
+	}
+	if lastDigestAt != nil && lastDigestAt.Add(watchDigestInterval).After(processAt) {
+		processAt = lastDigestAt.Add(watchDigestInterval)
+	}
+	
+	taskID := fmt.Sprintf("watch:digest:%s:%d", userID, processAt.Unix())
+	err = processor.distributor.DistributeTaskSendWatchDigest(ctx, &PayloadSendWatchDigest{
+		UserID: userID,
+	},
+		asynq.ProcessAt(processAt),
+		asynq.TaskID(taskID),
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueDefault),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+	
+	return nil
+}
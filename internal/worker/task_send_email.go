@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	
+	"github.com/hibiken/asynq"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/mailer"
+	"github.com/rs/zerolog/log"
+)
+
+// PayloadSendEmail chứa dữ liệu của một email giao dịch cần gửi.
+// Địa chỉ email và tên người nhận được lấy từ database khi xử lý task, nên luôn là thông tin mới nhất.
+// Email gửi đến một địa chỉ chưa chắc thuộc về tài khoản nào (ví dụ: mã OTP) dùng RecipientEmail thay cho RecipientID.
+type PayloadSendEmail struct {
+	RecipientID    string              `json:"recipient_id,omitempty"`
+	RecipientEmail string              `json:"recipient_email,omitempty"`
+	Template       mailer.TemplateName `json:"template"`
+	Locale         mailer.Locale       `json:"locale"`
+	Data           json.RawMessage     `json:"data"` // Dữ liệu của email mẫu, ví dụ: mailer.OrderConfirmationData
+}
+
+// NewPayloadSendEmail tạo payload gửi email mẫu name cho người dùng recipientID
+func NewPayloadSendEmail(recipientID string, name mailer.TemplateName, locale mailer.Locale, data any) (*PayloadSendEmail, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal email data: %w", err)
+	}
+	
+	return &PayloadSendEmail{
+		RecipientID: recipientID,
+		Template:    name,
+		Locale:      locale,
+		Data:        jsonData,
+	}, nil
+}
+
+// DistributeEmail tạo payload và đưa task gửi email mẫu name cho người dùng recipientID vào hàng đợi.
+// Email không quan trọng bằng thông báo trong ứng dụng nên được xử lý ở hàng đợi mặc định.
+func DistributeEmail(ctx context.Context, distributor TaskDistributor, recipientID string, name mailer.TemplateName, locale mailer.Locale, data any) error {
+	payload, err := NewPayloadSendEmail(recipientID, name, locale, data)
+	if err != nil {
+		return err
+	}
+	
+	return distributor.DistributeTaskSendEmail(ctx, payload,
+		asynq.MaxRetry(5),
+		asynq.Queue(QueueDefault),
+	)
+}
+
+// DistributeEmailToAddress đưa task gửi email mẫu name đến địa chỉ email vào hàng đợi, không cần tài khoản người dùng.
+// Dùng cho email chứa mã OTP nên được xử lý ở hàng đợi ưu tiên và không thử lại quá lâu vì mã sẽ hết hạn.
+func DistributeEmailToAddress(ctx context.Context, distributor TaskDistributor, email string, name mailer.TemplateName, locale mailer.Locale, data any) error {
+	payload, err := NewPayloadSendEmail("", name, locale, data)
+	if err != nil {
+		return err
+	}
+	payload.RecipientEmail = email
+	
+	return distributor.DistributeTaskSendEmail(ctx, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue(QueueCritical),
+	)
+}
+
+// DistributeTaskSendEmail đưa task gửi email vào hàng đợi, để HTTP handler không phải chờ SMTP
+func (distributor *RedisTaskDistributor) DistributeTaskSendEmail(
+	ctx context.Context,
+	payload *PayloadSendEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	
+	task := asynq.NewTask(TaskSendEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Str("recipient_id", payload.RecipientID).
+		Str("template", string(payload.Template)).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("task enqueued")
+	
+	return nil
+}
+
+// ProcessTaskSendEmail render email mẫu và gửi đến địa chỉ email của người nhận
+func (processor *RedisTaskProcessor) ProcessTaskSendEmail(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendEmail
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	
+	data, err := mailer.DecodeTemplateData(payload.Template, payload.Data)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+	
+	recipientEmail, recipientName := payload.RecipientEmail, ""
+	if recipientEmail == "" {
+		// Người dùng đã xóa tài khoản sẽ không nhận email nữa
+		recipient, err := processor.store.GetUserByID(ctx, payload.RecipientID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				log.Info().
+					Str("recipient_id", payload.RecipientID).
+					Str("template", string(payload.Template)).
+					Msg("recipient not found, skipping email")
+				return nil
+			}
+			return fmt.Errorf("failed to get recipient: %w", err)
+		}
+		recipientEmail, recipientName = recipient.Email, recipient.FullName
+	}
+	
+	email, err := processor.emailTemplates.Render(payload.Template, payload.Locale, recipientName, data)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+	
+	err = processor.emailSender.Send(ctx, mailer.Message{
+		To:    []string{recipientEmail},
+		Email: email,
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Str("recipient_id", payload.RecipientID).
+			Str("template", string(payload.Template)).
+			Str("provider", processor.emailSender.Name()).
+			Msg("failed to send email")
+		return err
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Str("recipient_id", payload.RecipientID).
+		Str("template", string(payload.Template)).
+		Msg("task processed")
+	
+	return nil
+}
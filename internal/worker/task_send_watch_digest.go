@@ -0,0 +1,185 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	
+	"github.com/hibiken/asynq"
+	db "github.com/katatrina/gundam-BE/internal/db/sqlc"
+	"github.com/katatrina/gundam-BE/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+// maxWatchDigestNames là số tên Gundam tối đa được liệt kê trong một thông báo tổng hợp
+const maxWatchDigestNames = 3
+
+type PayloadSendWatchDigest struct {
+	UserID string `json:"user_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendWatchDigest(
+	ctx context.Context,
+	payload *PayloadSendWatchDigest,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+	
+	task := asynq.NewTask(TaskSendWatchDigest, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Str("user_id", payload.UserID).
+		Str("task_id", info.ID).
+		Time("process_at", info.NextProcessAt).
+		Msg("task enqueued")
+	
+	return nil
+}
+
+// ProcessTaskSendWatchDigest gom tất cả các Gundam đang chờ thông báo của người dùng thành một thông báo duy nhất.
+func (processor *RedisTaskProcessor) ProcessTaskSendWatchDigest(
+	ctx context.Context,
+	task *asynq.Task,
+) error {
+	var payload PayloadSendWatchDigest
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+	
+	// Kiểm tra lại giới hạn vì nhiều task có thể được lên lịch cùng lúc trước khi thông báo đầu tiên được gửi
+	lastDigestAt, err := processor.store.GetLastWatchDigestTime(ctx, payload.UserID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get last watch digest time: %w", err)
+	}
+	if lastDigestAt != nil && time.Since(*lastDigestAt) < watchDigestInterval {
+		return processor.scheduleWatchDigest(ctx, payload.UserID)
+	}
+	
+	matches, err := processor.store.ListPendingWatchMatches(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to list pending watch matches: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	
+	ids := make([]int64, 0, len(matches))
+	available := make([]db.ListPendingWatchMatchesRow, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+		// Gundam đã được bán hoặc gỡ xuống trước khi kịp thông báo thì bỏ qua
+		if match.IsAvailable {
+			available = append(available, match)
+		}
+	}
+	
+	if len(available) > 0 {
+		err = processor.distributor.DistributeTaskSendNotification(ctx, newWatchDigestNotification(payload.UserID, available),
+			asynq.MaxRetry(3),
+			asynq.Queue(QueueCritical),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to send watch digest notification: %w", err)
+		}
+	}
+	
+	if err = processor.store.MarkWatchMatchesNotified(ctx, ids); err != nil {
+		return fmt.Errorf("failed to mark watch matches as notified: %w", err)
+	}
+	
+	log.Info().
+		Str("type", task.Type()).
+		Str("user_id", payload.UserID).
+		Int("matches", len(matches)).
+		Int("available", len(available)).
+		Msg("task processed")
+	
+	return nil
+}
+
+// priceDrop trả về số tiền Gundam đã giảm giá, 0 nếu dòng không phải do giảm giá.
+func priceDrop(match db.ListPendingWatchMatchesRow) int64 {
+	if match.PreviousPrice == nil || *match.PreviousPrice <= match.Price {
+		return 0
+	}
+	
+	return *match.PreviousPrice - match.Price
+}
+
+// newWatchDigestNotification tạo nội dung thông báo cho các Gundam khớp với mục theo dõi của người dùng.
+// Nếu chỉ có một Gundam thì thông báo trỏ thẳng đến tin đăng hoặc phiên đấu giá đó.
+func newWatchDigestNotification(userID string, matches []db.ListPendingWatchMatchesRow) *PayloadSendNotification {
+	if len(matches) == 1 {
+		match := matches[0]
+		
+		reason := "nằm trong danh sách yêu thích của bạn"
+		if match.SavedSearchName != nil {
+			reason = fmt.Sprintf("khớp với tìm kiếm \"%s\" của bạn", *match.SavedSearchName)
+		}
+		
+		if drop := priceDrop(match); drop > 0 {
+			return &PayloadSendNotification{
+				RecipientID: userID,
+				Title:       "Gundam bạn theo dõi vừa giảm giá",
+				Message: fmt.Sprintf("Gundam \"%s\" %s vừa giảm giá từ %s xuống %s (giảm %s).",
+					match.GundamName, reason, util.FormatVND(*match.PreviousPrice), util.FormatVND(match.Price), util.FormatVND(drop)),
+				Type:        "watch_price_drop",
+				ReferenceID: strconv.FormatInt(match.GundamID, 10),
+			}
+		}
+		
+		if match.AuctionID != nil {
+			return &PayloadSendNotification{
+				RecipientID: userID,
+				Title:       "Gundam bạn theo dõi sắp được đấu giá",
+				Message: fmt.Sprintf("Gundam \"%s\" %s sắp được đấu giá với giá khởi điểm %s.",
+					match.GundamName, reason, util.FormatVND(match.Price)),
+				Type:        "watch_auction",
+				ReferenceID: match.AuctionID.String(),
+			}
+		}
+		
+		return &PayloadSendNotification{
+			RecipientID: userID,
+			Title:       "Gundam bạn theo dõi vừa được đăng bán",
+			Message: fmt.Sprintf("Gundam \"%s\" %s vừa được đăng bán với giá %s.",
+				match.GundamName, reason, util.FormatVND(match.Price)),
+			Type:        "watch_listing",
+			ReferenceID: strconv.FormatInt(match.GundamID, 10),
+		}
+	}
+	
+	names := make([]string, 0, maxWatchDigestNames)
+	for _, match := range matches[:min(len(matches), maxWatchDigestNames)] {
+		name := fmt.Sprintf("\"%s\"", match.GundamName)
+		if drop := priceDrop(match); drop > 0 {
+			name += fmt.Sprintf(" (giảm %s)", util.FormatVND(drop))
+		}
+		names = append(names, name)
+	}
+	
+	message := strings.Join(names, ", ")
+	if remaining := len(matches) - len(names); remaining > 0 {
+		message += fmt.Sprintf(" và %d Gundam khác", remaining)
+	}
+	message += " khớp với danh sách yêu thích hoặc tìm kiếm đã lưu của bạn vừa được đăng bán, giảm giá hoặc đưa lên đấu giá."
+	
+	return &PayloadSendNotification{
+		RecipientID: userID,
+		Title:       fmt.Sprintf("%d Gundam bạn theo dõi vừa xuất hiện", len(matches)),
+		Message:     message,
+		Type:        "watch_digest",
+	}
+}
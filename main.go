@@ -15,6 +15,7 @@ import (
 	"github.com/katatrina/gundam-BE/internal/event"
 	"github.com/katatrina/gundam-BE/internal/mailer"
 	ordertracking "github.com/katatrina/gundam-BE/internal/order_tracking"
+	"github.com/katatrina/gundam-BE/internal/storage"
 	"github.com/katatrina/gundam-BE/internal/util"
 	"github.com/katatrina/gundam-BE/internal/worker"
 	"github.com/redis/go-redis/v9"
@@ -104,9 +105,22 @@ func main() {
 	}
 	log.Info().Msg("connected to Redis ✅")
 	
-	mailService, err := mailer.NewGmailSender(appConfig.GmailSMTPUsername, appConfig.GmailSMTPPassword, appConfig, redisDb)
+	// Tạo email sender theo EMAIL_PROVIDER, không kết nối đến provider khi khởi động
+	emailSender, err := mailer.NewSenderFromConfig(&appConfig)
 	if err != nil {
-		log.Fatal().Err(err).Msg("failed to create mail service 😣")
+		log.Fatal().Err(err).Msg("failed to create email sender 😣")
+	}
+	log.Info().Str("provider", emailSender.Name()).Msg("email sender created ✅")
+	
+	emailTemplates, err := mailer.NewTemplateEngine(appConfig.EmailFromName)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to parse email templates 😣")
+	}
+	
+	// Worker dùng file store để dọn ảnh không còn được dùng
+	fileStore, err := storage.NewFileStoreFromConfig(&appConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create file store 😣")
 	}
 	
 	redisOpt := asynq.RedisClientOpt{
@@ -137,8 +151,8 @@ func main() {
 	sseServer := event.NewSSEServer()
 	go sseServer.Run() // Chạy trong goroutine riêng
 	
-	go runRedisTaskProcessor(redisOpt, store, firebaseApp, taskDistributor, sseServer)
-	runHTTPServer(&appConfig, store, redisDb, taskDistributor, taskInspector, mailService, ghnService, sseServer)
+	go runRedisTaskProcessor(redisOpt, store, firebaseApp, taskDistributor, sseServer, emailSender, emailTemplates, fileStore)
+	runHTTPServer(&appConfig, store, redisDb, taskDistributor, taskInspector, ghnService, sseServer)
 }
 
 // getConfigPath determines the config file path based on environment
@@ -165,8 +179,8 @@ func initializeFirebase(ctx context.Context, environment string) (*firebase.App,
 	return firebase.NewApp(ctx, nil, opt)
 }
 
-func runHTTPServer(appConfig *util.Config, store db.Store, redisDb *redis.Client, taskDistributor worker.TaskDistributor, taskInspector worker.TaskInspector, mailer *mailer.GmailSender, deliveryService delivery.IDeliveryProvider, eventSender event.EventSender) {
-	server, err := api.NewServer(store, redisDb, taskDistributor, taskInspector, appConfig, mailer, deliveryService, eventSender)
+func runHTTPServer(appConfig *util.Config, store db.Store, redisDb *redis.Client, taskDistributor worker.TaskDistributor, taskInspector worker.TaskInspector, deliveryService delivery.IDeliveryProvider, eventSender event.EventSender) {
+	server, err := api.NewServer(store, redisDb, taskDistributor, taskInspector, appConfig, deliveryService, eventSender)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create HTTP server 😣")
 	}
@@ -213,8 +227,8 @@ func setupNgrokTunnel(appConfig *util.Config, server *api.Server) {
 }
 
 // runRedisTaskProcessor creates a new task processor and starts it.
-func runRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, firebaseApp *firebase.App, taskDistributor worker.TaskDistributor, eventSender event.EventSender) {
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, firebaseApp, taskDistributor, eventSender)
+func runRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, firebaseApp *firebase.App, taskDistributor worker.TaskDistributor, eventSender event.EventSender, emailSender mailer.EmailSender, emailTemplates *mailer.TemplateEngine, fileStore storage.FileStore) {
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, firebaseApp, taskDistributor, eventSender, emailSender, emailTemplates, fileStore)
 	
 	err := taskProcessor.Start()
 	if err != nil {